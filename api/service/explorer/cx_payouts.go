@@ -0,0 +1,121 @@
+package explorer
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/nordicenergy/nordicenergy-core/core/types"
+	common2 "github.com/nordicenergy/nordicenergy-core/internal/common"
+	"github.com/nordicenergy/nordicenergy-core/internal/utils"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// Constants of the cross-shard payout index. The payouts of the cross-shard
+// transactions received by an address are indexed apart from its transaction
+// history, whose hashes are of the transactions of the shard only, for the
+// rosetta search of the transactions of the address.
+const (
+	CxPayoutPrefix           = "cp"
+	CxPayoutCheckpointPrefix = "dp"
+	// CxPayoutReindexKey is the key of the next block of the chain whose
+	// cross-shard payouts are to be re-indexed
+	CxPayoutReindexKey = "explorer_cx_payout_reindex"
+)
+
+// GetCxPayoutCheckpointKey ...
+func GetCxPayoutCheckpointKey(blockNum *big.Int) string {
+	return fmt.Sprintf("%s_%x", CxPayoutCheckpointPrefix, blockNum)
+}
+
+func cxPayoutPrefix(addr common.Address) []byte {
+	return append([]byte(CxPayoutPrefix+"_"), addr.Bytes()...)
+}
+
+func cxPayoutKey(payout *indexedTx) []byte {
+	key := cxPayoutPrefix(payout.Address)
+	key = appendUint64(key, payout.Time)
+	key = appendUint64(key, payout.BlockNum)
+	var index [4]byte
+	binary.BigEndian.PutUint32(index[:], payout.Index)
+	return append(key, index[:]...)
+}
+
+// blockCxPayouts returns the payouts of the cross-shard transactions received
+// by block, indexed for their receivers. Their index is their position among
+// the incoming receipts of block.
+func blockCxPayouts(block *types.Block) []*indexedTx {
+	payouts := []*indexedTx{}
+	index := 0
+	for _, proof := range block.IncomingReceipts() {
+		for _, cx := range proof.Receipts {
+			if cx.To != nil {
+				payouts = append(payouts, &indexedTx{
+					Address:  *cx.To,
+					Type:     txTypeReceived,
+					Time:     block.Time().Uint64(),
+					BlockNum: block.NumberU64(),
+					Index:    uint32(index),
+					Hash:     cx.TxHash,
+				})
+			}
+			index++
+		}
+	}
+	return payouts
+}
+
+// DumpCxPayouts indexes the payouts of the cross-shard transactions received
+// by block
+func (storage *Storage) DumpCxPayouts(block *types.Block) {
+	if block == nil {
+		return
+	}
+	checkpoint := GetCxPayoutCheckpointKey(block.Number())
+	if _, err := storage.GetDB().Get([]byte(checkpoint), nil); err == nil {
+		return
+	}
+
+	storage.lock.Lock()
+	defer storage.lock.Unlock()
+
+	batch := new(leveldb.Batch)
+	for _, payout := range blockCxPayouts(block) {
+		batch.Put(cxPayoutKey(payout), payout.Hash.Bytes())
+	}
+	batch.Put([]byte(checkpoint), []byte{})
+	if err := storage.GetDB().Write(batch, nil); err != nil {
+		utils.Logger().Error().Err(err).Uint64("blockNum", block.NumberU64()).
+			Msg("[Explorer Storage] cannot write cross-shard payouts")
+	}
+}
+
+// GetCxPayouts returns the hashes of the cross-shard transactions paid out to
+// the address of the query, none for the transactions sent
+func (storage *Storage) GetCxPayouts(query TxHistoryQuery) ([]common.Hash, error) {
+	addr, err := common2.Bech32ToAddress(query.Address)
+	if err != nil {
+		return nil, err
+	}
+	txType, all, err := parseTxType(query.TxType)
+	if err != nil {
+		return nil, err
+	}
+	if !all && txType != txTypeReceived {
+		return []common.Hash{}, nil
+	}
+
+	values, err := storage.pageRange(
+		query.timeRange(cxPayoutPrefix(addr)), query.Order == "DESC",
+		query.PageIndex, query.PageSize, nil,
+	)
+	if err != nil {
+		return nil, err
+	}
+	hashes := make([]common.Hash, len(values))
+	for i, value := range values {
+		hashes[i] = common.BytesToHash(value)
+	}
+	return hashes, nil
+}
@@ -0,0 +1,62 @@
+package explorer
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	blockfactory "github.com/nordicenergy/nordicenergy-core/block/factory"
+	"github.com/nordicenergy/nordicenergy-core/core/types"
+	common2 "github.com/nordicenergy/nordicenergy-core/internal/common"
+)
+
+func TestDumpCxPayouts(t *testing.T) {
+	storage := newTestStorage(t)
+	addr := common.BytesToAddress([]byte{0x11})
+	header := blockfactory.NewTestHeader().With().Number(big.NewInt(7)).Time(big.NewInt(100)).Header()
+	block := types.NewBlockWithHeader(header).WithBody(nil, nil, nil, types.CXReceiptsProofs{
+		{Receipts: types.CXReceipts{
+			{TxHash: common.Hash{1}, To: &addr, Amount: big.NewInt(1)},
+			// no receiver
+			{TxHash: common.Hash{2}, Amount: big.NewInt(1)},
+			{TxHash: common.Hash{3}, To: &addr, Amount: big.NewInt(1)},
+		}},
+	})
+
+	payouts := blockCxPayouts(block)
+	if len(payouts) != 2 {
+		t.Fatalf("%d payouts indexed, expected 2", len(payouts))
+	}
+	for i, expected := range []struct {
+		hash  common.Hash
+		index uint32
+	}{{common.Hash{1}, 0}, {common.Hash{3}, 2}} {
+		payout := payouts[i]
+		if payout.Hash != expected.hash || payout.Index != expected.index || payout.Address != addr ||
+			payout.Type != txTypeReceived || payout.BlockNum != 7 || payout.Time != 100 {
+			t.Errorf("payout %d indexed as %+v", i, payout)
+		}
+	}
+
+	storage.Dump(block, block.NumberU64())
+	storage.DumpCxPayouts(block)
+	// dumped once
+	storage.DumpCxPayouts(block)
+
+	bech32 := common2.MustAddressToBech32(addr)
+	hashes, err := storage.GetCxPayouts(TxHistoryQuery{Address: bech32, Order: "DESC"})
+	if err != nil || !reflect.DeepEqual(hashes, []common.Hash{{3}, {1}}) {
+		t.Errorf("payouts %v (%v), expected the 2 payouts, the latest first", hashes, err)
+	}
+	if hashes, err := storage.GetCxPayouts(TxHistoryQuery{Address: bech32, TxType: Sent}); err != nil || len(hashes) != 0 {
+		t.Errorf("sent payouts %v (%v), expected none", hashes, err)
+	}
+	// the payouts are not in the transaction history of the receiver
+	if hashes, err := storage.GetTxHistory(TxHistoryQuery{Address: bech32}, false); err != nil || len(hashes) != 0 {
+		t.Errorf("transaction history %v (%v), expected none", hashes, err)
+	}
+	if count, _ := storage.GetTxCount(bech32, Received, false); count != 0 {
+		t.Errorf("received count %d, expected 0", count)
+	}
+}
@@ -0,0 +1,301 @@
+package explorer
+
+import (
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/nordicenergy/nordicenergy-core/core/types"
+	common2 "github.com/nordicenergy/nordicenergy-core/internal/common"
+	"github.com/nordicenergy/nordicenergy-core/internal/utils"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+// Constants of the transaction index. Each transaction of an address has its
+// own key, ordered by the time and the position of its block, for the history
+// of an address to be read by page and by time range.
+const (
+	TxIndexPrefix        = "ti"
+	StakingTxIndexPrefix = "si"
+	TxCountPrefix        = "tc"
+	StakingTxCountPrefix = "sc"
+	// VersionKey is the key of the version of the index format
+	VersionKey = "explorer_version"
+	// IndexVersion is the version of the index format. The previous version
+	// held all the records of an address in a single value under its
+	// address key.
+	IndexVersion = 2
+)
+
+const (
+	txTypeSent byte = iota
+	txTypeReceived
+)
+
+// ErrInvalidTxType is returned for a transaction type other than Sent,
+// Received or ALL
+var ErrInvalidTxType = errors.New("invalid transaction type")
+
+// TxHistoryQuery is a query of the transactions of an address
+type TxHistoryQuery struct {
+	// Address is the bech32 address
+	Address string
+	// TxType is Sent or Received, all the transactions if empty or ALL
+	TxType string
+	// Order is DESC for the latest transactions first, ascending otherwise
+	Order     string
+	PageIndex uint32
+	// PageSize is the number of transactions of a page, all if zero
+	PageSize uint32
+	// FromTime and ToTime bound the time of the blocks of the transactions,
+	// in unix seconds and included, unbounded if zero
+	FromTime uint64
+	ToTime   uint64
+}
+
+// indexedTx is a transaction of an address in the index
+type indexedTx struct {
+	Address  common.Address
+	Staking  bool
+	Type     byte
+	Time     uint64
+	BlockNum uint64
+	Index    uint32
+	Hash     common.Hash
+}
+
+// parseTxType returns the type of txType, and whether it is all the types
+func parseTxType(txType string) (byte, bool, error) {
+	switch txType {
+	case "", "ALL":
+		return 0, true, nil
+	case Sent:
+		return txTypeSent, false, nil
+	case Received:
+		return txTypeReceived, false, nil
+	}
+	return 0, false, ErrInvalidTxType
+}
+
+func txIndexPrefix(staking bool, addr common.Address) []byte {
+	prefix := TxIndexPrefix
+	if staking {
+		prefix = StakingTxIndexPrefix
+	}
+	return append([]byte(prefix+"_"), addr.Bytes()...)
+}
+
+func (tx *indexedTx) key() []byte {
+	key := txIndexPrefix(tx.Staking, tx.Address)
+	key = appendUint64(key, tx.Time)
+	key = appendUint64(key, tx.BlockNum)
+	var index [4]byte
+	binary.BigEndian.PutUint32(index[:], tx.Index)
+	return append(append(key, index[:]...), tx.Type)
+}
+
+func txCountKey(staking bool, addr common.Address, txType byte) []byte {
+	prefix := TxCountPrefix
+	if staking {
+		prefix = StakingTxCountPrefix
+	}
+	return append(append([]byte(prefix+"_"), addr.Bytes()...), txType)
+}
+
+func appendUint64(b []byte, n uint64) []byte {
+	var enc [8]byte
+	binary.BigEndian.PutUint64(enc[:], n)
+	return append(b, enc[:]...)
+}
+
+// blockTxs returns the transactions of block indexed for their senders and
+// receivers
+func blockTxs(block *types.Block) []*indexedTx {
+	txs := []*indexedTx{}
+	add := func(bech32 string, staking bool, txType byte, index int, hash common.Hash) {
+		addr, err := common2.Bech32ToAddress(bech32)
+		if err != nil {
+			// no receiver
+			return
+		}
+		txs = append(txs, &indexedTx{
+			Address:  addr,
+			Staking:  staking,
+			Type:     txType,
+			Time:     block.Time().Uint64(),
+			BlockNum: block.NumberU64(),
+			Index:    uint32(index),
+			Hash:     hash,
+		})
+	}
+
+	for i, tx := range block.Transactions() {
+		explorerTransaction, err := GetTransaction(tx, block)
+		if err != nil {
+			utils.Logger().Error().Err(err).Str("txHash", tx.HashByType().String()).
+				Msg("[Explorer Storage] Failed to get GetTransaction mapping")
+			continue
+		}
+		add(explorerTransaction.From, false, txTypeSent, i, tx.HashByType())
+		add(explorerTransaction.To, false, txTypeReceived, i, tx.HashByType())
+	}
+
+	for i, tx := range block.StakingTransactions() {
+		explorerTransaction, err := GetStakingTransaction(tx, block)
+		if err != nil {
+			utils.Logger().Error().Err(err).Str("txHash", tx.Hash().String()).
+				Msg("[Explorer Storage] Failed to get StakingTransaction mapping")
+			continue
+		}
+		add(explorerTransaction.From, true, txTypeSent, i, tx.Hash())
+		// For delegate/undelegate, also store as received staking transaction with to address
+		add(explorerTransaction.To, true, txTypeReceived, i, tx.Hash())
+	}
+	return txs
+}
+
+// indexTxs adds to batch the keys of txs, the address keys and the counts of
+// the transactions of the addresses. The transactions indexed already, as
+// the ones of the internal transactions of a transaction of the address, are
+// not counted again. The address keys of the previous format, holding the
+// records of their address until migrated, are kept. The caller holds the
+// lock.
+func (storage *Storage) indexTxs(batch *leveldb.Batch, txs []*indexedTx) {
+	counts := map[string]uint64{}
+	indexed := map[string]bool{}
+	for _, tx := range txs {
+		key := tx.key()
+		if indexed[string(key)] {
+			continue
+		}
+		indexed[string(key)] = true
+		if ok, _ := storage.GetDB().Has(key, nil); ok {
+			continue
+		}
+		batch.Put(key, tx.Hash.Bytes())
+		counts[string(txCountKey(tx.Staking, tx.Address, tx.Type))]++
+		if bech32, err := common2.AddressToBech32(tx.Address); err == nil {
+			addrKey := []byte(GetAddressKey(bech32))
+			if ok, _ := storage.GetDB().Has(addrKey, nil); !ok {
+				batch.Put(addrKey, []byte{})
+			}
+		}
+	}
+	for key, n := range counts {
+		batch.Put([]byte(key), appendUint64(nil, storage.readCount([]byte(key))+n))
+	}
+}
+
+func (storage *Storage) readCount(key []byte) uint64 {
+	data, err := storage.GetDB().Get(key, nil)
+	if err != nil || len(data) != 8 {
+		return 0
+	}
+	return binary.BigEndian.Uint64(data)
+}
+
+// GetTxHistory returns the hashes of the transactions, or of the staking
+// transactions, of the query
+func (storage *Storage) GetTxHistory(query TxHistoryQuery, staking bool) ([]common.Hash, error) {
+	addr, err := common2.Bech32ToAddress(query.Address)
+	if err != nil {
+		return nil, err
+	}
+	txType, all, err := parseTxType(query.TxType)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := txIndexPrefix(staking, addr)
+	values, err := storage.pageRange(
+		query.timeRange(prefix), query.Order == "DESC",
+		query.PageIndex, query.PageSize, typeMatch(txType, all),
+	)
+	if err != nil {
+		return nil, err
+	}
+	hashes := make([]common.Hash, len(values))
+	for i, value := range values {
+		hashes[i] = common.BytesToHash(value)
+	}
+	return hashes, nil
+}
+
+// timeRange returns the range of the keys with prefix, followed by the time
+// of their block, of the time range of the query
+func (query *TxHistoryQuery) timeRange(prefix []byte) *util.Range {
+	rng := util.BytesPrefix(prefix)
+	if query.FromTime > 0 {
+		rng.Start = appendUint64(append([]byte{}, prefix...), query.FromTime)
+	}
+	if query.ToTime > 0 && query.ToTime+1 > query.ToTime {
+		rng.Limit = appendUint64(append([]byte{}, prefix...), query.ToTime+1)
+	}
+	return rng
+}
+
+// typeMatch returns the match of the keys ending with txType, nil for all
+// the types
+func typeMatch(txType byte, all bool) func(key, value []byte) bool {
+	if all {
+		return nil
+	}
+	return func(key, value []byte) bool {
+		return key[len(key)-1] == txType
+	}
+}
+
+// pageValues returns the values of the page of the keys with prefix, among
+// the ones match returns true for, all if match is nil
+func (storage *Storage) pageValues(
+	prefix []byte, desc bool, pageIndex, pageSize uint32, match func(key, value []byte) bool,
+) ([][]byte, error) {
+	return storage.pageRange(util.BytesPrefix(prefix), desc, pageIndex, pageSize, match)
+}
+
+// pageRange returns the values of the page of the keys of rng, among the
+// ones match returns true for, all if match is nil. The page size is
+// unlimited if zero.
+func (storage *Storage) pageRange(
+	rng *util.Range, desc bool, pageIndex, pageSize uint32, match func(key, value []byte) bool,
+) ([][]byte, error) {
+	it := storage.GetDB().NewIterator(rng, nil)
+	defer it.Release()
+
+	move, ok := it.Next, it.First()
+	if desc {
+		move, ok = it.Prev, it.Last()
+	}
+	skip := uint64(pageIndex) * uint64(pageSize)
+	values := [][]byte{}
+	for ; ok && (pageSize == 0 || len(values) < int(pageSize)); ok = move() {
+		if match != nil && !match(it.Key(), it.Value()) {
+			continue
+		}
+		if skip > 0 {
+			skip--
+			continue
+		}
+		values = append(values, common.CopyBytes(it.Value()))
+	}
+	return values, it.Error()
+}
+
+// GetTxCount returns the number of transactions, or of staking transactions,
+// of address of txType
+func (storage *Storage) GetTxCount(address, txType string, staking bool) (uint64, error) {
+	addr, err := common2.Bech32ToAddress(address)
+	if err != nil {
+		return 0, err
+	}
+	t, all, err := parseTxType(txType)
+	if err != nil {
+		return 0, err
+	}
+	if !all {
+		return storage.readCount(txCountKey(staking, addr, t)), nil
+	}
+	return storage.readCount(txCountKey(staking, addr, txTypeSent)) +
+		storage.readCount(txCountKey(staking, addr, txTypeReceived)), nil
+}
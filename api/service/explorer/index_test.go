@@ -0,0 +1,183 @@
+package explorer
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+	blockfactory "github.com/nordicenergy/nordicenergy-core/block/factory"
+	"github.com/nordicenergy/nordicenergy-core/core/types"
+	common2 "github.com/nordicenergy/nordicenergy-core/internal/common"
+	"github.com/syndtr/goleveldb/leveldb"
+	ldbstorage "github.com/syndtr/goleveldb/leveldb/storage"
+)
+
+func newTestStorage(t *testing.T) *Storage {
+	db, err := leveldb.Open(ldbstorage.NewMemStorage(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Storage{db: db, quit: make(chan struct{})}
+}
+
+func TestTxHistory(t *testing.T) {
+	storage := newTestStorage(t)
+	addr := common.BytesToAddress([]byte{0x11})
+	txs := []*indexedTx{
+		{Address: addr, Type: txTypeSent, Time: 100, BlockNum: 1, Index: 0, Hash: common.Hash{1}},
+		{Address: addr, Type: txTypeReceived, Time: 200, BlockNum: 2, Index: 0, Hash: common.Hash{2}},
+		{Address: addr, Type: txTypeSent, Time: 200, BlockNum: 2, Index: 1, Hash: common.Hash{3}},
+		{Address: addr, Type: txTypeSent, Time: 300, BlockNum: 3, Index: 0, Hash: common.Hash{4}},
+		{Address: addr, Staking: true, Type: txTypeSent, Time: 300, BlockNum: 3, Index: 0, Hash: common.Hash{5}},
+		{Address: common.BytesToAddress([]byte{0x22}), Type: txTypeReceived, Time: 100, BlockNum: 1, Hash: common.Hash{1}},
+	}
+	batch := new(leveldb.Batch)
+	storage.indexTxs(batch, txs)
+	if err := storage.GetDB().Write(batch, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	bech32 := common2.MustAddressToBech32(addr)
+	tests := []struct {
+		query  TxHistoryQuery
+		hashes []common.Hash
+	}{
+		{TxHistoryQuery{}, []common.Hash{{1}, {2}, {3}, {4}}},
+		{TxHistoryQuery{Order: "DESC"}, []common.Hash{{4}, {3}, {2}, {1}}},
+		{TxHistoryQuery{TxType: Sent}, []common.Hash{{1}, {3}, {4}}},
+		{TxHistoryQuery{TxType: Received}, []common.Hash{{2}}},
+		{TxHistoryQuery{PageIndex: 1, PageSize: 3}, []common.Hash{{4}}},
+		{TxHistoryQuery{Order: "DESC", PageIndex: 1, PageSize: 2}, []common.Hash{{2}, {1}}},
+		{TxHistoryQuery{FromTime: 150, ToTime: 200}, []common.Hash{{2}, {3}}},
+		{TxHistoryQuery{FromTime: 300}, []common.Hash{{4}}},
+		{TxHistoryQuery{ToTime: 100, Order: "DESC"}, []common.Hash{{1}}},
+		{TxHistoryQuery{PageIndex: 2, PageSize: 3}, []common.Hash{}},
+	}
+	for i, test := range tests {
+		test.query.Address = bech32
+		hashes, err := storage.GetTxHistory(test.query, false)
+		if err != nil {
+			t.Fatalf("Test %v: %v", i, err)
+		}
+		if !reflect.DeepEqual(hashes, test.hashes) {
+			t.Errorf("Test %v: hashes %v, expected %v", i, hashes, test.hashes)
+		}
+	}
+
+	if hashes, _ := storage.GetTxHistory(TxHistoryQuery{Address: bech32}, true); len(hashes) != 1 || hashes[0] != (common.Hash{5}) {
+		t.Errorf("staking hashes %v, expected the staking transaction", hashes)
+	}
+	if _, err := storage.GetTxHistory(TxHistoryQuery{Address: bech32, TxType: "X"}, false); err != ErrInvalidTxType {
+		t.Errorf("error %v, expected %v", err, ErrInvalidTxType)
+	}
+	for _, test := range []struct {
+		txType string
+		count  uint64
+	}{{"ALL", 4}, {Sent, 3}, {Received, 1}} {
+		if count, _ := storage.GetTxCount(bech32, test.txType, false); count != test.count {
+			t.Errorf("%s count %d, expected %d", test.txType, count, test.count)
+		}
+	}
+	addresses, err := storage.GetAddresses(10, "")
+	if err != nil || len(addresses) != 2 {
+		t.Errorf("addresses %v (%v), expected the 2 addresses indexed", addresses, err)
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	storage := newTestStorage(t)
+	addr := common.BytesToAddress([]byte{0x11})
+	bech32 := common2.MustAddressToBech32(addr)
+	old := Address{
+		ID: bech32,
+		TXs: TxRecords{
+			{Hash: common.Hash{1}.Hex(), Type: Sent, Timestamp: "100000"},
+			{Hash: common.Hash{2}.Hex(), Type: Received, Timestamp: "200000"},
+			// unknown to the chain
+			{Hash: common.Hash{3}.Hex(), Type: Sent, Timestamp: "200000"},
+		},
+		StakingTXs: TxRecords{
+			{Hash: common.Hash{4}.Hex(), Type: Sent, Timestamp: "300000"},
+		},
+	}
+	data, err := rlp.EncodeToBytes(old)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := storage.GetDB().Put([]byte(GetAddressKey(bech32)), data, nil); err != nil {
+		t.Fatal(err)
+	}
+	blocks := map[common.Hash]uint64{{1}: 1, {2}: 2, {4}: 3}
+	lookup := func(hash common.Hash) (uint64, uint64, bool) {
+		blockNum, ok := blocks[hash]
+		return blockNum, 0, ok
+	}
+
+	converted, dropped, err := storage.Migrate(lookup)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if converted != 3 || dropped != 1 {
+		t.Errorf("%d records converted and %d dropped, expected 3 and 1", converted, dropped)
+	}
+	if v := storage.Version(); v != IndexVersion {
+		t.Errorf("version %d, expected %d", v, IndexVersion)
+	}
+	hashes, err := storage.GetTxHistory(TxHistoryQuery{Address: bech32, FromTime: 150}, false)
+	if err != nil || !reflect.DeepEqual(hashes, []common.Hash{{2}}) {
+		t.Errorf("hashes %v (%v), expected the received transaction", hashes, err)
+	}
+	if count, _ := storage.GetTxCount(bech32, "", true); count != 1 {
+		t.Errorf("staking count %d, expected 1", count)
+	}
+	if value, err := storage.GetDB().Get([]byte(GetAddressKey(bech32)), nil); err != nil || len(value) != 0 {
+		t.Errorf("address key of the previous format kept")
+	}
+
+	// the migration is done once
+	if converted, _, _ := storage.Migrate(lookup); converted != 0 {
+		t.Errorf("%d records converted again", converted)
+	}
+}
+
+func TestDumpBeforeMigrate(t *testing.T) {
+	storage := newTestStorage(t)
+	addr := common.BytesToAddress([]byte{0x11})
+	bech32 := common2.MustAddressToBech32(addr)
+	old := Address{
+		ID:  bech32,
+		TXs: TxRecords{{Hash: common.Hash{1}.Hex(), Type: Received, Timestamp: "100000"}},
+	}
+	data, err := rlp.EncodeToBytes(old)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := storage.GetDB().Put([]byte(GetAddressKey(bech32)), data, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// a block committed before the re-indexer migrates the index
+	tx := types.NewTransaction(0, addr, 0, big.NewInt(1), 21000, big.NewInt(1), nil)
+	block := types.NewBlock(blockfactory.NewTestHeader().With().Number(big.NewInt(2)).Time(big.NewInt(200)).Header(),
+		[]*types.Transaction{tx}, types.Receipts{&types.Receipt{}}, nil, nil, nil)
+	storage.Dump(block, block.NumberU64())
+
+	converted, _, err := storage.Migrate(func(hash common.Hash) (uint64, uint64, bool) {
+		return 1, 0, hash == common.Hash{1}
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if converted != 1 {
+		t.Errorf("%d records converted, expected the record of the previous format", converted)
+	}
+	hashes, err := storage.GetTxHistory(TxHistoryQuery{Address: bech32}, false)
+	if err != nil || !reflect.DeepEqual(hashes, []common.Hash{{1}, tx.HashByType()}) {
+		t.Errorf("hashes %v (%v), expected the transactions of both formats", hashes, err)
+	}
+	if count, _ := storage.GetTxCount(bech32, Received, false); count != 2 {
+		t.Errorf("received count %d, expected 2", count)
+	}
+}
@@ -0,0 +1,130 @@
+package explorer
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/nordicenergy/nordicenergy-core/core/types"
+	common2 "github.com/nordicenergy/nordicenergy-core/internal/common"
+	"github.com/nordicenergy/nordicenergy-core/internal/utils"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// Constants of the internal transaction index. The value transfers made by
+// contracts are indexed under the addresses sending and receiving them, and
+// their transactions are added to the transaction history of the addresses.
+const (
+	InternalTxPrefix           = "it"
+	InternalTxCheckpointPrefix = "di"
+)
+
+// GetInternalTxCheckpointKey ...
+func GetInternalTxCheckpointKey(blockNum *big.Int) string {
+	return fmt.Sprintf("%s_%x", InternalTxCheckpointPrefix, blockNum)
+}
+
+// InternalTx is a value transfer made by a contract during the execution of
+// a transaction
+type InternalTx struct {
+	Type     string
+	From     common.Address
+	To       common.Address
+	Value    *big.Int
+	TxHash   common.Hash
+	BlockNum uint64
+	Time     uint64
+	// TxIndex is the position of the transaction in its block, Seq the one
+	// of the transfer in the transaction
+	TxIndex uint32
+	Seq     uint32
+}
+
+func (tx *InternalTx) key(addr common.Address, txType byte) []byte {
+	key := append([]byte(InternalTxPrefix+"_"), addr.Bytes()...)
+	key = appendUint64(key, tx.Time)
+	key = appendUint64(key, tx.BlockNum)
+	var pos [8]byte
+	binary.BigEndian.PutUint32(pos[:4], tx.TxIndex)
+	binary.BigEndian.PutUint32(pos[4:], tx.Seq)
+	return append(append(key, pos[:]...), txType)
+}
+
+// DumpInternalTxs indexes txs, the internal transactions of block, and adds
+// their transactions to the transaction history of their senders and
+// receivers
+func (storage *Storage) DumpInternalTxs(block *types.Block, txs []*InternalTx) {
+	if block == nil {
+		return
+	}
+	checkpoint := GetInternalTxCheckpointKey(block.Number())
+	if _, err := storage.GetDB().Get([]byte(checkpoint), nil); err == nil {
+		return
+	}
+
+	storage.lock.Lock()
+	defer storage.lock.Unlock()
+
+	batch := new(leveldb.Batch)
+	history := []*indexedTx{}
+	for _, tx := range txs {
+		encoded, err := rlp.EncodeToBytes(tx)
+		if err != nil {
+			utils.Logger().Error().Err(err).Str("txHash", tx.TxHash.Hex()).
+				Msg("[Explorer Storage] cannot encode internal transaction")
+			continue
+		}
+		for _, holder := range []struct {
+			addr   common.Address
+			txType byte
+		}{{tx.From, txTypeSent}, {tx.To, txTypeReceived}} {
+			batch.Put(tx.key(holder.addr, holder.txType), encoded)
+			history = append(history, &indexedTx{
+				Address:  holder.addr,
+				Type:     holder.txType,
+				Time:     tx.Time,
+				BlockNum: tx.BlockNum,
+				Index:    tx.TxIndex,
+				Hash:     tx.TxHash,
+			})
+		}
+	}
+	storage.indexTxs(batch, history)
+	batch.Put([]byte(checkpoint), []byte{})
+	if err := storage.GetDB().Write(batch, nil); err != nil {
+		utils.Logger().Error().Err(err).Uint64("blockNum", block.NumberU64()).
+			Msg("[Explorer Storage] cannot write internal transactions")
+	}
+}
+
+// GetInternalTxs returns the internal transactions of the query, sent or
+// received by a contract of address
+func (storage *Storage) GetInternalTxs(query TxHistoryQuery) ([]*InternalTx, error) {
+	addr, err := common2.Bech32ToAddress(query.Address)
+	if err != nil {
+		return nil, err
+	}
+	txType, all, err := parseTxType(query.TxType)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := append([]byte(InternalTxPrefix+"_"), addr.Bytes()...)
+	values, err := storage.pageRange(
+		query.timeRange(prefix), query.Order == "DESC",
+		query.PageIndex, query.PageSize, typeMatch(txType, all),
+	)
+	if err != nil {
+		return nil, err
+	}
+	txs := make([]*InternalTx, len(values))
+	for i, value := range values {
+		txs[i] = &InternalTx{}
+		if err := rlp.DecodeBytes(value, txs[i]); err != nil {
+			return nil, err
+		}
+	}
+	return txs, nil
+}
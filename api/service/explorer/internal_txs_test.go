@@ -0,0 +1,76 @@
+package explorer
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	blockfactory "github.com/nordicenergy/nordicenergy-core/block/factory"
+	"github.com/nordicenergy/nordicenergy-core/core/types"
+	common2 "github.com/nordicenergy/nordicenergy-core/internal/common"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+func TestDumpInternalTxs(t *testing.T) {
+	storage := newTestStorage(t)
+	sender := common.BytesToAddress([]byte{0x11})
+	contract := common.BytesToAddress([]byte{0x22})
+	receiver := common.BytesToAddress([]byte{0x33})
+
+	// the transaction of the sender calling the contract, indexed already
+	batch := new(leveldb.Batch)
+	storage.indexTxs(batch, []*indexedTx{
+		{Address: sender, Type: txTypeSent, Time: 100, BlockNum: 1, Hash: common.Hash{1}},
+		{Address: contract, Type: txTypeReceived, Time: 100, BlockNum: 1, Hash: common.Hash{1}},
+	})
+	if err := storage.GetDB().Write(batch, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	block := types.NewBlockWithHeader(blockfactory.NewTestHeader().With().
+		Number(big.NewInt(1)).Time(big.NewInt(100)).Header())
+	txs := []*InternalTx{
+		{Type: "call", From: contract, To: receiver, Value: big.NewInt(5), TxHash: common.Hash{1}, BlockNum: 1, Time: 100},
+		{Type: "call", From: contract, To: sender, Value: big.NewInt(7), TxHash: common.Hash{1}, BlockNum: 1, Time: 100, Seq: 1},
+	}
+	storage.DumpInternalTxs(block, txs)
+	// dumped once
+	storage.DumpInternalTxs(block, txs)
+
+	bech32 := func(addr common.Address) string { return common2.MustAddressToBech32(addr) }
+	hashes, err := storage.GetTxHistory(TxHistoryQuery{Address: bech32(receiver)}, false)
+	if err != nil || !reflect.DeepEqual(hashes, []common.Hash{{1}}) {
+		t.Errorf("hashes %v (%v), expected the transaction of the internal transaction", hashes, err)
+	}
+	for _, test := range []struct {
+		addr   common.Address
+		txType string
+		count  uint64
+	}{
+		{receiver, Received, 1},
+		// the sender sent and received in the transaction
+		{sender, Sent, 1},
+		{sender, Received, 1},
+		// sent and received once, whatever the number of transfers
+		{contract, Sent, 1},
+		{contract, Received, 1},
+	} {
+		if count, _ := storage.GetTxCount(bech32(test.addr), test.txType, false); count != test.count {
+			t.Errorf("%x %s count %d, expected %d", test.addr, test.txType, count, test.count)
+		}
+	}
+
+	internals, err := storage.GetInternalTxs(TxHistoryQuery{Address: bech32(contract), TxType: Sent, Order: "DESC"})
+	if err != nil || len(internals) != 2 || internals[0].To != sender || internals[1].To != receiver {
+		t.Errorf("internal transactions %v (%v), expected the 2 transfers, latest first", internals, err)
+	}
+	internals, err = storage.GetInternalTxs(TxHistoryQuery{Address: bech32(contract), TxType: Received})
+	if err != nil || len(internals) != 0 {
+		t.Errorf("internal transactions %v (%v), expected none received", internals, err)
+	}
+	internals, err = storage.GetInternalTxs(TxHistoryQuery{Address: bech32(sender), PageSize: 1})
+	if err != nil || len(internals) != 1 || internals[0].Value.Int64() != 7 {
+		t.Errorf("internal transactions %v (%v), expected the transfer received", internals, err)
+	}
+}
@@ -0,0 +1,219 @@
+package explorer
+
+import (
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/nordicenergy/nordicenergy-core/core"
+	common2 "github.com/nordicenergy/nordicenergy-core/internal/common"
+	"github.com/nordicenergy/nordicenergy-core/internal/utils"
+	"github.com/syndtr/goleveldb/leveldb"
+	"github.com/syndtr/goleveldb/leveldb/util"
+)
+
+const (
+	// ReindexKey is the key of the next block of the chain to be re-indexed
+	ReindexKey = "explorer_reindex"
+	// reindexProgressInterval is the number of blocks re-indexed between two
+	// saves of the progress
+	reindexProgressInterval = 1000
+)
+
+// TxLookup returns the number of the block of the transaction of hash and its
+// index in the block, false if the transaction is unknown
+type TxLookup func(hash common.Hash) (blockNum, index uint64, ok bool)
+
+// Version returns the version of the index format
+func (storage *Storage) Version() uint64 {
+	data, err := storage.GetDB().Get([]byte(VersionKey), nil)
+	if err != nil {
+		return 1
+	}
+	version, err := strconv.ParseUint(string(data), 10, 64)
+	if err != nil {
+		return 1
+	}
+	return version
+}
+
+// Migrate converts the index of the previous format, holding all the records
+// of an address in a single value under its address key, to the current one.
+// The block and the position of the transactions, not recorded by the
+// previous format, are found with lookup. It returns the number of records
+// converted and dropped, for transactions lookup does not know.
+func (storage *Storage) Migrate(lookup TxLookup) (int, int, error) {
+	if storage.Version() >= IndexVersion {
+		return 0, 0, nil
+	}
+	converted, dropped := 0, 0
+	it := storage.GetDB().NewIterator(util.BytesPrefix([]byte(AddressPrefix+"_")), nil)
+	defer it.Release()
+	for it.Next() {
+		if len(it.Value()) == 0 {
+			// address of the current format, or converted already
+			continue
+		}
+		var address Address
+		if err := rlp.DecodeBytes(it.Value(), &address); err != nil {
+			utils.Logger().Error().Err(err).Str("key", string(it.Key())).
+				Msg("[Explorer Storage] cannot decode address records")
+			continue
+		}
+		addr, err := common2.Bech32ToAddress(string(it.Key()[PrefixLen:]))
+		if err != nil {
+			utils.Logger().Error().Err(err).Str("key", string(it.Key())).
+				Msg("[Explorer Storage] dropping records of an invalid address")
+			dropped += len(address.TXs) + len(address.StakingTXs)
+			if err := storage.GetDB().Delete(it.Key(), nil); err != nil {
+				return converted, dropped, err
+			}
+			continue
+		}
+
+		txs := []*indexedTx{}
+		for _, records := range []struct {
+			txs     TxRecords
+			staking bool
+		}{{address.TXs, false}, {address.StakingTXs, true}} {
+			for _, record := range records.txs {
+				tx := migrateRecord(record, addr, records.staking, lookup)
+				if tx == nil {
+					dropped++
+					continue
+				}
+				txs = append(txs, tx)
+			}
+		}
+
+		storage.lock.Lock()
+		batch := new(leveldb.Batch)
+		storage.indexTxs(batch, txs)
+		batch.Put(append([]byte{}, it.Key()...), []byte{})
+		err = storage.GetDB().Write(batch, nil)
+		storage.lock.Unlock()
+		if err != nil {
+			return converted, dropped, err
+		}
+		converted += len(txs)
+	}
+	if err := it.Error(); err != nil {
+		return converted, dropped, err
+	}
+	err := storage.GetDB().Put(
+		[]byte(VersionKey), []byte(strconv.FormatUint(IndexVersion, 10)), nil,
+	)
+	return converted, dropped, err
+}
+
+// migrateRecord returns the indexed transaction of record of addr, nil if
+// the transaction is unknown
+func migrateRecord(record *TxRecord, addr common.Address, staking bool, lookup TxLookup) *indexedTx {
+	txType, all, err := parseTxType(record.Type)
+	if err != nil || all {
+		return nil
+	}
+	hash := common.HexToHash(record.Hash)
+	blockNum, index, ok := lookup(hash)
+	if !ok {
+		return nil
+	}
+	// the previous format recorded the block time in milliseconds
+	ms, _ := strconv.ParseUint(record.Timestamp, 10, 64)
+	return &indexedTx{
+		Address:  addr,
+		Staking:  staking,
+		Type:     txType,
+		Time:     ms / 1000,
+		BlockNum: blockNum,
+		Index:    uint32(index),
+		Hash:     hash,
+	}
+}
+
+// StartReindexer migrates the index of the previous format, then indexes in
+// the background the blocks of bc not indexed yet, from the last block it
+// indexed. The blocks committed meanwhile are indexed by Dump,
+// DumpTokenTransfers and DumpCxPayouts. The internal transactions are not
+// re-indexed, as tracing the blocks requires their parent state.
+func (storage *Storage) StartReindexer(bc *core.BlockChain) {
+	storage.reindexOnce.Do(func() {
+		go storage.reindex(bc)
+	})
+}
+
+// StopReindexer stops the re-indexing, to be resumed by the next start
+func (storage *Storage) StopReindexer() {
+	storage.stopOnce.Do(func() {
+		close(storage.quit)
+	})
+}
+
+func (storage *Storage) reindex(bc *core.BlockChain) {
+	lookup := func(hash common.Hash) (uint64, uint64, bool) {
+		blockHash, blockNum, index := bc.ReadTxLookupEntry(hash)
+		return blockNum, index, blockHash != (common.Hash{})
+	}
+	converted, dropped, err := storage.Migrate(lookup)
+	if err != nil {
+		utils.Logger().Error().Err(err).Msg("[Explorer Storage] cannot migrate index")
+		return
+	}
+	if converted > 0 || dropped > 0 {
+		utils.Logger().Info().Int("converted", converted).Int("dropped", dropped).
+			Msg("[Explorer Storage] migrated index")
+	}
+
+	// the token transfers and the cross-shard payouts are indexed from the
+	// last block they were, for the blocks indexed before their index to be
+	// indexed too
+	next := storage.reindexProgress(ReindexKey)
+	for _, key := range []string{TokenReindexKey, CxPayoutReindexKey} {
+		if keyNext := storage.reindexProgress(key); keyNext < next {
+			next = keyNext
+		}
+	}
+	utils.Logger().Info().Uint64("from", next).Msg("[Explorer Storage] re-indexing blocks")
+	save := func(next uint64) {
+		storage.saveReindexProgress(ReindexKey, next)
+		storage.saveReindexProgress(TokenReindexKey, next)
+		storage.saveReindexProgress(CxPayoutReindexKey, next)
+	}
+	for ; next <= bc.CurrentBlock().NumberU64(); next++ {
+		select {
+		case <-storage.quit:
+			save(next)
+			return
+		default:
+		}
+		block := bc.GetBlockByNumber(next)
+		if block == nil {
+			break
+		}
+		storage.Dump(block, next)
+		storage.DumpTokenTransfers(block, bc.GetReceiptsByHash(block.Hash()))
+		storage.DumpCxPayouts(block)
+		if next%reindexProgressInterval == 0 {
+			save(next)
+		}
+	}
+	save(next)
+	utils.Logger().Info().Uint64("to", next).Msg("[Explorer Storage] re-indexed blocks")
+}
+
+func (storage *Storage) reindexProgress(key string) uint64 {
+	data, err := storage.GetDB().Get([]byte(key), nil)
+	if err != nil {
+		return 0
+	}
+	next, _ := strconv.ParseUint(string(data), 10, 64)
+	return next
+}
+
+func (storage *Storage) saveReindexProgress(key string, next uint64) {
+	err := storage.GetDB().Put([]byte(key), []byte(strconv.FormatUint(next, 10)), nil)
+	if err != nil {
+		utils.Logger().Error().Err(err).Str("key", key).
+			Msg("[Explorer Storage] cannot save re-index progress")
+	}
+}
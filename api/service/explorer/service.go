@@ -65,6 +65,9 @@ func (s *Service) Start() error {
 // Stop shutdowns explorer service.
 func (s *Service) Stop() error {
 	utils.Logger().Info().Msg("Shutting down explorer service.")
+	if s.Storage != nil {
+		s.Storage.StopReindexer()
+	}
 	if err := s.server.Shutdown(context.Background()); err != nil {
 		utils.Logger().Error().Err(err).Msg("Error when shutting down explorer server")
 	} else {
@@ -6,7 +6,6 @@ import (
 	"path"
 	"sync"
 
-	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/nordicenergy/nordicenergy-core/core/types"
 	nodeconfig "github.com/nordicenergy/nordicenergy-core/internal/configs/node"
 	"github.com/nordicenergy/nordicenergy-core/internal/utils"
@@ -40,12 +39,16 @@ var once sync.Once
 type Storage struct {
 	db   *leveldb.DB
 	lock sync.Mutex
+
+	reindexOnce sync.Once
+	stopOnce    sync.Once
+	quit        chan struct{}
 }
 
 // GetStorageInstance returns attack model by using singleton pattern.
 func GetStorageInstance(ip, port string) *Storage {
 	once.Do(func() {
-		storage = &Storage{}
+		storage = &Storage{quit: make(chan struct{})}
 		storage.Init(ip, port)
 	})
 	return storage
@@ -77,6 +80,9 @@ func (storage *Storage) GetDB() *leveldb.DB {
 
 // Dump extracts information from block and index them into lvdb for explorer.
 func (storage *Storage) Dump(block *types.Block, height uint64) {
+	if block == nil {
+		return
+	}
 	// Skip dump for redundant blocks with lower block number than the checkpoint block number
 	blockCheckpoint := GetCheckpointKey(block.Header().Number())
 	if _, err := storage.GetDB().Get([]byte(blockCheckpoint), nil); err == nil {
@@ -85,46 +91,14 @@ func (storage *Storage) Dump(block *types.Block, height uint64) {
 
 	storage.lock.Lock()
 	defer storage.lock.Unlock()
-	if block == nil {
-		return
-	}
-
-	acntsTxns, acntsStakingTxns := computeAccountsTransactionsMapForBlock(block)
 
-	for address, txRecords := range acntsTxns {
-		storage.UpdateTxAddressStorage(address, txRecords, false /* isStaking */)
-	}
-	for address, txRecords := range acntsStakingTxns {
-		storage.UpdateTxAddressStorage(address, txRecords, true /* isStaking */)
-	}
-
-	// save checkpoint of block dumped
-	storage.GetDB().Put([]byte(blockCheckpoint), []byte{}, nil)
-}
-
-// UpdateTxAddressStorage updates specific addr tx Address.
-func (storage *Storage) UpdateTxAddressStorage(addr string, txRecords TxRecords, isStaking bool) {
-	var address Address
-	key := GetAddressKey(addr)
-	if data, err := storage.GetDB().Get([]byte(key), nil); err == nil {
-		if err = rlp.DecodeBytes(data, &address); err != nil {
-			utils.Logger().Error().
-				Bool("isStaking", isStaking).Err(err).Msg("Failed due to error")
-		}
-	}
-
-	address.ID = addr
-	if isStaking {
-		address.StakingTXs = append(address.StakingTXs, txRecords...)
-	} else {
-		address.TXs = append(address.TXs, txRecords...)
-	}
-	encoded, err := rlp.EncodeToBytes(address)
-	if err == nil {
-		storage.GetDB().Put([]byte(key), encoded, nil)
-	} else {
-		utils.Logger().Error().
-			Bool("isStaking", isStaking).Err(err).Msg("cannot encode address")
+	// index the transactions with the checkpoint of block dumped
+	batch := new(leveldb.Batch)
+	storage.indexTxs(batch, blockTxs(block))
+	batch.Put([]byte(blockCheckpoint), []byte{})
+	if err := storage.GetDB().Write(batch, nil); err != nil {
+		utils.Logger().Error().Err(err).Uint64("blockNum", height).
+			Msg("[Explorer Storage] cannot write block index")
 	}
 }
 
@@ -132,7 +106,9 @@ func (storage *Storage) UpdateTxAddressStorage(addr string, txRecords TxRecords,
 func (storage *Storage) GetAddresses(size int, prefix string) ([]string, error) {
 	db := storage.GetDB()
 	key := GetAddressKey(prefix)
-	iterator := db.NewIterator(&util.Range{Start: []byte(key)}, nil)
+	// stop at the end of the address keys
+	limit := util.BytesPrefix([]byte(AddressPrefix + "_")).Limit
+	iterator := db.NewIterator(&util.Range{Start: []byte(key), Limit: limit}, nil)
 	addresses := make([]string, 0)
 	read := 0
 	for iterator.Next() && read < size {
@@ -151,86 +127,3 @@ func (storage *Storage) GetAddresses(size int, prefix string) ([]string, error)
 	}
 	return addresses, nil
 }
-
-func computeAccountsTransactionsMapForBlock(
-	block *types.Block,
-) (map[string]TxRecords, map[string]TxRecords) {
-	// mapping from account address to TxRecords for txns in the block
-	var acntsTxns map[string]TxRecords = make(map[string]TxRecords)
-	// mapping from account address to TxRecords for staking txns in the block
-	var acntsStakingTxns map[string]TxRecords = make(map[string]TxRecords)
-
-	// Store txs
-	for _, tx := range block.Transactions() {
-		explorerTransaction, err := GetTransaction(tx, block)
-		if err != nil {
-			utils.Logger().Error().Err(err).Str("txHash", tx.HashByType().String()).
-				Msg("[Explorer Storage] Failed to get GetTransaction mapping")
-			continue
-		}
-
-		// store as sent transaction with from address
-		txRecord := &TxRecord{
-			Hash:      explorerTransaction.ID,
-			Type:      Sent,
-			Timestamp: explorerTransaction.Timestamp,
-		}
-		acntTxns, ok := acntsTxns[explorerTransaction.From]
-		if !ok {
-			acntTxns = make(TxRecords, 0)
-		}
-		acntTxns = append(acntTxns, txRecord)
-		acntsTxns[explorerTransaction.From] = acntTxns
-
-		// store as received transaction with to address
-		txRecord = &TxRecord{
-			Hash:      explorerTransaction.ID,
-			Type:      Received,
-			Timestamp: explorerTransaction.Timestamp,
-		}
-		acntTxns, ok = acntsTxns[explorerTransaction.To]
-		if !ok {
-			acntTxns = make(TxRecords, 0)
-		}
-		acntTxns = append(acntTxns, txRecord)
-		acntsTxns[explorerTransaction.To] = acntTxns
-	}
-
-	// Store staking txns
-	for _, tx := range block.StakingTransactions() {
-		explorerTransaction, err := GetStakingTransaction(tx, block)
-		if err != nil {
-			utils.Logger().Error().Err(err).Str("txHash", tx.Hash().String()).
-				Msg("[Explorer Storage] Failed to get StakingTransaction mapping")
-			continue
-		}
-
-		// store as sent staking transaction with from address
-		txRecord := &TxRecord{
-			Hash:      explorerTransaction.ID,
-			Type:      Sent,
-			Timestamp: explorerTransaction.Timestamp,
-		}
-		acntStakingTxns, ok := acntsStakingTxns[explorerTransaction.From]
-		if !ok {
-			acntStakingTxns = make(TxRecords, 0)
-		}
-		acntStakingTxns = append(acntStakingTxns, txRecord)
-		acntsStakingTxns[explorerTransaction.From] = acntStakingTxns
-
-		// For delegate/undelegate, also store as received staking transaction with to address
-		txRecord = &TxRecord{
-			Hash:      explorerTransaction.ID,
-			Type:      Received,
-			Timestamp: explorerTransaction.Timestamp,
-		}
-		acntStakingTxns, ok = acntsStakingTxns[explorerTransaction.To]
-		if !ok {
-			acntStakingTxns = make(TxRecords, 0)
-		}
-		acntStakingTxns = append(acntStakingTxns, txRecord)
-		acntsStakingTxns[explorerTransaction.To] = acntStakingTxns
-	}
-
-	return acntsTxns, acntsStakingTxns
-}
@@ -0,0 +1,419 @@
+package explorer
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/nordicenergy/nordicenergy-core/core/types"
+	common2 "github.com/nordicenergy/nordicenergy-core/internal/common"
+	"github.com/nordicenergy/nordicenergy-core/internal/utils"
+	"github.com/pkg/errors"
+	"github.com/syndtr/goleveldb/leveldb"
+)
+
+// Constants of the token index. The transfers are indexed under the holders
+// sending and receiving them and under the token contract, and the balances
+// under the holder and the token contract.
+const (
+	TokenTransferPrefix   = "tt"
+	TokenContractPrefix   = "tk"
+	TokenBalancePrefix    = "tb"
+	TokenHolderPrefix     = "th"
+	TokenCheckpointPrefix = "dt"
+	// TokenReindexKey is the key of the next block of the chain whose token
+	// transfers are to be re-indexed
+	TokenReindexKey = "explorer_token_reindex"
+)
+
+// Standards of the tokens indexed
+const (
+	TokenStandardHRC20   = "HRC20"
+	TokenStandardHRC721  = "HRC721"
+	TokenStandardHRC1155 = "HRC1155"
+)
+
+// maxBatchTransfers is the maximum number of tokens of a batch transfer indexed
+const maxBatchTransfers = 256
+
+var (
+	// transferTopic is the event of HRC20 transfers, and of HRC721 ones with
+	// the token ID indexed too
+	transferTopic = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+	// transferSingleTopic and transferBatchTopic are the events of HRC1155
+	// transfers
+	transferSingleTopic = crypto.Keccak256Hash([]byte("TransferSingle(address,address,address,uint256,uint256)"))
+	transferBatchTopic  = crypto.Keccak256Hash([]byte("TransferBatch(address,address,address,uint256[],uint256[])"))
+
+	// ErrNoTokenQuery is returned for a query of token transfers with neither
+	// a holder nor a token
+	ErrNoTokenQuery = errors.New("neither holder nor token to query the transfers of")
+	// ErrBatchTooLarge is returned for a batch transfer of more tokens than
+	// indexed, whose transfers are not indexed
+	ErrBatchTooLarge = errors.Errorf("batch transfer of more than %d tokens", maxBatchTransfers)
+
+	errMalformedArray = errors.New("malformed uint256 array")
+)
+
+// GetTokenCheckpointKey ...
+func GetTokenCheckpointKey(blockNum *big.Int) string {
+	return fmt.Sprintf("%s_%x", TokenCheckpointPrefix, blockNum)
+}
+
+// TokenTransfer is a transfer of tokens decoded from a transfer event
+type TokenTransfer struct {
+	Token    common.Address
+	Standard string
+	From     common.Address
+	To       common.Address
+	// TokenID is the token transferred, zero for HRC20
+	TokenID *big.Int
+	// Value is the amount transferred, one for HRC721
+	Value    *big.Int
+	TxHash   common.Hash
+	BlockNum uint64
+	Time     uint64
+	LogIndex uint32
+	// BatchIndex is the position of the token in an HRC1155 batch transfer
+	BatchIndex uint16
+}
+
+// TokenBalance is the balance of a token held by a holder
+type TokenBalance struct {
+	Holder   common.Address
+	Token    common.Address
+	Standard string
+	// TokenID is the token held, zero for HRC20
+	TokenID *big.Int
+	Balance *big.Int
+}
+
+// TokenTransferQuery is a query of token transfers
+type TokenTransferQuery struct {
+	// Address is the bech32 address of a holder, Token the one of a token
+	// contract. With both, the transfers of the token by the holder.
+	Address string
+	Token   string
+	// Order is DESC for the latest transfers first, ascending otherwise
+	Order     string
+	PageIndex uint32
+	// PageSize is the number of transfers of a page, all if zero
+	PageSize uint32
+}
+
+// decodeTokenTransfers returns the token transfers of the event log, none if
+// it is not a transfer event. It returns ErrBatchTooLarge for a batch
+// transfer of more tokens than indexed.
+func decodeTokenTransfers(log *types.Log) ([]*TokenTransfer, error) {
+	if len(log.Topics) == 0 {
+		return nil, nil
+	}
+	transfer := func(standard string, from, to common.Hash, id, value *big.Int) *TokenTransfer {
+		return &TokenTransfer{
+			Token:    log.Address,
+			Standard: standard,
+			From:     common.BytesToAddress(from.Bytes()),
+			To:       common.BytesToAddress(to.Bytes()),
+			TokenID:  id,
+			Value:    value,
+			TxHash:   log.TxHash,
+			LogIndex: uint32(log.Index),
+		}
+	}
+	topics, data := log.Topics, log.Data
+	switch topics[0] {
+	case transferTopic:
+		if len(topics) == 3 && len(data) == 32 {
+			return []*TokenTransfer{transfer(
+				TokenStandardHRC20, topics[1], topics[2], big.NewInt(0), new(big.Int).SetBytes(data),
+			)}, nil
+		}
+		if len(topics) == 4 && len(data) == 0 {
+			return []*TokenTransfer{transfer(
+				TokenStandardHRC721, topics[1], topics[2], topics[3].Big(), big.NewInt(1),
+			)}, nil
+		}
+	case transferSingleTopic:
+		if len(topics) == 4 && len(data) == 64 {
+			return []*TokenTransfer{transfer(
+				TokenStandardHRC1155, topics[2], topics[3],
+				new(big.Int).SetBytes(data[:32]), new(big.Int).SetBytes(data[32:]),
+			)}, nil
+		}
+	case transferBatchTopic:
+		if len(topics) != 4 || len(data) < 64 {
+			return nil, nil
+		}
+		ids, err1 := decodeUint256Array(data, new(big.Int).SetBytes(data[:32]))
+		values, err2 := decodeUint256Array(data, new(big.Int).SetBytes(data[32:64]))
+		if err1 == ErrBatchTooLarge || err2 == ErrBatchTooLarge {
+			return nil, ErrBatchTooLarge
+		}
+		if err1 != nil || err2 != nil || len(ids) != len(values) {
+			// not a batch transfer of the standard
+			return nil, nil
+		}
+		transfers := []*TokenTransfer{}
+		for i := range ids {
+			t := transfer(TokenStandardHRC1155, topics[2], topics[3], ids[i], values[i])
+			t.BatchIndex = uint16(i)
+			transfers = append(transfers, t)
+		}
+		return transfers, nil
+	}
+	return nil, nil
+}
+
+// decodeUint256Array decodes the ABI encoded uint256 array at offset of data.
+// It returns ErrBatchTooLarge for an array longer than the batch transfers
+// indexed.
+func decodeUint256Array(data []byte, offset *big.Int) ([]*big.Int, error) {
+	if !offset.IsUint64() || offset.Uint64()+32 > uint64(len(data)) {
+		return nil, errMalformedArray
+	}
+	start := offset.Uint64() + 32
+	length := new(big.Int).SetBytes(data[offset.Uint64():start])
+	if !length.IsUint64() || length.Uint64() > uint64(len(data))/32 ||
+		start+32*length.Uint64() > uint64(len(data)) {
+		return nil, errMalformedArray
+	}
+	if length.Uint64() > maxBatchTransfers {
+		return nil, ErrBatchTooLarge
+	}
+	res := make([]*big.Int, length.Uint64())
+	for i := range res {
+		pos := start + 32*uint64(i)
+		res[i] = new(big.Int).SetBytes(data[pos : pos+32])
+	}
+	return res, nil
+}
+
+// blockTokenTransfers returns the token transfers of the logs of receipts,
+// the receipts of block
+func blockTokenTransfers(block *types.Block, receipts types.Receipts) []*TokenTransfer {
+	transfers := []*TokenTransfer{}
+	for _, receipt := range receipts {
+		for _, log := range receipt.Logs {
+			decoded, err := decodeTokenTransfers(log)
+			if err != nil {
+				utils.Logger().Error().Err(err).Str("txHash", receipt.TxHash.Hex()).
+					Uint64("blockNum", block.NumberU64()).Uint("logIndex", log.Index).
+					Msg("[Explorer Storage] token transfers not indexed")
+				continue
+			}
+			for _, t := range decoded {
+				t.BlockNum, t.Time = block.NumberU64(), block.Time().Uint64()
+				if t.TxHash == (common.Hash{}) {
+					t.TxHash = receipt.TxHash
+				}
+				transfers = append(transfers, t)
+			}
+		}
+	}
+	return transfers
+}
+
+func (t *TokenTransfer) position() []byte {
+	key := appendUint64(nil, t.BlockNum)
+	var pos [6]byte
+	binary.BigEndian.PutUint32(pos[:4], t.LogIndex)
+	binary.BigEndian.PutUint16(pos[4:], t.BatchIndex)
+	return append(key, pos[:]...)
+}
+
+func tokenTransferKey(holder common.Address, t *TokenTransfer, txType byte) []byte {
+	key := append([]byte(TokenTransferPrefix+"_"), holder.Bytes()...)
+	return append(append(key, t.position()...), txType)
+}
+
+func tokenContractKey(t *TokenTransfer) []byte {
+	key := append([]byte(TokenContractPrefix+"_"), t.Token.Bytes()...)
+	return append(key, t.position()...)
+}
+
+func tokenBalanceKey(holder, token common.Address, id *big.Int) []byte {
+	key := append([]byte(TokenBalancePrefix+"_"), holder.Bytes()...)
+	key = append(key, token.Bytes()...)
+	return append(key, common.BigToHash(id).Bytes()...)
+}
+
+func tokenHolderKey(token, holder common.Address, id *big.Int) []byte {
+	key := append([]byte(TokenHolderPrefix+"_"), token.Bytes()...)
+	key = append(key, holder.Bytes()...)
+	return append(key, common.BigToHash(id).Bytes()...)
+}
+
+// DumpTokenTransfers indexes the token transfers of the logs of receipts,
+// the receipts of block, and updates the token balances of the holders
+func (storage *Storage) DumpTokenTransfers(block *types.Block, receipts types.Receipts) {
+	if block == nil {
+		return
+	}
+	checkpoint := GetTokenCheckpointKey(block.Number())
+
+	// the checkpoint is read under the lock, for the transfers of a block
+	// dumped concurrently by the re-indexer not to be credited twice
+	storage.lock.Lock()
+	defer storage.lock.Unlock()
+	if _, err := storage.GetDB().Get([]byte(checkpoint), nil); err == nil {
+		return
+	}
+
+	batch := new(leveldb.Batch)
+	balances := map[string]*TokenBalance{}
+	credit := func(holder common.Address, t *TokenTransfer, value *big.Int) {
+		if holder == (common.Address{}) {
+			// minted or burnt
+			return
+		}
+		key := string(tokenBalanceKey(holder, t.Token, t.TokenID))
+		b, ok := balances[key]
+		if !ok {
+			b = storage.readTokenBalance([]byte(key))
+			if b == nil {
+				b = &TokenBalance{
+					Holder: holder, Token: t.Token, Standard: t.Standard,
+					TokenID: t.TokenID, Balance: big.NewInt(0),
+				}
+			}
+			balances[key] = b
+		}
+		b.Balance.Add(b.Balance, value)
+	}
+
+	for _, t := range blockTokenTransfers(block, receipts) {
+		encoded, err := rlp.EncodeToBytes(t)
+		if err != nil {
+			utils.Logger().Error().Err(err).Str("txHash", t.TxHash.Hex()).
+				Msg("[Explorer Storage] cannot encode token transfer")
+			continue
+		}
+		batch.Put(tokenContractKey(t), encoded)
+		if t.From != (common.Address{}) {
+			batch.Put(tokenTransferKey(t.From, t, txTypeSent), encoded)
+		}
+		if t.To != (common.Address{}) {
+			batch.Put(tokenTransferKey(t.To, t, txTypeReceived), encoded)
+		}
+		credit(t.From, t, new(big.Int).Neg(t.Value))
+		credit(t.To, t, t.Value)
+	}
+
+	for key, b := range balances {
+		holderKey := tokenHolderKey(b.Token, b.Holder, b.TokenID)
+		if b.Balance.Sign() <= 0 {
+			batch.Delete([]byte(key))
+			batch.Delete(holderKey)
+			continue
+		}
+		encoded, err := rlp.EncodeToBytes(b)
+		if err != nil {
+			continue
+		}
+		batch.Put([]byte(key), encoded)
+		batch.Put(holderKey, encoded)
+	}
+	batch.Put([]byte(checkpoint), []byte{})
+	if err := storage.GetDB().Write(batch, nil); err != nil {
+		utils.Logger().Error().Err(err).Uint64("blockNum", block.NumberU64()).
+			Msg("[Explorer Storage] cannot write token transfers")
+	}
+}
+
+func (storage *Storage) readTokenBalance(key []byte) *TokenBalance {
+	data, err := storage.GetDB().Get(key, nil)
+	if err != nil {
+		return nil
+	}
+	b := &TokenBalance{}
+	if err := rlp.DecodeBytes(data, b); err != nil {
+		return nil
+	}
+	return b
+}
+
+// GetTokenTransfers returns the token transfers of the query
+func (storage *Storage) GetTokenTransfers(query TokenTransferQuery) ([]*TokenTransfer, error) {
+	var prefix []byte
+	var match func(key, value []byte) bool
+	switch {
+	case query.Address != "":
+		holder, err := common2.Bech32ToAddress(query.Address)
+		if err != nil {
+			return nil, err
+		}
+		prefix = append([]byte(TokenTransferPrefix+"_"), holder.Bytes()...)
+		if query.Token != "" {
+			token, err := common2.Bech32ToAddress(query.Token)
+			if err != nil {
+				return nil, err
+			}
+			match = func(key, value []byte) bool {
+				t := &TokenTransfer{}
+				return rlp.DecodeBytes(value, t) == nil && t.Token == token
+			}
+		}
+	case query.Token != "":
+		token, err := common2.Bech32ToAddress(query.Token)
+		if err != nil {
+			return nil, err
+		}
+		prefix = append([]byte(TokenContractPrefix+"_"), token.Bytes()...)
+	default:
+		return nil, ErrNoTokenQuery
+	}
+
+	values, err := storage.pageValues(
+		prefix, query.Order == "DESC", query.PageIndex, query.PageSize, match,
+	)
+	if err != nil {
+		return nil, err
+	}
+	transfers := make([]*TokenTransfer, len(values))
+	for i, value := range values {
+		transfers[i] = &TokenTransfer{}
+		if err := rlp.DecodeBytes(value, transfers[i]); err != nil {
+			return nil, err
+		}
+	}
+	return transfers, nil
+}
+
+// GetTokenBalances returns the balances of the tokens held by address
+func (storage *Storage) GetTokenBalances(address string) ([]*TokenBalance, error) {
+	addr, err := common2.Bech32ToAddress(address)
+	if err != nil {
+		return nil, err
+	}
+	return storage.getTokenBalances(append([]byte(TokenBalancePrefix+"_"), addr.Bytes()...), 0, 0)
+}
+
+// GetTokenHolders returns the balances of the holders of the token contract
+// of the bech32 address token
+func (storage *Storage) GetTokenHolders(token string, pageIndex, pageSize uint32) ([]*TokenBalance, error) {
+	addr, err := common2.Bech32ToAddress(token)
+	if err != nil {
+		return nil, err
+	}
+	return storage.getTokenBalances(
+		append([]byte(TokenHolderPrefix+"_"), addr.Bytes()...), pageIndex, pageSize,
+	)
+}
+
+func (storage *Storage) getTokenBalances(prefix []byte, pageIndex, pageSize uint32) ([]*TokenBalance, error) {
+	values, err := storage.pageValues(prefix, false, pageIndex, pageSize, nil)
+	if err != nil {
+		return nil, err
+	}
+	balances := make([]*TokenBalance, len(values))
+	for i, value := range values {
+		balances[i] = &TokenBalance{}
+		if err := rlp.DecodeBytes(value, balances[i]); err != nil {
+			return nil, err
+		}
+	}
+	return balances, nil
+}
@@ -0,0 +1,206 @@
+package explorer
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	blockfactory "github.com/nordicenergy/nordicenergy-core/block/factory"
+	"github.com/nordicenergy/nordicenergy-core/core/types"
+	common2 "github.com/nordicenergy/nordicenergy-core/internal/common"
+)
+
+var (
+	testToken  = common.BytesToAddress([]byte{0xaa})
+	testHolder = common.BytesToAddress([]byte{0x11})
+	testOther  = common.BytesToAddress([]byte{0x22})
+)
+
+func addressTopic(addr common.Address) common.Hash {
+	return common.BytesToHash(addr.Bytes())
+}
+
+func uint256s(ns ...int64) []byte {
+	data := []byte{}
+	for _, n := range ns {
+		data = append(data, common.BigToHash(big.NewInt(n)).Bytes()...)
+	}
+	return data
+}
+
+func hrc20Log(from, to common.Address, value int64) *types.Log {
+	return &types.Log{
+		Address: testToken,
+		Topics:  []common.Hash{transferTopic, addressTopic(from), addressTopic(to)},
+		Data:    uint256s(value),
+	}
+}
+
+func TestDecodeTokenTransfers(t *testing.T) {
+	operator := addressTopic(common.BytesToAddress([]byte{0x33}))
+	tests := []struct {
+		log       *types.Log
+		standard  string
+		ids       []int64
+		values    []int64
+		transfers int
+	}{
+		{hrc20Log(testHolder, testOther, 5), TokenStandardHRC20, []int64{0}, []int64{5}, 1},
+		{&types.Log{
+			Topics: []common.Hash{transferTopic, addressTopic(testHolder), addressTopic(testOther), common.BigToHash(big.NewInt(7))},
+		}, TokenStandardHRC721, []int64{7}, []int64{1}, 1},
+		{&types.Log{
+			Topics: []common.Hash{transferSingleTopic, operator, addressTopic(testHolder), addressTopic(testOther)},
+			Data:   uint256s(3, 4),
+		}, TokenStandardHRC1155, []int64{3}, []int64{4}, 1},
+		{&types.Log{
+			Topics: []common.Hash{transferBatchTopic, operator, addressTopic(testHolder), addressTopic(testOther)},
+			// offsets of the ids and the values, then the arrays
+			Data: uint256s(64, 160, 2, 1, 2, 2, 10, 20),
+		}, TokenStandardHRC1155, []int64{1, 2}, []int64{10, 20}, 2},
+		// not a transfer
+		{&types.Log{Topics: []common.Hash{{1}}}, "", nil, nil, 0},
+		// arrays of different lengths
+		{&types.Log{
+			Topics: []common.Hash{transferBatchTopic, operator, addressTopic(testHolder), addressTopic(testOther)},
+			Data:   uint256s(64, 128, 1, 1, 0),
+		}, "", nil, nil, 0},
+	}
+	for i, test := range tests {
+		transfers, err := decodeTokenTransfers(test.log)
+		if err != nil {
+			t.Fatalf("Test %v: %v", i, err)
+		}
+		if len(transfers) != test.transfers {
+			t.Fatalf("Test %v: %d transfers, expected %d", i, len(transfers), test.transfers)
+		}
+		for j, transfer := range transfers {
+			if transfer.Standard != test.standard || transfer.From != testHolder || transfer.To != testOther {
+				t.Errorf("Test %v: transfer %+v", i, transfer)
+			}
+			if transfer.TokenID.Int64() != test.ids[j] || transfer.Value.Int64() != test.values[j] {
+				t.Errorf("Test %v: token %v value %v, expected %v %v",
+					i, transfer.TokenID, transfer.Value, test.ids[j], test.values[j])
+			}
+			if transfer.BatchIndex != uint16(j) {
+				t.Errorf("Test %v: batch index %d, expected %d", i, transfer.BatchIndex, j)
+			}
+		}
+	}
+}
+
+func TestDecodeTokenTransfersBatchTooLarge(t *testing.T) {
+	operator := addressTopic(common.BytesToAddress([]byte{0x33}))
+	n := int64(maxBatchTransfers + 1)
+	// offsets of the ids and the values, then the arrays of n tokens
+	words := []int64{64, 64 + 32*(n+1), n}
+	for i := int64(0); i < n; i++ {
+		words = append(words, i)
+	}
+	words = append(words, n)
+	for i := int64(0); i < n; i++ {
+		words = append(words, 1)
+	}
+	log := &types.Log{
+		Topics: []common.Hash{transferBatchTopic, operator, addressTopic(testHolder), addressTopic(testOther)},
+		Data:   uint256s(words...),
+	}
+	if transfers, err := decodeTokenTransfers(log); err != ErrBatchTooLarge || len(transfers) != 0 {
+		t.Errorf("transfers %v (%v), expected %v", transfers, err, ErrBatchTooLarge)
+	}
+}
+
+func TestDumpTokenTransfers(t *testing.T) {
+	storage := newTestStorage(t)
+	blocks := [][]*types.Log{
+		{hrc20Log(common.Address{}, testHolder, 100)},
+		{hrc20Log(testHolder, testOther, 30), hrc20Log(testHolder, testOther, 70)},
+	}
+	for i, logs := range blocks {
+		header := blockfactory.NewTestHeader().With().
+			Number(big.NewInt(int64(i + 1))).Time(big.NewInt(int64(100 * (i + 1)))).Header()
+		for j, log := range logs {
+			log.Index = uint(j)
+		}
+		receipts := types.Receipts{&types.Receipt{TxHash: common.Hash{byte(i + 1)}, Logs: logs}}
+		storage.DumpTokenTransfers(types.NewBlockWithHeader(header), receipts)
+		// dumped once
+		storage.DumpTokenTransfers(types.NewBlockWithHeader(header), receipts)
+	}
+
+	holder, other := common2.MustAddressToBech32(testHolder), common2.MustAddressToBech32(testOther)
+	token := common2.MustAddressToBech32(testToken)
+	balances, err := storage.GetTokenBalances(holder)
+	if err != nil || len(balances) != 0 {
+		t.Errorf("balances %v (%v), expected none after transferring all", balances, err)
+	}
+	balances, err = storage.GetTokenBalances(other)
+	if err != nil || len(balances) != 1 || balances[0].Balance.Int64() != 100 || balances[0].Token != testToken {
+		t.Errorf("balances %v (%v), expected 100 tokens", balances, err)
+	}
+	holders, err := storage.GetTokenHolders(token, 0, 10)
+	if err != nil || len(holders) != 1 || holders[0].Holder != testOther {
+		t.Errorf("holders %v (%v), expected the receiver", holders, err)
+	}
+
+	tests := []struct {
+		query  TokenTransferQuery
+		values []int64
+	}{
+		{TokenTransferQuery{Address: holder}, []int64{100, 30, 70}},
+		{TokenTransferQuery{Address: holder, Order: "DESC", PageSize: 2}, []int64{70, 30}},
+		{TokenTransferQuery{Address: holder, PageIndex: 1, PageSize: 2}, []int64{70}},
+		{TokenTransferQuery{Address: other, Token: token}, []int64{30, 70}},
+		{TokenTransferQuery{Address: other, Token: holder}, []int64{}},
+		{TokenTransferQuery{Token: token}, []int64{100, 30, 70}},
+	}
+	for i, test := range tests {
+		transfers, err := storage.GetTokenTransfers(test.query)
+		if err != nil {
+			t.Fatalf("Test %v: %v", i, err)
+		}
+		values := []int64{}
+		for _, transfer := range transfers {
+			values = append(values, transfer.Value.Int64())
+		}
+		if len(values) != len(test.values) {
+			t.Fatalf("Test %v: values %v, expected %v", i, values, test.values)
+		}
+		for j := range values {
+			if values[j] != test.values[j] {
+				t.Errorf("Test %v: values %v, expected %v", i, values, test.values)
+			}
+		}
+	}
+	if transfers, _ := storage.GetTokenTransfers(TokenTransferQuery{Token: token}); transfers[0].TxHash != (common.Hash{1}) || transfers[0].Time != 100 {
+		t.Errorf("transfer %+v, expected the hash and the time of its transaction", transfers[0])
+	}
+	if _, err := storage.GetTokenTransfers(TokenTransferQuery{}); err != ErrNoTokenQuery {
+		t.Errorf("error %v, expected %v", err, ErrNoTokenQuery)
+	}
+}
+
+func TestDumpTokenTransfersConcurrently(t *testing.T) {
+	storage := newTestStorage(t)
+	header := blockfactory.NewTestHeader().With().Number(big.NewInt(1)).Time(big.NewInt(100)).Header()
+	receipts := types.Receipts{&types.Receipt{
+		TxHash: common.Hash{1}, Logs: []*types.Log{hrc20Log(common.Address{}, testHolder, 100)},
+	}}
+
+	// the block committed and re-indexed at the same time is credited once
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			storage.DumpTokenTransfers(types.NewBlockWithHeader(header), receipts)
+		}()
+	}
+	wg.Wait()
+
+	balances, err := storage.GetTokenBalances(common2.MustAddressToBech32(testHolder))
+	if err != nil || len(balances) != 1 || balances[0].Balance.Int64() != 100 {
+		t.Errorf("balances %v (%v), expected 100 tokens", balances, err)
+	}
+}
@@ -0,0 +1,83 @@
+package exporter
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// checkpointBlock is a block exported
+type checkpointBlock struct {
+	Number uint64      `json:"number"`
+	Hash   common.Hash `json:"hash"`
+}
+
+// checkpoint is the progress of the export, the blocks exported last, to
+// find the blocks to roll back on a reorg
+type checkpoint struct {
+	path  string
+	depth int
+	// Blocks are the blocks exported last, the latest last
+	Blocks []checkpointBlock `json:"blocks"`
+}
+
+// loadCheckpoint returns the checkpoint saved at path, keeping depth blocks,
+// empty if none is saved
+func loadCheckpoint(path string, depth int) (*checkpoint, error) {
+	c := &checkpoint{path: path, depth: depth}
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// last returns the block exported last, false if none is
+func (c *checkpoint) last() (checkpointBlock, bool) {
+	if len(c.Blocks) == 0 {
+		return checkpointBlock{}, false
+	}
+	return c.Blocks[len(c.Blocks)-1], true
+}
+
+// add adds the block exported
+func (c *checkpoint) add(number uint64, hash common.Hash) {
+	c.Blocks = append(c.Blocks, checkpointBlock{number, hash})
+	if len(c.Blocks) > c.depth {
+		c.Blocks = append([]checkpointBlock{}, c.Blocks[len(c.Blocks)-c.depth:]...)
+	}
+}
+
+// truncate removes the blocks from number on
+func (c *checkpoint) truncate(number uint64) {
+	i := len(c.Blocks)
+	for i > 0 && c.Blocks[i-1].Number >= number {
+		i--
+	}
+	c.Blocks = c.Blocks[:i]
+}
+
+// save writes the checkpoint to its file, replaced atomically
+func (c *checkpoint) save() error {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return err
+	}
+	tmp := c.path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, c.path)
+}
@@ -0,0 +1,303 @@
+package exporter
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"hash/crc32"
+	"io"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/event"
+	blockfactory "github.com/nordicenergy/nordicenergy-core/block/factory"
+	"github.com/nordicenergy/nordicenergy-core/core"
+	"github.com/nordicenergy/nordicenergy-core/core/types"
+)
+
+// testChain is a chain of blocks of shard 0, the canonical ones by number
+type testChain struct {
+	blocks                    []*types.Block
+	chainFeed, headFeed, logs event.Feed
+}
+
+// extend appends n blocks to the chain, fork distinguishing the blocks of
+// the forks
+func (c *testChain) extend(n int, fork int64) {
+	for i := 0; i < n; i++ {
+		header := blockfactory.NewTestHeader().With().
+			Number(big.NewInt(int64(len(c.blocks)))).
+			Time(big.NewInt(fork))
+		if len(c.blocks) > 0 {
+			header = header.ParentHash(c.blocks[len(c.blocks)-1].Hash())
+		}
+		c.blocks = append(c.blocks, types.NewBlockWithHeader(header.Header()))
+	}
+}
+
+func (c *testChain) ShardID() uint32                              { return 0 }
+func (c *testChain) CurrentBlock() *types.Block                   { return c.blocks[len(c.blocks)-1] }
+func (c *testChain) GetReceiptsByHash(common.Hash) types.Receipts { return nil }
+
+func (c *testChain) GetBlockByNumber(number uint64) *types.Block {
+	if number >= uint64(len(c.blocks)) {
+		return nil
+	}
+	return c.blocks[number]
+}
+
+func (c *testChain) SubscribeChainEvent(ch chan<- core.ChainEvent) event.Subscription {
+	return c.chainFeed.Subscribe(ch)
+}
+
+func (c *testChain) SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) event.Subscription {
+	return c.headFeed.Subscribe(ch)
+}
+
+func (c *testChain) SubscribeRemovedLogsEvent(ch chan<- core.RemovedLogsEvent) event.Subscription {
+	return c.logs.Subscribe(ch)
+}
+
+func readRecords(t *testing.T, path string) []*Record {
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	records := []*Record{}
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		record := &Record{}
+		if err := json.Unmarshal(scanner.Bytes(), record); err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestExportRollback(t *testing.T) {
+	dir, err := ioutil.TempDir("", "exporter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	config := Config{
+		CheckpointPath: filepath.Join(dir, "checkpoint.json"),
+		StartBlock:     1,
+		ReorgDepth:     4,
+		FilePath:       filepath.Join(dir, "records.ndjson"),
+	}
+
+	chain := &testChain{}
+	chain.extend(4, 0)
+	s, err := New(config, chain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	initMetrics()
+	if err := s.export(); err != nil {
+		t.Fatal(err)
+	}
+
+	// replace blocks 2 and 3 by a longer fork
+	chain.blocks = chain.blocks[:2]
+	chain.extend(3, 1)
+	if err := s.export(); err != nil {
+		t.Fatal(err)
+	}
+	for _, sink := range s.sinks {
+		sink.Close()
+	}
+
+	records := readRecords(t, config.FilePath)
+	expected := []struct {
+		recordType string
+		number     uint64
+	}{
+		{RecordBlock, 1}, {RecordBlock, 2}, {RecordBlock, 3},
+		{RecordRollback, 2},
+		{RecordBlock, 2}, {RecordBlock, 3}, {RecordBlock, 4},
+	}
+	if len(records) != len(expected) {
+		t.Fatalf("expected %d records, got %d", len(expected), len(records))
+	}
+	for i, e := range expected {
+		if records[i].Type != e.recordType || records[i].BlockNumber != e.number {
+			t.Errorf("record %d: expected %s %d, got %s %d",
+				i, e.recordType, e.number, records[i].Type, records[i].BlockNumber)
+		}
+	}
+	if records[6].BlockHash != chain.blocks[4].Hash() {
+		t.Error("the block exported last is not the head of the fork")
+	}
+
+	// the export resumes from the checkpoint
+	c, err := loadCheckpoint(config.CheckpointPath, config.ReorgDepth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if last, ok := c.last(); !ok || last.Number != 4 || last.Hash != chain.blocks[4].Hash() {
+		t.Errorf("unexpected checkpoint %+v", c.Blocks)
+	}
+}
+
+func TestKafkaSink(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	type produced struct {
+		topic  string
+		keys   []string
+		values [][]byte
+		err    error
+	}
+	producedC := make(chan produced, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			producedC <- produced{err: err}
+			return
+		}
+		defer conn.Close()
+		p, correlationID, err := readProduceRequest(conn)
+		if err != nil {
+			producedC <- produced{err: err}
+			return
+		}
+		producedC <- produced{p.topic, p.keys, p.values, nil}
+
+		response := kafkaWriter{}
+		response.int32(correlationID)
+		response.int32(1)
+		response.string(p.topic)
+		response.int32(1)
+		response.int32(0) // partition
+		response.int16(0) // no error
+		response.int64(0)
+		response.int64(-1)
+		response.int32(0) // throttle time
+		size := kafkaWriter{}
+		size.int32(int32(response.Len()))
+		conn.Write(append(size.Bytes(), response.Bytes()...))
+	}()
+
+	sink, err := NewKafkaSink(KafkaConfig{Broker: listener.Addr().String(), Topic: "blocks"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sink.Close()
+	if err := sink.Write([]*Record{rollbackRecord(0, 5), rollbackRecord(0, 6)}); err != nil {
+		t.Fatal(err)
+	}
+
+	p := <-producedC
+	if p.err != nil {
+		t.Fatal(p.err)
+	}
+	if p.topic != "blocks" || len(p.keys) != 2 || p.keys[0] != "0:5" || p.keys[1] != "0:6" {
+		t.Fatalf("unexpected records %s %v", p.topic, p.keys)
+	}
+	record := &Record{}
+	if err := json.Unmarshal(p.values[1], record); err != nil {
+		t.Fatal(err)
+	}
+	if record.Type != RecordRollback || record.BlockNumber != 6 {
+		t.Errorf("unexpected record %+v", record)
+	}
+}
+
+type produceRequest struct {
+	topic  string
+	keys   []string
+	values [][]byte
+}
+
+// readProduceRequest reads a produce request of a single batch, checking
+// its crc
+func readProduceRequest(conn net.Conn) (*produceRequest, int32, error) {
+	conn.SetDeadline(time.Now().Add(10 * time.Second))
+	var size int32
+	if err := binary.Read(conn, binary.BigEndian, &size); err != nil {
+		return nil, 0, err
+	}
+	data := make([]byte, size)
+	if _, err := io.ReadFull(conn, data); err != nil {
+		return nil, 0, err
+	}
+	r := bytes.NewReader(data)
+	readString := func() string {
+		var n int16
+		binary.Read(r, binary.BigEndian, &n)
+		if n < 0 {
+			return ""
+		}
+		s := make([]byte, n)
+		io.ReadFull(r, s)
+		return string(s)
+	}
+	var header struct {
+		Key, Version  int16
+		CorrelationID int32
+	}
+	binary.Read(r, binary.BigEndian, &header)
+	readString() // client id
+	readString() // transactional id
+	var acks int16
+	var timeout, topics int32
+	binary.Read(r, binary.BigEndian, &acks)
+	binary.Read(r, binary.BigEndian, &timeout)
+	binary.Read(r, binary.BigEndian, &topics)
+	p := &produceRequest{topic: readString()}
+	var partitions, partition, batchSize int32
+	binary.Read(r, binary.BigEndian, &partitions)
+	binary.Read(r, binary.BigEndian, &partition)
+	binary.Read(r, binary.BigEndian, &batchSize)
+
+	var batch struct {
+		BaseOffset  int64
+		Length      int32
+		LeaderEpoch int32
+		Magic       int8
+		CRC         uint32
+	}
+	if err := binary.Read(r, binary.BigEndian, &batch); err != nil {
+		return nil, 0, err
+	}
+	tail := make([]byte, batch.Length-4-1-4)
+	if _, err := io.ReadFull(r, tail); err != nil {
+		return nil, 0, err
+	}
+	if crc32.Checksum(tail, crc32c) != batch.CRC {
+		return nil, 0, io.ErrUnexpectedEOF
+	}
+	t := bytes.NewReader(tail[2+4+8+8+8+2+4:])
+	var count int32
+	binary.Read(t, binary.BigEndian, &count)
+	readBytes := func() []byte {
+		n, _ := binary.ReadVarint(t)
+		b := make([]byte, n)
+		io.ReadFull(t, b)
+		return b
+	}
+	for i := int32(0); i < count; i++ {
+		binary.ReadVarint(t) // length
+		t.ReadByte()         // attributes
+		binary.ReadVarint(t) // timestamp delta
+		binary.ReadVarint(t) // offset delta
+		p.keys = append(p.keys, string(readBytes()))
+		p.values = append(p.values, readBytes())
+		binary.ReadVarint(t) // headers
+	}
+	return p, header.CorrelationID, nil
+}
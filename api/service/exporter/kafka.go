@@ -0,0 +1,282 @@
+package exporter
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"hash/crc32"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Constants of the Kafka protocol, produce requests version 3 with record
+// batches of the format 2
+const (
+	kafkaProduceKey     = 0
+	kafkaProduceVersion = 3
+	kafkaRecordMagic    = 2
+	// kafkaMaxResponse bounds the size of the responses read
+	kafkaMaxResponse = 1 << 20
+)
+
+var crc32c = crc32.MakeTable(crc32.Castagnoli)
+
+// KafkaConfig is the config of a Kafka sink
+type KafkaConfig struct {
+	// Broker is the address of the broker leading Partition of Topic
+	Broker    string
+	Topic     string
+	Partition int32
+	ClientID  string
+	// Acks is the number of acknowledgements of the broker to wait for, -1
+	// for all the in-sync replicas
+	Acks    int16
+	Timeout time.Duration
+}
+
+// KafkaSink produces the records, as JSON values keyed by their key, to a
+// partition of a topic of a broker speaking the Kafka protocol. The
+// rollbacks are produced as rollback records.
+type KafkaSink struct {
+	lock          sync.Mutex
+	config        KafkaConfig
+	conn          net.Conn
+	correlationID int32
+}
+
+// NewKafkaSink returns the Kafka sink of config
+func NewKafkaSink(config KafkaConfig) (*KafkaSink, error) {
+	if config.Broker == "" || config.Topic == "" {
+		return nil, errors.New("kafka sink without broker or topic")
+	}
+	if config.Acks == 0 {
+		config.Acks = 1
+	}
+	if config.Timeout == 0 {
+		config.Timeout = 10 * time.Second
+	}
+	if config.ClientID == "" {
+		config.ClientID = "ngy-exporter"
+	}
+	return &KafkaSink{config: config}, nil
+}
+
+// Name implements Sink
+func (s *KafkaSink) Name() string {
+	return "kafka"
+}
+
+// Write implements Sink
+func (s *KafkaSink) Write(records []*Record) error {
+	if len(records) == 0 {
+		return nil
+	}
+	keys, values := make([][]byte, len(records)), make([][]byte, len(records))
+	for i, record := range records {
+		value, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+		keys[i], values[i] = []byte(record.Key), value
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if err := s.produce(keys, values); err != nil {
+		// reconnect on the next write
+		s.closeConn()
+		return err
+	}
+	return nil
+}
+
+// Rollback implements Sink
+func (s *KafkaSink) Rollback(shardID uint32, blockNum uint64) error {
+	return s.Write([]*Record{rollbackRecord(shardID, blockNum)})
+}
+
+// Close implements Sink
+func (s *KafkaSink) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.closeConn()
+	return nil
+}
+
+func (s *KafkaSink) closeConn() {
+	if s.conn != nil {
+		s.conn.Close()
+		s.conn = nil
+	}
+}
+
+func (s *KafkaSink) produce(keys, values [][]byte) error {
+	if s.conn == nil {
+		conn, err := net.DialTimeout("tcp", s.config.Broker, s.config.Timeout)
+		if err != nil {
+			return err
+		}
+		s.conn = conn
+	}
+	s.correlationID++
+	request := encodeProduceRequest(
+		s.correlationID, s.config, encodeRecordBatch(keys, values, time.Now()),
+	)
+	if err := s.conn.SetDeadline(time.Now().Add(2 * s.config.Timeout)); err != nil {
+		return err
+	}
+	if _, err := s.conn.Write(request); err != nil {
+		return err
+	}
+
+	var size int32
+	if err := binary.Read(s.conn, binary.BigEndian, &size); err != nil {
+		return err
+	}
+	if size < 4 || size > kafkaMaxResponse {
+		return errors.Errorf("invalid kafka response size %d", size)
+	}
+	response := make([]byte, size)
+	if _, err := io.ReadFull(s.conn, response); err != nil {
+		return err
+	}
+	return checkProduceResponse(response, s.correlationID)
+}
+
+// kafkaWriter writes the primitive types of the Kafka protocol
+type kafkaWriter struct {
+	bytes.Buffer
+}
+
+func (w *kafkaWriter) int8(n int8)   { w.WriteByte(byte(n)) }
+func (w *kafkaWriter) int16(n int16) { binary.Write(w, binary.BigEndian, n) }
+func (w *kafkaWriter) int32(n int32) { binary.Write(w, binary.BigEndian, n) }
+func (w *kafkaWriter) int64(n int64) { binary.Write(w, binary.BigEndian, n) }
+
+func (w *kafkaWriter) string(s string) {
+	w.int16(int16(len(s)))
+	w.WriteString(s)
+}
+
+func (w *kafkaWriter) varint(n int64) {
+	var buf [binary.MaxVarintLen64]byte
+	w.Write(buf[:binary.PutVarint(buf[:], n)])
+}
+
+func (w *kafkaWriter) varbytes(b []byte) {
+	w.varint(int64(len(b)))
+	w.Write(b)
+}
+
+// encodeRecordBatch returns the record batch of the records of keys and
+// values produced at t
+func encodeRecordBatch(keys, values [][]byte, t time.Time) []byte {
+	records := kafkaWriter{}
+	for i := range values {
+		record := kafkaWriter{}
+		record.int8(0)          // attributes
+		record.varint(0)        // timestamp delta
+		record.varint(int64(i)) // offset delta
+		record.varbytes(keys[i])
+		record.varbytes(values[i])
+		record.varint(0) // headers
+		records.varint(int64(record.Len()))
+		records.Write(record.Bytes())
+	}
+
+	ms := t.UnixNano() / int64(time.Millisecond)
+	// the part of the batch after the crc, the crc is computed over
+	tail := kafkaWriter{}
+	tail.int16(0) // attributes
+	tail.int32(int32(len(values) - 1))
+	tail.int64(ms)
+	tail.int64(ms)
+	tail.int64(-1) // producer id
+	tail.int16(-1) // producer epoch
+	tail.int32(-1) // base sequence
+	tail.int32(int32(len(values)))
+	tail.Write(records.Bytes())
+
+	batch := kafkaWriter{}
+	batch.int64(0) // base offset
+	batch.int32(int32(4 + 1 + 4 + tail.Len()))
+	batch.int32(-1) // partition leader epoch
+	batch.int8(kafkaRecordMagic)
+	binary.Write(&batch, binary.BigEndian, crc32.Checksum(tail.Bytes(), crc32c))
+	batch.Write(tail.Bytes())
+	return batch.Bytes()
+}
+
+// encodeProduceRequest returns the produce request of batch to the partition
+// of config, with its size
+func encodeProduceRequest(correlationID int32, config KafkaConfig, batch []byte) []byte {
+	body := kafkaWriter{}
+	body.int16(kafkaProduceKey)
+	body.int16(kafkaProduceVersion)
+	body.int32(correlationID)
+	body.string(config.ClientID)
+	body.int16(-1) // no transactional id
+	body.int16(config.Acks)
+	body.int32(int32(config.Timeout / time.Millisecond))
+	body.int32(1) // topics
+	body.string(config.Topic)
+	body.int32(1) // partitions
+	body.int32(config.Partition)
+	body.int32(int32(len(batch)))
+	body.Write(batch)
+
+	request := kafkaWriter{}
+	request.int32(int32(body.Len()))
+	request.Write(body.Bytes())
+	return request.Bytes()
+}
+
+// checkProduceResponse returns the error of the produce response, without
+// its size
+func checkProduceResponse(response []byte, correlationID int32) error {
+	r := bytes.NewReader(response)
+	var id, topics int32
+	if err := binary.Read(r, binary.BigEndian, &id); err != nil {
+		return err
+	}
+	if id != correlationID {
+		return errors.Errorf("kafka response %d to request %d", id, correlationID)
+	}
+	if err := binary.Read(r, binary.BigEndian, &topics); err != nil {
+		return err
+	}
+	for i := int32(0); i < topics; i++ {
+		var nameLen int16
+		if err := binary.Read(r, binary.BigEndian, &nameLen); err != nil {
+			return err
+		}
+		if _, err := r.Seek(int64(nameLen), io.SeekCurrent); err != nil {
+			return err
+		}
+		var partitions int32
+		if err := binary.Read(r, binary.BigEndian, &partitions); err != nil {
+			return err
+		}
+		for j := int32(0); j < partitions; j++ {
+			var partition struct {
+				Index         int32
+				ErrorCode     int16
+				BaseOffset    int64
+				LogAppendTime int64
+			}
+			if err := binary.Read(r, binary.BigEndian, &partition); err != nil {
+				return err
+			}
+			if partition.ErrorCode != 0 {
+				return errors.Errorf(
+					"kafka error %d producing to partition %d", partition.ErrorCode, partition.Index,
+				)
+			}
+		}
+	}
+	return nil
+}
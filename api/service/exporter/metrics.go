@@ -0,0 +1,55 @@
+package exporter
+
+import (
+	"sync"
+
+	prom "github.com/nordicenergy/nordicenergy-core/api/service/prometheus"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	// exporterRecordCounterVec counts the records written to each sink, by
+	// outcome
+	exporterRecordCounterVec = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ngy",
+			Subsystem: "exporter",
+			Name:      "records",
+			Help:      "number of records written to the sinks or failed",
+		},
+		[]string{
+			"sink",
+			"result",
+		},
+	)
+	// exporterRollbackCounter counts the rollbacks of reorgs
+	exporterRollbackCounter = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "ngy",
+			Subsystem: "exporter",
+			Name:      "rollbacks",
+			Help:      "number of rollbacks of the blocks exported replaced by a reorg",
+		},
+	)
+	// exporterBlockGauge is the number of the block exported last
+	exporterBlockGauge = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: "ngy",
+			Subsystem: "exporter",
+			Name:      "block",
+			Help:      "number of the block exported last",
+		},
+	)
+
+	onceMetrics sync.Once
+)
+
+func initMetrics() {
+	onceMetrics.Do(func() {
+		prom.PromRegistry().MustRegister(
+			exporterRecordCounterVec,
+			exporterRollbackCounter,
+			exporterBlockGauge,
+		)
+	})
+}
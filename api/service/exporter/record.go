@@ -0,0 +1,245 @@
+package exporter
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/nordicenergy/nordicenergy-core/core/types"
+)
+
+// Types of the records exported
+const (
+	RecordBlock              = "block"
+	RecordTransaction        = "transaction"
+	RecordStakingTransaction = "staking_transaction"
+	RecordReceipt            = "receipt"
+	RecordLog                = "log"
+	RecordCXReceipt          = "cx_receipt"
+	// RecordRollback is the record of the rollback of the blocks from its
+	// block number on, replaced by a reorg
+	RecordRollback = "rollback"
+)
+
+// Directions of the cross-shard receipts
+const (
+	CXOutgoing = "outgoing"
+	CXIncoming = "incoming"
+)
+
+// Record is a normalized record of the chain exported to the sinks
+type Record struct {
+	Type        string      `json:"type"`
+	ShardID     uint32      `json:"shardId"`
+	BlockNumber uint64      `json:"blockNumber"`
+	BlockHash   common.Hash `json:"blockHash"`
+	// Key identifies the record among the ones of its type
+	Key string `json:"key"`
+	// Data is the Block, Transaction, StakingTransaction, Receipt, Log or
+	// CXReceipt of the record, none for a rollback
+	Data interface{} `json:"data,omitempty"`
+}
+
+// Block is the data of a block record
+type Block struct {
+	Number         uint64         `json:"number"`
+	Hash           common.Hash    `json:"hash"`
+	ParentHash     common.Hash    `json:"parentHash"`
+	Epoch          uint64         `json:"epoch"`
+	ViewID         uint64         `json:"viewId"`
+	Timestamp      uint64         `json:"timestamp"`
+	Miner          common.Address `json:"miner"`
+	StateRoot      common.Hash    `json:"stateRoot"`
+	GasLimit       uint64         `json:"gasLimit"`
+	GasUsed        uint64         `json:"gasUsed"`
+	TxCount        int            `json:"transactionCount"`
+	StakingTxCount int            `json:"stakingTransactionCount"`
+}
+
+// Transaction is the data of a transaction record
+type Transaction struct {
+	Hash      common.Hash     `json:"hash"`
+	Index     int             `json:"index"`
+	From      common.Address  `json:"from"`
+	To        *common.Address `json:"to"`
+	Value     *big.Int        `json:"value"`
+	Nonce     uint64          `json:"nonce"`
+	Gas       uint64          `json:"gas"`
+	GasPrice  *big.Int        `json:"gasPrice"`
+	Input     hexutil.Bytes   `json:"input"`
+	ShardID   uint32          `json:"shardId"`
+	ToShardID uint32          `json:"toShardId"`
+}
+
+// StakingTransaction is the data of a staking transaction record
+type StakingTransaction struct {
+	Hash     common.Hash    `json:"hash"`
+	Index    int            `json:"index"`
+	From     common.Address `json:"from"`
+	Type     string         `json:"type"`
+	Nonce    uint64         `json:"nonce"`
+	Gas      uint64         `json:"gas"`
+	GasPrice *big.Int       `json:"gasPrice"`
+}
+
+// Receipt is the data of a receipt record
+type Receipt struct {
+	TxHash            common.Hash     `json:"transactionHash"`
+	Status            uint64          `json:"status"`
+	GasUsed           uint64          `json:"gasUsed"`
+	CumulativeGasUsed uint64          `json:"cumulativeGasUsed"`
+	ContractAddress   *common.Address `json:"contractAddress"`
+	LogCount          int             `json:"logCount"`
+}
+
+// Log is the data of a log record
+type Log struct {
+	TxHash  common.Hash    `json:"transactionHash"`
+	Index   uint           `json:"logIndex"`
+	Address common.Address `json:"address"`
+	Topics  []common.Hash  `json:"topics"`
+	Data    hexutil.Bytes  `json:"data"`
+}
+
+// CXReceipt is the data of a cross-shard receipt record, of a transaction
+// of the block to another shard, or of one of another shard to the block
+type CXReceipt struct {
+	Direction string          `json:"direction"`
+	TxHash    common.Hash     `json:"transactionHash"`
+	From      common.Address  `json:"from"`
+	To        *common.Address `json:"to"`
+	ShardID   uint32          `json:"shardId"`
+	ToShardID uint32          `json:"toShardId"`
+	Amount    *big.Int        `json:"amount"`
+}
+
+// blockRecords returns the records of block and of its receipts, the block
+// record first
+func blockRecords(block *types.Block, receipts types.Receipts) []*Record {
+	shardID, number, hash := block.ShardID(), block.NumberU64(), block.Hash()
+	records := []*Record{}
+	add := func(recordType, key string, data interface{}) {
+		records = append(records, &Record{
+			Type:        recordType,
+			ShardID:     shardID,
+			BlockNumber: number,
+			BlockHash:   hash,
+			Key:         fmt.Sprintf("%d:%s", shardID, key),
+			Data:        data,
+		})
+	}
+
+	header := block.Header()
+	add(RecordBlock, hash.Hex(), &Block{
+		Number:         number,
+		Hash:           hash,
+		ParentHash:     block.ParentHash(),
+		Epoch:          block.Epoch().Uint64(),
+		ViewID:         header.ViewID().Uint64(),
+		Timestamp:      block.Time().Uint64(),
+		Miner:          header.Coinbase(),
+		StateRoot:      block.Root(),
+		GasLimit:       block.GasLimit(),
+		GasUsed:        block.GasUsed(),
+		TxCount:        len(block.Transactions()),
+		StakingTxCount: len(block.StakingTransactions()),
+	})
+
+	for i, tx := range block.Transactions() {
+		txHash := tx.HashByType()
+		from, _ := types.Sender(types.NewEIP155Signer(tx.ChainID()), tx)
+		add(RecordTransaction, txHash.Hex(), &Transaction{
+			Hash:      txHash,
+			Index:     i,
+			From:      from,
+			To:        tx.To(),
+			Value:     tx.Value(),
+			Nonce:     tx.Nonce(),
+			Gas:       tx.GasLimit(),
+			GasPrice:  tx.GasPrice(),
+			Input:     tx.Data(),
+			ShardID:   tx.ShardID(),
+			ToShardID: tx.ToShardID(),
+		})
+		if tx.ShardID() != tx.ToShardID() {
+			add(RecordCXReceipt, CXOutgoing+":"+txHash.Hex(), &CXReceipt{
+				Direction: CXOutgoing,
+				TxHash:    txHash,
+				From:      from,
+				To:        tx.To(),
+				ShardID:   tx.ShardID(),
+				ToShardID: tx.ToShardID(),
+				Amount:    tx.Value(),
+			})
+		}
+	}
+
+	for i, tx := range block.StakingTransactions() {
+		from, _ := tx.SenderAddress()
+		add(RecordStakingTransaction, tx.Hash().Hex(), &StakingTransaction{
+			Hash:     tx.Hash(),
+			Index:    i,
+			From:     from,
+			Type:     tx.StakingType().String(),
+			Nonce:    tx.Nonce(),
+			Gas:      tx.GasLimit(),
+			GasPrice: tx.GasPrice(),
+		})
+	}
+
+	for _, receipt := range receipts {
+		var contract *common.Address
+		if receipt.ContractAddress != (common.Address{}) {
+			addr := receipt.ContractAddress
+			contract = &addr
+		}
+		add(RecordReceipt, receipt.TxHash.Hex(), &Receipt{
+			TxHash:            receipt.TxHash,
+			Status:            receipt.Status,
+			GasUsed:           receipt.GasUsed,
+			CumulativeGasUsed: receipt.CumulativeGasUsed,
+			ContractAddress:   contract,
+			LogCount:          len(receipt.Logs),
+		})
+		for _, log := range receipt.Logs {
+			txHash := log.TxHash
+			if txHash == (common.Hash{}) {
+				txHash = receipt.TxHash
+			}
+			add(RecordLog, fmt.Sprintf("%s:%d", txHash.Hex(), log.Index), &Log{
+				TxHash:  txHash,
+				Index:   log.Index,
+				Address: log.Address,
+				Topics:  log.Topics,
+				Data:    log.Data,
+			})
+		}
+	}
+
+	for _, proof := range block.IncomingReceipts() {
+		for _, cx := range proof.Receipts {
+			add(RecordCXReceipt, CXIncoming+":"+cx.TxHash.Hex(), &CXReceipt{
+				Direction: CXIncoming,
+				TxHash:    cx.TxHash,
+				From:      cx.From,
+				To:        cx.To,
+				ShardID:   cx.ShardID,
+				ToShardID: cx.ToShardID,
+				Amount:    cx.Amount,
+			})
+		}
+	}
+	return records
+}
+
+// rollbackRecord returns the record of the rollback of the blocks of shardID
+// from blockNum on
+func rollbackRecord(shardID uint32, blockNum uint64) *Record {
+	return &Record{
+		Type:        RecordRollback,
+		ShardID:     shardID,
+		BlockNumber: blockNum,
+		Key:         fmt.Sprintf("%d:%d", shardID, blockNum),
+	}
+}
@@ -0,0 +1,311 @@
+// Package exporter defines a service streaming the blocks of the chain, with
+// their transactions, staking transactions, receipts, logs and cross-shard
+// receipts, to external sinks: newline-delimited JSON files, a Kafka broker
+// or a SQL database. The progress is checkpointed, the export resumes from
+// the checkpoint on restart, and the blocks exported replaced by a reorg are
+// rolled back in the sinks. The records are delivered at least once.
+package exporter
+
+import (
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/event"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/nordicenergy/nordicenergy-core/core"
+	"github.com/nordicenergy/nordicenergy-core/core/types"
+	"github.com/nordicenergy/nordicenergy-core/internal/utils"
+	"github.com/pkg/errors"
+)
+
+const (
+	// defaultReorgDepth is the default number of blocks exported last kept
+	// to find the ones replaced by a reorg
+	defaultReorgDepth = 128
+	// retryInterval is the interval the export is retried at after an error
+	retryInterval = 5 * time.Second
+)
+
+// Config is the config of the exporter service
+type Config struct {
+	// CheckpointPath is the file of the progress of the export
+	CheckpointPath string
+	// StartBlock is the first block exported without checkpoint
+	StartBlock uint64
+	// ReorgDepth is the number of blocks exported last the reorgs are rolled
+	// back within, defaultReorgDepth if zero
+	ReorgDepth int
+	// The sinks, disabled if nil or empty
+	FilePath string
+	Kafka    *KafkaConfig
+	SQL      *SQLConfig
+}
+
+// Chain is the chain exported
+type Chain interface {
+	ShardID() uint32
+	CurrentBlock() *types.Block
+	GetBlockByNumber(number uint64) *types.Block
+	GetReceiptsByHash(hash common.Hash) types.Receipts
+	SubscribeChainEvent(ch chan<- core.ChainEvent) event.Subscription
+	SubscribeChainHeadEvent(ch chan<- core.ChainHeadEvent) event.Subscription
+	SubscribeRemovedLogsEvent(ch chan<- core.RemovedLogsEvent) event.Subscription
+}
+
+// Service exports the blocks of a chain to sinks
+type Service struct {
+	chain      Chain
+	sinks      []Sink
+	checkpoint *checkpoint
+	startBlock uint64
+
+	stopC  chan struct{}
+	stopOn sync.Once
+	doneC  chan struct{}
+}
+
+// New returns the exporter service of chain, writing to the sinks of config
+func New(config Config, chain Chain) (*Service, error) {
+	sinks := []Sink{}
+	closeSinks := func() {
+		for _, sink := range sinks {
+			sink.Close()
+		}
+	}
+	if config.FilePath != "" {
+		sink, err := NewFileSink(config.FilePath)
+		if err != nil {
+			return nil, errors.Wrap(err, "cannot open the file sink")
+		}
+		sinks = append(sinks, sink)
+	}
+	if config.Kafka != nil {
+		sink, err := NewKafkaSink(*config.Kafka)
+		if err != nil {
+			closeSinks()
+			return nil, err
+		}
+		sinks = append(sinks, sink)
+	}
+	if config.SQL != nil {
+		sink, err := NewSQLSink(*config.SQL)
+		if err != nil {
+			closeSinks()
+			return nil, errors.Wrap(err, "cannot open the sql sink")
+		}
+		sinks = append(sinks, sink)
+	}
+	if len(sinks) == 0 {
+		return nil, errors.New("exporter without sink")
+	}
+	s, err := newService(config, chain, sinks)
+	if err != nil {
+		closeSinks()
+		return nil, err
+	}
+	return s, nil
+}
+
+func newService(config Config, chain Chain, sinks []Sink) (*Service, error) {
+	if config.CheckpointPath == "" {
+		return nil, errors.New("exporter without checkpoint path")
+	}
+	depth := config.ReorgDepth
+	if depth <= 0 {
+		depth = defaultReorgDepth
+	}
+	c, err := loadCheckpoint(config.CheckpointPath, depth)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot load the exporter checkpoint")
+	}
+	return &Service{
+		chain:      chain,
+		sinks:      sinks,
+		checkpoint: c,
+		startBlock: config.StartBlock,
+		stopC:      make(chan struct{}),
+		doneC:      make(chan struct{}),
+	}, nil
+}
+
+// Start starts the export.
+func (s *Service) Start() error {
+	utils.Logger().Info().Int("sinks", len(s.sinks)).Msg("Starting exporter service.")
+	initMetrics()
+	go s.loop()
+	return nil
+}
+
+// Stop stops the export and closes the sinks.
+func (s *Service) Stop() error {
+	utils.Logger().Info().Msg("Shutting down exporter service.")
+	s.stopOn.Do(func() {
+		close(s.stopC)
+	})
+	<-s.doneC
+	for _, sink := range s.sinks {
+		if err := sink.Close(); err != nil {
+			utils.Logger().Error().Err(err).Str("sink", sink.Name()).
+				Msg("[Exporter] cannot close sink")
+		}
+	}
+	return nil
+}
+
+// APIs returns the RPC apis of the exporter service, none.
+func (s *Service) APIs() []rpc.API {
+	return nil
+}
+
+// loop exports the blocks on the chain events, and retries after the errors
+func (s *Service) loop() {
+	defer close(s.doneC)
+
+	// the events are received apart from the export, not to hold the chain
+	// on a slow sink
+	wakeC := make(chan struct{}, 1)
+	watchDone := make(chan struct{})
+	defer func() { <-watchDone }()
+	go func() {
+		defer close(watchDone)
+		s.watch(wakeC)
+	}()
+
+	retry := time.NewTimer(0)
+	defer retry.Stop()
+	for {
+		select {
+		case <-wakeC:
+		case <-retry.C:
+		case <-s.stopC:
+			return
+		}
+		if err := s.export(); err != nil {
+			utils.Logger().Error().Err(err).Msg("[Exporter] cannot export blocks, retrying")
+			retry.Reset(retryInterval)
+		}
+	}
+}
+
+// watch signals wakeC on the chain events until the service stops
+func (s *Service) watch(wakeC chan<- struct{}) {
+	chainC := make(chan core.ChainEvent, 64)
+	headC := make(chan core.ChainHeadEvent, 16)
+	removedC := make(chan core.RemovedLogsEvent, 16)
+	chainSub := s.chain.SubscribeChainEvent(chainC)
+	defer chainSub.Unsubscribe()
+	headSub := s.chain.SubscribeChainHeadEvent(headC)
+	defer headSub.Unsubscribe()
+	removedSub := s.chain.SubscribeRemovedLogsEvent(removedC)
+	defer removedSub.Unsubscribe()
+
+	for {
+		select {
+		case <-chainC:
+		case <-headC:
+		case <-removedC:
+			// the logs of the blocks replaced by a reorg, the blocks
+			// exported are checked against the chain
+		case err := <-chainSub.Err():
+			utils.Logger().Error().Err(err).Msg("[Exporter] chain subscription closed")
+			return
+		case <-s.stopC:
+			return
+		}
+		select {
+		case wakeC <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// export exports the blocks of the chain after the checkpoint, rolling back
+// first the ones replaced by a reorg
+func (s *Service) export() error {
+	if err := s.rollback(); err != nil {
+		return err
+	}
+	head := s.chain.CurrentBlock().NumberU64()
+	for {
+		next := s.startBlock
+		last, ok := s.checkpoint.last()
+		if ok {
+			next = last.Number + 1
+		}
+		if next > head {
+			return nil
+		}
+		select {
+		case <-s.stopC:
+			return nil
+		default:
+		}
+
+		block := s.chain.GetBlockByNumber(next)
+		if block == nil {
+			return nil
+		}
+		if ok && block.ParentHash() != last.Hash {
+			// reorg since the rollback
+			if err := s.rollback(); err != nil {
+				return err
+			}
+			if l, _ := s.checkpoint.last(); l == last {
+				return errors.Errorf("block %d does not follow the block exported last", next)
+			}
+			continue
+		}
+		records := blockRecords(block, s.chain.GetReceiptsByHash(block.Hash()))
+		for _, sink := range s.sinks {
+			if err := sink.Write(records); err != nil {
+				exporterRecordCounterVec.WithLabelValues(sink.Name(), "failed").Add(float64(len(records)))
+				return errors.Wrapf(err, "cannot write block %d to sink %s", next, sink.Name())
+			}
+			exporterRecordCounterVec.WithLabelValues(sink.Name(), "written").Add(float64(len(records)))
+		}
+		s.checkpoint.add(block.NumberU64(), block.Hash())
+		if err := s.checkpoint.save(); err != nil {
+			return errors.Wrap(err, "cannot save the exporter checkpoint")
+		}
+		exporterBlockGauge.Set(float64(block.NumberU64()))
+	}
+}
+
+// rollback rolls back in the sinks the blocks exported not in the chain any
+// more, replaced by a reorg or a rewind of the chain
+func (s *Service) rollback() error {
+	blocks := s.checkpoint.Blocks
+	i := len(blocks)
+	for ; i > 0; i-- {
+		b := blocks[i-1]
+		if block := s.chain.GetBlockByNumber(b.Number); block != nil && block.Hash() == b.Hash {
+			break
+		}
+	}
+	if i == len(blocks) {
+		return nil
+	}
+	from := blocks[i].Number
+	if i == 0 {
+		utils.Logger().Error().Uint64("from", from).Int("depth", len(blocks)).
+			Msg("[Exporter] reorg deeper than the blocks checkpointed, rolling back all of them")
+	}
+	shardID := s.chain.ShardID()
+	for _, sink := range s.sinks {
+		if err := sink.Rollback(shardID, from); err != nil {
+			return errors.Wrapf(err, "cannot roll back sink %s from block %d", sink.Name(), from)
+		}
+	}
+	s.checkpoint.truncate(from)
+	if i == 0 {
+		// export again from the first block rolled back
+		s.startBlock = from
+	}
+	if err := s.checkpoint.save(); err != nil {
+		return errors.Wrap(err, "cannot save the exporter checkpoint")
+	}
+	exporterRollbackCounter.Inc()
+	utils.Logger().Info().Uint64("from", from).Msg("[Exporter] rolled back blocks")
+	return nil
+}
@@ -0,0 +1,75 @@
+package exporter
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Sink is a destination of the exported records
+type Sink interface {
+	// Name is the name of the sink in the logs and the metrics
+	Name() string
+	// Write writes the records of a block, the block record first
+	Write(records []*Record) error
+	// Rollback discards the records of the blocks of shardID from blockNum
+	// on, replaced by a reorg
+	Rollback(shardID uint32, blockNum uint64) error
+	Close() error
+}
+
+// FileSink appends the records to a file, as newline-delimited JSON. The
+// rollbacks are appended as rollback records.
+type FileSink struct {
+	lock sync.Mutex
+	file *os.File
+}
+
+// NewFileSink returns the sink appending the records to the file at path
+func NewFileSink(path string) (*FileSink, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	return &FileSink{file: file}, nil
+}
+
+// Name implements Sink
+func (s *FileSink) Name() string {
+	return "file"
+}
+
+// Write implements Sink
+func (s *FileSink) Write(records []*Record) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	w := bufio.NewWriter(s.file)
+	enc := json.NewEncoder(w)
+	for _, record := range records {
+		if err := enc.Encode(record); err != nil {
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	return s.file.Sync()
+}
+
+// Rollback implements Sink
+func (s *FileSink) Rollback(shardID uint32, blockNum uint64) error {
+	return s.Write([]*Record{rollbackRecord(shardID, blockNum)})
+}
+
+// Close implements Sink
+func (s *FileSink) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.file.Close()
+}
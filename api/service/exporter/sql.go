@@ -0,0 +1,137 @@
+package exporter
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// SQLConfig is the config of a SQL sink
+type SQLConfig struct {
+	// Driver is the name of the database/sql driver, linked in by the binary,
+	// postgres for instance
+	Driver string
+	DSN    string
+	// TablePrefix prefixes the names of the tables, ngy_ if empty
+	TablePrefix string
+}
+
+// sqlTables are the tables of the records of each type
+var sqlTables = map[string]string{
+	RecordBlock:              "blocks",
+	RecordTransaction:        "transactions",
+	RecordStakingTransaction: "staking_transactions",
+	RecordReceipt:            "receipts",
+	RecordLog:                "logs",
+	RecordCXReceipt:          "cx_receipts",
+}
+
+// SQLSink writes the records to a table per record type of a SQL database,
+// with the PostgreSQL dialect. The records are upserted by key, the
+// rollbacks delete the records of the blocks rolled back.
+type SQLSink struct {
+	db     *sql.DB
+	prefix string
+}
+
+// NewSQLSink returns the SQL sink of config, creating its tables if needed
+func NewSQLSink(config SQLConfig) (*SQLSink, error) {
+	db, err := sql.Open(config.Driver, config.DSN)
+	if err != nil {
+		return nil, err
+	}
+	s := &SQLSink{db: db, prefix: config.TablePrefix}
+	if s.prefix == "" {
+		s.prefix = "ngy_"
+	}
+	if err := s.createTables(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+func (s *SQLSink) table(recordType string) string {
+	return s.prefix + sqlTables[recordType]
+}
+
+func (s *SQLSink) createTables() error {
+	for recordType := range sqlTables {
+		table := s.table(recordType)
+		if _, err := s.db.Exec(fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s (
+	record_key TEXT PRIMARY KEY,
+	shard_id BIGINT NOT NULL,
+	block_number BIGINT NOT NULL,
+	block_hash TEXT NOT NULL,
+	data TEXT NOT NULL
+)`, table)); err != nil {
+			return errors.Wrapf(err, "cannot create table %s", table)
+		}
+		if _, err := s.db.Exec(fmt.Sprintf(
+			`CREATE INDEX IF NOT EXISTS %s_block ON %s (shard_id, block_number)`, table, table,
+		)); err != nil {
+			return errors.Wrapf(err, "cannot index table %s", table)
+		}
+	}
+	return nil
+}
+
+// Name implements Sink
+func (s *SQLSink) Name() string {
+	return "sql"
+}
+
+// Write implements Sink
+func (s *SQLSink) Write(records []*Record) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	for _, record := range records {
+		if _, ok := sqlTables[record.Type]; !ok {
+			continue
+		}
+		data, err := json.Marshal(record.Data)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		if _, err := tx.Exec(fmt.Sprintf(
+			`INSERT INTO %s (record_key, shard_id, block_number, block_hash, data)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (record_key) DO UPDATE SET shard_id = EXCLUDED.shard_id,
+block_number = EXCLUDED.block_number, block_hash = EXCLUDED.block_hash, data = EXCLUDED.data`,
+			s.table(record.Type)),
+			record.Key, int64(record.ShardID), int64(record.BlockNumber), record.BlockHash.Hex(), string(data),
+		); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// Rollback implements Sink
+func (s *SQLSink) Rollback(shardID uint32, blockNum uint64) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	for recordType := range sqlTables {
+		if _, err := tx.Exec(fmt.Sprintf(
+			`DELETE FROM %s WHERE shard_id = $1 AND block_number >= $2`, s.table(recordType)),
+			int64(shardID), int64(blockNum),
+		); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// Close implements Sink
+func (s *SQLSink) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,93 @@
+// blssigner is a stand-in remote signing service for consensus bls keys.
+// It loads the keys from key files and serves signatures to validators over
+// HTTP with mutual TLS, so that the key material stays off validator hosts.
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/nordicenergy/nordicenergy-core/crypto/bls"
+	"github.com/nordicenergy/nordicenergy-core/internal/blsgen"
+	"github.com/nordicenergy/nordicenergy-core/internal/remotesigner"
+	"github.com/nordicenergy/nordicenergy-core/internal/utils"
+)
+
+var (
+	version string
+	builtBy string
+	builtAt string
+	commit  string
+)
+
+func printVersion(me string) {
+	fmt.Fprintf(os.Stderr, "nordicenergy-core (C) 2019. %v, version %v-%v (%v %v)\n", path.Base(me), version, commit, builtBy, builtAt)
+	os.Exit(0)
+}
+
+func main() {
+	addr := flag.String("addr", "127.0.0.1:9950", "address the signing service listens on")
+	blsDir := flag.String("bls_dir", "", "directory of the bls key files to sign with")
+	blsKeys := flag.String("bls_keys", "", "comma separated list of bls key files to sign with")
+	passFile := flag.String("pass_file", "", "the .pass file of the bls keys, default to the .pass file next to each key")
+	certFile := flag.String("tls_cert", "", "certificate of the signing service")
+	keyFile := flag.String("tls_key", "", "private key of the signing service certificate")
+	caFile := flag.String("tls_ca", "", "CA the validator client certificates are signed by")
+	insecure := flag.Bool("insecure", false, "serve without TLS, for local testing only")
+	logFolder := flag.String("log_folder", "latest", "the folder collecting the logs of this execution")
+	logMaxSize := flag.Int("log_max_size", 100, "the max size in megabytes of the log file before it gets rotated")
+	versionFlag := flag.Bool("version", false, "Output version info")
+	verbosity := flag.Int("verbosity", 3, "Logging verbosity: 0=silent, 1=error, 2=warn, 3=info, 4=debug, 5=detail (default: 3)")
+
+	flag.Parse()
+
+	if *versionFlag {
+		printVersion(os.Args[0])
+	}
+
+	utils.SetLogVerbosity(log.Lvl(*verbosity))
+	utils.AddLogFile(fmt.Sprintf("%v/blssigner.log", *logFolder), *logMaxSize)
+
+	cfg := blsgen.Config{PassSrcType: blsgen.PassSrcAuto}
+	if *blsKeys != "" {
+		cfg.MultiBlsKeys = strings.Split(*blsKeys, ",")
+	}
+	if *blsDir != "" {
+		cfg.BlsDir = blsDir
+	}
+	if *passFile != "" {
+		cfg.PassSrcType, cfg.PassFile = blsgen.PassSrcFile, passFile
+	}
+	keys, err := blsgen.LoadKeys(cfg)
+	if err != nil {
+		utils.FatalErrMsg(err, "cannot load bls keys")
+	}
+	signer := remotesigner.LocalKeySigner{LocalSigner: bls.LocalSigner{}}
+	for _, key := range keys {
+		signer.LocalSigner[key.Pub.Bytes] = key.Pri
+		fmt.Printf("serving bls key %s\n", key.Pub.Bytes.Hex())
+	}
+
+	if *insecure {
+		fmt.Printf("blssigner listening on http://%s without TLS\n", *addr)
+		err = http.ListenAndServe(*addr, remotesigner.NewHandler(signer))
+	} else {
+		server, tlsErr := remotesigner.NewServer(*addr, signer, remotesigner.TLSConfig{
+			CertFile: *certFile,
+			KeyFile:  *keyFile,
+			CAFile:   *caFile,
+		})
+		if tlsErr != nil {
+			utils.FatalErrMsg(tlsErr, "cannot set up TLS")
+		}
+		fmt.Printf("blssigner listening on https://%s\n", *addr)
+		err = server.ListenAndServeTLS("", "")
+	}
+	utils.FatalErrMsg(err, "signing service stopped")
+}
@@ -0,0 +1,85 @@
+// slashingprotection imports and exports the slashing protection records of
+// the bls keys of a node, in the interchange format, to move the keys to
+// another host without signing again what they already signed. The node has
+// to be stopped while it runs.
+//
+//	slashingprotection -db_dir <dir> export <file>
+//	slashingprotection -db_dir <dir> import <file>
+
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path"
+
+	"github.com/nordicenergy/nordicenergy-core/consensus/protection"
+	"github.com/nordicenergy/nordicenergy-core/internal/utils"
+)
+
+var (
+	version string
+	builtBy string
+	builtAt string
+	commit  string
+)
+
+func printVersion(me string) {
+	fmt.Fprintf(os.Stderr, "nordicenergy-core (C) 2019. %v, version %v-%v (%v %v)\n", path.Base(me), version, commit, builtBy, builtAt)
+	os.Exit(0)
+}
+
+func usage() {
+	fmt.Fprintf(os.Stderr, "usage: %s [flags] export|import <file>\n", path.Base(os.Args[0]))
+	flag.PrintDefaults()
+	os.Exit(2)
+}
+
+func main() {
+	dbDir := flag.String("db_dir", ".", "the database directory of the node")
+	versionFlag := flag.Bool("version", false, "Output version info")
+
+	flag.Usage = usage
+	flag.Parse()
+
+	if *versionFlag {
+		printVersion(os.Args[0])
+	}
+	if flag.NArg() != 2 {
+		usage()
+	}
+	command, file := flag.Arg(0), flag.Arg(1)
+
+	dir := protection.Dir(*dbDir)
+	db, err := protection.Open(dir)
+	if err != nil {
+		utils.FatalErrMsg(err, "cannot open the slashing protection database %s", dir)
+	}
+	defer db.Close()
+
+	switch command {
+	case "export":
+		f, err := os.Create(file)
+		if err != nil {
+			utils.FatalErrMsg(err, "cannot create %s", file)
+		}
+		defer f.Close()
+		if err := db.Export(f); err != nil {
+			utils.FatalErrMsg(err, "cannot export the slashing protection records")
+		}
+		fmt.Printf("slashing protection records exported to %s\n", file)
+	case "import":
+		f, err := os.Open(file)
+		if err != nil {
+			utils.FatalErrMsg(err, "cannot open %s", file)
+		}
+		defer f.Close()
+		if err := db.Import(f); err != nil {
+			utils.FatalErrMsg(err, "cannot import the slashing protection records")
+		}
+		fmt.Printf("slashing protection records imported from %s\n", file)
+	default:
+		usage()
+	}
+}
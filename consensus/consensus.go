@@ -7,8 +7,10 @@ import (
 
 	"github.com/nordicenergy/nordicenergy-core/crypto/bls"
 
+	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/nordicenergy/abool"
 	bls_core "github.com/nordicenergy/bls/ffi/go/bls"
+	"github.com/nordicenergy/nordicenergy-core/consensus/protection"
 	"github.com/nordicenergy/nordicenergy-core/consensus/quorum"
 	"github.com/nordicenergy/nordicenergy-core/core"
 	"github.com/nordicenergy/nordicenergy-core/core/types"
@@ -93,6 +95,9 @@ type Consensus struct {
 	// The post-consensus job func passed from Node object
 	// Called when consensus on a new block is dnet
 	PostConsensusJob func(*types.Block) error
+	// OnViewChange is called, if set, when the node starts a view change to
+	// nextViewID for the block blockNum
+	OnViewChange func(blockNum, nextViewID uint64, cause ViewChangeCause)
 	// The verifier func passed from Node object
 	BlockVerifier BlockVerifierFunc
 	// verified block to state sync broadcast
@@ -124,6 +129,14 @@ type Consensus struct {
 	NextBlockDue time.Time
 	// Temporary flag to control whether aggregate signature signing is enabled
 	AggregateSig bool
+	// Refuses signatures conflicting with what the local keys already signed, nil if disabled
+	slashingProtection *protection.DB
+	// The source of time of the consensus timeouts and the block time
+	clock utils.Clock
+	// The traces of the last consensus rounds
+	timeline *timeline
+	// Verifies the signatures of the votes the leader receives in batches
+	voteVerifier *voteVerifier
 
 	// TODO (leo): an new metrics system to keep track of the consensus/viewchange
 	// finality of previous consensus in the unit of milliseconds
@@ -138,6 +151,26 @@ func (consensus *Consensus) SetCommitDelay(delay time.Duration) {
 	consensus.delayCommit = delay
 }
 
+// SetSlashingProtection sets the database the local keys record their
+// signatures in, so that conflicting signatures are refused before signing.
+func (consensus *Consensus) SetSlashingProtection(db *protection.DB) {
+	consensus.slashingProtection = db
+}
+
+// SetFBFTLogStore makes the FBFT log and the consensus state persist in db.
+// They are replayed on Start, so that a restarted node resumes its round.
+func (consensus *Consensus) SetFBFTLogStore(db ethdb.KeyValueStore) {
+	consensus.FBFTLog.SetStore(db)
+}
+
+// SetClock makes the consensus timeouts and the block time run on clock.
+// It has to be called before Start.
+func (consensus *Consensus) SetClock(clock utils.Clock) {
+	consensus.clock = clock
+	consensus.consensusTimeout = createTimeout(clock)
+	consensus.msgSender.clock = clock
+}
+
 // BlocksSynchronized lets the main loop know that block synchronization finished
 // thus the blockchain is likely to be up to date.
 func (consensus *Consensus) BlocksSynchronized() {
@@ -195,7 +228,10 @@ func New(
 	consensus.phase = FBFTAnnounce
 	consensus.current = State{mode: Normal}
 	// FBFT timeout
-	consensus.consensusTimeout = createTimeout()
+	consensus.clock = utils.SystemClock{}
+	consensus.consensusTimeout = createTimeout(consensus.clock)
+	consensus.timeline = newTimeline(DefaultTimelineSize)
+	consensus.voteVerifier = newVoteVerifier(consensus.onVotesVerified)
 
 	if multiBLSPriKey != nil {
 		consensus.priKey = multiBLSPriKey
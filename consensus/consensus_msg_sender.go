@@ -24,6 +24,8 @@ type MessageSender struct {
 	host p2p.Host
 	// RetryTimes is number of retry attempts
 	retryTimes int
+	// clock measures the interval between retries
+	clock utils.Clock
 }
 
 // MessageRetry controls the message that can be retried
@@ -38,7 +40,11 @@ type MessageRetry struct {
 
 // NewMessageSender initializes the consensus message sender.
 func NewMessageSender(host p2p.Host) *MessageSender {
-	return &MessageSender{host: host, retryTimes: int(phaseDuration.Seconds()) / RetryIntervalInSec}
+	return &MessageSender{
+		host:       host,
+		retryTimes: int(phaseDuration.Seconds()) / RetryIntervalInSec,
+		clock:      utils.SystemClock{},
+	}
 }
 
 // Reset resets the sender's state for new block
@@ -92,7 +98,7 @@ func (sender *MessageSender) SendWithoutRetry(groups []nodeconfig.GroupID, p2pMs
 // Retry will retry the consensus message for <RetryTimes> times.
 func (sender *MessageSender) Retry(msgRetry *MessageRetry) {
 	for {
-		time.Sleep(RetryIntervalInSec * time.Second)
+		<-sender.clock.After(RetryIntervalInSec * time.Second)
 
 		if msgRetry.retryCount >= sender.retryTimes {
 			// Retried enough times
@@ -61,7 +61,7 @@ var (
 
 // Signs the consensus message and returns the marshaled message.
 func (consensus *Consensus) signAndMarshalConsensusMessage(message *msg_pb.Message,
-	priKey *bls_core.SecretKey) ([]byte, error) {
+	priKey *bls.PrivateKeyWrapper) ([]byte, error) {
 	if err := consensus.signConsensusMessage(message, priKey); err != nil {
 		return empty, err
 	}
@@ -110,30 +110,66 @@ func (consensus *Consensus) UpdatePublicKeys(pubKeys []bls_cosi.PublicKeyWrapper
 
 // NewFaker returns a faker consensus.
 func NewFaker() *Consensus {
-	return &Consensus{}
+	return &Consensus{clock: utils.SystemClock{}}
 }
 
 // Sign on the hash of the message
-func (consensus *Consensus) signMessage(message []byte, priKey *bls_core.SecretKey) []byte {
+func (consensus *Consensus) signMessage(message []byte, priKey *bls.PrivateKeyWrapper) ([]byte, error) {
 	hash := hash.Keccak256(message)
-	signature := priKey.SignHash(hash[:])
-	return signature.Serialize()
+	signature, err := priKey.SignHash(hash[:])
+	if err != nil {
+		return nil, err
+	}
+	return signature.Serialize(), nil
 }
 
 // Sign on the consensus message signature field.
 func (consensus *Consensus) signConsensusMessage(message *msg_pb.Message,
-	priKey *bls_core.SecretKey) error {
+	priKey *bls.PrivateKeyWrapper) error {
+	if err := consensus.checkSlashingProtection(message, priKey); err != nil {
+		return err
+	}
 	message.Signature = nil
 	marshaledMessage, err := protobuf.Marshal(message)
 	if err != nil {
 		return err
 	}
 	// 64 byte of signature on previous data
-	signature := consensus.signMessage(marshaledMessage, priKey)
+	signature, err := consensus.signMessage(marshaledMessage, priKey)
+	if err != nil {
+		return err
+	}
 	message.Signature = signature
 	return nil
 }
 
+// checkSlashingProtection records the block the message is about as signed
+// by the key, or returns an error if that conflicts with an earlier signature.
+func (consensus *Consensus) checkSlashingProtection(
+	message *msg_pb.Message, priKey *bls.PrivateKeyWrapper,
+) error {
+	if consensus.slashingProtection == nil {
+		return nil
+	}
+	var (
+		blockNum, viewID uint64
+		blockHash        common.Hash
+	)
+	if request := message.GetConsensus(); request != nil {
+		blockNum, viewID = request.BlockNum, request.ViewId
+		blockHash = common.BytesToHash(request.BlockHash)
+	} else if request := message.GetViewchange(); request != nil {
+		// view change messages are not about a block, the signed payload stands in for it
+		blockNum, viewID = request.BlockNum, request.ViewId
+		blockHash = hash.Keccak256Hash(request.Payload)
+	} else {
+		return nil
+	}
+	return consensus.slashingProtection.CheckAndRecord(
+		priKey.Pub.Bytes, message.Type, blockNum, viewID, blockHash,
+	)
+}
+
 // UpdateBitmaps update the bitmaps for prepare and commit phase
 func (consensus *Consensus) UpdateBitmaps() {
 	consensus.getLogger().Debug().
@@ -420,7 +456,13 @@ func (consensus *Consensus) UpdateConsensusInformation() Mode {
 	// This is a very rare case scenario and not likely to cause any issue in mainnet. But we need to think about
 	// a solution to take care of this case because the coinbase of the latest block doesn't really represent the
 	// the real current leader in case of M1 view change.
-	if !curHeader.IsLastBlockInEpoch() && curHeader.Number().Uint64() != 0 {
+	if consensus.isLeaderRotationBlock(curHeader) && curHeader.Number().Uint64() != 0 {
+		if leaderPubKey := consensus.rotatedLeader(curHeader); leaderPubKey != nil {
+			consensus.LeaderPubKey = leaderPubKey
+		} else {
+			hasError = true
+		}
+	} else if !curHeader.IsLastBlockInEpoch() && curHeader.Number().Uint64() != 0 {
 		leaderPubKey, err := consensus.getLeaderPubKeyFromCoinbase(curHeader)
 		if err != nil || leaderPubKey == nil {
 			consensus.getLogger().Error().Err(err).
@@ -566,6 +608,15 @@ func (consensus *Consensus) selfCommit(payload []byte) error {
 	commitPayload := signature.ConstructCommitPayload(consensus.Blockchain,
 		block.Epoch(), block.Hash(), block.NumberU64(), block.Header().ViewID().Uint64())
 	for i, key := range consensus.priKey {
+		sig, err := key.SignHash(commitPayload)
+		if err != nil {
+			consensus.getLogger().Error().
+				Err(err).
+				Int("Index", i).
+				Str("Key", key.Pub.Bytes.Hex()).
+				Msg("[selfCommit] New Leader failed to sign commit")
+			continue
+		}
 		if err := consensus.commitBitmap.SetKey(key.Pub.Bytes, true); err != nil {
 			consensus.getLogger().Error().
 				Err(err).
@@ -578,7 +629,7 @@ func (consensus *Consensus) selfCommit(payload []byte) error {
 		if _, err := consensus.Decider.AddNewVote(
 			quorum.Commit,
 			[]*bls_cosi.PublicKeyWrapper{key.Pub},
-			key.Pri.SignHash(commitPayload),
+			sig,
 			common.BytesToHash(consensus.blockHash[:]),
 			block.NumberU64(),
 			block.Header().ViewID().Uint64(),
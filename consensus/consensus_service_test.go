@@ -35,7 +35,8 @@ func TestSignAndMarshalConsensusMessage(t *testing.T) {
 	consensus.blockHash = [32]byte{}
 
 	msg := &msg_pb.Message{}
-	marshaledMessage, err := consensus.signAndMarshalConsensusMessage(msg, blsPriKey)
+	key := bls.WrapperFromPrivateKey(blsPriKey)
+	marshaledMessage, err := consensus.signAndMarshalConsensusMessage(msg, &key)
 
 	if err != nil || len(marshaledMessage) == 0 {
 		t.Errorf("Failed to sign and marshal the message: %s", err)
@@ -20,11 +20,15 @@ func TestConsensusInitialization(t *testing.T) {
 	host, multiBLSPrivateKey, consensus, decider, err := GenerateConsensusForTesting()
 	assert.NoError(t, err)
 
-	messageSender := &MessageSender{host: host, retryTimes: int(phaseDuration.Seconds()) / RetryIntervalInSec}
+	messageSender := &MessageSender{
+		host:       host,
+		retryTimes: int(phaseDuration.Seconds()) / RetryIntervalInSec,
+		clock:      utils.SystemClock{},
+	}
 	fbtLog := NewFBFTLog()
 	state := State{mode: Normal}
 
-	timeouts := createTimeout()
+	timeouts := createTimeout(utils.SystemClock{})
 	expectedTimeouts := make(map[TimeoutType]time.Duration)
 	expectedTimeouts[timeoutConsensus] = phaseDuration
 	expectedTimeouts[timeoutViewChange] = viewChangeDuration
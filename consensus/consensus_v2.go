@@ -29,8 +29,9 @@ import (
 )
 
 var (
-	errSenderPubKeyNotLeader  = errors.New("sender pubkey doesn't match leader")
-	errVerifyMessageSignature = errors.New("verify message signature failed")
+	errSenderPubKeyNotLeader = errors.New("sender pubkey doesn't match leader")
+	// ErrVerifyMessageSignature is returned for a message not signed by its sender
+	ErrVerifyMessageSignature = errors.New("verify message signature failed")
 	errParsingFBFTMessage     = errors.New("failed parsing FBFT message")
 )
 
@@ -41,6 +42,8 @@ const (
 	// CommitSigReceiverTimeout is the timeout for the receiving side of the commit sig
 	// if timeout, the receiver should instead ready directly from db for the commit sig
 	CommitSigReceiverTimeout = 8 * time.Second
+	// tickPeriod is the period the main loop checks the consensus timeouts at
+	tickPeriod = 250 * time.Millisecond
 )
 
 // IsViewChangingMode return true if curernt mode is viewchanging
@@ -72,7 +75,7 @@ func (consensus *Consensus) HandleMessageUpdate(ctx context.Context, msg *msg_pb
 	if msg.Type != msg_pb.MessageType_PREPARE && msg.Type != msg_pb.MessageType_COMMIT {
 		// Leader doesn't need to check validator's message signature since the consensus signature will be checked
 		if !consensus.senderKeySanityChecks(msg, senderKey) {
-			return errVerifyMessageSignature
+			return ErrVerifyMessageSignature
 		}
 	}
 
@@ -165,6 +168,7 @@ func (consensus *Consensus) finalCommit() {
 	consensus.Blockchain.WriteCommitSig(block.NumberU64(), commitSigAndBitmap)
 
 	block.SetCurrentCommitSig(commitSigAndBitmap)
+	consensus.traceRoundEvent(block.NumberU64(), RoundEventFinalCommit)
 	err = consensus.commitBlock(block, FBFTMsg)
 
 	if err != nil || consensus.blockNum-beforeCatchupNum != 1 {
@@ -234,7 +238,7 @@ func (consensus *Consensus) finalCommit() {
 	// If still the leader, send commit sig/bitmap to finish the new block proposal,
 	// else, the block proposal will timeout by itself.
 	if consensus.IsLeader() {
-		if block.IsLastBlockInEpoch() {
+		if block.IsLastBlockInEpoch() || consensus.isLeaderRotationBlock(block.Header()) {
 			// No pipelining
 			go func() {
 				consensus.getLogger().Info().Msg("[finalCommit] sending block proposal signal")
@@ -245,7 +249,7 @@ func (consensus *Consensus) finalCommit() {
 			go func() {
 				select {
 				case consensus.CommitSigChannel <- commitSigAndBitmap:
-				case <-time.After(CommitSigSenderTimeout):
+				case <-consensus.clock.After(CommitSigSenderTimeout):
 					utils.Logger().Error().Err(err).Msg("[finalCommit] channel not received after 6s for commitSigAndBitmap")
 				}
 			}()
@@ -283,6 +287,7 @@ func (consensus *Consensus) BlockCommitSigs(blockNum uint64) ([]byte, error) {
 func (consensus *Consensus) Start(
 	blockChannel chan *types.Block, stopChan, stoppedChan, startChannel chan struct{},
 ) {
+	consensus.replayFBFTLog()
 	go func() {
 		toStart := make(chan struct{}, 1)
 		isInitialLeader := consensus.IsLeader()
@@ -299,20 +304,20 @@ func (consensus *Consensus) Start(
 		}
 		consensus.getLogger().Info().Time("time", time.Now()).Msg("[ConsensusMainLoop] Consensus started")
 		defer close(stoppedChan)
-		ticker := time.NewTicker(250 * time.Millisecond)
-		defer ticker.Stop()
+		tick := consensus.clock.After(tickPeriod)
 		consensus.consensusTimeout[timeoutBootstrap].Start()
 		consensus.getLogger().Info().Msg("[ConsensusMainLoop] Start bootstrap timeout (only once)")
 
 		vdfInProgress := false
 		// Set up next block due time.
-		consensus.NextBlockDue = time.Now().Add(consensus.BlockPeriod)
+		consensus.NextBlockDue = consensus.clock.Now().Add(consensus.BlockPeriod)
 		start := false
 		for {
 			select {
 			case <-toStart:
 				start = true
-			case <-ticker.C:
+			case <-tick:
+				tick = consensus.clock.After(tickPeriod)
 				if !start && isInitialLeader {
 					continue
 				}
@@ -326,11 +331,15 @@ func (consensus *Consensus) Start(
 					}
 					if k != timeoutViewChange {
 						consensus.getLogger().Warn().Msg("[ConsensusMainLoop] Ops Consensus Timeout!!!")
-						consensus.startViewChange()
+						cause := ViewChangeConsensusTimeout
+						if k == timeoutBootstrap {
+							cause = ViewChangeBootstrapTimeout
+						}
+						consensus.startViewChange(cause)
 						break
 					} else {
 						consensus.getLogger().Warn().Msg("[ConsensusMainLoop] Ops View Change Timeout!!!")
-						consensus.startViewChange()
+						consensus.startViewChange(ViewChangeViewChangeTimeout)
 						break
 					}
 				}
@@ -371,11 +380,11 @@ func (consensus *Consensus) Start(
 				}
 				// Sleep to wait for the full block time
 				consensus.getLogger().Info().Msg("[ConsensusMainLoop] Waiting for Block Time")
-				<-time.After(time.Until(consensus.NextBlockDue))
+				<-consensus.clock.After(consensus.NextBlockDue.Sub(consensus.clock.Now()))
 				consensus.StartFinalityCount()
 
 				// Update time due for next block
-				consensus.NextBlockDue = time.Now().Add(consensus.BlockPeriod)
+				consensus.NextBlockDue = consensus.clock.Now().Add(consensus.BlockPeriod)
 
 				//VRF/VDF is only generated in the beacon chain
 				if consensus.NeedsRandomNumberGeneration(newBlock.Header().Epoch()) {
@@ -450,7 +459,7 @@ func (consensus *Consensus) Start(
 					}
 				}
 
-				startTime = time.Now()
+				startTime = consensus.clock.Now()
 				consensus.msgSender.Reset(newBlock.NumberU64())
 
 				consensus.getLogger().Info().
@@ -478,10 +487,10 @@ func (consensus *Consensus) Close() error {
 	// We only need to wait consensus is in normal commit phase
 	utils.Logger().Warn().Str("phase", consensus.phase.String()).Msg("[shutdown] commit phase has to wait")
 
-	maxWait := time.Now().Add(2 * consensus.BlockPeriod)
-	for time.Now().Before(maxWait) && consensus.GetConsensusPhase() == "Commit" {
+	maxWait := consensus.clock.Now().Add(2 * consensus.BlockPeriod)
+	for consensus.clock.Now().Before(maxWait) && consensus.GetConsensusPhase() == "Commit" {
 		utils.Logger().Warn().Msg("[shutdown] wait for consensus finished")
-		time.Sleep(time.Millisecond * 100)
+		<-consensus.clock.After(time.Millisecond * 100)
 	}
 	return nil
 }
@@ -702,6 +711,7 @@ func (consensus *Consensus) commitBlock(blk *types.Block, committedMsg *FBFTMess
 	}
 
 	consensus.FinishFinalityCount()
+	consensus.traceRoundEvent(blk.NumberU64(), RoundEventCommitted)
 	consensus.PostConsensusJob(blk)
 	consensus.SetupForNewConsensus(blk, committedMsg)
 	utils.Logger().Info().Uint64("blockNum", blk.NumberU64()).
@@ -714,7 +724,20 @@ func (consensus *Consensus) commitBlock(blk *types.Block, committedMsg *FBFTMess
 func (consensus *Consensus) SetupForNewConsensus(blk *types.Block, committedMsg *FBFTMessage) {
 	atomic.StoreUint64(&consensus.blockNum, blk.NumberU64()+1)
 	consensus.SetCurBlockViewID(committedMsg.ViewID + 1)
+	wasLeader := consensus.IsLeader()
 	consensus.LeaderPubKey = committedMsg.SenderPubkeys[0]
+	if consensus.isLeaderRotationBlock(blk.Header()) {
+		if leader := consensus.rotatedLeader(blk.Header()); leader != nil {
+			consensus.LeaderPubKey = leader
+		}
+		// the leader of the last block proposes the next one in finalCommit
+		if !wasLeader && consensus.IsLeader() {
+			go func() {
+				consensus.getLogger().Info().Msg("[SetupForNewConsensus] I am the New Leader")
+				consensus.ReadySignal <- SyncProposal
+			}()
+		}
+	}
 	// Update consensus keys at last so the change of leader status doesn't mess up normal flow
 	if blk.IsLastBlockInEpoch() {
 		consensus.SetMode(consensus.UpdateConsensusInformation())
@@ -747,7 +770,6 @@ func (consensus *Consensus) GenerateVrfAndProof(newBlock *types.Block, vrfBlockN
 			Msg("[GenerateVrfAndProof] VRF generation error")
 		return vrfBlockNumbers
 	}
-	sk := vrf_bls.NewVRFSigner(key.Pri)
 	blockHash := [32]byte{}
 	previousHeader := consensus.Blockchain.GetHeaderByNumber(
 		newBlock.NumberU64() - 1,
@@ -758,7 +780,13 @@ func (consensus *Consensus) GenerateVrfAndProof(newBlock *types.Block, vrfBlockN
 	previousHash := previousHeader.Hash()
 	copy(blockHash[:], previousHash[:])
 
-	vrf, proof := sk.Evaluate(blockHash[:])
+	vrf, proof, err := vrf_bls.EvaluateWithSigner(key.SignHash, blockHash[:])
+	if err != nil {
+		consensus.getLogger().Error().
+			Err(err).
+			Msg("[GenerateVrfAndProof] VRF generation error")
+		return vrfBlockNumbers
+	}
 	newBlock.AddVrf(append(vrf[:], proof...))
 
 	consensus.getLogger().Info().
@@ -64,6 +64,8 @@ func (consensus *Consensus) construct(
 	if len(priKeys) == 0 {
 		return nil, errors.New("No private keys provided")
 	}
+	// persist the round the message is signed in before it can be sent
+	consensus.persistState()
 	message := &msg_pb.Message{
 		ServiceType: msg_pb.ServiceType_CONSENSUS,
 		Type:        p,
@@ -104,18 +106,22 @@ func (consensus *Consensus) construct(
 		needMsgSig = false
 		sig := bls_core.Sign{}
 		for _, priKey := range priKeys {
-			if s := priKey.Pri.SignHash(consensusMsg.BlockHash); s != nil {
-				sig.Add(s)
+			s, err := priKey.SignHash(consensusMsg.BlockHash)
+			if err != nil {
+				return nil, err
 			}
+			sig.Add(s)
 		}
 		consensusMsg.Payload = sig.Serialize()
 	case msg_pb.MessageType_COMMIT:
 		needMsgSig = false
 		sig := bls_core.Sign{}
 		for _, priKey := range priKeys {
-			if s := priKey.Pri.SignHash(payloadForSign); s != nil {
-				sig.Add(s)
+			s, err := priKey.SignHash(payloadForSign)
+			if err != nil {
+				return nil, err
 			}
+			sig.Add(s)
 		}
 		consensusMsg.Payload = sig.Serialize()
 	case msg_pb.MessageType_PREPARED:
@@ -129,7 +135,7 @@ func (consensus *Consensus) construct(
 	var err error
 	if needMsgSig {
 		// The message that needs signing only needs to be signed with a single key
-		marshaledMessage, err = consensus.signAndMarshalConsensusMessage(message, priKeys[0].Pri)
+		marshaledMessage, err = consensus.signAndMarshalConsensusMessage(message, priKeys[0])
 	} else {
 		// Skip message (potentially multi-sig) signing for validator consensus messages (prepare and commit)
 		// as signature is already signed on the block data.
@@ -6,6 +6,7 @@ import (
 	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
 	bls_core "github.com/nordicenergy/bls/ffi/go/bls"
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
@@ -14,6 +15,7 @@ import (
 	"github.com/nordicenergy/nordicenergy-core/core/types"
 	"github.com/nordicenergy/nordicenergy-core/crypto/bls"
 	bls_cosi "github.com/nordicenergy/nordicenergy-core/crypto/bls"
+	"github.com/nordicenergy/nordicenergy-core/internal/utils"
 )
 
 // FBFTMessage is the record of pbft messages received by a node during FBFT process
@@ -106,6 +108,8 @@ type FBFTLog struct {
 
 	messages map[fbftMsgID]*FBFTMessage // store messages received in FBFT
 	msgLock  sync.RWMutex
+
+	store *fbftStore // write-ahead log of the above, nil if the log is in memory only
 }
 
 // NewFBFTLog returns new instance of FBFTLog
@@ -118,12 +122,66 @@ func NewFBFTLog() *FBFTLog {
 	return &pbftLog
 }
 
+// SetStore makes the log persist its blocks and messages in db, so that
+// they can be replayed with Replay after a restart
+func (log *FBFTLog) SetStore(db ethdb.KeyValueStore) {
+	log.store = newFBFTStore(db)
+}
+
+// IsPersistent returns whether the log persists its blocks and messages
+func (log *FBFTLog) IsPersistent() bool {
+	return log.store != nil
+}
+
+// Replay loads the blocks and messages persisted before a restart into the log
+func (log *FBFTLog) Replay() error {
+	if log.store == nil {
+		return nil
+	}
+	blocks, verified, err := log.store.readBlocks()
+	if err != nil {
+		return err
+	}
+	msgs, err := log.store.readMessages()
+	if err != nil {
+		return err
+	}
+
+	log.blockLock.Lock()
+	for _, block := range blocks {
+		log.blocks[block.Hash()] = block
+		if _, ok := verified[block.Hash()]; ok {
+			log.verifiedBlocks[block.Hash()] = struct{}{}
+		}
+	}
+	log.blockLock.Unlock()
+
+	log.msgLock.Lock()
+	for _, msg := range msgs {
+		log.messages[msg.id()] = msg
+	}
+	log.msgLock.Unlock()
+	return nil
+}
+
+// persist runs the store write if the log is persistent, logging failures
+// as the in-memory log stays usable
+func (log *FBFTLog) persist(write func(*fbftStore) error) {
+	if log.store == nil {
+		return
+	}
+	if err := write(log.store); err != nil {
+		utils.Logger().Warn().Err(err).Msg("[FBFTLog] failed to persist fbft log")
+	}
+}
+
 // AddBlock add a new block into the log
 func (log *FBFTLog) AddBlock(block *types.Block) {
 	log.blockLock.Lock()
 	defer log.blockLock.Unlock()
 
 	log.blocks[block.Hash()] = block
+	log.persist(func(s *fbftStore) error { return s.writeBlock(block) })
 }
 
 // MarkBlockVerified marks the block as verified
@@ -132,6 +190,7 @@ func (log *FBFTLog) MarkBlockVerified(block *types.Block) {
 	defer log.blockLock.Unlock()
 
 	log.verifiedBlocks[block.Hash()] = struct{}{}
+	log.persist(func(s *fbftStore) error { return s.writeBlockVerified(block) })
 }
 
 // IsBlockVerified checks whether the block is verified
@@ -176,6 +235,12 @@ func (log *FBFTLog) DeleteBlocksLessThan(number uint64) {
 			delete(log.verifiedBlocks, h)
 		}
 	}
+	log.persist(func(s *fbftStore) error {
+		if err := s.deleteLessThan(fbftBlockPrefix, number); err != nil {
+			return err
+		}
+		return s.deleteLessThan(fbftVerifiedPrefix, number)
+	})
 }
 
 // DeleteBlockByNumber deletes block of specific number
@@ -187,6 +252,7 @@ func (log *FBFTLog) DeleteBlockByNumber(number uint64) {
 		if block.NumberU64() == number {
 			delete(log.blocks, h)
 			delete(log.verifiedBlocks, h)
+			log.persist(func(s *fbftStore) error { return s.deleteBlock(block) })
 		}
 	}
 }
@@ -201,6 +267,9 @@ func (log *FBFTLog) DeleteMessagesLessThan(number uint64) {
 			delete(log.messages, h)
 		}
 	}
+	log.persist(func(s *fbftStore) error {
+		return s.deleteLessThan(fbftMsgPrefix, number)
+	})
 }
 
 // AddVerifiedMessage adds a signature verified pbft message into the log
@@ -211,6 +280,7 @@ func (log *FBFTLog) AddVerifiedMessage(msg *FBFTMessage) {
 	msg.Verified = true
 
 	log.messages[msg.id()] = msg
+	log.persist(func(s *fbftStore) error { return s.writeMessage(msg) })
 }
 
 // AddNotVerifiedMessage adds a not signature verified pbft message into the log
@@ -221,6 +291,7 @@ func (log *FBFTLog) AddNotVerifiedMessage(msg *FBFTMessage) {
 	msg.Verified = false
 
 	log.messages[msg.id()] = msg
+	log.persist(func(s *fbftStore) error { return s.writeMessage(msg) })
 }
 
 // GetNotVerifiedCommittedMessages returns not verified committed pbft messages with matching blockNum, viewID and blockHash
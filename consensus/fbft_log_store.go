@@ -0,0 +1,292 @@
+package consensus
+
+import (
+	"encoding/binary"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/rlp"
+	msg_pb "github.com/nordicenergy/nordicenergy-core/api/proto/message"
+	"github.com/nordicenergy/nordicenergy-core/core/types"
+	"github.com/nordicenergy/nordicenergy-core/crypto/bls"
+)
+
+var (
+	fbftMsgPrefix      = []byte("fbft-msg-")      // fbftMsgPrefix + num (uint64 big endian) + msg id -> storedFBFTMessage
+	fbftBlockPrefix    = []byte("fbft-block-")    // fbftBlockPrefix + num (uint64 big endian) + hash -> block
+	fbftVerifiedPrefix = []byte("fbft-verified-") // fbftVerifiedPrefix + num (uint64 big endian) + hash -> empty
+	fbftStateKey       = []byte("fbft-state")     // fbftStateKey -> fbftState
+)
+
+// fbftStore is the write-ahead log of the FBFT log and the consensus state,
+// so that a restarted node resumes the round it was in.
+type fbftStore struct {
+	db ethdb.KeyValueStore
+
+	lastState fbftState
+	stateLock sync.Mutex
+}
+
+// storedFBFTMessage is the on disk form of a FBFTMessage. Only the fields of
+// the announce, prepared and committed messages kept in the FBFT log are stored.
+type storedFBFTMessage struct {
+	MessageType        uint32
+	ViewID             uint64
+	BlockNum           uint64
+	BlockHash          common.Hash
+	Block              []byte
+	SenderPubkeys      []bls.SerializedPublicKey
+	SenderPubkeyBitmap []byte
+	LeaderPubkey       []byte
+	Payload            []byte
+	Verified           bool
+}
+
+// fbftState is the on disk form of the consensus State
+type fbftState struct {
+	BlockNum       uint64
+	Mode           uint64
+	BlockViewID    uint64
+	ViewChangingID uint64
+}
+
+func newFBFTStore(db ethdb.KeyValueStore) *fbftStore {
+	return &fbftStore{db: db}
+}
+
+func fbftNumKey(prefix []byte, num uint64, suffix []byte) []byte {
+	key := make([]byte, len(prefix)+8+len(suffix))
+	copy(key, prefix)
+	binary.BigEndian.PutUint64(key[len(prefix):], num)
+	copy(key[len(prefix)+8:], suffix)
+	return key
+}
+
+func (s *fbftStore) writeMessage(msg *FBFTMessage) error {
+	stored := storedFBFTMessage{
+		MessageType:        uint32(msg.MessageType),
+		ViewID:             msg.ViewID,
+		BlockNum:           msg.BlockNum,
+		BlockHash:          msg.BlockHash,
+		Block:              msg.Block,
+		SenderPubkeys:      make([]bls.SerializedPublicKey, len(msg.SenderPubkeys)),
+		SenderPubkeyBitmap: msg.SenderPubkeyBitmap,
+		Payload:            msg.Payload,
+		Verified:           msg.Verified,
+	}
+	for i, key := range msg.SenderPubkeys {
+		stored.SenderPubkeys[i] = key.Bytes
+	}
+	if msg.LeaderPubkey != nil {
+		stored.LeaderPubkey = msg.LeaderPubkey.Bytes[:]
+	}
+	data, err := rlp.EncodeToBytes(&stored)
+	if err != nil {
+		return err
+	}
+	id := msg.id()
+	return s.db.Put(fbftNumKey(fbftMsgPrefix, msg.BlockNum, id[:]), data)
+}
+
+func (s *fbftStore) writeBlock(block *types.Block) error {
+	data, err := rlp.EncodeToBytes(block)
+	if err != nil {
+		return err
+	}
+	return s.db.Put(fbftNumKey(fbftBlockPrefix, block.NumberU64(), block.Hash().Bytes()), data)
+}
+
+func (s *fbftStore) writeBlockVerified(block *types.Block) error {
+	return s.db.Put(fbftNumKey(fbftVerifiedPrefix, block.NumberU64(), block.Hash().Bytes()), []byte{})
+}
+
+func (s *fbftStore) deleteBlock(block *types.Block) error {
+	if err := s.db.Delete(
+		fbftNumKey(fbftBlockPrefix, block.NumberU64(), block.Hash().Bytes()),
+	); err != nil {
+		return err
+	}
+	return s.db.Delete(fbftNumKey(fbftVerifiedPrefix, block.NumberU64(), block.Hash().Bytes()))
+}
+
+// deleteLessThan deletes the entries under prefix for block numbers less than number
+func (s *fbftStore) deleteLessThan(prefix []byte, number uint64) error {
+	it := s.db.NewIteratorWithPrefix(prefix)
+	defer it.Release()
+
+	batch := s.db.NewBatch()
+	for it.Next() {
+		key := it.Key()
+		if len(key) < len(prefix)+8 ||
+			binary.BigEndian.Uint64(key[len(prefix):]) >= number {
+			break
+		}
+		if err := batch.Delete(common.CopyBytes(key)); err != nil {
+			return err
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	return batch.Write()
+}
+
+func (s *fbftStore) readMessages() ([]*FBFTMessage, error) {
+	it := s.db.NewIteratorWithPrefix(fbftMsgPrefix)
+	defer it.Release()
+
+	msgs := []*FBFTMessage{}
+	for it.Next() {
+		stored := storedFBFTMessage{}
+		if err := rlp.DecodeBytes(it.Value(), &stored); err != nil {
+			return nil, err
+		}
+		msg := &FBFTMessage{
+			MessageType:        msg_pb.MessageType(stored.MessageType),
+			ViewID:             stored.ViewID,
+			BlockNum:           stored.BlockNum,
+			BlockHash:          stored.BlockHash,
+			Block:              stored.Block,
+			SenderPubkeys:      make([]*bls.PublicKeyWrapper, len(stored.SenderPubkeys)),
+			SenderPubkeyBitmap: stored.SenderPubkeyBitmap,
+			Payload:            stored.Payload,
+			Verified:           stored.Verified,
+		}
+		for i, key := range stored.SenderPubkeys {
+			wrapper, err := storedPubKey(key[:])
+			if err != nil {
+				return nil, err
+			}
+			msg.SenderPubkeys[i] = wrapper
+		}
+		if len(stored.LeaderPubkey) != 0 {
+			wrapper, err := storedPubKey(stored.LeaderPubkey)
+			if err != nil {
+				return nil, err
+			}
+			msg.LeaderPubkey = wrapper
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs, it.Error()
+}
+
+func storedPubKey(key []byte) (*bls.PublicKeyWrapper, error) {
+	object, err := bls.BytesToBLSPublicKey(key)
+	if err != nil {
+		return nil, err
+	}
+	wrapper := &bls.PublicKeyWrapper{Object: object}
+	copy(wrapper.Bytes[:], key)
+	return wrapper, nil
+}
+
+func (s *fbftStore) readBlocks() ([]*types.Block, map[common.Hash]struct{}, error) {
+	verified := map[common.Hash]struct{}{}
+	vit := s.db.NewIteratorWithPrefix(fbftVerifiedPrefix)
+	for vit.Next() {
+		verified[common.BytesToHash(vit.Key()[len(fbftVerifiedPrefix)+8:])] = struct{}{}
+	}
+	vit.Release()
+	if err := vit.Error(); err != nil {
+		return nil, nil, err
+	}
+
+	it := s.db.NewIteratorWithPrefix(fbftBlockPrefix)
+	defer it.Release()
+	blocks := []*types.Block{}
+	for it.Next() {
+		block := new(types.Block)
+		if err := rlp.DecodeBytes(it.Value(), block); err != nil {
+			return nil, nil, err
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks, verified, it.Error()
+}
+
+// writeState persists the consensus state if it changed since the last write
+func (s *fbftStore) writeState(state fbftState) error {
+	s.stateLock.Lock()
+	defer s.stateLock.Unlock()
+	if state == s.lastState {
+		return nil
+	}
+	data, err := rlp.EncodeToBytes(&state)
+	if err != nil {
+		return err
+	}
+	if err := s.db.Put(fbftStateKey, data); err != nil {
+		return err
+	}
+	s.lastState = state
+	return nil
+}
+
+// readState returns the persisted consensus state, nil if there is none
+func (s *fbftStore) readState() (*fbftState, error) {
+	has, err := s.db.Has(fbftStateKey)
+	if err != nil || !has {
+		return nil, err
+	}
+	data, err := s.db.Get(fbftStateKey)
+	if err != nil {
+		return nil, err
+	}
+	state := fbftState{}
+	if err := rlp.DecodeBytes(data, &state); err != nil {
+		return nil, err
+	}
+	s.stateLock.Lock()
+	s.lastState = state
+	s.stateLock.Unlock()
+	return &state, nil
+}
+
+// replayFBFTLog loads the persisted FBFT log and, if it is still about the
+// block the node is at, the persisted consensus state
+func (consensus *Consensus) replayFBFTLog() {
+	store := consensus.FBFTLog.store
+	if store == nil {
+		return
+	}
+	if err := consensus.FBFTLog.Replay(); err != nil {
+		consensus.getLogger().Error().Err(err).Msg("[replayFBFTLog] failed to replay fbft log")
+		return
+	}
+	state, err := store.readState()
+	if err != nil {
+		consensus.getLogger().Error().Err(err).Msg("[replayFBFTLog] failed to read consensus state")
+		return
+	}
+	if state == nil || state.BlockNum != consensus.blockNum {
+		return
+	}
+	consensus.current.SetMode(Mode(state.Mode))
+	consensus.SetCurBlockViewID(state.BlockViewID)
+	consensus.SetViewChangingID(state.ViewChangingID)
+	consensus.getLogger().Info().
+		Str("mode", Mode(state.Mode).String()).
+		Uint64("blockViewID", state.BlockViewID).
+		Uint64("viewChangingID", state.ViewChangingID).
+		Msg("[replayFBFTLog] restored consensus state")
+}
+
+// persistState writes the consensus state to the FBFT log store if it
+// changed. It is called when constructing a message, so that the round the
+// node signed in is on disk before anything is sent.
+func (consensus *Consensus) persistState() {
+	store := consensus.FBFTLog.store
+	if store == nil {
+		return
+	}
+	if err := store.writeState(fbftState{
+		BlockNum:       consensus.blockNum,
+		Mode:           uint64(consensus.current.Mode()),
+		BlockViewID:    consensus.GetCurBlockViewID(),
+		ViewChangingID: consensus.GetViewChangingID(),
+	}); err != nil {
+		consensus.getLogger().Warn().Err(err).Msg("[persistState] failed to persist consensus state")
+	}
+}
@@ -3,9 +3,13 @@ package consensus
 import (
 	"bytes"
 	"encoding/binary"
+	"math/big"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/core/rawdb"
 	msg_pb "github.com/nordicenergy/nordicenergy-core/api/proto/message"
+	blockfactory "github.com/nordicenergy/nordicenergy-core/block/factory"
+	"github.com/nordicenergy/nordicenergy-core/core/types"
 	"github.com/nordicenergy/nordicenergy-core/crypto/bls"
 )
 
@@ -102,3 +106,49 @@ func TestHasMatchingAnnounce(t *testing.T) {
 		t.Error("notFound should be false")
 	}
 }
+
+func TestFBFTLog_Replay(t *testing.T) {
+	db := rawdb.NewMemoryDatabase()
+	log := NewFBFTLog()
+	log.SetStore(db)
+
+	blocks := []*types.Block{}
+	for _, num := range []int64{1, 2, 3} {
+		header := blockfactory.NewTestHeader().With().Number(big.NewInt(num)).Header()
+		block := types.NewBlockWithHeader(header)
+		blocks = append(blocks, block)
+		log.AddBlock(block)
+		log.AddVerifiedMessage(&FBFTMessage{
+			MessageType: msg_pb.MessageType_PREPARED,
+			BlockNum:    uint64(num),
+			ViewID:      uint64(num),
+			BlockHash:   block.Hash(),
+			Payload:     []byte{byte(num)},
+		})
+	}
+	log.MarkBlockVerified(blocks[2])
+	log.PruneCacheBeforeBlock(3)
+
+	replayed := NewFBFTLog()
+	replayed.SetStore(db)
+	if err := replayed.Replay(); err != nil {
+		t.Fatal(err)
+	}
+	if replayed.GetBlockByHash(blocks[0].Hash()) != nil {
+		t.Error("pruned block was replayed")
+	}
+	for _, block := range blocks[1:] {
+		if replayed.GetBlockByHash(block.Hash()) == nil {
+			t.Errorf("block %d was not replayed", block.NumberU64())
+		}
+		if !replayed.HasMatchingPrepared(block.NumberU64(), block.Hash()) {
+			t.Errorf("prepared message of block %d was not replayed", block.NumberU64())
+		}
+	}
+	if len(replayed.GetMessagesByTypeSeq(msg_pb.MessageType_PREPARED, 1)) != 0 {
+		t.Error("pruned message was replayed")
+	}
+	if replayed.IsBlockVerified(blocks[1]) || !replayed.IsBlockVerified(blocks[2]) {
+		t.Error("block verification was not replayed")
+	}
+}
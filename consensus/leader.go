@@ -1,6 +1,7 @@
 package consensus
 
 import (
+	"bytes"
 	"time"
 
 	"github.com/nordicenergy/nordicenergy-core/crypto/bls"
@@ -39,6 +40,7 @@ func (consensus *Consensus) announce(block *types.Block) {
 		return
 	}
 
+	consensus.traceRoundStart(block.NumberU64(), block.Header().ViewID().Uint64())
 	networkMessage, err := consensus.construct(msg_pb.MessageType_ANNOUNCE, nil, []*bls.PrivateKeyWrapper{key})
 	if err != nil {
 		consensus.getLogger().Err(err).
@@ -58,6 +60,13 @@ func (consensus *Consensus) announce(block *types.Block) {
 
 	// Leader sign the block hash itself
 	for i, key := range consensus.priKey {
+		sig, err := key.SignHash(consensus.blockHash[:])
+		if err != nil {
+			consensus.getLogger().Warn().Err(err).Msgf(
+				"[Announce] Leader failed to sign with key at index %d", i,
+			)
+			continue
+		}
 		if err := consensus.prepareBitmap.SetKey(key.Pub.Bytes, true); err != nil {
 			consensus.getLogger().Warn().Err(err).Msgf(
 				"[Announce] Leader prepareBitmap SetKey failed for key at index %d", i,
@@ -68,7 +77,7 @@ func (consensus *Consensus) announce(block *types.Block) {
 		if _, err := consensus.Decider.AddNewVote(
 			quorum.Prepare,
 			[]*bls.PublicKeyWrapper{key.Pub},
-			key.Pri.SignHash(consensus.blockHash[:]),
+			sig,
 			block.Hash(),
 			block.NumberU64(),
 			block.Header().ViewID().Uint64(),
@@ -92,6 +101,7 @@ func (consensus *Consensus) announce(block *types.Block) {
 			Uint64("blockNum", block.NumberU64()).
 			Msg("[Announce] Sent Announce Message!!")
 	}
+	consensus.traceRoundEvent(block.NumberU64(), RoundEventAnnounce)
 
 	consensus.switchPhase("Announce", FBFTPrepare)
 }
@@ -115,8 +125,7 @@ func (consensus *Consensus) onPrepare(recvMsg *FBFTMessage) {
 		return
 	}
 
-	blockHash := consensus.blockHash[:]
-	prepareBitmap := consensus.prepareBitmap
+	blockHash := consensus.blockHash
 	// proceed only when the message is not received before
 	for _, signer := range recvMsg.SenderPubkeys {
 		signed := consensus.Decider.ReadBallot(quorum.Prepare, signer.Bytes)
@@ -138,7 +147,6 @@ func (consensus *Consensus) onPrepare(recvMsg *FBFTMessage) {
 	signerCount := consensus.Decider.SignersCount(quorum.Prepare)
 	//// Read - End
 
-	// Check BLS signature for the multi-sig
 	prepareSig := recvMsg.Payload
 	var sign bls_core.Sign
 	err := sign.Deserialize(prepareSig)
@@ -155,27 +163,54 @@ func (consensus *Consensus) onPrepare(recvMsg *FBFTMessage) {
 			signerPubKey.Add(pubKey.Object)
 		}
 	}
-	if !sign.VerifyHash(signerPubKey, blockHash) {
-		consensus.getLogger().Error().Msg("[OnPrepare] Received invalid BLS signature")
-		return
-	}
 
 	consensus.getLogger().Debug().
 		Int64("NumReceivedSoFar", signerCount).
 		Int64("PublicKeys", consensus.Decider.ParticipantsCount()).
 		Msg("[OnPrepare] Received New Prepare Signature")
 
+	// Check BLS signature for the multi-sig, together with the other votes
+	consensus.voteVerifier.add(&pendingVote{
+		phase:  quorum.Prepare,
+		msg:    recvMsg,
+		sig:    &sign,
+		signer: signerPubKey,
+		hash:   blockHash[:],
+	})
+}
+
+// addPrepareVote adds a prepare vote whose signature is verified. The round
+// could have moved on while the signature was verified, so the checks of
+// onPrepare are done again.
+func (consensus *Consensus) addPrepareVote(vote *pendingVote) {
+	recvMsg := vote.msg
+	//// Read - Start
+	if !consensus.isRightBlockNumAndViewID(recvMsg) ||
+		!bytes.Equal(vote.hash, consensus.blockHash[:]) {
+		return
+	}
+	for _, signer := range recvMsg.SenderPubkeys {
+		if consensus.Decider.ReadBallot(quorum.Prepare, signer.Bytes) != nil {
+			return
+		}
+	}
+	if consensus.Decider.IsQuorumAchieved(quorum.Prepare) {
+		return
+	}
+	//// Read - End
+
 	//// Write - Start
 	if _, err := consensus.Decider.AddNewVote(
 		quorum.Prepare, recvMsg.SenderPubkeys,
-		&sign, recvMsg.BlockHash,
+		vote.sig, recvMsg.BlockHash,
 		recvMsg.BlockNum, recvMsg.ViewID,
 	); err != nil {
 		consensus.getLogger().Warn().Err(err).Msg("submit vote prepare failed")
 		return
 	}
+	consensus.traceVote(quorum.Prepare, recvMsg.BlockNum, recvMsg.SenderPubkeys)
 	// Set the bitmap indicating that this validator signed.
-	if err := prepareBitmap.SetKeysAtomic(recvMsg.SenderPubkeys, true); err != nil {
+	if err := consensus.prepareBitmap.SetKeysAtomic(recvMsg.SenderPubkeys, true); err != nil {
 		consensus.getLogger().Warn().Err(err).Msg("[OnPrepare] prepareBitmap.SetKey failed")
 		return
 	}
@@ -187,6 +222,7 @@ func (consensus *Consensus) onPrepare(recvMsg *FBFTMessage) {
 		if err := consensus.didReachPrepareQuorum(); err != nil {
 			return
 		}
+		consensus.traceRoundEvent(recvMsg.BlockNum, RoundEventPrepareQuorum)
 		consensus.switchPhase("onPrepare", FBFTCommit)
 	}
 	//// Read - End
@@ -210,15 +246,9 @@ func (consensus *Consensus) onCommit(recvMsg *FBFTMessage) {
 		}
 	}
 
-	commitBitmap := consensus.commitBitmap
-
-	// has to be called before verifying signature
-	quorumWasMet := consensus.Decider.IsQuorumAchieved(quorum.Commit)
-
 	signerCount := consensus.Decider.SignersCount(quorum.Commit)
 	//// Read - End
 
-	// Verify the signature on commitPayload is correct
 	logger := consensus.getLogger().With().
 		Str("recvMsg", recvMsg.String()).
 		Int64("numReceivedSoFar", signerCount).Logger()
@@ -242,10 +272,6 @@ func (consensus *Consensus) onCommit(recvMsg *FBFTMessage) {
 	}
 	commitPayload := signature.ConstructCommitPayload(consensus.Blockchain,
 		blockObj.Epoch(), blockObj.Hash(), blockObj.NumberU64(), blockObj.Header().ViewID().Uint64())
-	logger = logger.With().
-		Uint64("MsgViewID", recvMsg.ViewID).
-		Uint64("MsgBlockNum", recvMsg.BlockNum).
-		Logger()
 
 	signerPubKey := &bls_core.PublicKey{}
 	if recvMsg.HasSingleSender() {
@@ -255,10 +281,44 @@ func (consensus *Consensus) onCommit(recvMsg *FBFTMessage) {
 			signerPubKey.Add(pubKey.Object)
 		}
 	}
-	if !sign.VerifyHash(signerPubKey, commitPayload) {
-		logger.Error().Msg("[OnCommit] Cannot verify commit message")
+
+	// Verify the signature on commitPayload is correct, together with the other votes
+	consensus.voteVerifier.add(&pendingVote{
+		phase:  quorum.Commit,
+		msg:    recvMsg,
+		sig:    &sign,
+		signer: signerPubKey,
+		hash:   commitPayload,
+	})
+}
+
+// addCommitVote adds a commit vote whose signature is verified. The round
+// could have moved on while the signature was verified, so the checks of
+// onCommit are done again.
+func (consensus *Consensus) addCommitVote(vote *pendingVote) {
+	recvMsg := vote.msg
+	//// Read - Start
+	if !consensus.isRightBlockNumAndViewID(recvMsg) {
 		return
 	}
+	for _, signer := range recvMsg.SenderPubkeys {
+		if consensus.Decider.ReadBallot(quorum.Commit, signer.Bytes) != nil {
+			return
+		}
+	}
+	blockObj := consensus.FBFTLog.GetBlockByHash(recvMsg.BlockHash)
+	if blockObj == nil {
+		return
+	}
+
+	// has to be called before adding the vote
+	quorumWasMet := consensus.Decider.IsQuorumAchieved(quorum.Commit)
+	//// Read - End
+
+	logger := consensus.getLogger().With().
+		Uint64("MsgViewID", recvMsg.ViewID).
+		Uint64("MsgBlockNum", recvMsg.BlockNum).
+		Logger()
 
 	//// Write - Start
 	// Check for potential double signing
@@ -267,13 +327,14 @@ func (consensus *Consensus) onCommit(recvMsg *FBFTMessage) {
 	}
 	if _, err := consensus.Decider.AddNewVote(
 		quorum.Commit, recvMsg.SenderPubkeys,
-		&sign, recvMsg.BlockHash,
+		vote.sig, recvMsg.BlockHash,
 		recvMsg.BlockNum, recvMsg.ViewID,
 	); err != nil {
 		return
 	}
+	consensus.traceVote(quorum.Commit, recvMsg.BlockNum, recvMsg.SenderPubkeys)
 	// Set the bitmap indicating that this validator signed.
-	if err := commitBitmap.SetKeysAtomic(recvMsg.SenderPubkeys, true); err != nil {
+	if err := consensus.commitBitmap.SetKeysAtomic(recvMsg.SenderPubkeys, true); err != nil {
 		consensus.getLogger().Warn().Err(err).
 			Msg("[OnCommit] commitBitmap.SetKey failed")
 		return
@@ -296,21 +357,23 @@ func (consensus *Consensus) onCommit(recvMsg *FBFTMessage) {
 
 	if !quorumWasMet && quorumIsMet {
 		logger.Info().Msg("[OnCommit] 2/3 Enough commits received")
+		consensus.traceRoundEvent(recvMsg.BlockNum, RoundEventCommitQuorum)
 
-		if !blockObj.IsLastBlockInEpoch() {
-			// only do early commit if it's not epoch block to avoid problems
+		if !blockObj.IsLastBlockInEpoch() && !consensus.isLeaderRotationBlock(blockObj.Header()) {
+			// only do early commit if it's not epoch block or the last block
+			// of the leader to avoid problems
 			consensus.preCommitAndPropose(blockObj)
 		}
 
 		go func(viewID uint64) {
 			waitTime := 1000 * time.Millisecond
-			maxWaitTime := time.Until(consensus.NextBlockDue) - 200*time.Millisecond
+			maxWaitTime := consensus.NextBlockDue.Sub(consensus.clock.Now()) - 200*time.Millisecond
 			if maxWaitTime > waitTime {
 				waitTime = maxWaitTime
 			}
 			consensus.getLogger().Info().Str("waitTime", waitTime.String()).
 				Msg("[OnCommit] Starting Grace Period")
-			time.Sleep(waitTime)
+			<-consensus.clock.After(waitTime)
 			logger.Info().Msg("[OnCommit] Commit Grace Period Ended")
 
 			consensus.mutex.Lock()
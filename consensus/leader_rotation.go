@@ -0,0 +1,56 @@
+package consensus
+
+import (
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/nordicenergy/nordicenergy-core/block"
+	"github.com/nordicenergy/nordicenergy-core/crypto/bls"
+	"github.com/nordicenergy/nordicenergy-core/crypto/hash"
+)
+
+// isLeaderRotationBlock returns whether header is the last block proposed by
+// the leader of a rotation window. The leader of the first block of an epoch
+// is set by the epoch change, so the last block of an epoch is never one.
+func (consensus *Consensus) isLeaderRotationBlock(header *block.Header) bool {
+	if consensus.Blockchain == nil || header.IsLastBlockInEpoch() {
+		return false
+	}
+	config := consensus.Blockchain.Config()
+	if !config.IsLeaderRotation(header.Epoch()) {
+		return false
+	}
+	return (header.Number().Uint64()+1)%config.LeaderRotationBlocksCount == 0
+}
+
+// leaderRotationSeed is the randomness the leader of the rotation window
+// starting at blockNum is picked with. It only depends on the shard and the
+// block number, so that every node picks the same leader and a leader cannot
+// bias the pick through the content of its blocks.
+func leaderRotationSeed(shardID uint32, blockNum uint64) common.Hash {
+	buf := make([]byte, 12)
+	binary.BigEndian.PutUint32(buf, shardID)
+	binary.BigEndian.PutUint64(buf[4:], blockNum)
+	return hash.Keccak256Hash(buf)
+}
+
+// rotatedLeader returns the leader of the rotation window following header,
+// picked by voting power among all the validators of the committee. It
+// returns nil if the committee has no such validator.
+func (consensus *Consensus) rotatedLeader(header *block.Header) *bls.PublicKeyWrapper {
+	blockNum := header.Number().Uint64() + 1
+	found, leader := consensus.Decider.WeightedLeader(
+		leaderRotationSeed(consensus.ShardID, blockNum),
+	)
+	if !found {
+		consensus.getLogger().Warn().
+			Uint64("blockNum", blockNum).
+			Msg("[rotatedLeader] no leader to rotate to")
+		return nil
+	}
+	consensus.getLogger().Info().
+		Uint64("blockNum", blockNum).
+		Str("leaderPubKey", leader.Bytes.Hex()).
+		Msg("[rotatedLeader] leader rotated")
+	return leader
+}
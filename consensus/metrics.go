@@ -81,6 +81,57 @@ var (
 			Buckets:   prometheus.ExpnetntialBuckets(800, 1.25, 10),
 		},
 	)
+	// consensusPhaseHistogramVec is used to keep track of the time from the
+	// start of a round to each of its phases, in the unit of millisecond
+	consensusPhaseHistogramVec = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "ngy",
+			Subsystem: "consensus",
+			Name:      "phase_latency",
+			Help:      "the latency of the consensus phases since the start of the round",
+			Buckets:   prometheus.ExpnetntialBuckets(50, 1.5, 12),
+		},
+		[]string{
+			"phase",
+		},
+	)
+	// consensusVoteCounterVec is used to keep track of the votes the leader received
+	consensusVoteCounterVec = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ngy",
+			Subsystem: "consensus",
+			Name:      "votes",
+			Help:      "counter of votes received by the leader",
+		},
+		[]string{
+			"phase",
+		},
+	)
+	// consensusSignerLatencyVec is used to keep track of the latency of the
+	// last vote of each signer since the start of the round
+	consensusSignerLatencyVec = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ngy",
+			Subsystem: "consensus",
+			Name:      "signer_latency",
+			Help:      "latency in millisecond of the last vote of a signer",
+		},
+		[]string{
+			"phase", "pubkey",
+		},
+	)
+	// consensusVCCauseCounterVec is used to keep track of the causes of view changes
+	consensusVCCauseCounterVec = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ngy",
+			Subsystem: "consensus",
+			Name:      "viewchange_cause",
+			Help:      "counter of view change by cause",
+		},
+		[]string{
+			"cause",
+		},
+	)
 
 	onceMetrics sync.Once
 
@@ -122,6 +173,10 @@ func initMetrics() {
 			consensusGaugeVec,
 			consensusPubkeyVec,
 			consensusFinalityHistogram,
+			consensusPhaseHistogramVec,
+			consensusVoteCounterVec,
+			consensusSignerLatencyVec,
+			consensusVCCauseCounterVec,
 		)
 	})
 }
@@ -0,0 +1,157 @@
+package protection
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+	msg_pb "github.com/nordicenergy/nordicenergy-core/api/proto/message"
+	"github.com/nordicenergy/nordicenergy-core/crypto/bls"
+	"github.com/pkg/errors"
+)
+
+// InterchangeVersion is the version of the interchange format written by Export
+const InterchangeVersion = "1"
+
+var (
+	errUnknownInterchangeVersion = errors.New("unknown slashing protection interchange version")
+	errUnknownMessageType        = errors.New("unknown consensus message type")
+	errInvalidInterchangeKey     = errors.New("invalid bls public key in interchange")
+)
+
+// Interchange is the JSON document used to move the slashing protection
+// records of BLS keys between hosts
+type Interchange struct {
+	Metadata InterchangeMetadata `json:"metadata"`
+	Data     []InterchangeKey    `json:"data"`
+}
+
+// InterchangeMetadata ..
+type InterchangeMetadata struct {
+	Version string `json:"interchange-format-version"`
+}
+
+// InterchangeKey is the last signed blocks of one BLS key
+type InterchangeKey struct {
+	PublicKey  string                 `json:"pubkey"`
+	Signatures []InterchangeSignature `json:"signed-messages"`
+}
+
+// InterchangeSignature is the last signed block of a consensus message type
+type InterchangeSignature struct {
+	MessageType string      `json:"message-type"`
+	BlockNum    uint64      `json:"block-num"`
+	ViewID      uint64      `json:"view-id"`
+	BlockHash   common.Hash `json:"block-hash"`
+}
+
+// Export writes the records of all keys in the database to w
+func (p *DB) Export(w io.Writer) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	byKey := map[bls.SerializedPublicKey][]InterchangeSignature{}
+	keys := []bls.SerializedPublicKey{}
+
+	it := p.db.NewIteratorWithPrefix(recordPrefix)
+	defer it.Release()
+	for it.Next() {
+		key, msgType, ok := parseRecordKey(it.Key())
+		if !ok {
+			continue
+		}
+		record := Record{}
+		if err := rlp.DecodeBytes(it.Value(), &record); err != nil {
+			return err
+		}
+		if _, ok := byKey[key]; !ok {
+			keys = append(keys, key)
+		}
+		byKey[key] = append(byKey[key], InterchangeSignature{
+			MessageType: msgType.String(),
+			BlockNum:    record.BlockNum,
+			ViewID:      record.ViewID,
+			BlockHash:   record.BlockHash,
+		})
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	sort.SliceStable(keys, func(i, j int) bool {
+		return keys[i].Hex() < keys[j].Hex()
+	})
+
+	interchange := Interchange{
+		Metadata: InterchangeMetadata{Version: InterchangeVersion},
+		Data:     make([]InterchangeKey, 0, len(keys)),
+	}
+	for _, key := range keys {
+		interchange.Data = append(interchange.Data, InterchangeKey{
+			PublicKey:  key.Hex(),
+			Signatures: byKey[key],
+		})
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(interchange)
+}
+
+// Import reads an interchange document from r and merges it into the
+// database. For every key and message type the later of the existing and
+// imported records is kept, so importing can never weaken the protection.
+func (p *DB) Import(r io.Reader) error {
+	interchange := Interchange{}
+	if err := json.NewDecoder(r).Decode(&interchange); err != nil {
+		return err
+	}
+	if interchange.Metadata.Version != InterchangeVersion {
+		return errors.Wrapf(
+			errUnknownInterchangeVersion, "got %s", interchange.Metadata.Version,
+		)
+	}
+
+	type entry struct {
+		key     bls.SerializedPublicKey
+		msgType msg_pb.MessageType
+		record  Record
+	}
+	entries := []entry{}
+	for _, data := range interchange.Data {
+		key := bls.SerializedPublicKey{}
+		raw, err := hex.DecodeString(strings.TrimPrefix(data.PublicKey, "0x"))
+		if err != nil || len(raw) != len(key) {
+			return errors.Wrapf(errInvalidInterchangeKey, "got %s", data.PublicKey)
+		}
+		copy(key[:], raw)
+		for _, sig := range data.Signatures {
+			msgType, ok := msg_pb.MessageType_value[sig.MessageType]
+			if !ok {
+				return errors.Wrapf(errUnknownMessageType, "got %s", sig.MessageType)
+			}
+			entries = append(entries, entry{
+				key, msg_pb.MessageType(msgType),
+				Record{sig.BlockNum, sig.ViewID, sig.BlockHash},
+			})
+		}
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	for i := range entries {
+		existing, err := p.read(entries[i].key, entries[i].msgType)
+		if err != nil {
+			return err
+		}
+		if existing != nil && !entries[i].record.isAfter(existing) {
+			continue
+		}
+		if err := p.write(entries[i].key, entries[i].msgType, &entries[i].record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
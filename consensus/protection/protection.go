@@ -0,0 +1,165 @@
+package protection
+
+import (
+	"encoding/binary"
+	"path/filepath"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/rlp"
+	msg_pb "github.com/nordicenergy/nordicenergy-core/api/proto/message"
+	"github.com/nordicenergy/nordicenergy-core/crypto/bls"
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrConflictingSignature is returned when the key already signed
+	// a different block at the same height and view
+	ErrConflictingSignature = errors.New("already signed a different block at this height and view")
+	// ErrStaleSignature is returned when the key already signed a later height or view
+	ErrStaleSignature = errors.New("already signed a later height or view")
+
+	recordPrefix = []byte("slashing-protection-")
+)
+
+// Record is the last signature made by a BLS key for one type of consensus message
+type Record struct {
+	BlockNum  uint64
+	ViewID    uint64
+	BlockHash common.Hash
+}
+
+// isAfter returns whether r is for a later height or view than other
+func (r *Record) isAfter(other *Record) bool {
+	if r.BlockNum != other.BlockNum {
+		return r.BlockNum > other.BlockNum
+	}
+	return r.ViewID > other.ViewID
+}
+
+// DB keeps, per BLS key and consensus message type, the last signed block
+// so that the node refuses to produce a conflicting signature, even across
+// restarts.
+type DB struct {
+	db   ethdb.KeyValueStore
+	lock sync.Mutex
+}
+
+// New returns a slashing protection database backed by db
+func New(db ethdb.KeyValueStore) *DB {
+	return &DB{db: db}
+}
+
+// Dir returns the directory of the slashing protection database of the node
+// whose database directory is dbDir
+func Dir(dbDir string) string {
+	return filepath.Join(dbDir, "slashing_protection")
+}
+
+// Open opens or creates the slashing protection database in dir
+func Open(dir string) (*DB, error) {
+	db, err := rawdb.NewLevelDBDatabase(dir, 16, 16, "")
+	if err != nil {
+		return nil, err
+	}
+	return New(db), nil
+}
+
+// Close closes the underlying database
+func (p *DB) Close() error {
+	return p.db.Close()
+}
+
+// CheckAndRecord returns an error if signing the given block with key would
+// conflict with what the key already signed for msgType. Otherwise the block
+// is recorded as the last signed one before the caller goes on to sign it.
+// Signing the last signed block again is allowed.
+func (p *DB) CheckAndRecord(
+	key bls.SerializedPublicKey, msgType msg_pb.MessageType,
+	blockNum, viewID uint64, blockHash common.Hash,
+) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	next := Record{blockNum, viewID, blockHash}
+	last, err := p.read(key, msgType)
+	if err != nil {
+		return err
+	}
+	if last != nil {
+		if last.isAfter(&next) {
+			return errors.Wrapf(
+				ErrStaleSignature, "key %s %s last signed block %d view %d",
+				key.Hex(), msgType, last.BlockNum, last.ViewID,
+			)
+		}
+		if !next.isAfter(last) {
+			if last.BlockHash != next.BlockHash {
+				return errors.Wrapf(
+					ErrConflictingSignature, "key %s %s block %d view %d signed %s",
+					key.Hex(), msgType, last.BlockNum, last.ViewID, last.BlockHash.Hex(),
+				)
+			}
+			return nil
+		}
+	}
+	return p.write(key, msgType, &next)
+}
+
+// LastSigned returns the last block signed by key for msgType, nil if there is none
+func (p *DB) LastSigned(
+	key bls.SerializedPublicKey, msgType msg_pb.MessageType,
+) (*Record, error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return p.read(key, msgType)
+}
+
+func (p *DB) read(key bls.SerializedPublicKey, msgType msg_pb.MessageType) (*Record, error) {
+	dbKey := recordKey(key, msgType)
+	has, err := p.db.Has(dbKey)
+	if err != nil || !has {
+		return nil, err
+	}
+	data, err := p.db.Get(dbKey)
+	if err != nil {
+		return nil, err
+	}
+	record := Record{}
+	if err := rlp.DecodeBytes(data, &record); err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
+
+func (p *DB) write(
+	key bls.SerializedPublicKey, msgType msg_pb.MessageType, record *Record,
+) error {
+	data, err := rlp.EncodeToBytes(record)
+	if err != nil {
+		return err
+	}
+	return p.db.Put(recordKey(key, msgType), data)
+}
+
+// recordKey = recordPrefix + key + msgType (uint32 big endian)
+func recordKey(key bls.SerializedPublicKey, msgType msg_pb.MessageType) []byte {
+	typeBytes := make([]byte, 4)
+	binary.BigEndian.PutUint32(typeBytes, uint32(msgType))
+	dbKey := append([]byte{}, recordPrefix...)
+	dbKey = append(dbKey, key[:]...)
+	return append(dbKey, typeBytes...)
+}
+
+// parseRecordKey is the inverse of recordKey
+func parseRecordKey(dbKey []byte) (bls.SerializedPublicKey, msg_pb.MessageType, bool) {
+	key := bls.SerializedPublicKey{}
+	if len(dbKey) != len(recordPrefix)+len(key)+4 {
+		return key, 0, false
+	}
+	copy(key[:], dbKey[len(recordPrefix):])
+	msgType := binary.BigEndian.Uint32(dbKey[len(recordPrefix)+len(key):])
+	return key, msg_pb.MessageType(msgType), true
+}
@@ -0,0 +1,121 @@
+package protection
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	msg_pb "github.com/nordicenergy/nordicenergy-core/api/proto/message"
+	"github.com/nordicenergy/nordicenergy-core/crypto/bls"
+	"github.com/pkg/errors"
+)
+
+var (
+	testKey1 = bls.SerializedPublicKey{1}
+	testKey2 = bls.SerializedPublicKey{2}
+)
+
+func TestDB_CheckAndRecord(t *testing.T) {
+	db := New(rawdb.NewMemoryDatabase())
+	hash1, hash2 := common.Hash{1}, common.Hash{2}
+
+	tests := []struct {
+		key      bls.SerializedPublicKey
+		msgType  msg_pb.MessageType
+		blockNum uint64
+		viewID   uint64
+		hash     common.Hash
+		expErr   error
+	}{
+		{testKey1, msg_pb.MessageType_PREPARE, 10, 10, hash1, nil},
+		// signing the same block again
+		{testKey1, msg_pb.MessageType_PREPARE, 10, 10, hash1, nil},
+		// double sign
+		{testKey1, msg_pb.MessageType_PREPARE, 10, 10, hash2, ErrConflictingSignature},
+		// other message type and other key are not affected
+		{testKey1, msg_pb.MessageType_COMMIT, 10, 10, hash2, nil},
+		{testKey2, msg_pb.MessageType_PREPARE, 10, 10, hash2, nil},
+		// a new view at the same height
+		{testKey1, msg_pb.MessageType_PREPARE, 10, 11, hash2, nil},
+		{testKey1, msg_pb.MessageType_PREPARE, 10, 10, hash1, ErrStaleSignature},
+		{testKey1, msg_pb.MessageType_PREPARE, 9, 12, hash1, ErrStaleSignature},
+		{testKey1, msg_pb.MessageType_PREPARE, 11, 12, hash1, nil},
+	}
+	for i, test := range tests {
+		err := db.CheckAndRecord(test.key, test.msgType, test.blockNum, test.viewID, test.hash)
+		if errors.Cause(err) != test.expErr {
+			t.Errorf("Test %v: unexpected error %v / %v", i, err, test.expErr)
+		}
+	}
+
+	last, err := db.LastSigned(testKey1, msg_pb.MessageType_PREPARE)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exp := (Record{11, 12, hash1}); *last != exp {
+		t.Errorf("unexpected last signed %+v / %+v", *last, exp)
+	}
+	if last, _ := db.LastSigned(testKey2, msg_pb.MessageType_COMMIT); last != nil {
+		t.Errorf("unexpected last signed %+v", *last)
+	}
+}
+
+func TestDB_ExportImport(t *testing.T) {
+	src := New(rawdb.NewMemoryDatabase())
+	records := []struct {
+		key     bls.SerializedPublicKey
+		msgType msg_pb.MessageType
+		record  Record
+	}{
+		{testKey1, msg_pb.MessageType_PREPARE, Record{10, 10, common.Hash{1}}},
+		{testKey1, msg_pb.MessageType_COMMIT, Record{10, 10, common.Hash{1}}},
+		{testKey2, msg_pb.MessageType_ANNOUNCE, Record{20, 21, common.Hash{2}}},
+	}
+	for _, r := range records {
+		if err := src.CheckAndRecord(
+			r.key, r.msgType, r.record.BlockNum, r.record.ViewID, r.record.BlockHash,
+		); err != nil {
+			t.Fatal(err)
+		}
+	}
+	exported := bytes.Buffer{}
+	if err := src.Export(&exported); err != nil {
+		t.Fatal(err)
+	}
+
+	dst := New(rawdb.NewMemoryDatabase())
+	// a later record on the destination is not overwritten by the import
+	if err := dst.CheckAndRecord(
+		testKey1, msg_pb.MessageType_COMMIT, 15, 15, common.Hash{3},
+	); err != nil {
+		t.Fatal(err)
+	}
+	if err := dst.Import(bytes.NewReader(exported.Bytes())); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[msg_pb.MessageType]Record{
+		msg_pb.MessageType_PREPARE: records[0].record,
+		msg_pb.MessageType_COMMIT:  {15, 15, common.Hash{3}},
+	}
+	for msgType, exp := range expected {
+		last, err := dst.LastSigned(testKey1, msgType)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if last == nil || *last != exp {
+			t.Errorf("%s: unexpected last signed %v / %+v", msgType, last, exp)
+		}
+	}
+	if err := dst.CheckAndRecord(
+		testKey2, msg_pb.MessageType_ANNOUNCE, 20, 21, common.Hash{4},
+	); errors.Cause(err) != ErrConflictingSignature {
+		t.Errorf("expected conflicting signature after import, got %v", err)
+	}
+
+	bad := `{"metadata":{"interchange-format-version":"0"},"data":[]}`
+	if err := dst.Import(bytes.NewReader([]byte(bad))); errors.Cause(err) != errUnknownInterchangeVersion {
+		t.Errorf("expected unknown version error, got %v", err)
+	}
+}
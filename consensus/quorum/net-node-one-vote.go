@@ -78,6 +78,19 @@ func (v *uniformVoteWeight) IsAllSigsCollected() bool {
 	return v.SignersCount(Commit) == v.ParticipantsCount()
 }
 
+// WeightedLeader picks a leader among the participants, which all have
+// the same voting power
+func (v *uniformVoteWeight) WeightedLeader(seed common.Hash) (bool, *bls.PublicKeyWrapper) {
+	participants := v.Participants()
+	if len(participants) == 0 {
+		return false, nil
+	}
+	index := new(big.Int).Mod(
+		new(big.Int).SetBytes(seed[:]), big.NewInt(int64(len(participants))),
+	)
+	return true, &participants[index.Int64()]
+}
+
 func (v *uniformVoteWeight) SetVoters(
 	subCommittee *shard.Committee, epoch *big.Int,
 ) (*TallyResult, error) {
@@ -203,6 +203,20 @@ func (v *stakedVoteWeight) IsAllSigsCollected() bool {
 	return v.voteTally.Commit.tally.Equal(numeric.NewDec(1))
 }
 
+// WeightedLeader picks a leader among the participants with a probability
+// proportional to their voting power
+func (v *stakedVoteWeight) WeightedLeader(seed common.Hash) (bool, *bls.PublicKeyWrapper) {
+	key, ok := v.roster.WeightedPick(seed)
+	if !ok {
+		return false, nil
+	}
+	index := v.IndexOf(key)
+	if index == -1 {
+		return false, nil
+	}
+	return true, &v.Participants()[index]
+}
+
 func (v *stakedVoteWeight) SetVoters(
 	subCommittee *shard.Committee, epoch *big.Int,
 ) (*TallyResult, error) {
@@ -239,3 +239,23 @@ func Test33nordicenergyNodes(t *testing.T) {
 			strconv.FormatBool(rewarded))
 	}
 }
+
+func TestWeightedLeader(t *testing.T) {
+	stakedVote, _, _, sKeys := setupBaseCase()
+	externalLeaders := 0
+	for i := 0; i < 1000; i++ {
+		found, leader := stakedVote.WeightedLeader(common.BigToHash(big.NewInt(int64(i))))
+		if !found {
+			t.Fatal("no leader picked")
+		}
+		if stakedVote.IndexOf(leader.Bytes) == -1 {
+			t.Fatalf("leader %s is not a participant", leader.Bytes.Hex())
+		}
+		if _, ok := sKeys[reg][leader.Bytes]; ok {
+			externalLeaders++
+		}
+	}
+	if externalLeaders == 0 {
+		t.Error("external validators never become leader")
+	}
+}
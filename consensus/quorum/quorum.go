@@ -130,6 +130,7 @@ type Decider interface {
 	QuorumThreshold() numeric.Dec
 	AmIMemberOfCommitee() bool
 	IsAllSigsCollected() bool
+	WeightedLeader(seed common.Hash) (bool, *bls.PublicKeyWrapper)
 	ResetPrepareAndCommitVotes()
 	ResetViewChangeVotes()
 }
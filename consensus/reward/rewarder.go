@@ -7,6 +7,9 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/nordicenergy/nordicenergy-core/shard"
+	"github.com/nordicenergy/nordicenergy-core/staking/availability"
+	"github.com/nordicenergy/nordicenergy-core/staking/slash"
+	staking "github.com/nordicenergy/nordicenergy-core/staking/types"
 )
 
 // Payout ..
@@ -17,11 +20,39 @@ type Payout struct {
 	EarningKey  bls.SerializedPublicKey
 }
 
+// DelegatorPayout is the reward paid to a delegation of a validator
+type DelegatorPayout struct {
+	Validator   common.Address
+	Delegator   common.Address
+	NewlyEarned *big.Int
+}
+
+// AppendDelegatorPayouts records the non-zero rewards paid to the delegations
+// of a validator, paid being the amounts returned by AddReward
+func AppendDelegatorPayouts(
+	payouts []DelegatorPayout, validator *staking.ValidatorWrapper, paid []*big.Int,
+) []DelegatorPayout {
+	for i := range paid {
+		if paid[i].Sign() <= 0 {
+			continue
+		}
+		payouts = append(payouts, DelegatorPayout{
+			Validator:   validator.Address,
+			Delegator:   validator.Delegations[i].DelegatorAddress,
+			NewlyEarned: paid[i],
+		})
+	}
+	return payouts
+}
+
 // CompletedRound ..
 type CompletedRound struct {
 	Total            *big.Int
 	BeaconchainAward []Payout
 	ShardChainAward  []Payout
+	DelegatorAward   []DelegatorPayout
+	Slashes          []slash.HistoryRecord
+	Downtime         []availability.DowntimeRecord
 }
 
 // Reader ..
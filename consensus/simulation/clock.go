@@ -0,0 +1,81 @@
+package simulation
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock is a utils.Clock whose time only moves when the simulation moves it.
+// The consensus timeouts, the block time and the view IDs of all the
+// simulated nodes are measured with it.
+type Clock struct {
+	lock    sync.Mutex
+	now     time.Time
+	waiters []waiter
+}
+
+type waiter struct {
+	at time.Time
+	c  chan time.Time
+}
+
+// NewClock returns a clock set to start
+func NewClock(start time.Time) *Clock {
+	return &Clock{now: start}
+}
+
+// Now ..
+func (c *Clock) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.now
+}
+
+// After returns a channel receiving the time once the clock moved by d
+func (c *Clock) After(d time.Duration) <-chan time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.waiters = append(c.waiters, waiter{at: c.now.Add(d), c: ch})
+	sort.SliceStable(c.waiters, func(i, j int) bool {
+		return c.waiters[i].at.Before(c.waiters[j].at)
+	})
+	return ch
+}
+
+// Advance moves the clock forward by d
+func (c *Clock) Advance(d time.Duration) {
+	c.AdvanceTo(c.Now().Add(d))
+}
+
+// AdvanceTo moves the clock forward to t, firing the channels returned by
+// After that are due by then. The clock never moves backward.
+func (c *Clock) AdvanceTo(t time.Time) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if t.Before(c.now) {
+		return
+	}
+	c.now = t
+	due := 0
+	for due < len(c.waiters) && !c.waiters[due].at.After(t) {
+		c.waiters[due].c <- c.waiters[due].at
+		due++
+	}
+	c.waiters = c.waiters[due:]
+}
+
+// nextWaiter returns the time the next channel returned by After fires at
+func (c *Clock) nextWaiter() (time.Time, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if len(c.waiters) == 0 {
+		return time.Time{}, false
+	}
+	return c.waiters[0].at, true
+}
@@ -0,0 +1,167 @@
+package simulation
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+
+	protobuf "github.com/golang/protobuf/proto"
+	"github.com/nordicenergy/nordicenergy-core/api/proto"
+	msg_pb "github.com/nordicenergy/nordicenergy-core/api/proto/message"
+	"github.com/nordicenergy/nordicenergy-core/crypto/bls"
+	"github.com/nordicenergy/nordicenergy-core/crypto/hash"
+	"github.com/nordicenergy/nordicenergy-core/internal/utils"
+	"github.com/nordicenergy/nordicenergy-core/p2p"
+)
+
+// Fault decides what becomes of a message in flight. It returns the
+// envelopes delivered in place of env, none if the message is lost.
+type Fault interface {
+	Apply(rng *rand.Rand, env *Envelope) []*Envelope
+}
+
+func matchType(types []msg_pb.MessageType, t msg_pb.MessageType) bool {
+	if len(types) == 0 {
+		return true
+	}
+	for _, want := range types {
+		if want == t {
+			return true
+		}
+	}
+	return false
+}
+
+// Drop loses messages of the given types, all types if none is given,
+// with probability Rate
+type Drop struct {
+	Rate  float64
+	Types []msg_pb.MessageType
+}
+
+// Apply ..
+func (f *Drop) Apply(rng *rand.Rand, env *Envelope) []*Envelope {
+	if matchType(f.Types, env.Type) && rng.Float64() < f.Rate {
+		return nil
+	}
+	return []*Envelope{env}
+}
+
+// Delay holds back messages of the given types, all types if none is
+// given, by a uniformly random duration between Min and Max
+type Delay struct {
+	Min   time.Duration
+	Max   time.Duration
+	Types []msg_pb.MessageType
+}
+
+// Apply ..
+func (f *Delay) Apply(rng *rand.Rand, env *Envelope) []*Envelope {
+	if !matchType(f.Types, env.Type) {
+		return []*Envelope{env}
+	}
+	delay := f.Min
+	if f.Max > f.Min {
+		delay += time.Duration(rng.Int63n(int64(f.Max - f.Min)))
+	}
+	delayed := env.Clone()
+	delayed.At = env.At.Add(delay)
+	return []*Envelope{delayed}
+}
+
+// Partition splits the network in groups of nodes, messages between nodes
+// of different groups are lost. A node not in any group is on its own.
+type Partition struct {
+	Groups [][]int
+}
+
+func (f *Partition) group(node int) int {
+	for i, group := range f.Groups {
+		for _, member := range group {
+			if member == node {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// Apply ..
+func (f *Partition) Apply(rng *rand.Rand, env *Envelope) []*Envelope {
+	from := f.group(env.From)
+	if from == -1 || from != f.group(env.To) {
+		return nil
+	}
+	return []*Envelope{env}
+}
+
+// EquivocatingLeader makes Node announce a second, conflicting proposal for
+// the same block number and view ID, signed with Key. Nodes with an odd index
+// receive the conflicting announce, the others the original one. The
+// conflicting proposal commits to a block hash no block has, so it can at
+// most stall the round and never be committed.
+type EquivocatingLeader struct {
+	Node int
+	Key  *bls.PrivateKeyWrapper
+
+	lock        sync.Mutex
+	conflicting map[string][]byte
+}
+
+// Apply ..
+func (f *EquivocatingLeader) Apply(rng *rand.Rand, env *Envelope) []*Envelope {
+	if env.From != f.Node || env.Type != msg_pb.MessageType_ANNOUNCE || env.To%2 == 0 {
+		return []*Envelope{env}
+	}
+	payload, err := f.conflictingAnnounce(env.Payload)
+	if err != nil {
+		utils.Logger().Warn().Err(err).Msg("[Simulation] cannot equivocate announce")
+		return []*Envelope{env}
+	}
+	conflicting := env.Clone()
+	conflicting.Payload = payload
+	return []*Envelope{conflicting}
+}
+
+func (f *EquivocatingLeader) conflictingAnnounce(payload []byte) ([]byte, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if conflicting, ok := f.conflicting[string(payload)]; ok {
+		return conflicting, nil
+	}
+
+	msg, err := parseMessage(payload)
+	if err != nil {
+		return nil, err
+	}
+	consensusMsg := msg.GetConsensus()
+	if consensusMsg == nil {
+		return nil, errNotConsensusMessage
+	}
+	blockHash := hash.Keccak256(append(consensusMsg.BlockHash, []byte("equivocation")...))
+	consensusMsg.BlockHash = blockHash
+	consensusMsg.Payload = blockHash
+
+	msg.Signature = nil
+	unsigned, err := protobuf.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	msgHash := hash.Keccak256(unsigned)
+	sig, err := f.Key.SignHash(msgHash[:])
+	if err != nil {
+		return nil, err
+	}
+	msg.Signature = sig.Serialize()
+	signed, err := protobuf.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	conflicting := p2p.ConstructMessage(proto.ConstructConsensusMessage(signed))
+
+	if f.conflicting == nil {
+		f.conflicting = map[string][]byte{}
+	}
+	f.conflicting[string(payload)] = conflicting
+	return conflicting, nil
+}
@@ -0,0 +1,303 @@
+package simulation
+
+import (
+	"container/heap"
+	"fmt"
+	"math/rand"
+	"runtime"
+	"sync"
+	"time"
+
+	protobuf "github.com/golang/protobuf/proto"
+	libp2p_host "github.com/libp2p/go-libp2p-core/host"
+	libp2p_peer "github.com/libp2p/go-libp2p-core/peer"
+	libp2p_pubsub "github.com/libp2p/go-libp2p-pubsub"
+	"github.com/nordicenergy/nordicenergy-core/api/proto"
+	msg_pb "github.com/nordicenergy/nordicenergy-core/api/proto/message"
+	nodeconfig "github.com/nordicenergy/nordicenergy-core/internal/configs/node"
+	"github.com/nordicenergy/nordicenergy-core/p2p"
+	"github.com/nordicenergy/nordicenergy-core/p2p/discovery"
+	sttypes "github.com/nordicenergy/nordicenergy-core/p2p/stream/types"
+	"github.com/pkg/errors"
+)
+
+// p2pMsgPrefixSize is the size of the header p2p.ConstructMessage puts on messages
+const p2pMsgPrefixSize = 5
+
+var (
+	errNotConsensusMessage = errors.New("not a consensus message")
+	errNotSupported        = errors.New("not supported by the simulated host")
+)
+
+// Envelope is a message in flight from a node to another
+type Envelope struct {
+	From    int
+	To      int
+	Type    msg_pb.MessageType
+	Payload []byte
+	// At is the time the message is delivered at
+	At time.Time
+
+	seq uint64
+}
+
+// Clone returns a copy of the envelope a Fault can change
+func (env *Envelope) Clone() *Envelope {
+	clone := *env
+	return &clone
+}
+
+// parseMessage returns the consensus message carried by a p2p message
+func parseMessage(payload []byte) (*msg_pb.Message, error) {
+	if len(payload) < p2pMsgPrefixSize+proto.MessageCategoryBytes ||
+		proto.MessageCategory(payload[p2pMsgPrefixSize]) != proto.Consensus {
+		return nil, errNotConsensusMessage
+	}
+	msg := &msg_pb.Message{}
+	if err := protobuf.Unmarshal(
+		payload[p2pMsgPrefixSize+proto.MessageCategoryBytes:], msg,
+	); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// envelopeQueue is a priority queue of envelopes ordered by delivery time,
+// ties broken by the order they were sent in
+type envelopeQueue []*Envelope
+
+func (q envelopeQueue) Len() int { return len(q) }
+func (q envelopeQueue) Less(i, j int) bool {
+	if q[i].At.Equal(q[j].At) {
+		return q[i].seq < q[j].seq
+	}
+	return q[i].At.Before(q[j].At)
+}
+func (q envelopeQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *envelopeQueue) Push(x interface{}) { *q = append(*q, x.(*Envelope)) }
+func (q *envelopeQueue) Pop() interface{} {
+	old := *q
+	env := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return env
+}
+
+// Network is an in-memory gossip network between the simulated nodes.
+// Messages take Latency to be delivered, then go through the faults in the
+// order they were added. The randomness of the faults comes from a seeded
+// source, so that a run can be reproduced.
+type Network struct {
+	clock   *Clock
+	latency time.Duration
+
+	lock   sync.Mutex
+	rng    *rand.Rand
+	faults []Fault
+	hosts  []*host
+	queue  envelopeQueue
+	seq    uint64
+	sent   chan struct{}
+
+	delivered uint64
+	dropped   uint64
+}
+
+// NewNetwork returns an empty network on clock
+func NewNetwork(clock *Clock, seed int64, latency time.Duration) *Network {
+	return &Network{
+		clock:   clock,
+		latency: latency,
+		rng:     rand.New(rand.NewSource(seed)),
+		sent:    make(chan struct{}, 1),
+	}
+}
+
+// AddFault makes the network apply fault to the messages sent from now on
+func (n *Network) AddFault(fault Fault) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.faults = append(n.faults, fault)
+}
+
+// RemoveFault stops applying fault, the messages already in flight are
+// not affected
+func (n *Network) RemoveFault(fault Fault) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	for i := range n.faults {
+		if n.faults[i] == fault {
+			n.faults = append(n.faults[:i], n.faults[i+1:]...)
+			return
+		}
+	}
+}
+
+// Stats returns the number of messages delivered to the nodes and the
+// number of messages lost to faults
+func (n *Network) Stats() (delivered, dropped uint64) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return n.delivered, n.dropped
+}
+
+// newHost returns the host of the next node, subscribed to groups
+func (n *Network) newHost(groups ...nodeconfig.GroupID) *host {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	h := &host{network: n, index: len(n.hosts), groups: groups}
+	n.hosts = append(n.hosts, h)
+	return h
+}
+
+func (n *Network) send(from int, groups []nodeconfig.GroupID, payload []byte) error {
+	msg, err := parseMessage(payload)
+	if err != nil {
+		return err
+	}
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	at := n.clock.Now().Add(n.latency)
+	for _, to := range n.hosts {
+		if to.index == from || !to.subscribed(groups) {
+			continue
+		}
+		envs := []*Envelope{{
+			From:    from,
+			To:      to.index,
+			Type:    msg.Type,
+			Payload: payload,
+			At:      at,
+		}}
+		for _, fault := range n.faults {
+			applied := []*Envelope{}
+			for _, env := range envs {
+				applied = append(applied, fault.Apply(n.rng, env)...)
+			}
+			envs = applied
+		}
+		if len(envs) == 0 {
+			n.dropped++
+		}
+		for _, env := range envs {
+			n.seq++
+			env.seq = n.seq
+			heap.Push(&n.queue, env)
+		}
+	}
+	select {
+	case n.sent <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+// popDue returns the next message due by now, nil if there is none
+func (n *Network) popDue(now time.Time) *Envelope {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if len(n.queue) == 0 || n.queue[0].At.After(now) {
+		return nil
+	}
+	n.delivered++
+	return heap.Pop(&n.queue).(*Envelope)
+}
+
+// nextDelivery returns the time the next message is delivered at
+func (n *Network) nextDelivery() (time.Time, bool) {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	if len(n.queue) == 0 {
+		return time.Time{}, false
+	}
+	return n.queue[0].At, true
+}
+
+// waitForSend yields to the node goroutines up to yields times and returns
+// whether one of them sent a message in the meantime
+func (n *Network) waitForSend(yields int) bool {
+	for i := 0; ; i++ {
+		select {
+		case <-n.sent:
+			return true
+		default:
+		}
+		if i == yields {
+			return false
+		}
+		runtime.Gosched()
+	}
+}
+
+// host is the p2p.Host of a simulated node. It only gossips consensus
+// messages, the libp2p specific parts are left empty.
+type host struct {
+	network *Network
+	index   int
+	groups  []nodeconfig.GroupID
+}
+
+func (h *host) subscribed(groups []nodeconfig.GroupID) bool {
+	for _, group := range groups {
+		for _, mine := range h.groups {
+			if group == mine {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (h *host) Start() error { return nil }
+func (h *host) Close() error { return nil }
+
+func (h *host) GetSelfPeer() p2p.Peer {
+	return p2p.Peer{IP: "127.0.0.1", Port: fmt.Sprint(9000 + h.index)}
+}
+
+func (h *host) AddPeer(*p2p.Peer) error { return nil }
+
+func (h *host) GetID() libp2p_peer.ID {
+	return libp2p_peer.ID(fmt.Sprintf("simulated-node-%d", h.index))
+}
+
+func (h *host) GetP2PHost() libp2p_host.Host      { return nil }
+func (h *host) GetDiscovery() discovery.Discovery { return nil }
+
+func (h *host) GetPeerCount() int {
+	h.network.lock.Lock()
+	defer h.network.lock.Unlock()
+	return len(h.network.hosts) - 1
+}
+
+func (h *host) ConnectHostPeer(p2p.Peer) error                  { return nil }
+func (h *host) AddStreamProtocol(protocols ...sttypes.Protocol) {}
+
+func (h *host) SendMessageToGroups(groups []nodeconfig.GroupID, msg []byte) error {
+	return h.network.send(h.index, groups, msg)
+}
+
+func (h *host) PubSub() *libp2p_pubsub.PubSub { return nil }
+
+func (h *host) C() (int, int, int) {
+	peers := h.GetPeerCount()
+	return peers, peers, 0
+}
+
+func (h *host) GetOrJoin(topic string) (*libp2p_pubsub.Topic, error) {
+	return nil, errNotSupported
+}
+
+func (h *host) ListPeer(topic string) []libp2p_peer.ID { return nil }
+
+func (h *host) ListTopic() []string {
+	topics := make([]string, len(h.groups))
+	for i, group := range h.groups {
+		topics[i] = group.String()
+	}
+	return topics
+}
+
+func (h *host) ListBlockedPeer() []libp2p_peer.ID    { return nil }
+func (h *host) PenalizePeer(libp2p_peer.ID, float64) {}
+func (h *host) BlockPeer(libp2p_peer.ID)             {}
@@ -0,0 +1,229 @@
+package simulation
+
+import (
+	"context"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	msg_pb "github.com/nordicenergy/nordicenergy-core/api/proto/message"
+	"github.com/nordicenergy/nordicenergy-core/consensus"
+	"github.com/nordicenergy/nordicenergy-core/core"
+	"github.com/nordicenergy/nordicenergy-core/core/types"
+	"github.com/nordicenergy/nordicenergy-core/crypto/bls"
+	"github.com/nordicenergy/nordicenergy-core/internal/utils"
+	"github.com/nordicenergy/nordicenergy-core/multibls"
+	"github.com/nordicenergy/nordicenergy-core/node/worker"
+	"github.com/nordicenergy/nordicenergy-core/shard"
+	"github.com/pkg/errors"
+)
+
+var (
+	errStopped         = errors.New("node stopped")
+	errLeaderNotInSlot = errors.New("leader key is not in the committee")
+)
+
+// Node is a simulated validator, running a real Consensus on its own copy
+// of the chain. It proposes blocks when it is the leader, the way a node
+// does, without transactions.
+type Node struct {
+	// Index is the position of the node in the network and in the committee
+	Index     int
+	Consensus *consensus.Consensus
+	Chain     *core.BlockChain
+	Keys      multibls.PrivateKeys
+
+	host      *host
+	worker    *worker.Worker
+	clock     *Clock
+	committee *shard.Committee
+
+	blockChannel chan *types.Block
+	stop         chan struct{}
+	stopped      chan struct{}
+
+	lock      sync.Mutex
+	committed []*types.Block
+}
+
+// verifyBlock is the block verifier of the node's consensus
+func (n *Node) verifyBlock(block *types.Block) error {
+	if block.NumberU64() <= n.Chain.CurrentBlock().NumberU64() {
+		return errors.Errorf("block %d is already committed", block.NumberU64())
+	}
+	if err := n.Chain.Validator().ValidateHeader(block, true); err != nil {
+		return err
+	}
+	return n.Chain.ValidateNewBlock(block)
+}
+
+// postConsensus records the blocks the node's consensus committed
+func (n *Node) postConsensus(block *types.Block) error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	n.committed = append(n.committed, block)
+	return nil
+}
+
+// Committed returns the blocks the node committed through consensus, in
+// the order it committed them
+func (n *Node) Committed() []*types.Block {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+	return append([]*types.Block{}, n.committed...)
+}
+
+// Height returns the number of the last block of the node's chain
+func (n *Node) Height() uint64 {
+	return n.Chain.CurrentBlock().NumberU64()
+}
+
+// accept does the checks a node does on consensus messages before handing
+// them to consensus, and returns the key of the sender
+func (n *Node) accept(msg *msg_pb.Message) (*bls.SerializedPublicKey, bool) {
+	c := n.Consensus
+	switch msg.Type {
+	case msg_pb.MessageType_PREPARE, msg_pb.MessageType_COMMIT:
+		if c.IsViewChangingMode() || !c.IsLeader() {
+			return nil, false
+		}
+	case msg_pb.MessageType_NEWVIEW, msg_pb.MessageType_VIEWCHANGE:
+		if !c.IsViewChangingMode() {
+			return nil, false
+		}
+	case msg_pb.MessageType_ANNOUNCE, msg_pb.MessageType_PREPARED, msg_pb.MessageType_COMMITTED:
+		if c.IsLeader() {
+			return nil, false
+		}
+	}
+
+	var senderKey []byte
+	if con := msg.GetConsensus(); con != nil {
+		if con.ShardId != c.ShardID {
+			return nil, false
+		}
+		senderKey = con.SenderPubkey
+	} else if vc := msg.GetViewchange(); vc != nil {
+		if vc.ShardId != c.ShardID {
+			return nil, false
+		}
+		senderKey = vc.SenderPubkey
+	} else {
+		return nil, false
+	}
+
+	key := bls.SerializedPublicKey{}
+	if len(senderKey) > 0 {
+		if len(senderKey) != bls.PublicKeySizeInBytes {
+			return nil, false
+		}
+		copy(key[:], senderKey)
+	}
+	return &key, true
+}
+
+// deliver hands a message to the node's consensus
+func (n *Node) deliver(env *Envelope) {
+	msg, err := parseMessage(env.Payload)
+	if err != nil {
+		utils.Logger().Debug().Err(err).Int("node", n.Index).Msg("[Simulation] unparseable message")
+		return
+	}
+	senderKey, ok := n.accept(msg)
+	if !ok {
+		return
+	}
+	if err := n.Consensus.HandleMessageUpdate(context.Background(), msg, senderKey); err != nil {
+		utils.Logger().Debug().Err(err).
+			Int("node", n.Index).
+			Str("type", msg.Type.String()).
+			Msg("[Simulation] message not handled")
+	}
+}
+
+// start runs the node's consensus and block proposals
+func (n *Node) start() {
+	n.blockChannel = make(chan *types.Block)
+	n.stop = make(chan struct{})
+	n.stopped = make(chan struct{})
+	startChannel := make(chan struct{})
+	n.Consensus.Start(n.blockChannel, n.stop, n.stopped, startChannel)
+	go n.proposeBlocks()
+	close(startChannel)
+}
+
+func (n *Node) close() {
+	close(n.stop)
+	<-n.stopped
+}
+
+// proposeBlocks proposes a block whenever consensus is ready for one
+func (n *Node) proposeBlocks() {
+	for {
+		select {
+		case <-n.stop:
+			return
+		case proposalType := <-n.Consensus.ReadySignal:
+			if !n.Consensus.IsLeader() {
+				continue
+			}
+			block, err := n.propose(proposalType)
+			if err != nil {
+				utils.Logger().Warn().Err(err).Int("node", n.Index).Msg("[Simulation] cannot propose block")
+				continue
+			}
+			select {
+			case n.blockChannel <- block:
+			case <-n.stop:
+				return
+			}
+		}
+	}
+}
+
+func (n *Node) propose(proposalType consensus.ProposalType) (*types.Block, error) {
+	sigs, err := n.lastCommitSigs(proposalType)
+	if err != nil {
+		return nil, err
+	}
+	if err := n.worker.UpdateCurrent(); err != nil {
+		return nil, err
+	}
+	n.worker.GetCurrentHeader().SetTime(big.NewInt(n.clock.Now().Unix()))
+	coinbase, err := n.coinbase(n.Consensus.LeaderPubKey)
+	if err != nil {
+		return nil, err
+	}
+	commitSigs := make(chan []byte, 1)
+	commitSigs <- sigs
+	return n.worker.FinalizeNewBlock(
+		commitSigs, n.Consensus.GetCurBlockViewID, coinbase, nil, nil,
+	)
+}
+
+// lastCommitSigs returns the commit signatures of the last block, waiting
+// for the ones of a block still being committed on an async proposal
+func (n *Node) lastCommitSigs(proposalType consensus.ProposalType) ([]byte, error) {
+	if proposalType == consensus.AsyncProposal {
+		select {
+		case sigs := <-n.Consensus.CommitSigChannel:
+			if len(sigs) > bls.BLSSignatureSizeInBytes {
+				return sigs, nil
+			}
+		case <-n.clock.After(consensus.CommitSigReceiverTimeout):
+		case <-n.stop:
+			return nil, errStopped
+		}
+	}
+	return n.Consensus.BlockCommitSigs(n.Chain.CurrentBlock().NumberU64())
+}
+
+// coinbase returns the address of the committee slot of the leader key
+func (n *Node) coinbase(leader *bls.PublicKeyWrapper) (common.Address, error) {
+	for _, slot := range n.committee.Slots {
+		if slot.BLSPublicKey == leader.Bytes {
+			return slot.EcdsaAddress, nil
+		}
+	}
+	return common.Address{}, errLeaderNotInSlot
+}
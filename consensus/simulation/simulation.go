@@ -0,0 +1,313 @@
+// Package simulation runs several validators with real Consensus objects and
+// quorum Deciders against an in-memory network and a simulated clock, so that
+// protocol changes can be exercised with message loss, delays, partitions and
+// malicious leaders before they reach a testnet.
+//
+// Which messages are delivered, when and in which order, and the time the
+// consensus timeouts see are decided by the simulation from a seeded source.
+// The consensus code still spawns its own goroutines. While it runs, the
+// simulation keeps the process on a single processor and yields to them
+// until the network goes quiet before it moves the clock, so that no wall
+// time is involved.
+package simulation
+
+import (
+	"math/big"
+	"runtime"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	blockfactory "github.com/nordicenergy/nordicenergy-core/block/factory"
+	"github.com/nordicenergy/nordicenergy-core/consensus"
+	"github.com/nordicenergy/nordicenergy-core/consensus/quorum"
+	"github.com/nordicenergy/nordicenergy-core/core"
+	"github.com/nordicenergy/nordicenergy-core/core/vm"
+	"github.com/nordicenergy/nordicenergy-core/crypto/bls"
+	chain2 "github.com/nordicenergy/nordicenergy-core/internal/chain"
+	nodeconfig "github.com/nordicenergy/nordicenergy-core/internal/configs/node"
+	"github.com/nordicenergy/nordicenergy-core/internal/params"
+	"github.com/nordicenergy/nordicenergy-core/multibls"
+	"github.com/nordicenergy/nordicenergy-core/node/worker"
+	"github.com/nordicenergy/nordicenergy-core/p2p"
+	"github.com/nordicenergy/nordicenergy-core/shard"
+	"github.com/pkg/errors"
+)
+
+// Default settings of a simulation
+const (
+	DefaultLatency  = 10 * time.Millisecond
+	DefaultIdleStep = time.Second
+	DefaultSettle   = 1000
+	// quietYields is the number of times the consensus goroutines are
+	// yielded to before the next message in flight is delivered
+	quietYields = 20
+)
+
+var (
+	// ErrSafetyViolation is returned when two nodes committed different blocks at the same height
+	ErrSafetyViolation = errors.New("nodes committed conflicting blocks")
+	// ErrNotFinal is returned when nodes did not reach the expected height
+	ErrNotFinal = errors.New("block not finalized")
+
+	errNoNodes = errors.New("simulation needs at least one node")
+)
+
+// simulationStart is the genesis time, so that block times and view IDs do
+// not depend on the wall time
+var simulationStart = time.Unix(1600000000, 0)
+
+// Config is the set up of a simulation
+type Config struct {
+	// NumNodes is the number of validators, each holding one bls key
+	NumNodes int
+	// Seed seeds the randomness of the network faults
+	Seed int64
+	// Latency is the time every message takes to be delivered
+	Latency time.Duration
+	// IdleStep is how far the clock moves when nothing else is due
+	IdleStep time.Duration
+	// Settle is the number of times the nodes are yielded to before the
+	// network is considered idle
+	Settle int
+	// LeaderRotationBlocks is the number of blocks a leader proposes before
+	// the leader is rotated, the one of the chain config if zero
+	LeaderRotationBlocks uint64
+}
+
+// Simulation is a shard of simulated validators
+type Simulation struct {
+	config  Config
+	clock   *Clock
+	network *Network
+	nodes   []*Node
+	started bool
+	procs   int
+}
+
+// ChainConfig returns the chain config of the simulated chains, which stay
+// before staking so that the committee is the one of the genesis block
+func ChainConfig() *params.ChainConfig {
+	config := *params.TestChainConfig
+	config.CrossLinkEpoch = params.EpochTBD
+	config.StakingEpoch = params.EpochTBD
+	config.PreStakingEpoch = params.EpochTBD
+	return &config
+}
+
+// New sets up the nodes of a simulation, sharing a genesis block whose
+// committee is made of their keys. Node 0 is the first leader.
+func New(config Config) (*Simulation, error) {
+	if config.NumNodes <= 0 {
+		return nil, errNoNodes
+	}
+	if config.Latency == 0 {
+		config.Latency = DefaultLatency
+	}
+	if config.IdleStep == 0 {
+		config.IdleStep = DefaultIdleStep
+	}
+	if config.Settle == 0 {
+		config.Settle = DefaultSettle
+	}
+	clock := NewClock(simulationStart)
+	sim := &Simulation{
+		config:  config,
+		clock:   clock,
+		network: NewNetwork(clock, config.Seed, config.Latency),
+	}
+
+	shardID := uint32(shard.BeaconChainShardID)
+	keys := make([]multibls.PrivateKeys, config.NumNodes)
+	committee := shard.Committee{ShardID: shardID}
+	for i := range keys {
+		keys[i] = multibls.GetPrivateKeys(bls.RandPrivateKey())
+		committee.Slots = append(committee.Slots, shard.Slot{
+			EcdsaAddress: common.BigToAddress(big.NewInt(int64(i + 1))),
+			BLSPublicKey: keys[i][0].Pub.Bytes,
+		})
+	}
+	chainConfig := ChainConfig()
+	if config.LeaderRotationBlocks > 0 {
+		chainConfig.LeaderRotationBlocksCount = config.LeaderRotationBlocks
+	}
+	genesis := core.Genesis{
+		Config:    chainConfig,
+		Factory:   blockfactory.NewFactory(chainConfig),
+		ShardID:   shardID,
+		Timestamp: uint64(simulationStart.Unix()),
+		Alloc:     core.GenesisAlloc{},
+		ShardState: shard.State{
+			Epoch:  big.NewInt(0),
+			Shards: []shard.Committee{committee},
+		},
+	}
+	group := nodeconfig.NewGroupIDByShardID(nodeconfig.ShardID(shardID))
+
+	for i := range keys {
+		db := rawdb.NewMemoryDatabase()
+		if _, err := genesis.Commit(db); err != nil {
+			return nil, err
+		}
+		chain, err := core.NewBlockChain(db, nil, chainConfig, chain2.Engine, vm.Config{}, nil)
+		if err != nil {
+			return nil, err
+		}
+		node := &Node{
+			Index:     i,
+			Chain:     chain,
+			Keys:      keys[i],
+			host:      sim.network.newHost(group),
+			worker:    worker.New(chainConfig, chain, chain2.Engine),
+			clock:     clock,
+			committee: &committee,
+		}
+		decider := quorum.NewDecider(quorum.SuperMajorityVote, shardID)
+		node.Consensus, err = consensus.New(node.host, shardID, p2p.Peer{}, keys[i], decider)
+		if err != nil {
+			return nil, err
+		}
+		c := node.Consensus
+		decider.SetMyPublicKeyProvider(func() (multibls.PublicKeys, error) {
+			return c.GetPublicKeys(), nil
+		})
+		c.Blockchain = chain
+		c.SetClock(clock)
+		c.SetBlockVerifier(node.verifyBlock)
+		c.PostConsensusJob = node.postConsensus
+		c.LeaderPubKey = keys[0][0].Pub
+		c.SetBlockNum(chain.CurrentBlock().NumberU64() + 1)
+		c.SetViewIDs(chain.CurrentHeader().ViewID().Uint64() + 1)
+		c.SetMode(c.UpdateConsensusInformation())
+		sim.nodes = append(sim.nodes, node)
+	}
+	// The engine is shared by all the chains and only reads the current
+	// header of the beacon chain while finalizing blocks
+	chain2.Engine.SetBeaconchain(sim.nodes[0].Chain)
+	return sim, nil
+}
+
+// Clock ..
+func (s *Simulation) Clock() *Clock {
+	return s.clock
+}
+
+// Network ..
+func (s *Simulation) Network() *Network {
+	return s.network
+}
+
+// Nodes ..
+func (s *Simulation) Nodes() []*Node {
+	return s.nodes
+}
+
+// Start starts the consensus of all the nodes
+func (s *Simulation) Start() {
+	if s.started {
+		return
+	}
+	s.started = true
+	s.procs = runtime.GOMAXPROCS(1)
+	for _, node := range s.nodes {
+		node.start()
+	}
+}
+
+// Stop stops the consensus of all the nodes
+func (s *Simulation) Stop() {
+	if !s.started {
+		return
+	}
+	s.started = false
+	for _, node := range s.nodes {
+		node.close()
+	}
+	runtime.GOMAXPROCS(s.procs)
+}
+
+// Run delivers the messages in flight and moves the clock until done
+// returns true or d of simulated time passed. It returns whether done was met.
+func (s *Simulation) Run(d time.Duration, done func() bool) bool {
+	s.Start()
+	deadline := s.clock.Now().Add(d)
+	for !done() {
+		now := s.clock.Now()
+		if env := s.network.popDue(now); env != nil {
+			s.nodes[env.To].deliver(env)
+			continue
+		}
+		if !now.Before(deadline) {
+			return false
+		}
+		// give the goroutines the last messages started a chance to send
+		// theirs before moving on
+		settle := quietYields
+		if _, ok := s.network.nextDelivery(); !ok {
+			settle = s.config.Settle
+		}
+		if s.network.waitForSend(settle) {
+			continue
+		}
+
+		next := now.Add(s.config.IdleStep)
+		if at, ok := s.network.nextDelivery(); ok && at.Before(next) {
+			next = at
+		}
+		if at, ok := s.clock.nextWaiter(); ok && at.Before(next) {
+			next = at
+		}
+		if next.After(deadline) {
+			next = deadline
+		}
+		s.clock.AdvanceTo(next)
+	}
+	return true
+}
+
+// RunUntilHeight runs the simulation until the given nodes, all of them if
+// none is given, committed the block at height, for at most d of simulated time
+func (s *Simulation) RunUntilHeight(height uint64, d time.Duration, nodes ...int) error {
+	s.Run(d, func() bool {
+		return s.CheckFinality(height, nodes...) == nil
+	})
+	return s.CheckFinality(height, nodes...)
+}
+
+// CheckFinality returns an error if one of the given nodes, all of them if
+// none is given, did not commit the block at height
+func (s *Simulation) CheckFinality(height uint64, nodes ...int) error {
+	if len(nodes) == 0 {
+		for i := range s.nodes {
+			nodes = append(nodes, i)
+		}
+	}
+	for _, i := range nodes {
+		if got := s.nodes[i].Height(); got < height {
+			return errors.Wrapf(ErrNotFinal, "node %d is at height %d, expected %d", i, got, height)
+		}
+	}
+	return nil
+}
+
+// CheckSafety returns an error if two nodes committed different blocks at
+// the same height
+func (s *Simulation) CheckSafety() error {
+	committed := map[uint64]common.Hash{}
+	for _, node := range s.nodes {
+		for num := uint64(1); num <= node.Height(); num++ {
+			header := node.Chain.GetHeaderByNumber(num)
+			if header == nil {
+				continue
+			}
+			if hash, ok := committed[num]; !ok {
+				committed[num] = header.Hash()
+			} else if hash != header.Hash() {
+				return errors.Wrapf(ErrSafetyViolation,
+					"node %d committed %s at height %d, another node %s",
+					node.Index, header.Hash().Hex(), num, hash.Hex())
+			}
+		}
+	}
+	return nil
+}
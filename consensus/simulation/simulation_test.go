@@ -0,0 +1,136 @@
+package simulation
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSimulation_Finality(t *testing.T) {
+	sim, err := New(Config{NumNodes: 4, Seed: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sim.Stop()
+	sim.Network().AddFault(&Delay{Max: 200 * time.Millisecond})
+
+	if err := sim.RunUntilHeight(3, 2*time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if err := sim.CheckSafety(); err != nil {
+		t.Fatal(err)
+	}
+	for _, node := range sim.Nodes() {
+		if len(node.Committed()) < 3 {
+			t.Errorf("node %d committed %d blocks through consensus, expected 3",
+				node.Index, len(node.Committed()))
+		}
+	}
+}
+
+func TestSimulation_Partition(t *testing.T) {
+	sim, err := New(Config{NumNodes: 4, Seed: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sim.Stop()
+	sim.Network().AddFault(&Partition{Groups: [][]int{{0, 1, 2}, {3}}})
+
+	// three out of four nodes are a quorum
+	if err := sim.RunUntilHeight(2, 2*time.Minute, 0, 1, 2); err != nil {
+		t.Fatal(err)
+	}
+	if sim.Nodes()[3].Height() != 0 {
+		t.Errorf("isolated node committed height %d", sim.Nodes()[3].Height())
+	}
+	if _, dropped := sim.Network().Stats(); dropped == 0 {
+		t.Errorf("expected the messages to the isolated node to be dropped")
+	}
+	if err := sim.CheckSafety(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSimulation_EquivocatingLeader(t *testing.T) {
+	sim, err := New(Config{NumNodes: 4, Seed: 3, IdleStep: 5 * time.Second})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sim.Stop()
+	leader := sim.Nodes()[0]
+	sim.Network().AddFault(&EquivocatingLeader{Node: leader.Index, Key: &leader.Keys[0]})
+
+	// the split announce cannot reach a quorum, the block is committed after
+	// a view change to the next leader
+	if err := sim.RunUntilHeight(1, 5*time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if err := sim.CheckSafety(); err != nil {
+		t.Fatal(err)
+	}
+	if viewID := sim.Nodes()[1].Chain.CurrentHeader().ViewID().Uint64(); viewID <= 1 {
+		t.Errorf("expected block 1 to be committed after a view change, got view ID %d", viewID)
+	}
+}
+
+func TestSimulation_LeaderRotation(t *testing.T) {
+	sim, err := New(Config{NumNodes: 4, Seed: 4, LeaderRotationBlocks: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sim.Stop()
+
+	if err := sim.RunUntilHeight(8, 5*time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if err := sim.CheckSafety(); err != nil {
+		t.Fatal(err)
+	}
+	// the leaders picked for the windows starting at blocks 2, 4, 6 and 8
+	// are nodes 1, 1, 1 and 3, the blocks are proposed without view change
+	expected := []int{0, 0, 1, 1, 1, 1, 1, 1, 3}
+	chain := sim.Nodes()[0].Chain
+	for num := uint64(1); num <= 8; num++ {
+		header := chain.GetHeaderByNumber(num)
+		leader := sim.Nodes()[expected[num]]
+		if coinbase, _ := leader.coinbase(leader.Keys[0].Pub); header.Coinbase() != coinbase {
+			t.Errorf("block %d proposed by %s, expected node %d", num, header.Coinbase().Hex(), leader.Index)
+		}
+	}
+}
+
+func TestSimulation_Reproducible(t *testing.T) {
+	run := func() (time.Time, uint64, uint64, []uint64) {
+		sim, err := New(Config{NumNodes: 4, Seed: 5})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer sim.Stop()
+		sim.Network().AddFault(&Delay{Max: 200 * time.Millisecond})
+
+		if err := sim.RunUntilHeight(3, 2*time.Minute); err != nil {
+			t.Fatal(err)
+		}
+		delivered, dropped := sim.Network().Stats()
+		viewIDs := []uint64{}
+		for num := uint64(1); num <= 3; num++ {
+			header := sim.Nodes()[0].Chain.GetHeaderByNumber(num)
+			viewIDs = append(viewIDs, header.ViewID().Uint64())
+		}
+		return sim.Clock().Now(), delivered, dropped, viewIDs
+	}
+
+	end1, delivered1, dropped1, views1 := run()
+	end2, delivered2, dropped2, views2 := run()
+	if !end1.Equal(end2) {
+		t.Errorf("runs ended at %v and %v", end1, end2)
+	}
+	if delivered1 != delivered2 || dropped1 != dropped2 {
+		t.Errorf("runs delivered %d/%d and dropped %d/%d messages",
+			delivered1, delivered2, dropped1, dropped2)
+	}
+	for i := range views1 {
+		if views1[i] != views2[i] {
+			t.Errorf("block %d committed at view %d and %d", i+1, views1[i], views2[i])
+		}
+	}
+}
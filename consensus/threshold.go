@@ -52,6 +52,11 @@ func (consensus *Consensus) didReachPrepareQuorum() error {
 	// so by this point, everynet has committed to the blockhash of this block
 	// in prepare and so this is the actual block.
 	for i, key := range consensus.priKey {
+		sig, err := key.SignHash(commitPayload)
+		if err != nil {
+			consensus.getLogger().Warn().Err(err).Msgf("[OnPrepare] Leader failed to sign commit with key at index %d", i)
+			continue
+		}
 		if err := consensus.commitBitmap.SetKey(key.Pub.Bytes, true); err != nil {
 			consensus.getLogger().Warn().Msgf("[OnPrepare] Leader commit bitmap set failed for key at index %d", i)
 			continue
@@ -60,7 +65,7 @@ func (consensus *Consensus) didReachPrepareQuorum() error {
 		if _, err := consensus.Decider.AddNewVote(
 			quorum.Commit,
 			[]*bls.PublicKeyWrapper{key.Pub},
-			key.Pri.SignHash(commitPayload),
+			sig,
 			blockObj.Hash(),
 			blockObj.NumberU64(),
 			blockObj.Header().ViewID().Uint64(),
@@ -0,0 +1,252 @@
+package consensus
+
+import (
+	"sync"
+	"time"
+
+	"github.com/nordicenergy/nordicenergy-core/consensus/quorum"
+	"github.com/nordicenergy/nordicenergy-core/crypto/bls"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// DefaultTimelineSize is the number of rounds the consensus timeline keeps
+const DefaultTimelineSize = 100
+
+// Events of a consensus round, in the order they happen
+const (
+	RoundEventAnnounce      = "announce"
+	RoundEventPrepareQuorum = "prepare_quorum"
+	RoundEventCommitQuorum  = "commit_quorum"
+	RoundEventFinalCommit   = "final_commit"
+	RoundEventCommitted     = "committed"
+)
+
+// ViewChangeCause is the reason a node started a view change
+type ViewChangeCause string
+
+// Causes of a view change
+const (
+	ViewChangeConsensusTimeout    ViewChangeCause = "consensus_timeout"
+	ViewChangeBootstrapTimeout    ViewChangeCause = "bootstrap_timeout"
+	ViewChangeViewChangeTimeout   ViewChangeCause = "viewchange_timeout"
+	ViewChangeConflictingAnnounce ViewChangeCause = "conflicting_announce"
+)
+
+// RoundEvent is a step of a consensus round
+type RoundEvent struct {
+	Name string `json:"name"`
+	// Elapsed is the time since the start of the round, in milliseconds
+	Elapsed int64 `json:"elapsed-ms"`
+}
+
+// VoteRecord is a vote the leader received during a round
+type VoteRecord struct {
+	Phase   string   `json:"phase"`
+	Signers []string `json:"signers"`
+	// Latency is the time since the start of the round, in milliseconds
+	Latency int64 `json:"latency-ms"`
+	// Count is the number of signers of the phase so far, this vote included
+	Count int64 `json:"count"`
+}
+
+// ViewChangeRecord is a view change started during a round
+type ViewChangeRecord struct {
+	Cause      ViewChangeCause `json:"cause"`
+	NextViewID uint64          `json:"next-view-id"`
+	// Elapsed is the time since the start of the round, in milliseconds
+	Elapsed int64 `json:"elapsed-ms"`
+}
+
+// RoundTimeline is the trace of the consensus on a block at a view ID. The
+// round of the leader starts when it announces the block, the round of a
+// validator when it receives the announce.
+type RoundTimeline struct {
+	BlockNum    uint64             `json:"block-num"`
+	ViewID      uint64             `json:"view-id"`
+	Leader      string             `json:"leader"`
+	IsLeader    bool               `json:"is-leader"`
+	Start       time.Time          `json:"start"`
+	Events      []RoundEvent       `json:"events"`
+	Votes       []VoteRecord       `json:"votes"`
+	ViewChanges []ViewChangeRecord `json:"view-changes"`
+}
+
+func (r *RoundTimeline) copy() RoundTimeline {
+	c := *r
+	c.Events = append([]RoundEvent{}, r.Events...)
+	c.Votes = append([]VoteRecord{}, r.Votes...)
+	c.ViewChanges = append([]ViewChangeRecord{}, r.ViewChanges...)
+	return c
+}
+
+// timeline keeps the traces of the last rounds, the oldest first
+type timeline struct {
+	lock   sync.Mutex
+	size   int
+	rounds []*RoundTimeline
+}
+
+func newTimeline(size int) *timeline {
+	return &timeline{size: size}
+}
+
+func (t *timeline) current() *RoundTimeline {
+	if len(t.rounds) == 0 {
+		return nil
+	}
+	return t.rounds[len(t.rounds)-1]
+}
+
+// start makes the round of blockNum at viewID the current round, unless it
+// already is
+func (t *timeline) start(
+	now time.Time, blockNum, viewID uint64, leader string, isLeader bool,
+) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if cur := t.current(); cur != nil && cur.BlockNum == blockNum && cur.ViewID == viewID {
+		return
+	}
+	t.rounds = append(t.rounds, &RoundTimeline{
+		BlockNum: blockNum,
+		ViewID:   viewID,
+		Leader:   leader,
+		IsLeader: isLeader,
+		Start:    now,
+	})
+	if len(t.rounds) > t.size {
+		t.rounds = t.rounds[len(t.rounds)-t.size:]
+	}
+}
+
+// event records an event of the current round if it is the round of
+// blockNum, only the first time the event happens. It returns the time since
+// the start of the round and whether the event was recorded.
+func (t *timeline) event(now time.Time, blockNum uint64, name string) (time.Duration, bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	cur := t.current()
+	if cur == nil || cur.BlockNum != blockNum {
+		return 0, false
+	}
+	for _, event := range cur.Events {
+		if event.Name == name {
+			return 0, false
+		}
+	}
+	elapsed := now.Sub(cur.Start)
+	cur.Events = append(cur.Events, RoundEvent{name, elapsed.Milliseconds()})
+	return elapsed, true
+}
+
+// vote records a vote of the current round if it is the round of blockNum,
+// and returns the time since the start of the round
+func (t *timeline) vote(
+	now time.Time, blockNum uint64, phase string, signers []string, count int64,
+) (time.Duration, bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	cur := t.current()
+	if cur == nil || cur.BlockNum != blockNum {
+		return 0, false
+	}
+	latency := now.Sub(cur.Start)
+	cur.Votes = append(cur.Votes, VoteRecord{phase, signers, latency.Milliseconds(), count})
+	return latency, true
+}
+
+// viewChange records a view change started during the current round
+func (t *timeline) viewChange(now time.Time, cause ViewChangeCause, nextViewID uint64) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	cur := t.current()
+	if cur == nil {
+		return
+	}
+	cur.ViewChanges = append(cur.ViewChanges, ViewChangeRecord{
+		cause, nextViewID, now.Sub(cur.Start).Milliseconds(),
+	})
+}
+
+// last returns copies of the last count rounds, the most recent first
+func (t *timeline) last(count int) []RoundTimeline {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if count <= 0 || count > len(t.rounds) {
+		count = len(t.rounds)
+	}
+	rounds := make([]RoundTimeline, 0, count)
+	for i := len(t.rounds) - 1; i >= len(t.rounds)-count; i-- {
+		rounds = append(rounds, t.rounds[i].copy())
+	}
+	return rounds
+}
+
+// RoundTimelines returns the traces of the last count consensus rounds, the
+// most recent first, all the kept ones if count is not positive
+func (consensus *Consensus) RoundTimelines(count int) []RoundTimeline {
+	if consensus.timeline == nil {
+		return nil
+	}
+	return consensus.timeline.last(count)
+}
+
+// traceRoundStart starts the trace of the round of blockNum at viewID
+func (consensus *Consensus) traceRoundStart(blockNum, viewID uint64) {
+	if consensus.timeline == nil {
+		return
+	}
+	leader := ""
+	if consensus.LeaderPubKey != nil {
+		leader = consensus.LeaderPubKey.Bytes.Hex()
+	}
+	consensus.timeline.start(
+		consensus.clock.Now(), blockNum, viewID, leader, consensus.IsLeader(),
+	)
+}
+
+// traceRoundEvent records an event of the round of blockNum and the time it
+// took to get to it
+func (consensus *Consensus) traceRoundEvent(blockNum uint64, name string) {
+	if consensus.timeline == nil {
+		return
+	}
+	if elapsed, ok := consensus.timeline.event(consensus.clock.Now(), blockNum, name); ok {
+		consensusPhaseHistogramVec.With(prometheus.Labels{"phase": name}).
+			Observe(float64(elapsed.Milliseconds()))
+	}
+}
+
+// traceVote records a vote the leader received for blockNum and the latency
+// of its signers
+func (consensus *Consensus) traceVote(
+	p quorum.Phase, blockNum uint64, signers []*bls.PublicKeyWrapper,
+) {
+	if consensus.timeline == nil {
+		return
+	}
+	keys := make([]string, len(signers))
+	for i := range signers {
+		keys[i] = signers[i].Bytes.Hex()
+	}
+	latency, ok := consensus.timeline.vote(
+		consensus.clock.Now(), blockNum, p.String(), keys, consensus.Decider.SignersCount(p),
+	)
+	consensusVoteCounterVec.With(prometheus.Labels{"phase": p.String()}).Add(float64(len(signers)))
+	if !ok {
+		return
+	}
+	for _, key := range keys {
+		consensusSignerLatencyVec.With(prometheus.Labels{"phase": p.String(), "pubkey": key}).
+			Set(float64(latency.Milliseconds()))
+	}
+}
+
+// traceViewChange records the cause of a view change
+func (consensus *Consensus) traceViewChange(cause ViewChangeCause, nextViewID uint64) {
+	consensusVCCauseCounterVec.With(prometheus.Labels{"cause": string(cause)}).Inc()
+	if consensus.timeline == nil {
+		return
+	}
+	consensus.timeline.viewChange(consensus.clock.Now(), cause, nextViewID)
+}
@@ -0,0 +1,54 @@
+package consensus
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeline(t *testing.T) {
+	tl := newTimeline(2)
+	start := time.Unix(1600000000, 0)
+
+	// nothing is recorded before a round starts
+	_, ok := tl.event(start, 1, RoundEventAnnounce)
+	assert.False(t, ok)
+
+	tl.start(start, 1, 5, "leader", true)
+	elapsed, ok := tl.event(start.Add(300*time.Millisecond), 1, RoundEventPrepareQuorum)
+	assert.True(t, ok)
+	assert.Equal(t, 300*time.Millisecond, elapsed)
+	// an event is recorded once per round
+	_, ok = tl.event(start.Add(time.Second), 1, RoundEventPrepareQuorum)
+	assert.False(t, ok)
+	// events of another block are not recorded in the round
+	_, ok = tl.event(start.Add(time.Second), 2, RoundEventCommitQuorum)
+	assert.False(t, ok)
+
+	latency, ok := tl.vote(start.Add(100*time.Millisecond), 1, "Prepare", []string{"key"}, 2)
+	assert.True(t, ok)
+	assert.Equal(t, 100*time.Millisecond, latency)
+	tl.viewChange(start.Add(27*time.Second), ViewChangeConsensusTimeout, 6)
+
+	// starting the same round again keeps its trace
+	tl.start(start.Add(28*time.Second), 1, 5, "leader", true)
+	rounds := tl.last(0)
+	assert.Len(t, rounds, 1)
+	assert.Equal(t, []RoundEvent{{RoundEventPrepareQuorum, 300}}, rounds[0].Events)
+	assert.Equal(t, []VoteRecord{{"Prepare", []string{"key"}, 100, 2}}, rounds[0].Votes)
+	assert.Equal(t, []ViewChangeRecord{{ViewChangeConsensusTimeout, 6, 27000}}, rounds[0].ViewChanges)
+
+	// only the last rounds are kept, the most recent first
+	tl.start(start.Add(30*time.Second), 1, 6, "next leader", false)
+	tl.start(start.Add(40*time.Second), 2, 7, "next leader", false)
+	rounds = tl.last(0)
+	assert.Len(t, rounds, 2)
+	assert.Equal(t, uint64(7), rounds[0].ViewID)
+	assert.Equal(t, uint64(6), rounds[1].ViewID)
+	assert.Len(t, tl.last(1), 1)
+
+	// the returned rounds are copies
+	rounds[0].Events = append(rounds[0].Events, RoundEvent{RoundEventCommitted, 0})
+	assert.Empty(t, tl.last(1)[0].Events)
+}
@@ -30,6 +30,8 @@ func (consensus *Consensus) onAnnounce(msg *msg_pb.Message) {
 		return
 	}
 	consensus.StartFinalityCount()
+	consensus.traceRoundStart(recvMsg.BlockNum, recvMsg.ViewID)
+	consensus.traceRoundEvent(recvMsg.BlockNum, RoundEventAnnounce)
 
 	consensus.getLogger().Debug().
 		Uint64("MsgViewID", recvMsg.ViewID).
@@ -210,6 +212,7 @@ func (consensus *Consensus) onPrepared(recvMsg *FBFTMessage) {
 
 	// tryCatchup is also run in onCommitted(), so need to lock with commitMutex.
 	if consensus.current.Mode() == Normal {
+		consensus.traceRoundEvent(blockObj.NumberU64(), RoundEventPrepareQuorum)
 		consensus.sendCommitMessages(&blockObj)
 		consensus.switchPhase("onPrepared", FBFTCommit)
 	} else {
@@ -292,6 +295,7 @@ func (consensus *Consensus) onCommitted(recvMsg *FBFTMessage) {
 	}
 
 	consensus.FBFTLog.AddVerifiedMessage(recvMsg)
+	consensus.traceRoundEvent(recvMsg.BlockNum, RoundEventCommitQuorum)
 	consensus.aggregatedCommitSig = aggSig
 	consensus.commitBitmap = mask
 
@@ -356,13 +360,17 @@ func (consensus *Consensus) getPriKeysInCommittee() []*bls.PrivateKeyWrapper {
 
 func (consensus *Consensus) constructP2pMessages(msgType msg_pb.MessageType, payloadForSign []byte, priKeys []*bls.PrivateKeyWrapper) []*NetworkMessage {
 	p2pMsgs := []*NetworkMessage{}
+	priKeys = consensus.filterSlashingProtectedKeys(msgType, priKeys)
+	if len(priKeys) == 0 {
+		return p2pMsgs
+	}
 	if consensus.AggregateSig {
 		networkMessage, err := consensus.construct(msgType, payloadForSign, priKeys)
 		if err != nil {
 			logger := consensus.getLogger().Err(err).
 				Str("message-type", msgType.String())
 			for _, key := range priKeys {
-				logger.Str("key", key.Pri.SerializeToHexStr())
+				logger.Str("key", key.Pub.Bytes.Hex())
 			}
 			logger.Msg("could not construct message")
 		} else {
@@ -375,7 +383,7 @@ func (consensus *Consensus) constructP2pMessages(msgType msg_pb.MessageType, pay
 			if err != nil {
 				consensus.getLogger().Err(err).
 					Str("message-type", msgType.String()).
-					Str("key", key.Pri.SerializeToHexStr()).
+					Str("key", key.Pub.Bytes.Hex()).
 					Msg("could not construct message")
 				continue
 			}
@@ -386,6 +394,31 @@ func (consensus *Consensus) constructP2pMessages(msgType msg_pb.MessageType, pay
 	return p2pMsgs
 }
 
+// filterSlashingProtectedKeys drops the keys for which signing the current
+// block would conflict with an earlier signature of the same key
+func (consensus *Consensus) filterSlashingProtectedKeys(
+	msgType msg_pb.MessageType, priKeys []*bls.PrivateKeyWrapper,
+) []*bls.PrivateKeyWrapper {
+	if consensus.slashingProtection == nil {
+		return priKeys
+	}
+	allowed := []*bls.PrivateKeyWrapper{}
+	for _, key := range priKeys {
+		if err := consensus.slashingProtection.CheckAndRecord(
+			key.Pub.Bytes, msgType, consensus.blockNum,
+			consensus.GetCurBlockViewID(), consensus.blockHash,
+		); err != nil {
+			consensus.getLogger().Error().Err(err).
+				Str("message-type", msgType.String()).
+				Str("key", key.Pub.Bytes.Hex()).
+				Msg("[SlashingProtection] refused to sign")
+			continue
+		}
+		allowed = append(allowed, key)
+	}
+	return allowed
+}
+
 func (consensus *Consensus) broadcastConsensusP2pMessages(p2pMsgs []*NetworkMessage) error {
 	groupID := []nodeconfig.GroupID{nodeconfig.NewGroupIDByShardID(nodeconfig.ShardID(consensus.ShardID))}
 
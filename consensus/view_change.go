@@ -127,7 +127,7 @@ func (consensus *Consensus) getNextViewID() (uint64, time.Duration) {
 	}
 	blockTimestamp := curHeader.Time().Int64()
 	stuckBlockViewID := curHeader.ViewID().Uint64() + 1
-	curTimestamp := time.Now().Unix()
+	curTimestamp := consensus.clock.Now().Unix()
 
 	// timestamp messed up in current validator node
 	if curTimestamp <= blockTimestamp {
@@ -190,6 +190,12 @@ func (consensus *Consensus) getNextLeaderKey(viewID uint64) *bls.PublicKeyWrappe
 			if curHeader.IsLastBlockInEpoch() {
 				consensus.getLogger().Info().Msg("[getNextLeaderKey] view change in the first block of new epoch")
 				lastLeaderPubKey = consensus.Decider.FirstParticipant(shard.Schedule.InstanceForEpoch(epoch))
+			} else if consensus.isLeaderRotationBlock(curHeader) {
+				// the leader of the stuck block is the one the rotation picked,
+				// not the one of the last block
+				if leader := consensus.rotatedLeader(curHeader); leader != nil {
+					lastLeaderPubKey = leader
+				}
 			}
 		}
 	}
@@ -200,12 +206,18 @@ func (consensus *Consensus) getNextLeaderKey(viewID uint64) *bls.PublicKeyWrappe
 		Uint64("newViewID", viewID).
 		Uint64("myCurBlockViewID", consensus.GetCurBlockViewID()).
 		Msg("[getNextLeaderKey] got leaderPubKey from coinbase")
-	// wasFound, next := consensus.Decider.NthNext(lastLeaderPubKey, gap)
-	// FIXME: rotate leader on nordicenergy nodes only before fully externalization
-	wasFound, next := consensus.Decider.NthNextngy(
-		shard.Schedule.InstanceForEpoch(epoch),
-		lastLeaderPubKey,
-		gap)
+	var wasFound bool
+	var next *bls.PublicKeyWrapper
+	if consensus.Blockchain != nil && consensus.Blockchain.Config().IsLeaderRotation(epoch) {
+		// all the validators take turns once the leader is rotated
+		wasFound, next = consensus.Decider.NthNext(lastLeaderPubKey, gap)
+	} else {
+		// rotate leader on nordicenergy nodes only before fully externalization
+		wasFound, next = consensus.Decider.NthNextngy(
+			shard.Schedule.InstanceForEpoch(epoch),
+			lastLeaderPubKey,
+			gap)
+	}
 	if !wasFound {
 		consensus.getLogger().Warn().
 			Str("key", consensus.LeaderPubKey.Bytes.Hex()).
@@ -217,16 +229,16 @@ func (consensus *Consensus) getNextLeaderKey(viewID uint64) *bls.PublicKeyWrappe
 	return next
 }
 
-func createTimeout() map[TimeoutType]*utils.Timeout {
+func createTimeout(clock utils.Clock) map[TimeoutType]*utils.Timeout {
 	timeouts := make(map[TimeoutType]*utils.Timeout)
-	timeouts[timeoutConsensus] = utils.NewTimeout(phaseDuration)
-	timeouts[timeoutViewChange] = utils.NewTimeout(viewChangeDuration)
-	timeouts[timeoutBootstrap] = utils.NewTimeout(bootstrapDuration)
+	timeouts[timeoutConsensus] = utils.NewTimeoutWithClock(phaseDuration, clock)
+	timeouts[timeoutViewChange] = utils.NewTimeoutWithClock(viewChangeDuration, clock)
+	timeouts[timeoutBootstrap] = utils.NewTimeoutWithClock(bootstrapDuration, clock)
 	return timeouts
 }
 
 // startViewChange start the view change process
-func (consensus *Consensus) startViewChange() {
+func (consensus *Consensus) startViewChange(cause ViewChangeCause) {
 	if consensus.disableViewChange {
 		return
 	}
@@ -253,6 +265,10 @@ func (consensus *Consensus) startViewChange() {
 		Str("NextLeader", consensus.LeaderPubKey.Bytes.Hex()).
 		Msg("[startViewChange]")
 	consensusVCCounterVec.With(prometheus.Labels{"viewchange": "started"}).Inc()
+	consensus.traceViewChange(cause, nextViewID)
+	if consensus.OnViewChange != nil {
+		go consensus.OnViewChange(consensus.blockNum, nextViewID, cause)
+	}
 
 	consensus.consensusTimeout[timeoutViewChange].SetDuration(duration)
 	defer consensus.consensusTimeout[timeoutViewChange].Start()
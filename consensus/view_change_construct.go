@@ -376,11 +376,16 @@ func (vc *viewChange) InitPayload(
 					vc.getLogger().Info().Uint64("viewID", viewID).Uint64("blockNum", blockNum).Msg("[InitPayload] add my M1 (prepared) type messaage")
 					msgToSign := append(preparedMsg.BlockHash[:], preparedMsg.Payload...)
 					for _, key := range privKeys {
+						sig, err := key.SignHash(msgToSign)
+						if err != nil {
+							vc.getLogger().Warn().Err(err).Str("key", key.Pub.Bytes.Hex()).Msg("[InitPayload] bhp sign failed")
+							continue
+						}
 						if err := vc.bhpBitmap[viewID].SetKey(key.Pub.Bytes, true); err != nil {
 							vc.getLogger().Warn().Str("key", key.Pub.Bytes.Hex()).Msg("[InitPayload] bhpBitmap setkey failed")
 							continue
 						}
-						vc.bhpSigs[viewID][key.Pub.Bytes.Hex()] = key.Pri.SignHash(msgToSign)
+						vc.bhpSigs[viewID][key.Pub.Bytes.Hex()] = sig
 					}
 					hasBlock = true
 					// if m1Payload is empty, we just add net
@@ -393,6 +398,11 @@ func (vc *viewChange) InitPayload(
 		if !hasBlock {
 			vc.getLogger().Info().Uint64("viewID", viewID).Uint64("blockNum", blockNum).Msg("[InitPayload] add my M2 (NIL) type messaage")
 			for _, key := range privKeys {
+				sig, err := key.SignHash(NIL)
+				if err != nil {
+					vc.getLogger().Warn().Err(err).Str("key", key.Pub.Bytes.Hex()).Msg("[InitPayload] nil sign failed")
+					continue
+				}
 				if err := vc.nilBitmap[viewID].SetKey(key.Pub.Bytes, true); err != nil {
 					vc.getLogger().Warn().Str("key", key.Pub.Bytes.Hex()).Msg("[InitPayload] nilBitmap setkey failed")
 					continue
@@ -400,7 +410,7 @@ func (vc *viewChange) InitPayload(
 				if _, ok := vc.nilSigs[viewID]; !ok {
 					vc.nilSigs[viewID] = map[string]*bls_core.Sign{}
 				}
-				vc.nilSigs[viewID][key.Pub.Bytes.Hex()] = key.Pri.SignHash(NIL)
+				vc.nilSigs[viewID][key.Pub.Bytes.Hex()] = sig
 			}
 		}
 	}
@@ -420,6 +430,11 @@ func (vc *viewChange) InitPayload(
 		binary.LittleEndian.PutUint64(viewIDBytes, viewID)
 		vc.getLogger().Info().Uint64("viewID", viewID).Uint64("blockNum", blockNum).Msg("[InitPayload] add my M3 (ViewID) type messaage")
 		for _, key := range privKeys {
+			sig, err := key.SignHash(viewIDBytes)
+			if err != nil {
+				vc.getLogger().Warn().Err(err).Str("key", key.Pub.Bytes.Hex()).Msg("[InitPayload] viewID sign failed")
+				continue
+			}
 			if err := vc.viewIDBitmap[viewID].SetKey(key.Pub.Bytes, true); err != nil {
 				vc.getLogger().Warn().Str("key", key.Pub.Bytes.Hex()).Msg("[InitPayload] viewIDBitmap setkey failed")
 				continue
@@ -427,7 +442,7 @@ func (vc *viewChange) InitPayload(
 			if _, ok := vc.viewIDSigs[viewID]; !ok {
 				vc.viewIDSigs[viewID] = map[string]*bls_core.Sign{}
 			}
-			vc.viewIDSigs[viewID][key.Pub.Bytes.Hex()] = key.Pri.SignHash(viewIDBytes)
+			vc.viewIDSigs[viewID][key.Pub.Bytes.Hex()] = sig
 		}
 	}
 
@@ -75,23 +75,23 @@ func (consensus *Consensus) constructViewChangeMessage(priKey *bls.PrivateKeyWra
 		Str("SenderPubKey", priKey.Pub.Bytes.Hex()).
 		Msg("[constructViewChangeMessage]")
 
-	sign := priKey.Pri.SignHash(msgToSign)
-	if sign != nil {
+	sign, err := priKey.SignHash(msgToSign)
+	if err == nil {
 		vcMsg.ViewchangeSig = sign.Serialize()
 	} else {
-		consensus.getLogger().Error().Msg("unable to serialize m1/m2 view change message signature")
+		consensus.getLogger().Error().Err(err).Msg("unable to serialize m1/m2 view change message signature")
 	}
 
 	viewIDBytes := make([]byte, 8)
 	binary.LittleEndian.PutUint64(viewIDBytes, consensus.GetViewChangingID())
-	sign1 := priKey.Pri.SignHash(viewIDBytes)
-	if sign1 != nil {
+	sign1, err := priKey.SignHash(viewIDBytes)
+	if err == nil {
 		vcMsg.ViewidSig = sign1.Serialize()
 	} else {
-		consensus.getLogger().Error().Msg("unable to serialize viewID signature")
+		consensus.getLogger().Error().Err(err).Msg("unable to serialize viewID signature")
 	}
 
-	marshaledMessage, err := consensus.signAndMarshalConsensusMessage(message, priKey.Pri)
+	marshaledMessage, err := consensus.signAndMarshalConsensusMessage(message, priKey)
 	if err != nil {
 		consensus.getLogger().Err(err).
 			Msg("[constructViewChangeMessage] failed to sign and marshal the viewchange message")
@@ -122,7 +122,7 @@ func (consensus *Consensus) constructNewViewMessage(viewID uint64, priKey *bls.P
 		return nil
 	}
 
-	marshaledMessage, err := consensus.signAndMarshalConsensusMessage(message, priKey.Pri)
+	marshaledMessage, err := consensus.signAndMarshalConsensusMessage(message, priKey)
 	if err != nil {
 		consensus.getLogger().Err(err).
 			Msg("[constructNewViewMessage] failed to sign and marshal the new view message")
@@ -0,0 +1,143 @@
+package consensus
+
+import (
+	"bytes"
+	"sync"
+
+	bls_core "github.com/nordicenergy/bls/ffi/go/bls"
+	"github.com/nordicenergy/nordicenergy-core/consensus/quorum"
+	bls_cosi "github.com/nordicenergy/nordicenergy-core/crypto/bls"
+	"github.com/nordicenergy/nordicenergy-core/internal/utils"
+)
+
+// maxVoteBatchSize is the largest number of votes verified at once
+const maxVoteBatchSize = 512
+
+// pendingVote is a vote the leader received whose signature is not verified yet
+type pendingVote struct {
+	phase quorum.Phase
+	msg   *FBFTMessage
+	sig   *bls_core.Sign
+	// signer is the aggregated public key of the senders of the vote
+	signer *bls_core.PublicKey
+	// hash is what the vote signs
+	hash []byte
+}
+
+// voteVerifier verifies the signatures of the votes the leader receives in
+// batches. Votes queue up while a batch is being verified and make up the
+// next batch, so a batch is as large as the load requires. The votes of a
+// batch signing the same hash are verified together; only if that fails are
+// they verified one by one to find the bad signatures.
+type voteVerifier struct {
+	lock    sync.Mutex
+	queue   []*pendingVote
+	running bool
+	// onVerified is called with the votes of a batch having a valid
+	// signature, in the order they were received
+	onVerified func(votes []*pendingVote)
+}
+
+func newVoteVerifier(onVerified func(votes []*pendingVote)) *voteVerifier {
+	return &voteVerifier{onVerified: onVerified}
+}
+
+// add queues vote for verification
+func (v *voteVerifier) add(vote *pendingVote) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	v.queue = append(v.queue, vote)
+	if !v.running {
+		v.running = true
+		go v.drain()
+	}
+}
+
+// drain verifies the queued votes until there is none left
+func (v *voteVerifier) drain() {
+	for {
+		v.lock.Lock()
+		if len(v.queue) == 0 {
+			v.running = false
+			v.lock.Unlock()
+			return
+		}
+		size := len(v.queue)
+		if size > maxVoteBatchSize {
+			size = maxVoteBatchSize
+		}
+		batch := v.queue[:size]
+		v.queue = v.queue[size:]
+		v.lock.Unlock()
+
+		if verified := verifyVotes(batch); len(verified) > 0 {
+			v.onVerified(verified)
+		}
+	}
+}
+
+// verifyVotes returns the votes having a valid signature, in their order
+func verifyVotes(votes []*pendingVote) []*pendingVote {
+	valid := make([]bool, len(votes))
+	// group the votes by the hash they sign, keeping the order of the groups
+	groups := [][]int{}
+	for i, vote := range votes {
+		found := false
+		for j, group := range groups {
+			if bytes.Equal(votes[group[0]].hash, vote.hash) {
+				groups[j] = append(group, i)
+				found = true
+				break
+			}
+		}
+		if !found {
+			groups = append(groups, []int{i})
+		}
+	}
+
+	for _, group := range groups {
+		pubs := make([]*bls_core.PublicKey, len(group))
+		sigs := make([]*bls_core.Sign, len(group))
+		for i, index := range group {
+			pubs[i], sigs[i] = votes[index].signer, votes[index].sig
+		}
+		if bls_cosi.VerifyBatch(votes[group[0]].hash, pubs, sigs) {
+			for _, index := range group {
+				valid[index] = true
+			}
+			continue
+		}
+		for _, index := range group {
+			vote := votes[index]
+			if valid[index] = vote.sig.VerifyHash(vote.signer, vote.hash); !valid[index] {
+				utils.Logger().Error().
+					Str("phase", vote.phase.String()).
+					Uint64("MsgBlockNum", vote.msg.BlockNum).
+					Interface("validatorPubKeys", vote.msg.SenderPubkeys).
+					Msg("[verifyVotes] Received invalid BLS signature")
+			}
+		}
+	}
+
+	verified := make([]*pendingVote, 0, len(votes))
+	for i, vote := range votes {
+		if valid[i] {
+			verified = append(verified, vote)
+		}
+	}
+	return verified
+}
+
+// onVotesVerified adds the verified votes to the current round
+func (consensus *Consensus) onVotesVerified(votes []*pendingVote) {
+	consensus.mutex.Lock()
+	defer consensus.mutex.Unlock()
+	for _, vote := range votes {
+		switch vote.phase {
+		case quorum.Prepare:
+			consensus.addPrepareVote(vote)
+		case quorum.Commit:
+			consensus.addCommitVote(vote)
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package consensus
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	bls_core "github.com/nordicenergy/bls/ffi/go/bls"
+	"github.com/nordicenergy/nordicenergy-core/consensus/quorum"
+	"github.com/nordicenergy/nordicenergy-core/crypto/bls"
+	"github.com/stretchr/testify/assert"
+)
+
+func makeVotes(count int, hash []byte) []*pendingVote {
+	votes := make([]*pendingVote, count)
+	for i := range votes {
+		key := bls.RandPrivateKey()
+		wrapper := bls.PublicKeyWrapper{Object: key.GetPublicKey()}
+		wrapper.Bytes.FromLibBLSPublicKey(wrapper.Object)
+		votes[i] = &pendingVote{
+			phase:  quorum.Prepare,
+			msg:    &FBFTMessage{SenderPubkeys: []*bls.PublicKeyWrapper{&wrapper}},
+			sig:    key.SignHash(hash),
+			signer: wrapper.Object,
+			hash:   hash,
+		}
+	}
+	return votes
+}
+
+func TestVerifyVotes(t *testing.T) {
+	prepareHash := []byte("0123456789abcdef0123456789abcdef")
+	commitHash := []byte("fedcba9876543210fedcba9876543210")
+	prepares, commits := makeVotes(6, prepareHash), makeVotes(4, commitHash)
+	votes := []*pendingVote{}
+	for i := range prepares {
+		votes = append(votes, prepares[i])
+		if i < len(commits) {
+			votes = append(votes, commits[i])
+		}
+	}
+	assert.Equal(t, votes, verifyVotes(votes))
+
+	// a vote signed by another key is found and left out
+	bad := *prepares[2]
+	bad.sig = bls.RandPrivateKey().SignHash(prepareHash)
+	votes[4] = &bad
+	verified := verifyVotes(votes)
+	assert.Len(t, verified, len(votes)-1)
+	assert.NotContains(t, verified, &bad)
+	assert.Equal(t, votes[5], verified[4])
+}
+
+func TestVoteVerifier(t *testing.T) {
+	verified := make(chan []*pendingVote, 10)
+	verifier := newVoteVerifier(func(votes []*pendingVote) {
+		verified <- votes
+	})
+	votes := makeVotes(20, []byte("0123456789abcdef0123456789abcdef"))
+	for _, vote := range votes {
+		verifier.add(vote)
+	}
+
+	received := []*pendingVote{}
+	for len(received) < len(votes) {
+		select {
+		case batch := <-verified:
+			received = append(received, batch...)
+		case <-time.After(10 * time.Second):
+			t.Fatalf("%d votes verified out of %d", len(received), len(votes))
+		}
+	}
+	assert.Equal(t, votes, received)
+}
+
+func BenchmarkVerifyVotes(b *testing.B) {
+	hash := []byte("0123456789abcdef0123456789abcdef")
+	for _, keys := range []int{256, 512} {
+		votes := makeVotes(keys, hash)
+		b.Run(fmt.Sprintf("individual-%d", keys), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for _, vote := range votes {
+					if !vote.sig.VerifyHash(vote.signer, vote.hash) {
+						b.Fatal("invalid signature")
+					}
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("batch-%d", keys), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if len(verifyVotes(votes)) != keys {
+					b.Fatal("invalid signature")
+				}
+			}
+		})
+		// one bad signature makes the batch fall back to verifying each vote
+		bad := *votes[0]
+		bad.sig = &bls_core.Sign{}
+		withBad := append([]*pendingVote{&bad}, votes[1:]...)
+		b.Run(fmt.Sprintf("batch-fallback-%d", keys), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if len(verifyVotes(withBad)) != keys-1 {
+					b.Fatal("bad signature not found")
+				}
+			}
+		})
+	}
+}
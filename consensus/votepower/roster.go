@@ -1,6 +1,7 @@
 package votepower
 
 import (
+	"bytes"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -261,3 +262,43 @@ func NewRoster(shardID uint32) *Roster {
 		ShardID: shardID,
 	}
 }
+
+// WeightedPick picks a voter with a probability proportional to its overall
+// voting power, using seed as the source of randomness. Voters are walked in
+// the order of their keys, so every node picks the same voter for a seed.
+// When no voter has any voting power, all voters are equally likely.
+func (r *Roster) WeightedPick(seed common.Hash) (bls.SerializedPublicKey, bool) {
+	if len(r.Voters) == 0 {
+		return bls.SerializedPublicKey{}, false
+	}
+	keys := make([]bls.SerializedPublicKey, 0, len(r.Voters))
+	total := big.NewInt(0)
+	for key, voter := range r.Voters {
+		keys = append(keys, key)
+		if voter.OverallPercent.IsPositive() {
+			total.Add(total, voter.OverallPercent.Int)
+		}
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i][:], keys[j][:]) < 0
+	})
+
+	target := new(big.Int).SetBytes(seed[:])
+	if total.Sign() == 0 {
+		index := target.Mod(target, big.NewInt(int64(len(keys))))
+		return keys[index.Int64()], true
+	}
+	target.Mod(target, total)
+	for _, key := range keys {
+		power := r.Voters[key].OverallPercent
+		if !power.IsPositive() {
+			continue
+		}
+		if target.Cmp(power.Int) < 0 {
+			return key, true
+		}
+		target.Sub(target, power.Int)
+	}
+	// unreachable, the target is below the total voting power
+	return keys[len(keys)-1], true
+}
@@ -121,6 +121,62 @@ func TestCompute(t *testing.T) {
 	}
 }
 
+func TestWeightedPick(t *testing.T) {
+	roster, err := Compute(&shard.Committee{
+		shard.BeaconChainShardID, slotList,
+	}, big.NewInt(3))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	externalPicks := 0
+	for i := 0; i < 1000; i++ {
+		seed := common.BigToHash(big.NewInt(int64(i)))
+		key, ok := roster.WeightedPick(seed)
+		if !ok {
+			t.Fatal("no voter picked")
+		}
+		if again, _ := roster.WeightedPick(seed); again != key {
+			t.Errorf("seed %d picked %s then %s", i, key.Hex(), again.Hex())
+		}
+		voter, exists := roster.Voters[key]
+		if !exists {
+			t.Fatalf("picked %s is not a voter", key.Hex())
+		}
+		if !voter.IsnordicenergyNode {
+			externalPicks++
+		}
+	}
+	if externalPicks == 0 {
+		t.Error("external validators are never picked")
+	}
+}
+
+func TestWeightedPickByVotingPower(t *testing.T) {
+	roster := NewRoster(shard.BeaconChainShardID)
+	for i := range slotList[:3] {
+		roster.Voters[slotList[i].BLSPublicKey] = &AccommodatenordicenergyVote{
+			OverallPercent: numeric.ZeroDec(),
+		}
+	}
+	// all voters are equally likely without voting power
+	if _, ok := roster.WeightedPick(common.Hash{}); !ok {
+		t.Fatal("no voter picked")
+	}
+
+	roster.Voters[slotList[1].BLSPublicKey].OverallPercent = numeric.netDec()
+	for i := 0; i < 100; i++ {
+		key, _ := roster.WeightedPick(common.BigToHash(big.NewInt(int64(i))))
+		if key != slotList[1].BLSPublicKey {
+			t.Errorf("voter without voting power %s picked", key.Hex())
+		}
+	}
+
+	if _, ok := NewRoster(shard.BeaconChainShardID).WeightedPick(common.Hash{}); ok {
+		t.Error("voter picked from an empty roster")
+	}
+}
+
 func compareRosters(a, b *Roster, t *testing.T) bool {
 	voterMatch := true
 	for k, voter := range a.Voters {
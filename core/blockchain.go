@@ -51,6 +51,7 @@ import (
 	"github.com/nordicenergy/nordicenergy-core/shard"
 	"github.com/nordicenergy/nordicenergy-core/shard/committee"
 	"github.com/nordicenergy/nordicenergy-core/staking/apr"
+	"github.com/nordicenergy/nordicenergy-core/staking/availability"
 	"github.com/nordicenergy/nordicenergy-core/staking/effective"
 	"github.com/nordicenergy/nordicenergy-core/staking/slash"
 	staking "github.com/nordicenergy/nordicenergy-core/staking/types"
@@ -65,6 +66,8 @@ var (
 	ErrNoGenesis = errors.New("Genesis not found in chain")
 	// errExceedMaxPendingSlashes ..
 	errExceedMaxPendingSlashes = errors.New("exceeed max pending slashes")
+	// ErrSlashAlreadyPending is returned if a slash record is already pending
+	ErrSlashAlreadyPending = errors.New("slashing evidence already pending")
 	errNilEpoch                = errors.New("nil epoch for voting power computation")
 )
 
@@ -86,6 +89,7 @@ const (
 	validatorListByDelegatorCacheLimit = 1024
 	pendingCrossLinksCacheLimit        = 2
 	blockAccumulatorCacheLimit         = 256
+	delegatorEarningsCacheLimit        = 4096
 	maxPendingSlashes                  = 512
 	// BlockChainVersion ensures that an incompatible database forces a resync from scratch.
 	BlockChainVersion = 3
@@ -156,6 +160,9 @@ type BlockChain struct {
 	validatorListByDelegatorCache *lru.Cache    // Cache of validator list by delegator
 	pendingCrossLinksCache        *lru.Cache    // Cache of last pending crosslinks
 	blockAccumulatorCache         *lru.Cache    // Cache of block accumulators
+	delegatorEarningsCache        *lru.Cache    // Cache of delegator earnings being accumulated
+	// Delegator earnings in the batch of the block being written
+	pendingDelegatorEarnings map[common.Address]*delegatorEarnings
 	quit                          chan struct{} // blockchain quit channel
 	running                       int32         // running must be called atomically
 	// procInterrupt must be atomically called
@@ -202,6 +209,7 @@ func NewBlockChain(
 	validatorListByDelegatorCache, _ := lru.New(validatorListByDelegatorCacheLimit)
 	pendingCrossLinksCache, _ := lru.New(pendingCrossLinksCacheLimit)
 	blockAccumulatorCache, _ := lru.New(blockAccumulatorCacheLimit)
+	delegatorEarningsCache, _ := lru.New(delegatorEarningsCacheLimit)
 
 	bc := &BlockChain{
 		chainConfig:                   chainConfig,
@@ -226,6 +234,7 @@ func NewBlockChain(
 		validatorListByDelegatorCache: validatorListByDelegatorCache,
 		pendingCrossLinksCache:        pendingCrossLinksCache,
 		blockAccumulatorCache:         blockAccumulatorCache,
+		delegatorEarningsCache:        delegatorEarningsCache,
 		engine:                        engine,
 		vmConfig:                      vmConfig,
 		badBlocks:                     badBlocks,
@@ -1223,6 +1232,7 @@ func (bc *BlockChain) WriteBlockWithState(
 	}
 
 	batch := bc.db.NewBatch()
+	bc.pendingDelegatorEarnings = nil
 	// Write the raw block
 	if err := rawdb.WriteBlock(batch, block); err != nil {
 		return NonStatTy, err
@@ -1257,6 +1267,7 @@ func (bc *BlockChain) WriteBlockWithState(
 		}
 		return NonStatTy, err
 	}
+	bc.cacheDelegatorEarnings()
 
 	// Update current block
 	if err := bc.writeHeadBlock(block); err != nil {
@@ -2068,6 +2079,237 @@ func (bc *BlockChain) ReadPendingSlashingCandidates() slash.Records {
 	return append(bc.pendingSlashes[0:0], bc.pendingSlashes...)
 }
 
+// ReadSlashHistory retrieves the slashes applied in the blocks of the given epoch
+// Returns empty results instead of error if there is not data found.
+func (bc *BlockChain) ReadSlashHistory(epoch *big.Int) ([]slash.HistoryRecord, error) {
+	data, err := rawdb.ReadSlashHistory(bc.db, epoch)
+	if err != nil || len(data) == 0 {
+		return []slash.HistoryRecord{}, nil
+	}
+	history := []slash.HistoryRecord{}
+	if err := rlp.DecodeBytes(data, &history); err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// ReadSlashEpochsByAddress retrieves the epochs with applied slashes in which
+// the address was the offender or a delegator of the offender.
+// Returns empty results instead of error if there is not data found.
+func (bc *BlockChain) ReadSlashEpochsByAddress(addr common.Address) ([]uint64, error) {
+	data, err := rawdb.ReadSlashEpochsByAddress(bc.db, addr)
+	if err != nil || len(data) == 0 {
+		return []uint64{}, nil
+	}
+	epochs := []uint64{}
+	if err := rlp.DecodeBytes(data, &epochs); err != nil {
+		return nil, err
+	}
+	return epochs, nil
+}
+
+// writeSlashHistory appends the slashes applied in a block to the slashing
+// history of its epoch and indexes the epoch for every involved address
+func (bc *BlockChain) writeSlashHistory(
+	batch rawdb.DatabaseWriter, epoch *big.Int, applied []slash.HistoryRecord,
+) error {
+	if len(applied) == 0 {
+		return nil
+	}
+	history, err := bc.ReadSlashHistory(epoch)
+	if err != nil {
+		return err
+	}
+	history = append(history, applied...)
+	bytes, err := rlp.EncodeToBytes(history)
+	if err != nil {
+		return err
+	}
+	if err := rawdb.WriteSlashHistory(batch, epoch, bytes); err != nil {
+		return err
+	}
+
+	involved, seen := []common.Address{}, map[common.Address]struct{}{}
+	for i := range applied {
+		addrs := []common.Address{applied[i].Record.Evidence.Offender}
+		for _, loss := range applied[i].Losses {
+			addrs = append(addrs, loss.Delegator)
+		}
+		for _, addr := range addrs {
+			if _, ok := seen[addr]; !ok {
+				seen[addr] = struct{}{}
+				involved = append(involved, addr)
+			}
+		}
+	}
+	for _, addr := range involved {
+		epochs, err := bc.ReadSlashEpochsByAddress(addr)
+		if err != nil {
+			return err
+		}
+		if l := len(epochs); l > 0 && epochs[l-1] == epoch.Uint64() {
+			continue
+		}
+		bytes, err := rlp.EncodeToBytes(append(epochs, epoch.Uint64()))
+		if err != nil {
+			return err
+		}
+		if err := rawdb.WriteSlashEpochsByAddress(batch, addr, bytes); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ReadDowntimeRecords retrieves the downtime slashes applied in an epoch.
+// Returns empty results instead of error if there is not data found.
+func (bc *BlockChain) ReadDowntimeRecords(
+	epoch *big.Int,
+) ([]availability.DowntimeRecord, error) {
+	data, err := rawdb.ReadDowntimeRecords(bc.db, epoch)
+	if err != nil || len(data) == 0 {
+		return []availability.DowntimeRecord{}, nil
+	}
+	records := []availability.DowntimeRecord{}
+	if err := rlp.DecodeBytes(data, &records); err != nil {
+		return nil, err
+	}
+	return records, nil
+}
+
+// writeDowntimeRecords stores the downtime slashes applied in a block
+// along with the ones already applied in its epoch
+func (bc *BlockChain) writeDowntimeRecords(
+	batch rawdb.DatabaseWriter, epoch *big.Int, applied []availability.DowntimeRecord,
+) error {
+	if len(applied) == 0 {
+		return nil
+	}
+	records, err := bc.ReadDowntimeRecords(epoch)
+	if err != nil {
+		return err
+	}
+	bytes, err := rlp.EncodeToBytes(append(records, applied...))
+	if err != nil {
+		return err
+	}
+	return rawdb.WriteDowntimeRecords(batch, epoch, bytes)
+}
+
+// ReadDelegatorEarnings retrieves the rewards a delegator earned in the given epoch
+func (bc *BlockChain) ReadDelegatorEarnings(
+	delegator common.Address, epoch *big.Int,
+) (staking.DelegationEarnings, error) {
+	return rawdb.ReadDelegatorEarnings(bc.db, delegator, epoch)
+}
+
+// ReadDelegatorEarningEpochs retrieves the epochs in which a delegator earned rewards
+func (bc *BlockChain) ReadDelegatorEarningEpochs(delegator common.Address) ([]uint64, error) {
+	return rawdb.ReadDelegatorEarningEpochs(bc.db, delegator)
+}
+
+// ReadDelegatorLifetimeEarnings retrieves the rewards a delegator earned since staking
+func (bc *BlockChain) ReadDelegatorLifetimeEarnings(
+	delegator common.Address,
+) (staking.DelegationEarnings, error) {
+	return rawdb.ReadDelegatorLifetimeEarnings(bc.db, delegator)
+}
+
+// delegatorEarnings is the earnings of a delegator in an epoch and since
+// staking, cached while they are accumulated block after block
+type delegatorEarnings struct {
+	epoch    uint64
+	earnings staking.DelegationEarnings
+	lifetime staking.DelegationEarnings
+	epochs   []uint64
+}
+
+// readDelegatorEarningsForUpdate returns the earnings of delegator to add
+// the rewards of a block of epoch to, from the cache if they are in it
+func (bc *BlockChain) readDelegatorEarningsForUpdate(
+	delegator common.Address, epoch *big.Int,
+) (*delegatorEarnings, error) {
+	if cached, ok := bc.delegatorEarningsCache.Get(delegator); ok {
+		entry := cached.(*delegatorEarnings)
+		if entry.epoch == epoch.Uint64() {
+			return entry, nil
+		}
+	}
+	earnings, err := bc.ReadDelegatorEarnings(delegator, epoch)
+	if err != nil {
+		return nil, err
+	}
+	lifetime, err := bc.ReadDelegatorLifetimeEarnings(delegator)
+	if err != nil {
+		return nil, err
+	}
+	epochs, err := bc.ReadDelegatorEarningEpochs(delegator)
+	if err != nil {
+		return nil, err
+	}
+	return &delegatorEarnings{epoch.Uint64(), earnings, lifetime, epochs}, nil
+}
+
+// cacheDelegatorEarnings caches the delegator earnings written in the batch
+// of the block once the batch is written
+func (bc *BlockChain) cacheDelegatorEarnings() {
+	for delegator, entry := range bc.pendingDelegatorEarnings {
+		bc.delegatorEarningsCache.Add(delegator, entry)
+	}
+	bc.pendingDelegatorEarnings = nil
+}
+
+// writeDelegatorEarnings adds the rewards paid to delegations in a block
+// to the epoch and lifetime earnings of each delegator. The earnings are
+// cached once the batch is written, so that every block only writes them.
+func (bc *BlockChain) writeDelegatorEarnings(
+	batch rawdb.DatabaseWriter, epoch *big.Int, payouts []reward.DelegatorPayout,
+) error {
+	delegators, earned := []common.Address{}, map[common.Address]staking.DelegationEarnings{}
+	for _, payout := range payouts {
+		if _, ok := earned[payout.Delegator]; !ok {
+			delegators = append(delegators, payout.Delegator)
+		}
+		earned[payout.Delegator] = earned[payout.Delegator].Add(
+			payout.Validator, payout.NewlyEarned,
+		)
+	}
+
+	for _, delegator := range delegators {
+		entry, err := bc.readDelegatorEarningsForUpdate(delegator, epoch)
+		if err != nil {
+			return err
+		}
+		// the cached earnings are updated only once the batch is written
+		earnings := append(staking.DelegationEarnings{}, entry.earnings...)
+		lifetime := append(staking.DelegationEarnings{}, entry.lifetime...)
+		epochs := append([]uint64{}, entry.epochs...)
+		for _, earning := range earned[delegator] {
+			earnings = earnings.Add(earning.ValidatorAddress, earning.Amount)
+			lifetime = lifetime.Add(earning.ValidatorAddress, earning.Amount)
+		}
+		if err := rawdb.WriteDelegatorEarnings(batch, delegator, epoch, earnings); err != nil {
+			return err
+		}
+		if err := rawdb.WriteDelegatorLifetimeEarnings(batch, delegator, lifetime); err != nil {
+			return err
+		}
+		if l := len(epochs); l == 0 || epochs[l-1] != epoch.Uint64() {
+			epochs = append(epochs, epoch.Uint64())
+			if err := rawdb.WriteDelegatorEarningEpochs(batch, delegator, epochs); err != nil {
+				return err
+			}
+		}
+		if bc.pendingDelegatorEarnings == nil {
+			bc.pendingDelegatorEarnings = map[common.Address]*delegatorEarnings{}
+		}
+		bc.pendingDelegatorEarnings[delegator] = &delegatorEarnings{
+			epoch.Uint64(), earnings, lifetime, epochs,
+		}
+	}
+	return nil
+}
+
 // ReadPendingCrossLinks retrieves pending crosslinks
 func (bc *BlockChain) ReadPendingCrossLinks() ([]types.CrossLink, error) {
 	bytes := []byte{}
@@ -2156,6 +2398,46 @@ func (bc *BlockChain) AddPendingSlashingCandidates(
 	return bc.writeSlashes(bc.pendingSlashes)
 }
 
+// AddPendingSlashingCandidate verifies record and adds it to the pending
+// slashes, with ErrSlashAlreadyPending if it is pending already. The check
+// is made under the lock of the pending slashes, for concurrent submissions
+// of the same record to add it once.
+func (bc *BlockChain) AddPendingSlashingCandidate(record slash.Record) error {
+	bc.pendingSlashingCandidatesMU.Lock()
+	defer bc.pendingSlashingCandidatesMU.Unlock()
+
+	state, err := bc.State()
+	if err != nil {
+		return err
+	}
+	if err := slash.Verify(bc, state, &record); err != nil {
+		return err
+	}
+	pendingSlashes, err := appendPendingSlash(bc.pendingSlashes, record)
+	if err != nil {
+		return err
+	}
+	bc.pendingSlashes = pendingSlashes
+	return bc.writeSlashes(bc.pendingSlashes)
+}
+
+// appendPendingSlash returns the pending slashes with record added, unless
+// it is pending already or there are too many
+func appendPendingSlash(pending slash.Records, record slash.Record) (slash.Records, error) {
+	hash := record.Hash()
+	for i := range pending {
+		if pending[i].Hash() == hash {
+			return nil, ErrSlashAlreadyPending
+		}
+	}
+	if c := len(pending); c+1 > maxPendingSlashes {
+		return nil, errors.Wrapf(
+			errExceedMaxPendingSlashes, "current %d with-additional %d", c, c+1,
+		)
+	}
+	return append(pending, record), nil
+}
+
 // AddPendingCrossLinks appends pending crosslinks
 func (bc *BlockChain) AddPendingCrossLinks(pendingCLs []types.CrossLink) (int, error) {
 	bc.pendingCrossLinksMutex.Lock()
@@ -2387,6 +2669,8 @@ func (bc *BlockChain) UpdateValidatorVotingPower(
 
 				if slash.IsBanned(wrapper) {
 					stats.BootedStatus = effective.BannedForDoubleSigning
+				} else if wrapper.Status == effective.Jailed {
+					stats.BootedStatus = effective.JailedForDowntime
 				} else if wrapper.Status == effective.Inactive {
 					stats.BootedStatus = effective.TurnedInactiveOrInsufficientUptime
 				} else {
@@ -2778,6 +3062,7 @@ func (bc *BlockChain) prepareStakingMetaData(
 			newDelegations[delegate.DelegatorAddress] = delegations
 		case staking.DirectiveUndelegate:
 		case staking.DirectiveCollectRewards:
+		case staking.DirectiveUnjail:
 		default:
 		}
 	}
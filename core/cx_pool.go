@@ -1,14 +1,28 @@
 package core
 
 import (
-	mapset "github.com/deckarep/golang-set"
+	"sort"
+	"sync"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/nordicenergy/nordicenergy-core/core/rawdb"
+	"github.com/nordicenergy/nordicenergy-core/internal/utils"
 )
 
 const (
 	// CxPoolSize is the maximum size of the pool
-	CxPoolSize = 50
+	CxPoolSize = 4096
+	// CxPoolInitialBackoff is the delay before the first re-broadcast of
+	// receipts broadcast once already
+	CxPoolInitialBackoff = 30 * time.Second
+	// CxPoolMaxBackoff is the maximum delay between two re-broadcasts
+	CxPoolMaxBackoff = 30 * time.Minute
+	// CxPoolMaxAttempts is the number of re-broadcasts after which receipts
+	// whose delivery cannot be confirmed are dropped from the pool
+	CxPoolMaxAttempts = 12
 )
 
 // CxEntry represents the egress receipt's blockHash and ToShardID
@@ -17,42 +31,230 @@ type CxEntry struct {
 	ToShardID uint32
 }
 
-// CxPool is to hold a pool of block outgoing receipts to be resend in next round broadcast
-// When a user/client doesn't find the destination shard get the mnety from cross shard tx
-// it can send RPC call along with txID to allow the any validator to
-// add the corresponding block's receipts to be resent
+// CxRecord is the delivery state of the receipts of an entry
+type CxRecord struct {
+	// Requested is whether the resend was requested by a user, which puts the
+	// entry ahead of the ones re-broadcast automatically
+	Requested bool
+	// Attempts is the number of re-broadcasts made
+	Attempts uint64
+	// NextAttempt is the unix time of the next re-broadcast
+	NextAttempt uint64
+}
+
+// CxPool holds the block outgoing receipts to be re-broadcast until their
+// delivery to the destination shard is confirmed, or CxPoolMaxAttempts
+// re-broadcasts when it cannot be. Receipts are re-broadcast
+// with an exponential backoff, the ones a user requested the resend of via
+// RPC first. A durable pool keeps its entries in a database across restarts.
 type CxPool struct {
-	pool    mapset.Set
+	lock    sync.Mutex
+	entries map[CxEntry]*CxRecord
 	maxSize int
+	db      ethdb.KeyValueStore
 }
 
-// NewCxPool creates a new CxPool
+// NewCxPool creates a new in-memory CxPool
 func NewCxPool(limit int) *CxPool {
-	pool := mapset.NewSet()
-	cxPool := CxPool{pool: pool, maxSize: limit}
-	return &cxPool
+	return &CxPool{entries: map[CxEntry]*CxRecord{}, maxSize: limit}
 }
 
-// Pool returns the pool of blockHashes of missing receipts
-func (cxPool *CxPool) Pool() mapset.Set {
-	return cxPool.pool
+// NewDurableCxPool creates a CxPool kept in db, loading the entries stored
+func NewDurableCxPool(limit int, db ethdb.KeyValueStore) (*CxPool, error) {
+	cxPool := NewCxPool(limit)
+	cxPool.db = db
+	err := rawdb.IterateCxPoolEntries(db, func(toShardID uint32, hash common.Hash, bytes []byte) error {
+		record := &CxRecord{}
+		if err := rlp.DecodeBytes(bytes, record); err != nil {
+			return err
+		}
+		cxPool.entries[CxEntry{hash, toShardID}] = record
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cxPool, nil
 }
 
 // Size return size of the pool
 func (cxPool *CxPool) Size() int {
-	return cxPool.pool.Cardinality()
+	cxPool.lock.Lock()
+	defer cxPool.lock.Unlock()
+	return len(cxPool.entries)
 }
 
-// Add add element into the pool if not exceed limit
+// Contains returns whether entry is in the pool
+func (cxPool *CxPool) Contains(entry CxEntry) bool {
+	cxPool.lock.Lock()
+	defer cxPool.lock.Unlock()
+	_, ok := cxPool.entries[entry]
+	return ok
+}
+
+// Add adds an entry requested by a user to be resent right away. A full pool
+// makes room by evicting the tracked entry the closest to expiring, it only
+// rejects the entry when full of entries requested by users.
 func (cxPool *CxPool) Add(entry CxEntry) bool {
-	if cxPool.Size() > cxPool.maxSize {
+	cxPool.lock.Lock()
+	defer cxPool.lock.Unlock()
+	record, ok := cxPool.entries[entry]
+	if !ok {
+		if len(cxPool.entries) >= cxPool.maxSize && !cxPool.evictTracked() {
+			return false
+		}
+		record = &CxRecord{}
+	}
+	record.Requested = true
+	record.NextAttempt = 0
+	cxPool.put(entry, record)
+	return true
+}
+
+// Track adds an entry whose receipts were just broadcast, to be re-broadcast
+// after the initial backoff until delivered, if the pool is not full
+func (cxPool *CxPool) Track(entry CxEntry, now time.Time) bool {
+	cxPool.lock.Lock()
+	defer cxPool.lock.Unlock()
+	if _, ok := cxPool.entries[entry]; ok {
+		return true
+	}
+	if len(cxPool.entries) >= cxPool.maxSize {
+		return false
+	}
+	cxPool.put(entry, &CxRecord{
+		NextAttempt: uint64(now.Add(CxPoolInitialBackoff).Unix()),
+	})
+	return true
+}
+
+// Due returns up to limit entries due for re-broadcast at now, the requested
+// ones first, then the longest overdue
+func (cxPool *CxPool) Due(now time.Time, limit int) []CxEntry {
+	cxPool.lock.Lock()
+	defer cxPool.lock.Unlock()
+	due := []CxEntry{}
+	for entry, record := range cxPool.entries {
+		if record.NextAttempt <= uint64(now.Unix()) {
+			due = append(due, entry)
+		}
+	}
+	sort.SliceStable(due, func(i, j int) bool {
+		ri, rj := cxPool.entries[due[i]], cxPool.entries[due[j]]
+		if ri.Requested != rj.Requested {
+			return ri.Requested
+		}
+		return ri.NextAttempt < rj.NextAttempt
+	})
+	if len(due) > limit {
+		due = due[:limit]
+	}
+	return due
+}
+
+// Retry records a re-broadcast of entry at now and schedules the next one
+// after the backoff. It drops the entry and returns false once the entry
+// reached the maximum number of attempts.
+func (cxPool *CxPool) Retry(entry CxEntry, now time.Time) bool {
+	cxPool.lock.Lock()
+	defer cxPool.lock.Unlock()
+	record, ok := cxPool.entries[entry]
+	if !ok {
 		return false
 	}
-	cxPool.pool.Add(entry)
+	record.Attempts++
+	if record.Attempts >= CxPoolMaxAttempts {
+		cxPool.remove(entry)
+		return false
+	}
+	record.Requested = false
+	record.NextAttempt = uint64(now.Add(cxBackoff(record.Attempts)).Unix())
+	cxPool.put(entry, record)
 	return true
 }
 
+// cxBackoff returns the delay after the attempts-th re-broadcast
+func cxBackoff(attempts uint64) time.Duration {
+	delay := CxPoolInitialBackoff
+	for i := uint64(0); i < attempts && delay < CxPoolMaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > CxPoolMaxBackoff {
+		delay = CxPoolMaxBackoff
+	}
+	return delay
+}
+
+// Remove removes entry, for receipts delivered or of an unknown block
+func (cxPool *CxPool) Remove(entry CxEntry) {
+	cxPool.lock.Lock()
+	defer cxPool.lock.Unlock()
+	cxPool.remove(entry)
+}
+
+// Outstanding returns the number of entries per destination shard
+func (cxPool *CxPool) Outstanding() map[uint32]int {
+	cxPool.lock.Lock()
+	defer cxPool.lock.Unlock()
+	res := map[uint32]int{}
+	for entry := range cxPool.entries {
+		res[entry.ToShardID]++
+	}
+	return res
+}
+
 // Clear empty the pool
 func (cxPool *CxPool) Clear() {
-	cxPool.pool.Clear()
+	cxPool.lock.Lock()
+	defer cxPool.lock.Unlock()
+	for entry := range cxPool.entries {
+		cxPool.remove(entry)
+	}
+}
+
+// evictTracked removes the entry not requested by a user with the most
+// attempts made, returning false if there is none
+func (cxPool *CxPool) evictTracked() bool {
+	var (
+		victim CxEntry
+		found  bool
+	)
+	for entry, record := range cxPool.entries {
+		if record.Requested {
+			continue
+		}
+		if !found || record.Attempts > cxPool.entries[victim].Attempts {
+			victim, found = entry, true
+		}
+	}
+	if found {
+		cxPool.remove(victim)
+	}
+	return found
+}
+
+func (cxPool *CxPool) put(entry CxEntry, record *CxRecord) {
+	cxPool.entries[entry] = record
+	if cxPool.db == nil {
+		return
+	}
+	bytes, err := rlp.EncodeToBytes(record)
+	if err == nil {
+		err = rawdb.WriteCxPoolEntry(cxPool.db, entry.ToShardID, entry.BlockHash, bytes)
+	}
+	if err != nil {
+		utils.Logger().Error().Err(err).Str("blockHash", entry.BlockHash.Hex()).
+			Uint32("toShardID", entry.ToShardID).Msg("[CxPool] cannot store entry")
+	}
+}
+
+func (cxPool *CxPool) remove(entry CxEntry) {
+	delete(cxPool.entries, entry)
+	if cxPool.db == nil {
+		return
+	}
+	if err := rawdb.DeleteCxPoolEntry(cxPool.db, entry.ToShardID, entry.BlockHash); err != nil {
+		utils.Logger().Error().Err(err).Str("blockHash", entry.BlockHash.Hex()).
+			Uint32("toShardID", entry.ToShardID).Msg("[CxPool] cannot delete entry")
+	}
 }
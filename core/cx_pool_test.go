@@ -0,0 +1,103 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+)
+
+func TestCxPoolPriorityAndBackoff(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	pool := NewCxPool(3)
+	tracked := CxEntry{common.Hash{1}, 1}
+	requested := CxEntry{common.Hash{2}, 2}
+	if !pool.Track(tracked, now) || !pool.Add(requested) {
+		t.Fatal("cannot add entries")
+	}
+	if due := pool.Due(now, 10); len(due) != 1 || due[0] != requested {
+		t.Errorf("due %v, expected only the requested entry", due)
+	}
+	later := now.Add(CxPoolInitialBackoff)
+	due := pool.Due(later, 10)
+	if len(due) != 2 || due[0] != requested || due[1] != tracked {
+		t.Errorf("due %v, expected the requested then the tracked entry", due)
+	}
+	if due := pool.Due(later, 1); len(due) != 1 {
+		t.Errorf("%d entries due, expected the limit 1", len(due))
+	}
+
+	if !pool.Retry(requested, later) {
+		t.Fatal("entry dropped after one attempt")
+	}
+	if due := pool.Due(later.Add(cxBackoff(1)-time.Second), 10); len(due) != 1 || due[0] != tracked {
+		t.Errorf("due %v, expected the retried entry to wait its backoff", due)
+	}
+	for i := 1; i < CxPoolMaxAttempts-1; i++ {
+		if !pool.Retry(requested, later) {
+			t.Fatalf("entry dropped after %d attempts", i+1)
+		}
+	}
+	if pool.Retry(requested, later) || pool.Contains(requested) {
+		t.Errorf("entry kept after %d attempts", CxPoolMaxAttempts)
+	}
+
+	pool.Add(CxEntry{common.Hash{3}, 1})
+	pool.Add(CxEntry{common.Hash{4}, 1})
+	if !pool.Add(CxEntry{common.Hash{5}, 1}) || pool.Contains(tracked) {
+		t.Errorf("requested entry not added in place of the tracked one")
+	}
+	if pool.Track(CxEntry{common.Hash{6}, 1}, later) {
+		t.Errorf("tracked entry added to a full pool")
+	}
+	if pool.Add(CxEntry{common.Hash{7}, 1}) {
+		t.Errorf("entry added to a pool full of requested entries")
+	}
+	if outstanding := pool.Outstanding(); outstanding[1] != 3 || outstanding[2] != 0 {
+		t.Errorf("outstanding %v, expected 3 to shard 1", outstanding)
+	}
+}
+
+func TestCxBackoff(t *testing.T) {
+	if d := cxBackoff(1); d != 2*CxPoolInitialBackoff {
+		t.Errorf("backoff after 1 attempt %v, expected %v", d, 2*CxPoolInitialBackoff)
+	}
+	if d := cxBackoff(CxPoolMaxAttempts); d != CxPoolMaxBackoff {
+		t.Errorf("backoff after %d attempts %v, expected %v", CxPoolMaxAttempts, d, CxPoolMaxBackoff)
+	}
+}
+
+func TestDurableCxPool(t *testing.T) {
+	db := memorydb.New()
+	now := time.Unix(1600000000, 0)
+	pool, err := NewDurableCxPool(CxPoolSize, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entries := []CxEntry{{common.Hash{1}, 1}, {common.Hash{2}, 2}, {common.Hash{3}, 3}}
+	pool.Add(entries[0])
+	pool.Track(entries[1], now)
+	pool.Track(entries[2], now)
+	pool.Retry(entries[1], now)
+	pool.Remove(entries[2])
+
+	reloaded, err := NewDurableCxPool(CxPoolSize, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if reloaded.Size() != 2 || !reloaded.Contains(entries[0]) || !reloaded.Contains(entries[1]) {
+		t.Fatalf("reloaded %d entries, expected the 2 entries left", reloaded.Size())
+	}
+	if r := reloaded.entries[entries[1]]; r.Attempts != 1 || r.NextAttempt != uint64(now.Add(cxBackoff(1)).Unix()) {
+		t.Errorf("reloaded record %+v, expected 1 attempt with its backoff", r)
+	}
+	if !reloaded.entries[entries[0]].Requested {
+		t.Errorf("requested entry reloaded as not requested")
+	}
+
+	reloaded.Clear()
+	if reloaded, _ := NewDurableCxPool(CxPoolSize, db); reloaded.Size() != 0 {
+		t.Errorf("%d entries reloaded after clear", reloaded.Size())
+	}
+}
@@ -258,6 +258,18 @@ func (bc *BlockChain) CommitOffChainData(
 
 			bc.writeValidatorStats(tempValidatorStats, batch)
 
+			if err := bc.writeDelegatorEarnings(
+				batch, epoch, roundResult.DelegatorAward,
+			); err != nil {
+				utils.Logger().Debug().Err(err).Msg("could not write delegator earnings")
+			}
+
+			if err := bc.writeDowntimeRecords(
+				batch, epoch, roundResult.Downtime,
+			); err != nil {
+				utils.Logger().Debug().Err(err).Msg("could not write downtime records")
+			}
+
 			records := slash.Records{}
 			if s := header.Slashes(); len(s) > 0 {
 				if err := rlp.DecodeBytes(s, &records); err != nil {
@@ -266,6 +278,11 @@ func (bc *BlockChain) CommitOffChainData(
 				if err := bc.DeleteFromPendingSlashingCandidates(records); err != nil {
 					utils.Logger().Debug().Err(err).Msg("could not deleting pending slashes")
 				}
+				if err := bc.writeSlashHistory(
+					batch, block.Epoch(), roundResult.Slashes,
+				); err != nil {
+					utils.Logger().Debug().Err(err).Msg("could not write slash history")
+				}
 			}
 		} else {
 			if isNewEpoch && isPreStaking {
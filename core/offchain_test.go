@@ -0,0 +1,165 @@
+package core
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	lru "github.com/hashicorp/golang-lru"
+	"github.com/nordicenergy/nordicenergy-core/consensus/reward"
+	"github.com/nordicenergy/nordicenergy-core/numeric"
+	"github.com/nordicenergy/nordicenergy-core/staking/availability"
+	staking "github.com/nordicenergy/nordicenergy-core/staking/types"
+	staketest "github.com/nordicenergy/nordicenergy-core/staking/types/test"
+)
+
+func TestWriteDowntimeRecords(t *testing.T) {
+	bc := &BlockChain{db: rawdb.NewMemoryDatabase()}
+	epoch := big.NewInt(10)
+	record := func(validator byte, slashed int64) availability.DowntimeRecord {
+		return availability.DowntimeRecord{
+			Validator:   common.Address{validator},
+			Epoch:       epoch,
+			JailedUntil: big.NewInt(12),
+			Computed: &staking.Computed{
+				Signed:     big.NewInt(1),
+				ToSign:     big.NewInt(10),
+				Percentage: numeric.NewDecWithPrec(1, 1),
+			},
+			TotalSlashed: big.NewInt(slashed),
+		}
+	}
+
+	for _, applied := range [][]availability.DowntimeRecord{
+		{record(1, 100)}, {}, {record(2, 200)},
+	} {
+		batch := bc.db.NewBatch()
+		if err := bc.writeDowntimeRecords(batch, epoch, applied); err != nil {
+			t.Fatal(err)
+		}
+		if err := batch.Write(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	records, err := bc.ReadDowntimeRecords(epoch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("unexpected records size %v / 2", len(records))
+	}
+	for i, exp := range []availability.DowntimeRecord{record(1, 100), record(2, 200)} {
+		if records[i].Validator != exp.Validator {
+			t.Errorf("record %v: unexpected validator %x", i, records[i].Validator)
+		}
+		if records[i].TotalSlashed.Cmp(exp.TotalSlashed) != 0 {
+			t.Errorf("record %v: unexpected slashed %v / %v", i,
+				records[i].TotalSlashed, exp.TotalSlashed)
+		}
+	}
+	if records, _ := bc.ReadDowntimeRecords(big.NewInt(11)); len(records) != 0 {
+		t.Errorf("unexpected records %v in another epoch", records)
+	}
+}
+
+func TestWriteDelegatorEarnings(t *testing.T) {
+	sdb, err := newTestStateDB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := makeVWrapperByIndex(validatorIndex)
+	w.Delegations[0].Reward = big.NewInt(0)
+	w.Delegations = append(w.Delegations, staking.NewDelegation(delegatorAddr, new(big.Int).Set(thirtyKnets)))
+	if err := updateStateValidators(sdb, []*staking.ValidatorWrapper{&w}); err != nil {
+		t.Fatal(err)
+	}
+	snapshot := staketest.CopyValidatorWrapper(w)
+	total := numeric.ZeroDec()
+	for _, d := range snapshot.Delegations {
+		total = total.Add(numeric.NewDecFromBigInt(d.Amount))
+	}
+	shares := map[common.Address]numeric.Dec{}
+	for _, d := range snapshot.Delegations {
+		shares[d.DelegatorAddress] = numeric.NewDecFromBigInt(d.Amount).Quo(total)
+	}
+
+	bc := &BlockChain{db: rawdb.NewMemoryDatabase()}
+	bc.delegatorEarningsCache, _ = lru.New(delegatorEarningsCacheLimit)
+	rewards := func() map[common.Address]*big.Int {
+		current, err := sdb.ValidatorWrapper(w.Address)
+		if err != nil {
+			t.Fatal(err)
+		}
+		m := map[common.Address]*big.Int{}
+		for _, d := range current.Delegations {
+			m[d.DelegatorAddress] = new(big.Int).Set(d.Reward)
+		}
+		return m
+	}
+
+	// two blocks in epoch 1 and one in epoch 2, each written in its own
+	// batch after a first batch of the block failed to be written
+	initial, epochStart := rewards(), map[uint64]map[common.Address]*big.Int{}
+	for _, epoch := range []int64{1, 1, 2} {
+		if _, ok := epochStart[uint64(epoch)]; !ok {
+			epochStart[uint64(epoch)] = rewards()
+		}
+		paid, err := sdb.AddReward(&snapshot, tenKnets, shares)
+		if err != nil {
+			t.Fatal(err)
+		}
+		payouts := reward.AppendDelegatorPayouts(nil, &snapshot, paid)
+		if err := bc.writeDelegatorEarnings(
+			bc.db.NewBatch(), big.NewInt(epoch), payouts,
+		); err != nil {
+			t.Fatal(err)
+		}
+		bc.pendingDelegatorEarnings = nil
+		batch := bc.db.NewBatch()
+		if err := bc.writeDelegatorEarnings(batch, big.NewInt(epoch), payouts); err != nil {
+			t.Fatal(err)
+		}
+		if err := batch.Write(); err != nil {
+			t.Fatal(err)
+		}
+		bc.cacheDelegatorEarnings()
+	}
+
+	// read back without the cache what AddReward credited in state
+	bc.delegatorEarningsCache.Purge()
+	final := rewards()
+	for _, d := range snapshot.Delegations {
+		addr := d.DelegatorAddress
+		for epoch, start := range epochStart {
+			end := final[addr]
+			if next, ok := epochStart[epoch+1]; ok {
+				end = next[addr]
+			}
+			exp := new(big.Int).Sub(end, start[addr])
+			earnings, err := bc.ReadDelegatorEarnings(addr, new(big.Int).SetUint64(epoch))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if earnings.Total().Cmp(exp) != 0 {
+				t.Errorf("delegator %x epoch %v: earned %v, paid %v", addr, epoch, earnings.Total(), exp)
+			}
+		}
+		exp := new(big.Int).Sub(final[addr], initial[addr])
+		lifetime, err := bc.ReadDelegatorLifetimeEarnings(addr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if lifetime.Total().Cmp(exp) != 0 || exp.Sign() <= 0 {
+			t.Errorf("delegator %x: lifetime earned %v, paid %v", addr, lifetime.Total(), exp)
+		}
+		epochs, err := bc.ReadDelegatorEarningEpochs(addr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(epochs) != 2 || epochs[0] != 1 || epochs[1] != 2 {
+			t.Errorf("delegator %x: unexpected epochs %v", addr, epochs)
+		}
+	}
+}
@@ -1,9 +1,11 @@
 package rawdb
 
 import (
+	"encoding/binary"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/nordicenergy/nordicenergy-core/core/types"
 	"github.com/nordicenergy/nordicenergy-core/internal/utils"
@@ -84,6 +86,68 @@ func WritePendingSlashingCandidates(db DatabaseWriter, bytes []byte) error {
 	return db.Put(pendingSlashingKey, bytes)
 }
 
+// ReadSlashHistory retrieves the slashes applied in the blocks of an epoch
+func ReadSlashHistory(db DatabaseReader, epoch *big.Int) ([]byte, error) {
+	return db.Get(slashHistoryKey(epoch))
+}
+
+// WriteSlashHistory stores the slashes applied in the blocks of an epoch
+func WriteSlashHistory(db DatabaseWriter, epoch *big.Int, bytes []byte) error {
+	return db.Put(slashHistoryKey(epoch), bytes)
+}
+
+// ReadSlashEpochsByAddress retrieves the epochs with slashes involving an address
+func ReadSlashEpochsByAddress(db DatabaseReader, addr common.Address) ([]byte, error) {
+	return db.Get(slashEpochsKey(addr))
+}
+
+// WriteSlashEpochsByAddress stores the epochs with slashes involving an address
+func WriteSlashEpochsByAddress(db DatabaseWriter, addr common.Address, bytes []byte) error {
+	return db.Put(slashEpochsKey(addr), bytes)
+}
+
+// ReadDowntimeRecords retrieves the downtime slashes applied in an epoch
+func ReadDowntimeRecords(db DatabaseReader, epoch *big.Int) ([]byte, error) {
+	return db.Get(downtimeRecordsKey(epoch))
+}
+
+// WriteDowntimeRecords stores the downtime slashes applied in an epoch
+func WriteDowntimeRecords(db DatabaseWriter, epoch *big.Int, bytes []byte) error {
+	return db.Put(downtimeRecordsKey(epoch), bytes)
+}
+
+// WriteCxPoolEntry stores the delivery state of the outgoing receipts of a
+// block to a destination shard
+func WriteCxPoolEntry(db DatabaseWriter, toShardID uint32, hash common.Hash, bytes []byte) error {
+	return db.Put(cxPoolKey(toShardID, hash), bytes)
+}
+
+// DeleteCxPoolEntry removes the delivery state of the outgoing receipts of a
+// block to a destination shard
+func DeleteCxPoolEntry(db DatabaseDeleter, toShardID uint32, hash common.Hash) error {
+	return db.Delete(cxPoolKey(toShardID, hash))
+}
+
+// IterateCxPoolEntries calls fn with every stored delivery state of outgoing
+// receipts, along with their destination shard and block hash
+func IterateCxPoolEntries(
+	db ethdb.Iteratee, fn func(toShardID uint32, hash common.Hash, bytes []byte) error,
+) error {
+	it := db.NewIteratorWithPrefix(cxPoolPrefix)
+	defer it.Release()
+	for it.Next() {
+		key := it.Key()[len(cxPoolPrefix):]
+		if len(key) != 4+common.HashLength {
+			continue
+		}
+		toShardID := binary.BigEndian.Uint32(key[:4])
+		if err := fn(toShardID, common.BytesToHash(key[4:]), it.Value()); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
+
 // ReadCXReceipts retrieves all the transactions of receipts given destination shardID, number and blockHash
 func ReadCXReceipts(db DatabaseReader, shardID uint32, number uint64, hash common.Hash) (types.CXReceipts, error) {
 	data, err := db.Get(cxReceiptKey(shardID, number, hash))
@@ -278,6 +342,83 @@ func WriteDelegationsByDelegator(db DatabaseWriter, delegator common.Address, in
 	return err
 }
 
+// ReadDelegatorEarnings retrieves the rewards earned by a delegator in an epoch
+// Returns empty results instead of error if there is not data found.
+func ReadDelegatorEarnings(
+	db DatabaseReader, delegator common.Address, epoch *big.Int,
+) (staking.DelegationEarnings, error) {
+	return readDelegationEarnings(db, delegatorEarningsKey(delegator, epoch))
+}
+
+// WriteDelegatorEarnings stores the rewards earned by a delegator in an epoch
+func WriteDelegatorEarnings(
+	db DatabaseWriter, delegator common.Address, epoch *big.Int, earnings staking.DelegationEarnings,
+) error {
+	return writeDelegationEarnings(db, delegatorEarningsKey(delegator, epoch), earnings)
+}
+
+// ReadDelegatorLifetimeEarnings retrieves the rewards earned by a delegator since staking
+// Returns empty results instead of error if there is not data found.
+func ReadDelegatorLifetimeEarnings(
+	db DatabaseReader, delegator common.Address,
+) (staking.DelegationEarnings, error) {
+	return readDelegationEarnings(db, delegatorLifetimeEarningsKey(delegator))
+}
+
+// WriteDelegatorLifetimeEarnings stores the rewards earned by a delegator since staking
+func WriteDelegatorLifetimeEarnings(
+	db DatabaseWriter, delegator common.Address, earnings staking.DelegationEarnings,
+) error {
+	return writeDelegationEarnings(db, delegatorLifetimeEarningsKey(delegator), earnings)
+}
+
+// ReadDelegatorEarningEpochs retrieves the epochs in which a delegator earned rewards
+// Returns empty results instead of error if there is not data found.
+func ReadDelegatorEarningEpochs(db DatabaseReader, delegator common.Address) ([]uint64, error) {
+	data, err := db.Get(delegatorEarningEpochsKey(delegator))
+	if err != nil || len(data) == 0 {
+		return []uint64{}, nil
+	}
+	epochs := []uint64{}
+	if err := rlp.DecodeBytes(data, &epochs); err != nil {
+		utils.Logger().Error().Err(err).Msg("Unable to Decode delegator earning epochs from database")
+		return nil, err
+	}
+	return epochs, nil
+}
+
+// WriteDelegatorEarningEpochs stores the epochs in which a delegator earned rewards
+func WriteDelegatorEarningEpochs(db DatabaseWriter, delegator common.Address, epochs []uint64) error {
+	bytes, err := rlp.EncodeToBytes(epochs)
+	if err != nil {
+		utils.Logger().Error().Msg("[WriteDelegatorEarningEpochs] Failed to encode")
+		return err
+	}
+	return db.Put(delegatorEarningEpochsKey(delegator), bytes)
+}
+
+func readDelegationEarnings(db DatabaseReader, key []byte) (staking.DelegationEarnings, error) {
+	data, err := db.Get(key)
+	if err != nil || len(data) == 0 {
+		return staking.DelegationEarnings{}, nil
+	}
+	earnings := staking.DelegationEarnings{}
+	if err := rlp.DecodeBytes(data, &earnings); err != nil {
+		utils.Logger().Error().Err(err).Msg("Unable to Decode delegation earnings from database")
+		return nil, err
+	}
+	return earnings, nil
+}
+
+func writeDelegationEarnings(db DatabaseWriter, key []byte, earnings staking.DelegationEarnings) error {
+	bytes, err := rlp.EncodeToBytes(earnings)
+	if err != nil {
+		utils.Logger().Error().Msg("[writeDelegationEarnings] Failed to encode")
+		return err
+	}
+	return db.Put(key, bytes)
+}
+
 // ReadBlockRewardAccumulator ..
 func ReadBlockRewardAccumulator(db DatabaseReader, number uint64) (*big.Int, error) {
 	data, err := db.Get(blockRewardAccumKey(number))
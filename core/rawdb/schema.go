@@ -60,6 +60,17 @@ var (
 	validatorSnapshotPrefix = []byte("validator-snapshot") // prefix for staking validator's snapshot information
 	validatorStatsPrefix    = []byte("validator-stats")    // prefix for staking validator's stats information
 	validatorListKey        = []byte("validator-list")     // key for all validators list
+	slashHistoryPrefix      = []byte("slash-history")      // prefix for slashes applied in an epoch
+	slashEpochsPrefix       = []byte("slash-epochs")       // prefix for epochs with slashes involving an address
+	downtimeRecordsPrefix   = []byte("downtime-records")   // prefix for downtime slashes applied in an epoch
+	cxPoolPrefix            = []byte("cx-pool")            // prefix for outgoing receipts waiting for delivery
+	// delegatorEarningsPrefix + delegator + epoch (big.Int.Bytes())
+	// -> rewards earned by a delegator in an epoch, per validator
+	delegatorEarningsPrefix = []byte("delegator-earnings")
+	// delegatorEarningEpochsPrefix + delegator -> epochs in which a delegator earned rewards
+	delegatorEarningEpochsPrefix = []byte("delegator-earning-epochs")
+	// delegatorLifetimeEarningsPrefix + delegator -> rewards earned by a delegator, per validator
+	delegatorLifetimeEarningsPrefix = []byte("delegator-lifetime-earnings")
 	// epochBlockNumberPrefix + epoch (big.Int.Bytes())
 	// -> epoch block number (big.Int.Bytes())
 	epochBlockNumberPrefix = []byte("nordicenergy-epoch-block-number")
@@ -215,6 +226,39 @@ func validatorStatsKey(addr common.Address) []byte {
 	return append(prefix, addr.Bytes()...)
 }
 
+// cxPoolKey = cxPoolPrefix + toShardID + hash
+func cxPoolKey(toShardID uint32, hash common.Hash) []byte {
+	sKey := make([]byte, 4)
+	binary.BigEndian.PutUint32(sKey, toShardID)
+	tmp := append(cxPoolPrefix, sKey...)
+	return append(tmp, hash.Bytes()...)
+}
+
+func slashHistoryKey(epoch *big.Int) []byte {
+	return append(slashHistoryPrefix, epoch.Bytes()...)
+}
+
+func slashEpochsKey(addr common.Address) []byte {
+	return append(slashEpochsPrefix, addr.Bytes()...)
+}
+
+func downtimeRecordsKey(epoch *big.Int) []byte {
+	return append(downtimeRecordsPrefix, epoch.Bytes()...)
+}
+
+func delegatorEarningsKey(delegator common.Address, epoch *big.Int) []byte {
+	tmp := append(delegatorEarningsPrefix, delegator.Bytes()...)
+	return append(tmp, epoch.Bytes()...)
+}
+
+func delegatorEarningEpochsKey(delegator common.Address) []byte {
+	return append(delegatorEarningEpochsPrefix, delegator.Bytes()...)
+}
+
+func delegatorLifetimeEarningsKey(delegator common.Address) []byte {
+	return append(delegatorLifetimeEarningsPrefix, delegator.Bytes()...)
+}
+
 func blockRewardAccumKey(number uint64) []byte {
 	return append(currentRewardGivenOutPrefix, encodeBlockNumber(number)...)
 }
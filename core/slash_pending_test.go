@@ -0,0 +1,33 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/nordicenergy/nordicenergy-core/staking/slash"
+	"github.com/pkg/errors"
+)
+
+func TestAppendPendingSlash(t *testing.T) {
+	record := func(reporter byte) slash.Record {
+		return slash.Record{Reporter: common.Address{reporter}}
+	}
+	pending, err := appendPendingSlash(slash.Records{}, record(1))
+	if err != nil || len(pending) != 1 {
+		t.Fatalf("pending %v with error %v, expected the record added", pending, err)
+	}
+	if _, err := appendPendingSlash(pending, record(1)); err != ErrSlashAlreadyPending {
+		t.Errorf("error %v adding a pending record, expected %v", err, ErrSlashAlreadyPending)
+	}
+	if pending, err = appendPendingSlash(pending, record(2)); err != nil || len(pending) != 2 {
+		t.Errorf("pending %v with error %v, expected 2 records", pending, err)
+	}
+
+	full := make(slash.Records, maxPendingSlashes)
+	for i := range full {
+		full[i].Evidence.Moment.Height = uint64(i)
+	}
+	if _, err := appendPendingSlash(full, record(1)); errors.Cause(err) != errExceedMaxPendingSlashes {
+		t.Errorf("error %v adding to full pending slashes, expected %v", err, errExceedMaxPendingSlashes)
+	}
+}
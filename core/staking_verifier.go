@@ -123,11 +123,13 @@ func VerifyAndCreateValidatorFromMsg(
 
 // VerifyAndEditValidatorFromMsg verifies the edit validator message using
 // the stateDB, chainContext and returns the edited validatorWrapper.
+// When commissionSchedule is set, a commission rate change is scheduled to
+// take effect staking.CommissionChangeDelay epochs later instead of immediately.
 //
 // Note that this function never updates the stateDB, it only reads from stateDB.
 func VerifyAndEditValidatorFromMsg(
 	stateDB vm.StateDB, chainContext ChainContext,
-	epoch, blockNum *big.Int, msg *staking.EditValidator,
+	epoch, blockNum *big.Int, msg *staking.EditValidator, commissionSchedule bool,
 ) (*staking.ValidatorWrapper, error) {
 	if stateDB == nil {
 		return nil, errStateDBIsMissing
@@ -156,6 +158,7 @@ func VerifyAndEditValidatorFromMsg(
 	if err != nil {
 		return nil, err
 	}
+	currentRate := wrapper.Validator.Rate
 	if err := staking.UpdateValidatorFromEditMsg(&wrapper.Validator, msg, epoch); err != nil {
 		return nil, err
 	}
@@ -164,6 +167,32 @@ func VerifyAndEditValidatorFromMsg(
 		return nil, errCommissionRateChangeTooHigh
 	}
 
+	if commissionSchedule {
+		if err := wrapper.SanityCheck(); err != nil {
+			return nil, err
+		}
+		if msg.CommissionRate == nil {
+			return wrapper, nil
+		}
+		if epoch == nil {
+			return nil, errEpochMissing
+		}
+		if newRate.Sub(currentRate).Abs().GT(wrapper.Validator.MaxChangeRate) {
+			return nil, errCommissionRateChangeTooFast
+		}
+		// the current rate stays in effect until the scheduled epoch
+		wrapper.Validator.Rate = currentRate
+		effectiveEpoch := new(big.Int).Add(
+			epoch, big.NewInt(staking.CommissionChangeDelay),
+		)
+		if err := wrapper.ScheduleCommissionChange(
+			epoch, effectiveEpoch, newRate,
+		); err != nil {
+			return nil, err
+		}
+		return wrapper, nil
+	}
+
 	snapshotValidator, err := chainContext.ReadValidatorSnapshot(wrapper.Address)
 	if err != nil {
 		return nil, errors.WithMessage(err, "validator snapshot not found.")
@@ -412,3 +441,40 @@ func VerifyAndCollectRewardsFromDelegation(
 	}
 	return updatedValidatorWrappers, totalRewards, nil
 }
+
+// VerifyAndUnjailFromMsg verifies the unjail message using the stateDB and
+// returns the validatorWrapper set back to active. The jail period has to be
+// over and the self delegation left after the downtime slash must still cover
+// the min self delegation.
+//
+// Note that this function never updates the stateDB, it only reads from stateDB.
+func VerifyAndUnjailFromMsg(
+	stateDB vm.StateDB, epoch *big.Int, msg *staking.Unjail,
+) (*staking.ValidatorWrapper, error) {
+	if stateDB == nil {
+		return nil, errStateDBIsMissing
+	}
+	if epoch == nil {
+		return nil, errEpochMissing
+	}
+	if !stateDB.IsValidator(msg.ValidatorAddress) {
+		return nil, errValidatorNotExist
+	}
+	wrapper, err := stateDB.ValidatorWrapperCopy(msg.ValidatorAddress)
+	if err != nil {
+		return nil, err
+	}
+	if wrapper.Status != effective.Jailed {
+		return nil, errValidatorNotJailed
+	}
+	if wrapper.JailedUntil != nil && epoch.Cmp(wrapper.JailedUntil) < 0 {
+		return nil, errors.Wrapf(
+			errJailPeriodNotOver, "jailed until epoch %v", wrapper.JailedUntil,
+		)
+	}
+	wrapper.Status = effective.Active
+	if err := wrapper.SanityCheck(); err != nil {
+		return nil, err
+	}
+	return wrapper, nil
+}
@@ -431,12 +431,13 @@ func defaultExpWrapperCreateValidator() staking.ValidatorWrapper {
 
 func TestVerifyAndEditValidatorFromMsg(t *testing.T) {
 	tests := []struct {
-		sdb             vm.StateDB
-		bc              ChainContext
-		epoch, blockNum *big.Int
-		msg             staking.EditValidator
-		expWrapper      staking.ValidatorWrapper
-		expErr          error
+		sdb                vm.StateDB
+		bc                 ChainContext
+		epoch, blockNum    *big.Int
+		msg                staking.EditValidator
+		commissionSchedule bool
+		expWrapper         staking.ValidatorWrapper
+		expErr             error
 	}{
 		{
 			// 0: positive case
@@ -653,10 +654,45 @@ func TestVerifyAndEditValidatorFromMsg(t *testing.T) {
 
 			expErr: errors.New("banned status"),
 		},
+		{
+			// 14: commission change is scheduled instead of applied
+			sdb:                makeStateDBForStake(t),
+			bc:                 makeFakeChainContextForStake(),
+			epoch:              big.NewInt(defaultEpoch),
+			blockNum:           big.NewInt(defaultBlockNumber),
+			msg:                defaultMsgEditValidator(),
+			commissionSchedule: true,
+
+			expWrapper: func() staking.ValidatorWrapper {
+				vw := defaultExpWrapperEditValidator()
+				vw.UpdateHeight = big.NewInt(defaultSnapBlockNumber)
+				vw.Rate = pointFiveDec
+				vw.CommissionSchedule = []staking.CommissionChange{{
+					Epoch: big.NewInt(defaultEpoch + staking.CommissionChangeDelay),
+					Rate:  pointTwoDec,
+				}}
+				return vw
+			}(),
+		},
+		{
+			// 15: scheduled rate is greater than maxChangeRate
+			sdb:      makeStateDBForStake(t),
+			bc:       makeFakeChainContextForStake(),
+			epoch:    big.NewInt(defaultEpoch),
+			blockNum: big.NewInt(defaultBlockNumber),
+			msg: func() staking.EditValidator {
+				msg := defaultMsgEditValidator()
+				msg.CommissionRate = &pointEightFiveDec
+				return msg
+			}(),
+			commissionSchedule: true,
+
+			expErr: errCommissionRateChangeTooFast,
+		},
 	}
 	for i, test := range tests {
 		w, err := VerifyAndEditValidatorFromMsg(test.sdb, test.bc, test.epoch, test.blockNum,
-			&test.msg)
+			&test.msg, test.commissionSchedule)
 
 		if assErr := assertError(err, test.expErr); assErr != nil {
 			t.Errorf("Test %v: unexpected Error: %v", i, assErr)
@@ -1518,6 +1554,107 @@ func expVWrappersForReward() []*staking.ValidatorWrapper {
 	return []*staking.ValidatorWrapper{&w1, &w2}
 }
 
+func TestVerifyAndUnjailFromMsg(t *testing.T) {
+	tests := []struct {
+		sdb   vm.StateDB
+		epoch *big.Int
+		msg   staking.Unjail
+
+		expVWrapper staking.ValidatorWrapper
+		expErr      error
+	}{
+		{
+			// 0: jail period is over
+			sdb:   makeStateForUnjail(t, big.NewInt(defaultEpoch)),
+			epoch: big.NewInt(defaultEpoch),
+			msg:   defaultMsgUnjail(),
+
+			expVWrapper: defaultExpVWrapperUnjail(big.NewInt(defaultEpoch)),
+		},
+		{
+			// 1: still in jail period
+			sdb:   makeStateForUnjail(t, big.NewInt(defaultNextEpoch)),
+			epoch: big.NewInt(defaultEpoch),
+			msg:   defaultMsgUnjail(),
+
+			expErr: errJailPeriodNotOver,
+		},
+		{
+			// 2: validator is not jailed
+			sdb:   makeStateDBForStake(t),
+			epoch: big.NewInt(defaultEpoch),
+			msg:   defaultMsgUnjail(),
+
+			expErr: errValidatorNotJailed,
+		},
+		{
+			// 3: nil state db
+			sdb:   nil,
+			epoch: big.NewInt(defaultEpoch),
+			msg:   defaultMsgUnjail(),
+
+			expErr: errStateDBIsMissing,
+		},
+		{
+			// 4: nil epoch
+			sdb:   makeStateForUnjail(t, big.NewInt(defaultEpoch)),
+			epoch: nil,
+			msg:   defaultMsgUnjail(),
+
+			expErr: errEpochMissing,
+		},
+		{
+			// 5: validator not exist
+			sdb:   makeStateForUnjail(t, big.NewInt(defaultEpoch)),
+			epoch: big.NewInt(defaultEpoch),
+			msg: staking.Unjail{
+				ValidatorAddress: makeTestAddr("addr not exist"),
+			},
+
+			expErr: errValidatorNotExist,
+		},
+	}
+	for i, test := range tests {
+		w, err := VerifyAndUnjailFromMsg(test.sdb, test.epoch, &test.msg)
+
+		if assErr := assertError(err, test.expErr); assErr != nil {
+			t.Errorf("Test %v: %v", i, assErr)
+		}
+		if err != nil || test.expErr != nil {
+			continue
+		}
+
+		if err := staketest.CheckValidatorWrapperEqual(*w, test.expVWrapper); err != nil {
+			t.Errorf("Test %v: %v", i, err)
+		}
+	}
+}
+
+func makeStateForUnjail(t *testing.T, jailedUntil *big.Int) *state.DB {
+	sdb := makeStateDBForStake(t)
+	w := makeVWrapperByIndex(validatorIndex)
+	w.Status = effective.Jailed
+	w.JailedUntil = jailedUntil
+	if err := sdb.UpdateValidatorWrapper(validatorAddr, &w); err != nil {
+		t.Fatal(err)
+	}
+	sdb.IntermediateRoot(true)
+	return sdb
+}
+
+func defaultMsgUnjail() staking.Unjail {
+	return staking.Unjail{
+		ValidatorAddress: validatorAddr,
+	}
+}
+
+func defaultExpVWrapperUnjail(jailedUntil *big.Int) staking.ValidatorWrapper {
+	w := makeVWrapperByIndex(validatorIndex)
+	w.Status = effective.Active
+	w.JailedUntil = jailedUntil
+	return w
+}
+
 // makeFakeChainContextForStake makes the default fakeChainContext for staking test
 func makeFakeChainContextForStake() *fakeChainContext {
 	ws := makeVWrappersForStake(defNumWrappersInState, defNumPubPerAddr)
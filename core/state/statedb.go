@@ -879,25 +879,30 @@ var (
 )
 
 // AddReward distributes the reward to all the delegators based on stake percentage.
-func (db *DB) AddReward(snapshot *stk.ValidatorWrapper, reward *big.Int, shareLookup map[common.Address]numeric.Dec) error {
+// It returns the amount paid to each delegation, in the order of the snapshot delegations.
+func (db *DB) AddReward(snapshot *stk.ValidatorWrapper, reward *big.Int, shareLookup map[common.Address]numeric.Dec) ([]*big.Int, error) {
 	if reward.Cmp(common.Big0) == 0 {
 		utils.Logger().Info().RawJSON("validator", []byte(snapshot.String())).
 			Msg("0 given as reward")
-		return nil
+		return nil, nil
 	}
 
 	curValidator, err := db.ValidatorWrapper(snapshot.Address)
 	if err != nil {
-		return errors.Wrapf(err, "failed to distribute rewards: validator does not exist")
+		return nil, errors.Wrapf(err, "failed to distribute rewards: validator does not exist")
 	}
 
 	if curValidator.Status == effective.Banned {
 		utils.Logger().Info().
 			RawJSON("slashed-validator", []byte(curValidator.String())).
 			Msg("cannot add reward to banned validator")
-		return nil
+		return nil, nil
 	}
 
+	paid := make([]*big.Int, len(snapshot.Delegations))
+	for i := range paid {
+		paid[i] = big.NewInt(0)
+	}
 	rewardPool := big.NewInt(0).Set(reward)
 	curValidator.BlockReward.Add(curValidator.BlockReward, reward)
 	// Payout commission
@@ -907,6 +912,7 @@ func (db *DB) AddReward(snapshot *stk.ValidatorWrapper, reward *big.Int, shareLo
 			curValidator.Delegations[0].Reward,
 			commissionInt,
 		)
+		paid[0].Add(paid[0], commissionInt)
 		rewardPool.Sub(rewardPool, commissionInt)
 	}
 
@@ -917,12 +923,13 @@ func (db *DB) AddReward(snapshot *stk.ValidatorWrapper, reward *big.Int, shareLo
 		percentage, ok := shareLookup[delegation.DelegatorAddress]
 
 		if !ok {
-			return errors.Wrapf(err, "missing delegation shares for reward distribution")
+			return nil, errors.Wrapf(err, "missing delegation shares for reward distribution")
 		}
 
 		rewardInt := percentage.MulInt(totalRewardForDelegators).RoundInt()
 		curDelegation := curValidator.Delegations[i]
 		curDelegation.Reward.Add(curDelegation.Reward, rewardInt)
+		paid[i].Add(paid[i], rewardInt)
 		rewardPool.Sub(rewardPool, rewardInt)
 	}
 
@@ -930,7 +937,8 @@ func (db *DB) AddReward(snapshot *stk.ValidatorWrapper, reward *big.Int, shareLo
 	// always at index 0)
 	if rewardPool.Cmp(common.Big0) > 0 {
 		curValidator.Delegations[0].Reward.Add(curValidator.Delegations[0].Reward, rewardPool)
+		paid[0].Add(paid[0], rewardPool)
 	}
 
-	return nil
+	return paid, nil
 }
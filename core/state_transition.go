@@ -47,6 +47,8 @@ var (
 	errNegativeAmount              = errors.New("amount can not be negative")
 	errDupIdentity                 = errors.New("validator identity exists")
 	errDupBlsKey                   = errors.New("BLS key exists")
+	errValidatorNotJailed          = errors.New("validator is not jailed")
+	errJailPeriodNotOver           = errors.New("validator jail period is not over")
 )
 
 /*
@@ -409,6 +411,19 @@ func (st *StateTransition) StakingTransitionDb() (usedGas uint64, err error) {
 			return 0, errInvalidSigner
 		}
 		_, err = st.verifyAndApplyCollectRewards(stkMsg)
+	case types.Unjail:
+		if !st.evm.ChainConfig().IsDowntimeSlash(st.evm.EpochNumber) {
+			return 0, staking.ErrInvalidStakingKind
+		}
+		stkMsg := &staking.Unjail{}
+		if err = rlp.DecodeBytes(msg.Data(), stkMsg); err != nil {
+			return 0, err
+		}
+		utils.Logger().Info().Msgf("[DEBUG STAKING] staking type: %s, gas: %d, txn: %+v", msg.Type(), gas, stkMsg)
+		if msg.From() != stkMsg.ValidatorAddress {
+			return 0, errInvalidSigner
+		}
+		err = st.verifyAndApplyUnjailTx(stkMsg)
 	default:
 		return 0, staking.ErrInvalidStakingKind
 	}
@@ -443,6 +458,7 @@ func (st *StateTransition) verifyAndApplyEditValidatorTx(
 ) error {
 	wrapper, err := VerifyAndEditValidatorFromMsg(
 		st.state, st.bc, st.evm.EpochNumber, blockNum, editValidator,
+		st.evm.ChainConfig().IsCommissionSchedule(st.evm.EpochNumber),
 	)
 	if err != nil {
 		return err
@@ -511,6 +527,14 @@ func (st *StateTransition) verifyAndApplyUndelegateTx(
 	return st.state.UpdateValidatorWrapper(wrapper.Address, wrapper)
 }
 
+func (st *StateTransition) verifyAndApplyUnjailTx(unjail *staking.Unjail) error {
+	wrapper, err := VerifyAndUnjailFromMsg(st.state, st.evm.EpochNumber, unjail)
+	if err != nil {
+		return err
+	}
+	return st.state.UpdateValidatorWrapper(wrapper.Address, wrapper)
+}
+
 func (st *StateTransition) verifyAndApplyCollectRewards(collectRewards *staking.CollectRewards) (*big.Int, error) {
 	if st.bc == nil {
 		return stakingReward.Nnet, errors.New("[CollectRewards] No chain context provided")
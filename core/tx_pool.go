@@ -810,10 +810,11 @@ func (pool *TxPool) validateStakingTx(tx *staking.StakingTransaction) error {
 		}
 		pendingBlockNumber := new(big.Int).Add(pool.chain.CurrentBlock().Number(), big.NewInt(1))
 
+		currentEpoch := pool.chain.CurrentBlock().Epoch()
 		_, err = VerifyAndEditValidatorFromMsg(
 			pool.currentState, chainContext,
-			pool.chain.CurrentBlock().Epoch(),
-			pendingBlockNumber, stkMsg,
+			currentEpoch, pendingBlockNumber, stkMsg,
+			pool.chainconfig.IsCommissionSchedule(currentEpoch),
 		)
 		return err
 	case staking.DirectiveDelegate:
@@ -881,6 +882,25 @@ func (pool *TxPool) validateStakingTx(tx *staking.StakingTransaction) error {
 
 		_, _, err = VerifyAndCollectRewardsFromDelegation(pool.currentState, delegations)
 		return err
+	case staking.DirectiveUnjail:
+		pendingEpoch := pool.pendingEpoch()
+		if !pool.chainconfig.IsDowntimeSlash(pendingEpoch) {
+			return staking.ErrInvalidStakingKind
+		}
+		msg, err := staking.RLPDecodeStakeMsg(tx.Data(), staking.DirectiveUnjail)
+		if err != nil {
+			return err
+		}
+		stkMsg, ok := msg.(*staking.Unjail)
+		if !ok {
+			return ErrInvalidMsgForStakingDirective
+		}
+		if from != stkMsg.ValidatorAddress {
+			return errors.WithMessagef(ErrInvalidSender, "staking transaction sender is %s", b32)
+		}
+
+		_, err = VerifyAndUnjailFromMsg(pool.currentState, pendingEpoch, stkMsg)
+		return err
 	default:
 		return staking.ErrInvalidStakingKind
 	}
@@ -56,12 +56,14 @@ const (
 	Delegate
 	Undelegate
 	CollectRewards
+	Unjail
 )
 
 // StakingTypeMap is the map from staking type to transactionType
 var StakingTypeMap = map[staking.Directive]TransactionType{staking.DirectiveCreateValidator: StakeCreateVal,
 	staking.DirectiveEditValidator: StakeEditVal, staking.DirectiveDelegate: Delegate,
-	staking.DirectiveUndelegate: Undelegate, staking.DirectiveCollectRewards: CollectRewards}
+	staking.DirectiveUndelegate: Undelegate, staking.DirectiveCollectRewards: CollectRewards,
+	staking.DirectiveUnjail: Unjail}
 
 // InternalTransaction defines the common interface for nordicenergy and ethereum transactions.
 type InternalTransaction interface {
@@ -120,6 +122,8 @@ func (txType TransactionType) String() string {
 		return "Undelegate"
 	} else if txType == CollectRewards {
 		return "CollectRewards"
+	} else if txType == Unjail {
+		return "Unjail"
 	}
 	return "Unknown"
 }
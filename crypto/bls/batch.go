@@ -0,0 +1,65 @@
+package bls
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+
+	"github.com/nordicenergy/bls/ffi/go/bls"
+)
+
+// VerifyBatch returns whether sigs[i] is a valid signature of hash by pubs[i]
+// for all i, with a single aggregate verification instead of one per
+// signature. Every signature and public key is weighted by a random
+// coefficient first, so that invalid signatures cannot cancel each other
+// out in the aggregate. It returns false on an empty or mismatching input.
+func VerifyBatch(hash []byte, pubs []*bls.PublicKey, sigs []*bls.Sign) bool {
+	if len(pubs) == 0 || len(pubs) != len(sigs) {
+		return false
+	}
+	if len(pubs) == 1 {
+		return sigs[0].VerifyHash(pubs[0], hash)
+	}
+
+	coefficients := make([]byte, 8*len(pubs))
+	if _, err := rand.Read(coefficients); err != nil {
+		return false
+	}
+	var aggregatedSig bls.Sign
+	var aggregatedPub bls.PublicKey
+	for i := range pubs {
+		coefficient := binary.BigEndian.Uint64(coefficients[8*i:])
+		// a zero coefficient would leave the signature out
+		coefficient |= 1
+		aggregatedSig.Add(mulSign(sigs[i], coefficient))
+		aggregatedPub.Add(mulPublicKey(pubs[i], coefficient))
+	}
+	return aggregatedSig.VerifyHash(&aggregatedPub, hash)
+}
+
+// mulSign returns sig multiplied by scalar, by double and add
+func mulSign(sig *bls.Sign, scalar uint64) *bls.Sign {
+	var result bls.Sign
+	base := *sig
+	for ; scalar > 0; scalar >>= 1 {
+		if scalar&1 == 1 {
+			result.Add(&base)
+		}
+		double := base
+		base.Add(&double)
+	}
+	return &result
+}
+
+// mulPublicKey returns pub multiplied by scalar, by double and add
+func mulPublicKey(pub *bls.PublicKey, scalar uint64) *bls.PublicKey {
+	var result bls.PublicKey
+	base := *pub
+	for ; scalar > 0; scalar >>= 1 {
+		if scalar&1 == 1 {
+			result.Add(&base)
+		}
+		double := base
+		base.Add(&double)
+	}
+	return &result
+}
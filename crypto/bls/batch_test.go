@@ -0,0 +1,43 @@
+package bls
+
+import (
+	"testing"
+
+	"github.com/nordicenergy/bls/ffi/go/bls"
+)
+
+func TestVerifyBatch(test *testing.T) {
+	hash := []byte("0123456789abcdef0123456789abcdef")
+	pubs := []*bls.PublicKey{}
+	sigs := []*bls.Sign{}
+	for i := 0; i < 8; i++ {
+		key := RandPrivateKey()
+		pubs = append(pubs, key.GetPublicKey())
+		sigs = append(sigs, key.SignHash(hash))
+	}
+	if !VerifyBatch(hash, pubs, sigs) {
+		test.Error("valid batch failed verification")
+	}
+	if VerifyBatch([]byte("fedcba9876543210fedcba9876543210"), pubs, sigs) {
+		test.Error("batch verified against another hash")
+	}
+	if VerifyBatch(hash, pubs, sigs[1:]) || VerifyBatch(hash, nil, nil) {
+		test.Error("mismatching batch verified")
+	}
+
+	// two invalid signatures whose sum is the sum of the valid ones
+	var first, second bls.Sign
+	first.Add(sigs[0])
+	first.Add(sigs[1])
+	forged := append([]*bls.Sign{&first, &second}, sigs[2:]...)
+	var aggregatedPub bls.PublicKey
+	for _, pub := range pubs {
+		aggregatedPub.Add(pub)
+	}
+	if !AggregateSig(forged).VerifyHash(&aggregatedPub, hash) {
+		test.Fatal("forged signatures expected to pass a plain aggregate verification")
+	}
+	if VerifyBatch(hash, pubs, forged) {
+		test.Error("forged signatures cancelling out passed batch verification")
+	}
+}
@@ -11,6 +11,10 @@ import (
 
 var (
 	emptyBLSPubKey = SerializedPublicKey{}
+
+	errNoSecretKey  = errors.New("no secret key or signer for bls key")
+	errUnknownKey   = errors.New("signer does not hold the bls key")
+	errSignHashFail = errors.New("failed to sign hash with bls key")
 )
 
 // PublicKeySizeInBytes ..
@@ -19,10 +23,58 @@ const (
 	BLSSignatureSizeInBytes = 96
 )
 
-// PrivateKeyWrapper combines the bls private key and the corresponding public key
+// PrivateKeyWrapper combines the bls private key and the corresponding public key.
+// Keys held outside of the process by a Signer have a nil Pri.
 type PrivateKeyWrapper struct {
-	Pri *bls.SecretKey
-	Pub *PublicKeyWrapper
+	Pri    *bls.SecretKey
+	Pub    *PublicKeyWrapper
+	Signer Signer
+}
+
+// SignHash signs hash with the key, through its Signer if it has one
+func (w *PrivateKeyWrapper) SignHash(hash []byte) (*bls.Sign, error) {
+	if w.Signer != nil {
+		return w.Signer.SignHash(w.Pub.Bytes, hash)
+	}
+	if w.Pri == nil {
+		return nil, errors.Wrapf(errNoSecretKey, "key %s", w.Pub.Bytes.Hex())
+	}
+	return signHash(w.Pri, hash)
+}
+
+// Signer produces the signatures of bls keys. Consensus signs through it so
+// that the secret keys can be kept off the node, e.g. by a remote signing service.
+type Signer interface {
+	SignHash(pubKey SerializedPublicKey, hash []byte) (*bls.Sign, error)
+}
+
+// LocalSigner is the in-process Signer, holding the secret keys in memory
+type LocalSigner map[SerializedPublicKey]*bls.SecretKey
+
+// NewLocalSigner returns a LocalSigner for the given secret keys
+func NewLocalSigner(keys ...*bls.SecretKey) LocalSigner {
+	signer := LocalSigner{}
+	for _, key := range keys {
+		signer[*FromLibBLSPublicKeyUnsafe(key.GetPublicKey())] = key
+	}
+	return signer
+}
+
+// SignHash ..
+func (s LocalSigner) SignHash(pubKey SerializedPublicKey, hash []byte) (*bls.Sign, error) {
+	key, ok := s[pubKey]
+	if !ok {
+		return nil, errors.Wrapf(errUnknownKey, "key %s", pubKey.Hex())
+	}
+	return signHash(key, hash)
+}
+
+func signHash(key *bls.SecretKey, hash []byte) (*bls.Sign, error) {
+	sig := key.SignHash(hash)
+	if sig == nil {
+		return nil, errSignHashFail
+	}
+	return sig, nil
 }
 
 // PublicKeyWrapper defines the bls public key in both serialized and
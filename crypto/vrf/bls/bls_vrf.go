@@ -73,6 +73,20 @@ func (k *PrivateKey) Evaluate(alpha []byte) ([32]byte, []byte) {
 	return beta, pi.Serialize()
 }
 
+// EvaluateWithSigner evaluates the VRF like Evaluate, with the BLS signature
+// produced by signHash so that the secret key can be held by a remote signer
+func EvaluateWithSigner(
+	signHash func(hash []byte) (*bls.Sign, error), alpha []byte,
+) ([32]byte, []byte, error) {
+	msgHash := sha256.Sum256(alpha)
+	pi, err := signHash(msgHash[:])
+	if err != nil {
+		return [32]byte{}, nil, err
+	}
+	beta := sha256.Sum256(pi.Serialize())
+	return beta, pi.Serialize(), nil
+}
+
 // ProofToHash asserts that proof is correct for input alpha and output VRF hash
 func (pk *PublicKey) ProofToHash(alpha, pi []byte) ([32]byte, error) {
 	nilIndex := [32]byte{}
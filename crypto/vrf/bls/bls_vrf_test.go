@@ -28,6 +28,27 @@ func TestVRF1(t *testing.T) {
 	}
 }
 
+func TestEvaluateWithSigner(t *testing.T) {
+	blsSk := bls.RandPrivateKey()
+	key := bls.WrapperFromPrivateKey(blsSk)
+	key.Signer = bls.NewLocalSigner(blsSk)
+
+	m1 := []byte("data1")
+	vrf, proof, err := EvaluateWithSigner(key.SignHash, m1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expVrf, expProof := NewVRFSigner(blsSk).Evaluate(m1)
+	if vrf != expVrf || !bytes.Equal(proof, expProof) {
+		t.Errorf("evaluation with signer differs from evaluation with secret key")
+	}
+	hash, err := NewVRFVerifier(blsSk.GetPublicKey()).ProofToHash(m1, proof)
+	if err != nil || hash != vrf {
+		t.Errorf("error hash doesn't match")
+	}
+}
+
 func TestVRF2(t *testing.T) {
 	blsSk := bls.RandPrivateKey()
 
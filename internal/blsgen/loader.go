@@ -3,14 +3,19 @@ package blsgen
 import (
 	"errors"
 	"fmt"
+	"time"
 
 	bls_core "github.com/nordicenergy/bls/ffi/go/bls"
+	"github.com/nordicenergy/nordicenergy-core/internal/remotesigner"
 	"github.com/nordicenergy/nordicenergy-core/multibls"
 )
 
 // LoadKeys load all BLS keys with the given config. If loading keys from files, the
 // file extension will decide which decryption algorithm to use.
 func LoadKeys(cfg Config) (multibls.PrivateKeys, error) {
+	if cfg.RemoteSigner != nil {
+		return loadRemoteKeys(*cfg.RemoteSigner)
+	}
 	decrypters, err := getKeyDecrypters(cfg)
 	if err != nil {
 		return nil, err
@@ -59,6 +64,32 @@ type Config struct {
 	AwsCfgSrcType AwsCfgSrcType
 	// AwsConfigFile set the json file to load aws config.
 	AwsConfigFile *string
+
+	// RemoteSigner, if set, loads the keys held by a remote signing service
+	// instead of key files. The secret keys are then never in process memory.
+	RemoteSigner *RemoteSignerConfig
+}
+
+// RemoteSignerConfig is the config for loading keys from a remote signing service
+type RemoteSignerConfig struct {
+	// URL of the signing service
+	URL string
+	// TLS is the client certificate and the CA of the service certificate
+	TLS remotesigner.TLSConfig
+	// Timeout of a signing request, remotesigner.DefaultTimeout if not set
+	Timeout time.Duration
+}
+
+func loadRemoteKeys(cfg RemoteSignerConfig) (multibls.PrivateKeys, error) {
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = remotesigner.DefaultTimeout
+	}
+	client, err := remotesigner.NewClient(cfg.URL, &cfg.TLS, timeout)
+	if err != nil {
+		return nil, err
+	}
+	return client.PrivateKeys()
 }
 
 func (cfg *Config) getPassProviderConfig() passDecrypterConfig {
@@ -22,6 +22,7 @@ import (
 	"github.com/nordicenergy/nordicenergy-core/shard"
 	"github.com/nordicenergy/nordicenergy-core/shard/committee"
 	"github.com/nordicenergy/nordicenergy-core/staking/availability"
+	"github.com/nordicenergy/nordicenergy-core/staking/network"
 	"github.com/nordicenergy/nordicenergy-core/staking/slash"
 	staking "github.com/nordicenergy/nordicenergy-core/staking/types"
 	"github.com/pkg/errors"
@@ -223,6 +224,7 @@ func (e *engineImpl) Finalize(
 
 	// Process Undelegations, set LastEpochInCommittee and set EPoS status
 	// Needs to be before AccumulateRewardsAndCountSigs
+	downtime := []availability.DowntimeRecord{}
 	if IsCommitteeSelectionBlock(chain, header) {
 		if err := payoutUndelegations(chain, header, state); err != nil {
 			return nil, nil, err
@@ -248,6 +250,17 @@ func (e *engineImpl) Finalize(
 			); err != nil {
 				return nil, nil, err
 			}
+			if chain.Config().IsDowntimeSlash(header.Epoch()) {
+				record, err := availability.ComputeAndApplyDowntimeSlash(
+					chain, state, addr, header.Epoch(),
+				)
+				if err != nil {
+					return nil, nil, err
+				}
+				if record != nil {
+					downtime = append(downtime, *record)
+				}
+			}
 		}
 	}
 
@@ -259,12 +272,27 @@ func (e *engineImpl) Finalize(
 	if err != nil {
 		return nil, nil, err
 	}
+	if len(downtime) > 0 {
+		payout = network.WithDowntime(payout, downtime)
+	}
+
+	// Apply scheduled commission changes for the next epoch.
+	// Needs to be after AccumulateRewardsAndCountSigs so the last block
+	// of the epoch is still paid out with the current commission rates.
+	if IsCommitteeSelectionBlock(chain, header) &&
+		chain.Config().IsCommissionSchedule(header.Epoch()) {
+		if err := applyCommissionSchedules(chain, header, state); err != nil {
+			return nil, nil, err
+		}
+	}
 
 	// Apply slashes
 	if isBeaconChain && inStakingEra && len(doubleSigners) > 0 {
-		if err := applySlashes(chain, header, state, doubleSigners); err != nil {
+		slashes, err := applySlashes(chain, header, state, doubleSigners)
+		if err != nil {
 			return nil, nil, err
 		}
+		payout = network.WithSlashes(payout, slashes)
 	} else if len(doubleSigners) > 0 {
 		return nil, nil, errors.New("slashes proposed in non-beacon chain or non-staking epoch")
 	}
@@ -321,6 +349,37 @@ func payoutUndelegations(
 	return nil
 }
 
+// Set the commission rates scheduled for the next epoch and prune the
+// commission history of the validators
+func applyCommissionSchedules(
+	chain engine.ChainReader, header *block.Header, state *state.DB,
+) error {
+	validators, err := chain.ReadValidatorList()
+	if err != nil {
+		const msg = "[Finalize] failed to read all validators"
+		return errors.Wrap(err, msg)
+	}
+	nextEpoch := new(big.Int).Add(header.Epoch(), common.Big1)
+	for _, validator := range validators {
+		wrapper, err := state.ValidatorWrapper(validator)
+		if err != nil {
+			return errors.Wrap(
+				err, "[Finalize] failed to get validator from state to finalize",
+			)
+		}
+		if wrapper.ApplyCommissionSchedule(nextEpoch) {
+			wrapper.UpdateHeight = header.Number()
+			utils.Logger().Info().
+				Str("validator", validator.Hex()).
+				Uint64("epoch", nextEpoch.Uint64()).
+				Str("rate", wrapper.Rate.String()).
+				Msg("applied scheduled commission change")
+		}
+		wrapper.PruneCommissionSchedule(nextEpoch)
+	}
+	return nil
+}
+
 // IsCommitteeSelectionBlock checks if the given header is for the committee selection block
 // which can only occur on beacon chain and if epoch > pre-staking epoch.
 func IsCommitteeSelectionBlock(chain engine.ChainReader, header *block.Header) bool {
@@ -352,7 +411,7 @@ func applySlashes(
 	header *block.Header,
 	state *state.DB,
 	doubleSigners slash.Records,
-) error {
+) ([]slash.HistoryRecord, error) {
 	type keyStruct struct {
 		height  uint64
 		viewID  uint64
@@ -392,18 +451,19 @@ func applySlashes(
 	})
 
 	// Do the slashing by groups in the sorted order
+	history := []slash.HistoryRecord{}
 	for _, key := range sortedKeys {
 		records := groupedRecords[key]
 		superCommittee, err := chain.ReadShardState(big.NewInt(int64(key.epoch)))
 
 		if err != nil {
-			return errors.New("could not read shard state")
+			return nil, errors.New("could not read shard state")
 		}
 
 		subComm, err := superCommittee.FindCommitteeByID(key.shardID)
 
 		if err != nil {
-			return errors.New("could not find shard committee")
+			return nil, errors.New("could not find shard committee")
 		}
 
 		// Apply the slashes, invariant: assume been verified as legit slash by this point
@@ -412,7 +472,7 @@ func applySlashes(
 			big.NewInt(int64(key.epoch)), subComm,
 		)
 		if err != nil {
-			return errors.Wrapf(err, "could not lookup cached voting power in slash application")
+			return nil, errors.Wrapf(err, "could not lookup cached voting power in slash application")
 		}
 		rate := slash.Rate(votingPower, records)
 		utils.Logger().Info().
@@ -425,7 +485,7 @@ func applySlashes(
 			records,
 			rate,
 		); err != nil {
-			return errors.New("[Finalize] could not apply slash")
+			return nil, errors.New("[Finalize] could not apply slash")
 		}
 
 		utils.Logger().Info().
@@ -433,8 +493,14 @@ func applySlashes(
 			RawJSON("records", []byte(records.String())).
 			RawJSON("applied", []byte(slashApplied.String())).
 			Msg("slash applied successfully")
+
+		for _, applied := range slashApplied.Applied {
+			applied.BlockNumber = header.Number().Uint64()
+			applied.Epoch = new(big.Int).Set(header.Epoch())
+			history = append(history, applied)
+		}
 	}
-	return nil
+	return history, nil
 }
 
 // QuorumForBlock returns the quorum for the given block header.
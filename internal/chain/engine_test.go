@@ -0,0 +1,114 @@
+package chain
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/nordicenergy/nordicenergy-core/block"
+	blockfactory "github.com/nordicenergy/nordicenergy-core/block/factory"
+	"github.com/nordicenergy/nordicenergy-core/consensus/engine"
+	"github.com/nordicenergy/nordicenergy-core/core/state"
+	"github.com/nordicenergy/nordicenergy-core/crypto/bls"
+	"github.com/nordicenergy/nordicenergy-core/numeric"
+	staking "github.com/nordicenergy/nordicenergy-core/staking/types"
+	staketest "github.com/nordicenergy/nordicenergy-core/staking/types/test"
+	pkgerrors "github.com/pkg/errors"
+)
+
+var testValidatorAddr = common.BigToAddress(big.NewInt(1))
+
+type fakeChainReader struct {
+	engine.ChainReader
+	validators []common.Address
+	err        error
+}
+
+func (cr *fakeChainReader) ReadValidatorList() ([]common.Address, error) {
+	return cr.validators, cr.err
+}
+
+func TestApplyCommissionSchedules(t *testing.T) {
+	sdb, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	w := staketest.GetDefaultValidatorWrapperWithAddr(
+		testValidatorAddr, []bls.SerializedPublicKey{{}},
+	)
+	rate := numeric.NewDecWithPrec(6, 1)
+	if err := w.ScheduleCommissionChange(big.NewInt(10), big.NewInt(12), rate); err != nil {
+		t.Fatal(err)
+	}
+	if err := sdb.UpdateValidatorWrapper(testValidatorAddr, &w); err != nil {
+		t.Fatal(err)
+	}
+	chain := &fakeChainReader{validators: []common.Address{testValidatorAddr}}
+
+	// the last block of epoch 10 keeps the current rate
+	if err := applyCommissionSchedules(chain, makeHeader(10, 100), sdb); err != nil {
+		t.Fatal(err)
+	}
+	wrapper, err := sdb.ValidatorWrapper(testValidatorAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wrapper.Rate.Equal(rate) || wrapper.PendingCommissionChange(big.NewInt(11)) == nil {
+		t.Errorf("commission changed before the scheduled epoch: %v", wrapper.Rate)
+	}
+
+	// the last block of epoch 11 sets the rate of epoch 12
+	if err := applyCommissionSchedules(chain, makeHeader(11, 200), sdb); err != nil {
+		t.Fatal(err)
+	}
+	wrapper, err = sdb.ValidatorWrapper(testValidatorAddr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !wrapper.Rate.Equal(rate) {
+		t.Errorf("commission not changed at the scheduled epoch: %v", wrapper.Rate)
+	}
+	if wrapper.UpdateHeight.Cmp(big.NewInt(200)) != 0 {
+		t.Errorf("unexpected update height %v", wrapper.UpdateHeight)
+	}
+	history := wrapper.CommissionHistory(big.NewInt(12))
+	if len(history) != 1 || !history[0].Rate.Equal(rate) {
+		t.Errorf("applied change not kept in the history: %v", history)
+	}
+
+	// the history is pruned to the last changes which took effect
+	for epoch := int64(13); epoch < 13+staking.CommissionHistoryLength; epoch++ {
+		wrapper.CommissionSchedule = append(wrapper.CommissionSchedule, staking.CommissionChange{
+			Epoch: big.NewInt(epoch), Rate: rate,
+		})
+	}
+	if err := applyCommissionSchedules(
+		chain, makeHeader(12+staking.CommissionHistoryLength, 300), sdb,
+	); err != nil {
+		t.Fatal(err)
+	}
+	if l := len(wrapper.CommissionSchedule); l != staking.CommissionHistoryLength {
+		t.Errorf("expected %v history entries, got %v", staking.CommissionHistoryLength, l)
+	}
+}
+
+func TestApplyCommissionSchedules_Error(t *testing.T) {
+	sdb, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cause := errors.New("no validator list")
+	chain := &fakeChainReader{err: cause}
+	if err := applyCommissionSchedules(chain, makeHeader(10, 100), sdb); pkgerrors.Cause(err) != cause {
+		t.Errorf("unexpected error %v", err)
+	}
+}
+
+func makeHeader(epoch, number int64) *block.Header {
+	return blockfactory.NewTestHeader().With().
+		Epoch(big.NewInt(epoch)).
+		Number(big.NewInt(number)).
+		Header()
+}
@@ -194,8 +194,8 @@ func AccumulateRewardsAndCountSigs(
 			return network.EmptyPayout, nil
 		}
 
-		newRewards, beaconP, shardP :=
-			big.NewInt(0), []reward.Payout{}, []reward.Payout{}
+		newRewards, beaconP, shardP, delegatorP :=
+			big.NewInt(0), []reward.Payout{}, []reward.Payout{}, []reward.DelegatorPayout{}
 
 		// Handle rewards for shardchain
 		if cxLinks := header.CrossLinks(); len(cxLinks) > 0 {
@@ -331,9 +331,11 @@ func AccumulateRewardsAndCountSigs(
 					if err != nil {
 						return network.EmptyPayout, err
 					}
-					if err := state.AddReward(snapshot.Validator, due, shares); err != nil {
+					paid, err := state.AddReward(snapshot.Validator, due, shares)
+					if err != nil {
 						return network.EmptyPayout, err
 					}
+					delegatorP = reward.AppendDelegatorPayouts(delegatorP, snapshot.Validator, paid)
 					shardP = append(shardP, reward.Payout{
 						ShardID:     payable.shardID,
 						Addr:        payable.EcdsaAddress,
@@ -402,9 +404,11 @@ func AccumulateRewardsAndCountSigs(
 				if err != nil {
 					return network.EmptyPayout, err
 				}
-				if err := state.AddReward(snapshot.Validator, due, shares); err != nil {
+				paid, err := state.AddReward(snapshot.Validator, due, shares)
+				if err != nil {
 					return network.EmptyPayout, err
 				}
+				delegatorP = reward.AppendDelegatorPayouts(delegatorP, snapshot.Validator, paid)
 				beaconP = append(beaconP, reward.Payout{
 					ShardID:     shard.BeaconChainShardID,
 					Addr:        voter.EarningAccount,
@@ -416,7 +420,7 @@ func AccumulateRewardsAndCountSigs(
 		utils.AnalysisEnd("accumulateRewardBeaconchainSelfPayout", nowEpoch, blockNow)
 
 		return network.NewStakingEraRewardForRound(
-			newRewards, missing, beaconP, shardP,
+			newRewards, missing, beaconP, shardP, delegatorP,
 		), nil
 	}
 
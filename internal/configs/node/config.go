@@ -92,7 +92,11 @@ type ConfigType struct {
 	isArchival       map[uint32]bool
 	WebHooks         struct {
 		Hooks *webhooks.Hooks
+		// Path is the YAML file the hooks were read from, reloaded when
+		// it changes
+		Path string
 	}
+	Explorer ExplorerConfig
 }
 
 // RPCServerConfig is the config for rpc listen addresses
@@ -108,6 +112,15 @@ type RPCServerConfig struct {
 	DebugEnabled bool
 }
 
+// ExplorerConfig is the config for the explorer node
+type ExplorerConfig struct {
+	// TraceInternalTxs enables the tracing of the blocks committed, to index
+	// the value transfers made by contracts. Only the blocks committed while
+	// it is enabled are traced, not the ones indexed before nor the ones
+	// re-indexed in the background.
+	TraceInternalTxs bool
+}
+
 // RosettaServerConfig is the config for the rosetta server
 type RosettaServerConfig struct {
 	HTTPEnabled bool
@@ -53,6 +53,10 @@ var (
 		S3Epoch:                    big.NewInt(28),
 		IstanbulEpoch:              big.NewInt(314),
 		ReceiptLogEpoch:            big.NewInt(101),
+		CommissionScheduleEpoch:    EpochTBD,
+		DowntimeSlashEpoch:         EpochTBD,
+		LeaderRotationEpoch:        EpochTBD,
+		LeaderRotationBlocksCount:  64,
 	}
 
 	// TestnetChainConfig contains the chain parameters to run a node on the nordicenergy test network.
@@ -74,6 +78,10 @@ var (
 		S3Epoch:                    big.NewInt(0),
 		IstanbulEpoch:              big.NewInt(43800),
 		ReceiptLogEpoch:            big.NewInt(0),
+		CommissionScheduleEpoch:    EpochTBD,
+		DowntimeSlashEpoch:         EpochTBD,
+		LeaderRotationEpoch:        EpochTBD,
+		LeaderRotationBlocksCount:  64,
 	}
 
 	// PangaeaChainConfig contains the chain parameters for the Pangaea network.
@@ -96,6 +104,10 @@ var (
 		S3Epoch:                    big.NewInt(0),
 		IstanbulEpoch:              big.NewInt(0),
 		ReceiptLogEpoch:            big.NewInt(0),
+		CommissionScheduleEpoch:    big.NewInt(0),
+		DowntimeSlashEpoch:         big.NewInt(0),
+		LeaderRotationEpoch:        big.NewInt(0),
+		LeaderRotationBlocksCount:  64,
 	}
 
 	// PartnerChainConfig contains the chain parameters for the Partner network.
@@ -118,6 +130,10 @@ var (
 		S3Epoch:                    big.NewInt(0),
 		IstanbulEpoch:              big.NewInt(0),
 		ReceiptLogEpoch:            big.NewInt(0),
+		CommissionScheduleEpoch:    big.NewInt(0),
+		DowntimeSlashEpoch:         big.NewInt(0),
+		LeaderRotationEpoch:        big.NewInt(0),
+		LeaderRotationBlocksCount:  64,
 	}
 
 	// StressnetChainConfig contains the chain parameters for the Stress test network.
@@ -140,6 +156,10 @@ var (
 		S3Epoch:                    big.NewInt(0),
 		IstanbulEpoch:              big.NewInt(0),
 		ReceiptLogEpoch:            big.NewInt(0),
+		CommissionScheduleEpoch:    big.NewInt(0),
+		DowntimeSlashEpoch:         big.NewInt(0),
+		LeaderRotationEpoch:        big.NewInt(0),
+		LeaderRotationBlocksCount:  64,
 	}
 
 	// LocalnetChainConfig contains the chain parameters to run for local development.
@@ -161,6 +181,10 @@ var (
 		S3Epoch:                    big.NewInt(0),
 		IstanbulEpoch:              big.NewInt(0),
 		ReceiptLogEpoch:            big.NewInt(0),
+		CommissionScheduleEpoch:    big.NewInt(0),
+		DowntimeSlashEpoch:         big.NewInt(0),
+		LeaderRotationEpoch:        big.NewInt(0),
+		LeaderRotationBlocksCount:  64,
 	}
 
 	// AllProtocolChanges ...
@@ -184,6 +208,10 @@ var (
 		big.NewInt(0),                      // S3Epoch
 		big.NewInt(0),                      // IstanbulEpoch
 		big.NewInt(0),                      // ReceiptLogEpoch
+		big.NewInt(0),                      // CommissionScheduleEpoch
+		big.NewInt(0),                      // DowntimeSlashEpoch
+		big.NewInt(0),                      // LeaderRotationEpoch
+		64,                                 // LeaderRotationBlocksCount
 	}
 
 	// TestChainConfig ...
@@ -207,6 +235,10 @@ var (
 		big.NewInt(0),        // S3Epoch
 		big.NewInt(0),        // IstanbulEpoch
 		big.NewInt(0),        // ReceiptLogEpoch
+		big.NewInt(0),        // CommissionScheduleEpoch
+		big.NewInt(0),        // DowntimeSlashEpoch
+		big.NewInt(0),        // LeaderRotationEpoch
+		64,                   // LeaderRotationBlocksCount
 	}
 
 	// TestRules ...
@@ -287,6 +319,23 @@ type ChainConfig struct {
 
 	// ReceiptLogEpoch is the first epoch support receiptlog
 	ReceiptLogEpoch *big.Int `json:"receipt-log-epoch,omitempty"`
+
+	// CommissionScheduleEpoch is the epoch from which validator commission rate
+	// changes are scheduled for a future epoch instead of applied immediately
+	CommissionScheduleEpoch *big.Int `json:"commission-schedule-epoch,omitempty"`
+
+	// DowntimeSlashEpoch is the epoch from which validators with too low
+	// availability are slashed and jailed
+	DowntimeSlashEpoch *big.Int `json:"downtime-slash-epoch,omitempty"`
+
+	// LeaderRotationEpoch is the epoch from which the leader is rotated every
+	// LeaderRotationBlocksCount blocks, picked by voting power among all the
+	// validators of the committee
+	LeaderRotationEpoch *big.Int `json:"leader-rotation-epoch,omitempty"`
+
+	// LeaderRotationBlocksCount is the number of blocks a leader proposes
+	// before the leader is rotated
+	LeaderRotationBlocksCount uint64 `json:"leader-rotation-blocks-count,omitempty"`
 }
 
 // String implements the fmt.Stringer interface.
@@ -387,6 +436,22 @@ func (c *ChainConfig) IsReceiptLog(epoch *big.Int) bool {
 	return isForked(c.ReceiptLogEpoch, epoch)
 }
 
+// IsCommissionSchedule determines whether commission rate changes are scheduled
+// for a future epoch rather than taking effect immediately
+func (c *ChainConfig) IsCommissionSchedule(epoch *big.Int) bool {
+	return isForked(c.CommissionScheduleEpoch, epoch)
+}
+
+// IsDowntimeSlash determines whether validators are slashed and jailed for downtime
+func (c *ChainConfig) IsDowntimeSlash(epoch *big.Int) bool {
+	return isForked(c.DowntimeSlashEpoch, epoch)
+}
+
+// IsLeaderRotation determines whether the leader is rotated by voting power
+func (c *ChainConfig) IsLeaderRotation(epoch *big.Int) bool {
+	return c.LeaderRotationBlocksCount > 0 && isForked(c.LeaderRotationEpoch, epoch)
+}
+
 // UpdateEthChainIDByShard update the ethChainID based on shard ID.
 func UpdateEthChainIDByShard(shardID uint32) {
 	once.Do(func() {
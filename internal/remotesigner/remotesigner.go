@@ -0,0 +1,189 @@
+// Package remotesigner lets consensus sign with bls keys held by an external
+// signing service, so that the key material never has to be on the validator
+// host. The service is spoken to over HTTP with mutual TLS.
+package remotesigner
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	bls_core "github.com/nordicenergy/bls/ffi/go/bls"
+	"github.com/nordicenergy/nordicenergy-core/crypto/bls"
+	"github.com/nordicenergy/nordicenergy-core/multibls"
+	"github.com/pkg/errors"
+)
+
+const (
+	keysPath = "/v1/keys"
+	signPath = "/v1/sign"
+
+	// DefaultTimeout is the default timeout of a request to the signing service
+	DefaultTimeout = 2 * time.Second
+
+	maxResponseSize = 1 << 20
+)
+
+var (
+	errInvalidSignature = errors.New("remote signer returned an invalid signature")
+	errNoKeys           = errors.New("remote signer holds no bls keys")
+)
+
+// KeysResponse is the reply of the signing service to a keys request
+type KeysResponse struct {
+	PublicKeys []string `json:"public-keys"`
+}
+
+// SignRequest asks the signing service to sign a hash with a bls key
+type SignRequest struct {
+	PublicKey string `json:"public-key"`
+	Hash      string `json:"hash"`
+}
+
+// SignResponse is the reply of the signing service to a sign request
+type SignResponse struct {
+	Signature string `json:"signature"`
+}
+
+// ErrorResponse is the reply of the signing service to a failed request
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// Client is a bls.Signer backed by a remote signing service
+type Client struct {
+	url    string
+	client *http.Client
+}
+
+// NewClient returns a client of the signing service at url, authenticating
+// both ends of the connection with the given TLS config. A nil tlsConfig
+// connects without TLS, which should only be used for tests.
+func NewClient(url string, tlsConfig *TLSConfig, timeout time.Duration) (*Client, error) {
+	transport := &http.Transport{}
+	if tlsConfig != nil {
+		config, err := tlsConfig.clientConfig()
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = config
+	}
+	return newClient(url, &http.Client{Transport: transport, Timeout: timeout}), nil
+}
+
+func newClient(url string, client *http.Client) *Client {
+	return &Client{url: strings.TrimSuffix(url, "/"), client: client}
+}
+
+// PublicKeys returns the bls public keys held by the signing service
+func (c *Client) PublicKeys() ([]bls.SerializedPublicKey, error) {
+	resp, err := c.client.Get(c.url + keysPath)
+	if err != nil {
+		return nil, err
+	}
+	reply := KeysResponse{}
+	if err := decodeResponse(resp, &reply); err != nil {
+		return nil, err
+	}
+	keys := make([]bls.SerializedPublicKey, 0, len(reply.PublicKeys))
+	for _, hexKey := range reply.PublicKeys {
+		key, err := parsePublicKey(hexKey)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// PrivateKeys returns the keys held by the signing service as keys that sign
+// through c. The secret keys of the returned keys are nil.
+func (c *Client) PrivateKeys() (multibls.PrivateKeys, error) {
+	pubKeys, err := c.PublicKeys()
+	if err != nil {
+		return nil, err
+	}
+	if len(pubKeys) == 0 {
+		return nil, errNoKeys
+	}
+	keys := make(multibls.PrivateKeys, 0, len(pubKeys))
+	for _, pubKey := range pubKeys {
+		object, err := bls.BytesToBLSPublicKey(pubKey[:])
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, bls.PrivateKeyWrapper{
+			Pub:    &bls.PublicKeyWrapper{Bytes: pubKey, Object: object},
+			Signer: c,
+		})
+	}
+	return keys, nil
+}
+
+// SignHash signs hash with the bls key of pubKey on the signing service.
+// The returned signature is verified before it is handed back.
+func (c *Client) SignHash(pubKey bls.SerializedPublicKey, hash []byte) (*bls_core.Sign, error) {
+	body, err := json.Marshal(SignRequest{
+		PublicKey: pubKey.Hex(),
+		Hash:      hex.EncodeToString(hash),
+	})
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.client.Post(c.url+signPath, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	reply := SignResponse{}
+	if err := decodeResponse(resp, &reply); err != nil {
+		return nil, err
+	}
+
+	sig := &bls_core.Sign{}
+	if err := sig.DeserializeHexStr(reply.Signature); err != nil {
+		return nil, errors.Wrap(errInvalidSignature, err.Error())
+	}
+	object, err := bls.BytesToBLSPublicKey(pubKey[:])
+	if err != nil {
+		return nil, err
+	}
+	if !sig.VerifyHash(object, hash) {
+		return nil, errors.Wrapf(errInvalidSignature, "key %s", pubKey.Hex())
+	}
+	return sig, nil
+}
+
+func decodeResponse(resp *http.Response, reply interface{}) error {
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		errReply := ErrorResponse{}
+		if err := json.Unmarshal(body, &errReply); err == nil && errReply.Error != "" {
+			return fmt.Errorf("remote signer: %s", errReply.Error)
+		}
+		return fmt.Errorf("remote signer: unexpected status %s", resp.Status)
+	}
+	return json.Unmarshal(body, reply)
+}
+
+func parsePublicKey(hexKey string) (bls.SerializedPublicKey, error) {
+	key := bls.SerializedPublicKey{}
+	raw, err := hex.DecodeString(strings.TrimPrefix(hexKey, "0x"))
+	if err != nil {
+		return key, err
+	}
+	if len(raw) != len(key) {
+		return key, fmt.Errorf("invalid bls public key length %d", len(raw))
+	}
+	copy(key[:], raw)
+	return key, nil
+}
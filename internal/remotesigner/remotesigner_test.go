@@ -0,0 +1,54 @@
+package remotesigner
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nordicenergy/nordicenergy-core/crypto/bls"
+	"github.com/nordicenergy/nordicenergy-core/crypto/hash"
+	"github.com/pkg/errors"
+)
+
+func TestClient(t *testing.T) {
+	held, other := bls.RandPrivateKey(), bls.RandPrivateKey()
+	server := httptest.NewServer(NewHandler(LocalKeySigner{bls.NewLocalSigner(held)}))
+	defer server.Close()
+	client := newClient(server.URL, server.Client())
+
+	keys, err := client.PrivateKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 1 || !keys[0].Pub.Object.IsEqual(held.GetPublicKey()) || keys[0].Pri != nil {
+		t.Fatalf("unexpected keys %v", keys)
+	}
+
+	msgHash := hash.Keccak256([]byte("block"))
+	sig, err := keys[0].SignHash(msgHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sig.IsEqual(held.SignHash(msgHash)) {
+		t.Errorf("remote signature differs from local signature")
+	}
+
+	otherKey := bls.WrapperFromPrivateKey(other)
+	if _, err := client.SignHash(otherKey.Pub.Bytes, msgHash); err == nil {
+		t.Errorf("expected error signing with a key the service does not hold")
+	}
+}
+
+func TestClient_InvalidSignature(t *testing.T) {
+	held, other := bls.RandPrivateKey(), bls.RandPrivateKey()
+	heldKey := bls.WrapperFromPrivateKey(held)
+	// a misbehaving service signing with the wrong key
+	signer := LocalKeySigner{bls.LocalSigner{heldKey.Pub.Bytes: other}}
+	server := httptest.NewServer(NewHandler(signer))
+	defer server.Close()
+	client := newClient(server.URL, server.Client())
+
+	_, err := client.SignHash(heldKey.Pub.Bytes, hash.Keccak256([]byte("block")))
+	if errors.Cause(err) != errInvalidSignature {
+		t.Errorf("expected invalid signature error, got %v", err)
+	}
+}
@@ -0,0 +1,112 @@
+package remotesigner
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/nordicenergy/nordicenergy-core/crypto/bls"
+	"github.com/nordicenergy/nordicenergy-core/internal/utils"
+)
+
+const maxRequestSize = 1 << 16
+
+// KeySigner is the Signer served by the signing service, it also has to
+// tell which keys it holds
+type KeySigner interface {
+	bls.Signer
+	PublicKeys() []bls.SerializedPublicKey
+}
+
+// LocalKeySigner is a KeySigner holding its keys in memory
+type LocalKeySigner struct {
+	bls.LocalSigner
+}
+
+// PublicKeys ..
+func (s LocalKeySigner) PublicKeys() []bls.SerializedPublicKey {
+	keys := make([]bls.SerializedPublicKey, 0, len(s.LocalSigner))
+	for key := range s.LocalSigner {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].Hex() < keys[j].Hex()
+	})
+	return keys
+}
+
+// NewHandler returns the http handler of a signing service signing with signer
+func NewHandler(signer KeySigner) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(keysPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		reply := KeysResponse{PublicKeys: []string{}}
+		for _, key := range signer.PublicKeys() {
+			reply.PublicKeys = append(reply.PublicKeys, key.Hex())
+		}
+		writeJSON(w, http.StatusOK, reply)
+	})
+	mux.HandleFunc(signPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+		req := SignRequest{}
+		if err := json.NewDecoder(io.LimitReader(r.Body, maxRequestSize)).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		pubKey, err := parsePublicKey(req.PublicKey)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		hash, err := hex.DecodeString(strings.TrimPrefix(req.Hash, "0x"))
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		sig, err := signer.SignHash(pubKey, hash)
+		if err != nil {
+			utils.Logger().Warn().Err(err).
+				Str("key", pubKey.Hex()).
+				Msg("[RemoteSigner] could not sign")
+			writeError(w, http.StatusForbidden, err.Error())
+			return
+		}
+		writeJSON(w, http.StatusOK, SignResponse{Signature: sig.SerializeToHexStr()})
+	})
+	return mux
+}
+
+// NewServer returns the signing service listening on addr, requiring clients
+// to authenticate with a certificate signed by the CA of tlsConfig
+func NewServer(addr string, signer KeySigner, tlsConfig TLSConfig) (*http.Server, error) {
+	config, err := tlsConfig.serverConfig()
+	if err != nil {
+		return nil, err
+	}
+	return &http.Server{
+		Addr:      addr,
+		Handler:   NewHandler(signer),
+		TLSConfig: config,
+	}, nil
+}
+
+func writeJSON(w http.ResponseWriter, status int, reply interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(reply); err != nil {
+		utils.Logger().Warn().Err(err).Msg("[RemoteSigner] could not write reply")
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, msg string) {
+	writeJSON(w, status, ErrorResponse{Error: msg})
+}
@@ -0,0 +1,60 @@
+package remotesigner
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+)
+
+var errInvalidCA = errors.New("no certificate found in CA file")
+
+// TLSConfig is the certificate of one end of the connection to the signing
+// service, and the CA the certificate of the other end has to be signed by
+type TLSConfig struct {
+	CertFile string
+	KeyFile  string
+	CAFile   string
+}
+
+func (cfg TLSConfig) load() (tls.Certificate, *x509.CertPool, error) {
+	cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return tls.Certificate{}, nil, err
+	}
+	caPEM, err := ioutil.ReadFile(cfg.CAFile)
+	if err != nil {
+		return tls.Certificate{}, nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return tls.Certificate{}, nil, errors.Wrapf(errInvalidCA, "file %s", cfg.CAFile)
+	}
+	return cert, pool, nil
+}
+
+func (cfg TLSConfig) clientConfig() (*tls.Config, error) {
+	cert, pool, err := cfg.load()
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		RootCAs:      pool,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
+
+func (cfg TLSConfig) serverConfig() (*tls.Config, error) {
+	cert, pool, err := cfg.load()
+	if err != nil {
+		return nil, err
+	}
+	return &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	}, nil
+}
@@ -14,34 +14,60 @@ const (
 	Expired
 )
 
+// Clock is the source of time of a Timeout, so that timeouts can also run
+// on a simulated time
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+// SystemClock is the Clock of the wall time
+type SystemClock struct{}
+
+// Now ..
+func (SystemClock) Now() time.Time {
+	return time.Now()
+}
+
+// After ..
+func (SystemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
 // Timeout is the implementation of timeout
 type Timeout struct {
 	state TimeoutState
 	d     time.Duration
 	start time.Time
+	clock Clock
 }
 
 // NewTimeout creates a new timeout class
 func NewTimeout(d time.Duration) *Timeout {
-	timeout := Timeout{state: Inactive, d: d, start: time.Now()}
+	return NewTimeoutWithClock(d, SystemClock{})
+}
+
+// NewTimeoutWithClock creates a new timeout class measuring time with clock
+func NewTimeoutWithClock(d time.Duration, clock Clock) *Timeout {
+	timeout := Timeout{state: Inactive, d: d, start: clock.Now(), clock: clock}
 	return &timeout
 }
 
 // Start starts the timeout clock
 func (timeout *Timeout) Start() {
 	timeout.state = Active
-	timeout.start = time.Now()
+	timeout.start = timeout.clock.Now()
 }
 
 // Stop stops the timeout clock
 func (timeout *Timeout) Stop() {
 	timeout.state = Inactive
-	timeout.start = time.Now()
+	timeout.start = timeout.clock.Now()
 }
 
 // CheckExpire checks whether the timeout is reached/expired
 func (timeout *Timeout) CheckExpire() bool {
-	if timeout.state == Active && time.Since(timeout.start) > timeout.d {
+	if timeout.state == Active && timeout.clock.Now().Sub(timeout.start) > timeout.d {
 		timeout.state = Expired
 	}
 	if timeout.state == Expired {
@@ -29,3 +29,31 @@ func TestCheckExpire(t *testing.T) {
 	}
 
 }
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+	ch <- c.now.Add(d)
+	return ch
+}
+
+func TestCheckExpireWithClock(t *testing.T) {
+	clock := &fakeClock{now: time.Unix(1600000000, 0)}
+	timer := NewTimeoutWithClock(time.Second, clock)
+	timer.Start()
+	clock.now = clock.now.Add(time.Second)
+	if timer.CheckExpire() == true {
+		t.Fatalf("CheckExpire should be false")
+	}
+	clock.now = clock.now.Add(time.Millisecond)
+	if timer.CheckExpire() == false {
+		t.Fatalf("CheckExpire should be true")
+	}
+}
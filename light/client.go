@@ -0,0 +1,183 @@
+// Package light verifies the headers of all the shards, and the transactions
+// and receipts of their blocks, without running a node. A Client starts from
+// a trusted shard state and follows the committees of the following epochs
+// through the shard states of the last beacon chain header of each epoch,
+// checking every header by the commit signature of its committee.
+package light
+
+import (
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/nordicenergy/nordicenergy-core/block"
+	"github.com/nordicenergy/nordicenergy-core/consensus/quorum"
+	"github.com/nordicenergy/nordicenergy-core/consensus/signature"
+	"github.com/nordicenergy/nordicenergy-core/internal/chain"
+	"github.com/nordicenergy/nordicenergy-core/internal/params"
+	"github.com/nordicenergy/nordicenergy-core/internal/utils"
+	"github.com/nordicenergy/nordicenergy-core/multibls"
+	"github.com/nordicenergy/nordicenergy-core/shard"
+	"github.com/pkg/errors"
+)
+
+// DefaultKeptEpochs is the number of epochs a client keeps the committees of
+const DefaultKeptEpochs = 64
+
+var (
+	// ErrUnknownEpoch is returned for a header of an epoch the client does not
+	// know the committees of
+	ErrUnknownEpoch = errors.New("committees of the epoch are unknown")
+	// ErrNotEpochHeader is returned when following the committees with a
+	// header other than the last beacon chain header of an epoch
+	ErrNotEpochHeader = errors.New("not the last beacon chain header of an epoch")
+	// ErrNotChild is returned when the child of a header does not point to it
+	ErrNotChild = errors.New("parent hash of the child does not match the header")
+	// ErrNoQuorum is returned when the signers of a header are not a quorum
+	ErrNoQuorum = errors.New("not enough voting power in the commit signature")
+	// ErrInvalidSignature is returned when the commit signature of a header
+	// does not verify
+	ErrInvalidSignature = errors.New("invalid commit signature")
+)
+
+// Client keeps the committees of the last epochs and verifies headers with
+// them. It is safe for concurrent use.
+type Client struct {
+	config *params.ChainConfig
+	kept   int
+
+	lock   sync.RWMutex
+	states map[uint64]*shard.State
+	latest uint64
+}
+
+// NewClient returns a client trusting state as the committees of epoch, for
+// a chain of config
+func NewClient(config *params.ChainConfig, epoch *big.Int, state *shard.State) *Client {
+	return &Client{
+		config: config,
+		kept:   DefaultKeptEpochs,
+		states: map[uint64]*shard.State{epoch.Uint64(): state},
+		latest: epoch.Uint64(),
+	}
+}
+
+// Config returns the chain config of the client
+func (c *Client) Config() *params.ChainConfig {
+	return c.config
+}
+
+// LatestEpoch returns the latest epoch the client knows the committees of
+func (c *Client) LatestEpoch() *big.Int {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return new(big.Int).SetUint64(c.latest)
+}
+
+// Committee returns the committee of shardID at epoch
+func (c *Client) Committee(epoch *big.Int, shardID uint32) (*shard.Committee, error) {
+	c.lock.RLock()
+	state, ok := c.states[epoch.Uint64()]
+	c.lock.RUnlock()
+	if !ok {
+		return nil, errors.Wrapf(ErrUnknownEpoch, "epoch %d", epoch.Uint64())
+	}
+	return state.FindCommitteeByID(shardID)
+}
+
+// VerifyHeader checks that header is signed by a quorum of the committee of
+// its shard, with the commit signature and bitmap of the block
+func (c *Client) VerifyHeader(header *block.Header, commitSig, commitBitmap []byte) error {
+	committee, err := c.Committee(header.Epoch(), header.ShardID())
+	if err != nil {
+		return err
+	}
+	publicKeys, err := committee.BLSPublicKeys()
+	if err != nil {
+		return errors.Wrap(err, "cannot read public keys of the committee")
+	}
+	payload := append(commitSig[:len(commitSig):len(commitSig)], commitBitmap...)
+	aggSig, mask, err := chain.ReadSignatureBitmapByPublicKeys(payload, publicKeys)
+	if err != nil {
+		return errors.Wrap(err, "cannot read commit signature")
+	}
+
+	if epoch := header.Epoch(); c.config.IsStaking(epoch) {
+		decider := quorum.NewDecider(quorum.SuperMajorityStake, committee.ShardID)
+		decider.SetMyPublicKeyProvider(func() (multibls.PublicKeys, error) {
+			return nil, nil
+		})
+		if _, err := decider.SetVoters(committee, epoch); err != nil {
+			return err
+		}
+		if !decider.IsQuorumAchievedByMask(mask) {
+			return ErrNoQuorum
+		}
+	} else if count := utils.CountnetBits(mask.Bitmap); count < int64(len(committee.Slots)*2/3+1) {
+		return ErrNoQuorum
+	}
+
+	commitPayload := signature.ConstructCommitPayload(c,
+		header.Epoch(), header.Hash(), header.Number().Uint64(), header.ViewID().Uint64())
+	if !aggSig.VerifyHash(mask.AggregatePublic, commitPayload) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+// VerifyHeaderWithChild checks header with the commit signature its child
+// carries
+func (c *Client) VerifyHeaderWithChild(header, child *block.Header) error {
+	if child.ParentHash() != header.Hash() {
+		return ErrNotChild
+	}
+	sig := child.LastCommitSignature()
+	return c.VerifyHeader(header, sig[:], child.LastCommitBitmap())
+}
+
+// AddEpochHeader verifies the last beacon chain header of an epoch and makes
+// the shard state it carries the committees of the next epoch
+func (c *Client) AddEpochHeader(header *block.Header, commitSig, commitBitmap []byte) error {
+	if header.ShardID() != shard.BeaconChainShardID || !header.IsLastBlockInEpoch() {
+		return ErrNotEpochHeader
+	}
+	if err := c.VerifyHeader(header, commitSig, commitBitmap); err != nil {
+		return err
+	}
+	state, err := header.GetShardState()
+	if err != nil {
+		return errors.Wrap(err, "cannot decode shard state of the header")
+	}
+	next := header.Epoch().Uint64() + 1
+	// shard states from before staking do not carry their epoch
+	if state.Epoch != nil && state.Epoch.Uint64() != next {
+		return errors.Errorf(
+			"shard state of epoch %d in the last header of epoch %d",
+			state.Epoch.Uint64(), header.Epoch().Uint64(),
+		)
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.states[next] = &state
+	if next > c.latest {
+		c.latest = next
+	}
+	c.prune()
+	return nil
+}
+
+// prune forgets the committees of all but the last kept epochs
+func (c *Client) prune() {
+	if len(c.states) <= c.kept {
+		return
+	}
+	epochs := make([]uint64, 0, len(c.states))
+	for epoch := range c.states {
+		epochs = append(epochs, epoch)
+	}
+	sort.Slice(epochs, func(i, j int) bool { return epochs[i] < epochs[j] })
+	for _, epoch := range epochs[:len(epochs)-c.kept] {
+		delete(c.states, epoch)
+	}
+}
@@ -0,0 +1,173 @@
+package light
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	bls_core "github.com/nordicenergy/bls/ffi/go/bls"
+	"github.com/nordicenergy/nordicenergy-core/block"
+	blockfactory "github.com/nordicenergy/nordicenergy-core/block/factory"
+	"github.com/nordicenergy/nordicenergy-core/consensus/signature"
+	"github.com/nordicenergy/nordicenergy-core/crypto/bls"
+	"github.com/nordicenergy/nordicenergy-core/internal/params"
+	"github.com/nordicenergy/nordicenergy-core/numeric"
+	"github.com/nordicenergy/nordicenergy-core/shard"
+	"github.com/pkg/errors"
+)
+
+const committeeSize = 4
+
+// newState returns the shard state of epoch for two shards, and the keys of
+// their committee members
+func newState(t *testing.T, epoch int64) (*shard.State, []*bls_core.SecretKey) {
+	keys := make([]*bls_core.SecretKey, 2*committeeSize)
+	state := &shard.State{Epoch: big.NewInt(epoch)}
+	for shardID := 0; shardID < 2; shardID++ {
+		committee := shard.Committee{ShardID: uint32(shardID)}
+		for i := 0; i < committeeSize; i++ {
+			key := bls.RandPrivateKey()
+			keys[shardID*committeeSize+i] = key
+			stake := numeric.NewDec(100)
+			slot := shard.Slot{EffectiveStake: &stake}
+			if err := slot.BLSPublicKey.FromLibBLSPublicKey(key.GetPublicKey()); err != nil {
+				t.Fatal(err)
+			}
+			committee.Slots = append(committee.Slots, slot)
+		}
+		state.Shards = append(state.Shards, committee)
+	}
+	return state, keys
+}
+
+func newHeader(epoch, number int64, shardID uint32) *block.Header {
+	return blockfactory.ForTest.NewHeader(big.NewInt(epoch)).With().
+		Number(big.NewInt(number)).
+		ViewID(big.NewInt(number)).
+		ShardID(shardID).
+		Header()
+}
+
+// testChainReader provides the chain config the commit payloads are built with
+type testChainReader struct{}
+
+func (testChainReader) Config() *params.ChainConfig { return params.TestChainConfig }
+
+// sign returns the commit signature and bitmap of header by the signers of
+// its committee
+func sign(
+	t *testing.T, header *block.Header, state *shard.State, keys []*bls_core.SecretKey, signers []int,
+) ([]byte, []byte) {
+	committee, err := state.FindCommitteeByID(header.ShardID())
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicKeys, err := committee.BLSPublicKeys()
+	if err != nil {
+		t.Fatal(err)
+	}
+	mask, err := bls.NewMask(publicKeys, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload := signature.ConstructCommitPayload(testChainReader{},
+		header.Epoch(), header.Hash(), header.Number().Uint64(), header.ViewID().Uint64())
+	var sig bls_core.Sign
+	for _, i := range signers {
+		key := keys[int(header.ShardID())*committeeSize+i]
+		sig.Add(key.SignHash(payload))
+		if err := mask.SetKey(publicKeys[i].Bytes, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return sig.Serialize(), mask.Bitmap
+}
+
+func TestVerifyHeader(t *testing.T) {
+	state, keys := newState(t, 1)
+	client := NewClient(params.TestChainConfig, big.NewInt(1), state)
+	all := []int{0, 1, 2, 3}
+
+	header := newHeader(1, 10, 1)
+	sig, bitmap := sign(t, header, state, keys, all)
+	if err := client.VerifyHeader(header, sig, bitmap); err != nil {
+		t.Errorf("header signed by the committee: %v", err)
+	}
+
+	sig, bitmap = sign(t, header, state, keys, []int{0})
+	if err := client.VerifyHeader(header, sig, bitmap); err != ErrNoQuorum {
+		t.Errorf("header signed by one member: %v, expected %v", err, ErrNoQuorum)
+	}
+
+	other := newHeader(1, 11, 1)
+	sig, bitmap = sign(t, other, state, keys, all)
+	if err := client.VerifyHeader(header, sig, bitmap); err != ErrInvalidSignature {
+		t.Errorf("header signed for another one: %v, expected %v", err, ErrInvalidSignature)
+	}
+
+	// the signature of a header is carried by its child
+	child := blockfactory.ForTest.NewHeader(big.NewInt(1)).With().
+		Number(big.NewInt(11)).
+		ShardID(1).
+		ParentHash(header.Hash()).
+		Header()
+	sig, bitmap = sign(t, header, state, keys, all)
+	var lastCommitSig [96]byte
+	copy(lastCommitSig[:], sig)
+	child.SetLastCommitSignature(lastCommitSig)
+	child.SetLastCommitBitmap(bitmap)
+	if err := client.VerifyHeaderWithChild(header, child); err != nil {
+		t.Errorf("header with its child: %v", err)
+	}
+	child.SetParentHash(common.Hash{})
+	if err := client.VerifyHeaderWithChild(header, child); err != ErrNotChild {
+		t.Errorf("header with another child: %v, expected %v", err, ErrNotChild)
+	}
+
+	header = newHeader(2, 20, 1)
+	sig, bitmap = sign(t, header, state, keys, all)
+	if err := client.VerifyHeader(header, sig, bitmap); errors.Cause(err) != ErrUnknownEpoch {
+		t.Errorf("header of an unknown epoch: %v, expected %v", err, ErrUnknownEpoch)
+	}
+}
+
+func TestAddEpochHeader(t *testing.T) {
+	state, keys := newState(t, 1)
+	nextState, nextKeys := newState(t, 2)
+	client := NewClient(params.TestChainConfig, big.NewInt(1), state)
+	all := []int{0, 1, 2, 3}
+
+	header := newHeader(1, 10, 0)
+	sig, bitmap := sign(t, header, state, keys, all)
+	if err := client.AddEpochHeader(header, sig, bitmap); err != ErrNotEpochHeader {
+		t.Errorf("header without shard state: %v, expected %v", err, ErrNotEpochHeader)
+	}
+
+	encoded, err := shard.EncodeWrapper(*nextState, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	header.SetShardState(encoded)
+	sig, bitmap = sign(t, header, state, keys, []int{0})
+	if err := client.AddEpochHeader(header, sig, bitmap); err != ErrNoQuorum {
+		t.Errorf("epoch header signed by one member: %v, expected %v", err, ErrNoQuorum)
+	}
+	sig, bitmap = sign(t, header, state, keys, all)
+	if err := client.AddEpochHeader(header, sig, bitmap); err != nil {
+		t.Fatalf("epoch header signed by the committee: %v", err)
+	}
+	if epoch := client.LatestEpoch(); epoch.Cmp(big.NewInt(2)) != 0 {
+		t.Errorf("latest epoch %v, expected 2", epoch)
+	}
+
+	// headers of the next epoch are signed by the next committee
+	header = newHeader(2, 20, 1)
+	sig, bitmap = sign(t, header, nextState, nextKeys, all)
+	if err := client.VerifyHeader(header, sig, bitmap); err != nil {
+		t.Errorf("header signed by the next committee: %v", err)
+	}
+	sig, bitmap = sign(t, header, state, keys, all)
+	if err := client.VerifyHeader(header, sig, bitmap); err != ErrInvalidSignature {
+		t.Errorf("header signed by the previous committee: %v, expected %v", err, ErrInvalidSignature)
+	}
+}
@@ -0,0 +1,206 @@
+package light
+
+import (
+	"bytes"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/nordicenergy/nordicenergy-core/block"
+	"github.com/nordicenergy/nordicenergy-core/core/state"
+	"github.com/nordicenergy/nordicenergy-core/core/types"
+	staking "github.com/nordicenergy/nordicenergy-core/staking/types"
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrRootMismatch is returned when a list does not hash to the root of
+	// the header
+	ErrRootMismatch = errors.New("root of the list does not match the header")
+	// ErrIndexOutOfRange is returned when proving an index past the list
+	ErrIndexOutOfRange = errors.New("index out of range")
+)
+
+// Proof is the list of the trie nodes on the path from the root of a header
+// to an item of a block
+type Proof [][]byte
+
+// Put implements ethdb.KeyValueWriter, for the trie to prove into
+func (p *Proof) Put(key []byte, value []byte) error {
+	*p = append(*p, common.CopyBytes(value))
+	return nil
+}
+
+// Delete implements ethdb.KeyValueWriter
+func (p *Proof) Delete(key []byte) error {
+	return errors.New("cannot delete from a proof")
+}
+
+// VerifyTransactions checks that txs and stakingTxs are the transactions of
+// the block of header
+func VerifyTransactions(
+	header *block.Header, txs types.Transactions, stakingTxs staking.StakingTransactions,
+) error {
+	root := types.EmptyRootHash
+	if len(txs) > 0 || len(stakingTxs) > 0 {
+		root = types.DeriveSha(txs, stakingTxs)
+	}
+	if root != header.TxHash() {
+		return ErrRootMismatch
+	}
+	return nil
+}
+
+// VerifyReceipts checks that receipts are the receipts of the block of header
+func VerifyReceipts(header *block.Header, receipts types.Receipts) error {
+	root := types.EmptyRootHash
+	if len(receipts) > 0 {
+		root = types.DeriveSha(receipts)
+	}
+	if root != header.ReceiptHash() {
+		return ErrRootMismatch
+	}
+	return nil
+}
+
+// ProveTransaction returns the proof of the index-th transaction of a block,
+// the staking transactions following the plain ones
+func ProveTransaction(
+	txs types.Transactions, stakingTxs staking.StakingTransactions, index uint64,
+) (Proof, error) {
+	return prove(index, txs, stakingTxs)
+}
+
+// ProveReceipt returns the proof of the index-th receipt of a block
+func ProveReceipt(receipts types.Receipts, index uint64) (Proof, error) {
+	return prove(index, receipts)
+}
+
+// VerifyTransactionProof returns the index-th transaction of the block of
+// header, proven by proof. It fails for a staking transaction, see
+// VerifyStakingTransactionProof.
+func VerifyTransactionProof(
+	header *block.Header, index uint64, proof Proof,
+) (*types.Transaction, error) {
+	value, err := verifyProof(header.TxHash(), index, proof)
+	if err != nil {
+		return nil, err
+	}
+	tx := new(types.Transaction)
+	if err := rlp.DecodeBytes(value, tx); err != nil {
+		return nil, errors.Wrap(err, "cannot decode transaction")
+	}
+	return tx, nil
+}
+
+// VerifyStakingTransactionProof returns the index-th transaction of the block
+// of header, a staking transaction, proven by proof
+func VerifyStakingTransactionProof(
+	header *block.Header, index uint64, proof Proof,
+) (*staking.StakingTransaction, error) {
+	value, err := verifyProof(header.TxHash(), index, proof)
+	if err != nil {
+		return nil, err
+	}
+	tx := new(staking.StakingTransaction)
+	if err := rlp.DecodeBytes(value, tx); err != nil {
+		return nil, errors.Wrap(err, "cannot decode staking transaction")
+	}
+	return tx, nil
+}
+
+// VerifyReceiptProof returns the index-th receipt of the block of header,
+// proven by proof
+func VerifyReceiptProof(header *block.Header, index uint64, proof Proof) (*types.Receipt, error) {
+	value, err := verifyProof(header.ReceiptHash(), index, proof)
+	if err != nil {
+		return nil, err
+	}
+	receipt := new(types.Receipt)
+	if err := rlp.DecodeBytes(value, receipt); err != nil {
+		return nil, errors.Wrap(err, "cannot decode receipt")
+	}
+	return receipt, nil
+}
+
+// prove builds the trie of lists the way types.DeriveSha does and proves its
+// index-th item
+func prove(index uint64, lists ...types.DerivableBase) (Proof, error) {
+	keybuf := new(bytes.Buffer)
+	t := new(trie.Trie)
+	num := uint64(0)
+	for _, list := range lists {
+		for i := 0; i < list.Len(); i++ {
+			keybuf.Reset()
+			rlp.Encode(keybuf, uint(num))
+			t.Update(keybuf.Bytes(), list.GetRlp(i))
+			num++
+		}
+	}
+	if index >= num {
+		return nil, ErrIndexOutOfRange
+	}
+	key, err := rlp.EncodeToBytes(uint(index))
+	if err != nil {
+		return nil, err
+	}
+	proof := Proof{}
+	if err := t.Prove(key, 0, &proof); err != nil {
+		return nil, err
+	}
+	return proof, nil
+}
+
+// VerifyAccountProof returns the account of address in the state of header,
+// proven by proof, the proof of core/state.DB.GetProof. It returns nil if
+// proof proves that there is no such account.
+func VerifyAccountProof(
+	header *block.Header, address common.Address, proof Proof,
+) (*state.Account, error) {
+	value, err := verifyTrieProof(header.Root(), crypto.Keccak256(address.Bytes()), proof)
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return nil, nil
+	}
+	account := new(state.Account)
+	if err := rlp.DecodeBytes(value, account); err != nil {
+		return nil, errors.Wrap(err, "cannot decode account")
+	}
+	return account, nil
+}
+
+// verifyProof returns the index-th item of the trie of root, proven by proof
+func verifyProof(root common.Hash, index uint64, proof Proof) ([]byte, error) {
+	key, err := rlp.EncodeToBytes(uint(index))
+	if err != nil {
+		return nil, err
+	}
+	value, err := verifyTrieProof(root, key, proof)
+	if err != nil {
+		return nil, err
+	}
+	if value == nil {
+		return nil, errors.Errorf("no item %d in the block", index)
+	}
+	return value, nil
+}
+
+// verifyTrieProof returns the value of key in the trie of root, proven by
+// proof, nil if proof proves there is none
+func verifyTrieProof(root common.Hash, key []byte, proof Proof) ([]byte, error) {
+	db := memorydb.New()
+	for _, node := range proof {
+		if err := db.Put(crypto.Keccak256(node), node); err != nil {
+			return nil, err
+		}
+	}
+	value, _, err := trie.VerifyProof(root, key, db)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid proof")
+	}
+	return value, nil
+}
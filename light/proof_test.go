@@ -0,0 +1,73 @@
+package light
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	blockfactory "github.com/nordicenergy/nordicenergy-core/block/factory"
+	"github.com/nordicenergy/nordicenergy-core/core/types"
+	staking "github.com/nordicenergy/nordicenergy-core/staking/types"
+)
+
+func TestProofs(t *testing.T) {
+	txs := types.Transactions{}
+	receipts := types.Receipts{}
+	for i := 0; i < 20; i++ {
+		txs = append(txs, types.NewTransaction(
+			uint64(i), common.BigToAddress(big.NewInt(int64(i))), 0,
+			big.NewInt(int64(i)), 21000, big.NewInt(1), nil,
+		))
+		receipts = append(receipts, types.NewReceipt(nil, i%3 == 0, uint64(21000*(i+1))))
+	}
+	header := blockfactory.NewTestHeader().With().
+		TxHash(types.DeriveSha(txs, staking.StakingTransactions{})).
+		ReceiptHash(types.DeriveSha(receipts)).
+		Header()
+
+	if err := VerifyTransactions(header, txs, nil); err != nil {
+		t.Errorf("transactions of the block: %v", err)
+	}
+	if err := VerifyTransactions(header, txs[1:], nil); err != ErrRootMismatch {
+		t.Errorf("transactions missing one: %v, expected %v", err, ErrRootMismatch)
+	}
+	if err := VerifyReceipts(header, receipts); err != nil {
+		t.Errorf("receipts of the block: %v", err)
+	}
+	if err := VerifyReceipts(header, receipts[:1]); err != ErrRootMismatch {
+		t.Errorf("receipts missing some: %v, expected %v", err, ErrRootMismatch)
+	}
+
+	for _, i := range []uint64{0, 1, 7, 19} {
+		proof, err := ProveTransaction(txs, nil, i)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tx, err := VerifyTransactionProof(header, i, proof)
+		if err != nil {
+			t.Errorf("proof of transaction %d: %v", i, err)
+		} else if tx.Hash() != txs[i].Hash() {
+			t.Errorf("proof of transaction %d gave %v, expected %v", i, tx.Hash(), txs[i].Hash())
+		}
+		// a proof only proves its own index
+		if _, err := VerifyTransactionProof(header, (i+1)%20, proof); err == nil {
+			t.Errorf("proof of transaction %d verified for another one", i)
+		}
+
+		proof, err = ProveReceipt(receipts, i)
+		if err != nil {
+			t.Fatal(err)
+		}
+		receipt, err := VerifyReceiptProof(header, i, proof)
+		if err != nil {
+			t.Errorf("proof of receipt %d: %v", i, err)
+		} else if receipt.Status != receipts[i].Status ||
+			receipt.CumulativeGasUsed != receipts[i].CumulativeGasUsed {
+			t.Errorf("proof of receipt %d gave %+v, expected %+v", i, receipt, receipts[i])
+		}
+	}
+
+	if _, err := ProveReceipt(receipts, 20); err != ErrIndexOutOfRange {
+		t.Errorf("proof past the receipts: %v, expected %v", err, ErrIndexOutOfRange)
+	}
+}
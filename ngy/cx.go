@@ -0,0 +1,199 @@
+package ngy
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/nordicenergy/nordicenergy-core/core"
+	"github.com/nordicenergy/nordicenergy-core/core/rawdb"
+	"github.com/nordicenergy/nordicenergy-core/core/types"
+	"github.com/nordicenergy/nordicenergy-core/shard"
+	"github.com/pkg/errors"
+)
+
+// ErrNotCrossShard is returned for the lifecycle of a transaction within a shard
+var ErrNotCrossShard = errors.New("not a cross-shard transaction")
+
+// CxStage is a stage of the lifecycle of a cross-shard transaction
+type CxStage string
+
+// The stages of a cross-shard transaction, in order
+const (
+	// CxStageUnknown is a transaction this node knows nothing about, its
+	// shard being neither the source nor the destination of the transaction
+	CxStageUnknown CxStage = "unknown"
+	// CxStageSent is the transaction included in a block of the source shard
+	CxStageSent CxStage = "sent"
+	// CxStageProofBroadcast is the receipts proof of the source block sent by
+	// this node to the destination shard
+	CxStageProofBroadcast CxStage = "proof-broadcast"
+	// CxStageReceived is the receipts proof waiting in the pending pool of
+	// this node on the destination shard
+	CxStageReceived CxStage = "received"
+	// CxStageIncluded is the receipt included in a block of the destination
+	// shard not crosslinked to the beacon chain yet
+	CxStageIncluded CxStage = "included"
+	// CxStageSpent is the receipt credited for good on the destination
+	// shard, the block including it being a beacon chain block or
+	// crosslinked to the beacon chain
+	CxStageSpent CxStage = "spent"
+)
+
+// CxStatus is the lifecycle of a cross-shard transaction as seen by this node
+type CxStatus struct {
+	TxHash    common.Hash `json:"txHash"`
+	Stage     CxStage     `json:"stage"`
+	ShardID   uint32      `json:"shardID"`
+	ToShardID uint32      `json:"toShardID"`
+	// SourceBlockNumber and SourceBlockHash are the source block of the
+	// transaction, if known
+	SourceBlockNumber uint64      `json:"sourceBlockNumber"`
+	SourceBlockHash   common.Hash `json:"sourceBlockHash"`
+	// BroadcastTime is the unix time this node last broadcast the receipts
+	// proof of the source block, zero if it did not
+	BroadcastTime int64 `json:"broadcastTime"`
+	// ResendPending is whether the receipts are waiting to be resent
+	ResendPending bool `json:"resendPending"`
+	// DestinationBlockNumber and DestinationBlockHash are the block of the
+	// destination shard including the receipt, if it is
+	DestinationBlockNumber uint64      `json:"destinationBlockNumber"`
+	DestinationBlockHash   common.Hash `json:"destinationBlockHash"`
+	// Spent is whether the destination shard marked the receipts proof of
+	// the source block spent, if this node follows the destination shard
+	Spent bool `json:"spent"`
+	// Final is whether this node cannot see the status change any more,
+	// either because it reached the last stage or because the destination
+	// shard is not followed by this node and the proof is not resent
+	Final bool `json:"final"`
+}
+
+// GetCxStatus returns the lifecycle of the cross-shard transaction txHash,
+// from the source shard data if this node is on the source shard and from
+// the destination shard data if it is on the destination shard. A node on
+// the source shard follows the receipts to the beacon chain up to the spent
+// stage, the ones to other shards only up to the proof broadcast.
+func (ngy *nordicenergy) GetCxStatus(txHash common.Hash) (*CxStatus, error) {
+	status := &CxStatus{TxHash: txHash, Stage: CxStageUnknown, Final: true}
+
+	if blockHash, blockNum, index := ngy.BlockChain.ReadTxLookupEntry(txHash); blockHash != (common.Hash{}) {
+		blk := ngy.BlockChain.GetBlockByHash(blockHash)
+		if blk != nil && int(index) < len(blk.Transactions()) {
+			tx := blk.Transactions()[index]
+			if tx.ShardID() == tx.ToShardID() {
+				return nil, ErrNotCrossShard
+			}
+			status.Stage = CxStageSent
+			status.ShardID, status.ToShardID = tx.ShardID(), tx.ToShardID()
+			status.SourceBlockNumber, status.SourceBlockHash = blockNum, blockHash
+			if t := ngy.NodeAPI.CXReceiptsBroadcastTime(blockHash, tx.ToShardID()); t != 0 {
+				status.Stage = CxStageProofBroadcast
+				status.BroadcastTime = t
+			}
+			status.ResendPending = ngy.CxPool.Contains(core.CxEntry{
+				BlockHash: blockHash, ToShardID: tx.ToShardID(),
+			})
+			if tx.ToShardID() == shard.BeaconChainShardID {
+				beaconDB := ngy.BeaconChain.ChainDb()
+				if cx, destHash, destNum, _ := rawdb.ReadCXReceipt(beaconDB, txHash); cx != nil {
+					ngy.setCxDestination(status, ngy.BeaconChain, destHash, destNum)
+				}
+			}
+			status.Final = cxFinal(status)
+			return status, nil
+		}
+	}
+
+	if cx, blockHash, blockNum, _ := rawdb.ReadCXReceipt(ngy.chainDb, txHash); cx != nil {
+		status.ShardID, status.ToShardID = cx.ShardID, cx.ToShardID
+		if blk := ngy.BlockChain.GetBlockByHash(blockHash); blk != nil {
+			if cxp := findCXReceiptsProof(blk.IncomingReceipts(), txHash); cxp != nil {
+				setCxSource(status, cxp)
+			}
+		}
+		ngy.setCxDestination(status, ngy.BlockChain, blockHash, blockNum)
+		status.Final = cxFinal(status)
+		return status, nil
+	}
+
+	if cxp := findCXReceiptsProof(ngy.NodeAPI.PendingCXReceipts(), txHash); cxp != nil {
+		status.Stage = CxStageReceived
+		status.ShardID, status.ToShardID = cxp.MerkleProof.ShardID, ngy.ShardID
+		setCxSource(status, cxp)
+		status.Final = cxFinal(status)
+	}
+	return status, nil
+}
+
+// setCxDestination sets the destination block of status, its stage from the
+// last block of the destination shard crosslinked to the beacon chain and
+// whether it is spent from the spent marker of destChain, the destination
+// shard chain. The source block of status must be set first.
+func (ngy *nordicenergy) setCxDestination(
+	status *CxStatus, destChain *core.BlockChain, blockHash common.Hash, blockNum uint64,
+) {
+	status.DestinationBlockNumber, status.DestinationBlockHash = blockNum, blockHash
+	var lastCrossLink *types.CrossLink
+	if status.ToShardID != shard.BeaconChainShardID {
+		lastCrossLink, _ = ngy.BeaconChain.ReadShardLastCrossLink(status.ToShardID)
+	}
+	status.Stage = cxDestinationStage(status.ToShardID, blockNum, lastCrossLink)
+	if status.SourceBlockHash != (common.Hash{}) {
+		status.Spent = destChain.IsSpent(&types.CXReceiptsProof{
+			MerkleProof: &types.CXMerkleProof{
+				ShardID:  status.ShardID,
+				BlockNum: new(big.Int).SetUint64(status.SourceBlockNumber),
+			},
+		})
+	}
+}
+
+// cxDestinationStage returns the stage of a receipt included in the block
+// blockNum of the shard toShardID, whose last block crosslinked to the
+// beacon chain is lastCrossLink, nil if there is none
+func cxDestinationStage(
+	toShardID uint32, blockNum uint64, lastCrossLink *types.CrossLink,
+) CxStage {
+	if toShardID == shard.BeaconChainShardID {
+		return CxStageSpent
+	}
+	if lastCrossLink != nil && lastCrossLink.BlockNum() >= blockNum {
+		return CxStageSpent
+	}
+	return CxStageIncluded
+}
+
+// cxFinal returns whether the node having status cannot see it change any
+// more. A node on the source shard of receipts to a shard other than the
+// beacon chain sees them up to the proof broadcast, and the resend pending
+// until the proof is not re-broadcast any more.
+func cxFinal(status *CxStatus) bool {
+	switch status.Stage {
+	case CxStageUnknown, CxStageSpent:
+		return true
+	case CxStageProofBroadcast:
+		return status.ToShardID != shard.BeaconChainShardID && !status.ResendPending
+	}
+	return false
+}
+
+// setCxSource sets the source block of status from the receipts proof cxp
+// the destination shard received
+func setCxSource(status *CxStatus, cxp *types.CXReceiptsProof) {
+	status.SourceBlockNumber = cxp.MerkleProof.BlockNum.Uint64()
+	status.SourceBlockHash = cxp.MerkleProof.BlockHash
+}
+
+// findCXReceiptsProof returns the proof among cxps carrying the receipt of txHash
+func findCXReceiptsProof(cxps []*types.CXReceiptsProof, txHash common.Hash) *types.CXReceiptsProof {
+	for _, cxp := range cxps {
+		if cxp.ContainsEmptyField() {
+			continue
+		}
+		for _, cx := range cxp.Receipts {
+			if cx.TxHash == txHash {
+				return cxp
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package ngy
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	blockfactory "github.com/nordicenergy/nordicenergy-core/block/factory"
+	"github.com/nordicenergy/nordicenergy-core/core/types"
+	"github.com/nordicenergy/nordicenergy-core/shard"
+)
+
+func TestCxDestinationStage(t *testing.T) {
+	crossLink := func(blockNum int64) *types.CrossLink {
+		return &types.CrossLink{BlockNumberF: big.NewInt(blockNum), ShardIDF: 1}
+	}
+	tests := []struct {
+		toShardID     uint32
+		blockNum      uint64
+		lastCrossLink *types.CrossLink
+		exp           CxStage
+	}{
+		{shard.BeaconChainShardID, 10, nil, CxStageSpent},
+		{1, 10, nil, CxStageIncluded},
+		{1, 10, crossLink(9), CxStageIncluded},
+		{1, 10, crossLink(10), CxStageSpent},
+		{1, 10, crossLink(11), CxStageSpent},
+	}
+	for i, test := range tests {
+		if got := cxDestinationStage(test.toShardID, test.blockNum, test.lastCrossLink); got != test.exp {
+			t.Errorf("Test %v: unexpected stage %v / %v", i, got, test.exp)
+		}
+	}
+}
+
+func TestCxFinal(t *testing.T) {
+	tests := []struct {
+		stage         CxStage
+		toShardID     uint32
+		resendPending bool
+		exp           bool
+	}{
+		{CxStageUnknown, 0, false, true},
+		// the source shard follows the receipts to the beacon chain only
+		{CxStageSent, shard.BeaconChainShardID, false, false},
+		{CxStageProofBroadcast, shard.BeaconChainShardID, false, false},
+		// the proof is still to be broadcast by this node
+		{CxStageSent, 2, false, false},
+		{CxStageProofBroadcast, 2, true, false},
+		{CxStageProofBroadcast, 2, false, true},
+		{CxStageReceived, 2, false, false},
+		{CxStageIncluded, 2, false, false},
+		{CxStageSpent, 2, false, true},
+		{CxStageSpent, shard.BeaconChainShardID, false, true},
+	}
+	for i, test := range tests {
+		status := &CxStatus{
+			Stage: test.stage, ToShardID: test.toShardID, ResendPending: test.resendPending,
+		}
+		if got := cxFinal(status); got != test.exp {
+			t.Errorf("Test %v: unexpected final %v / %v", i, got, test.exp)
+		}
+	}
+}
+
+func TestFindCXReceiptsProof(t *testing.T) {
+	txHash := common.BytesToHash([]byte("tx"))
+	makeProof := func(hashes ...common.Hash) *types.CXReceiptsProof {
+		cxs := types.CXReceipts{}
+		for _, hash := range hashes {
+			cxs = append(cxs, &types.CXReceipt{TxHash: hash, Amount: big.NewInt(1)})
+		}
+		return &types.CXReceiptsProof{
+			Receipts:     cxs,
+			MerkleProof:  &types.CXMerkleProof{BlockNum: big.NewInt(1)},
+			Header:       blockfactory.NewTestHeader(),
+			CommitSig:    []byte{1},
+			CommitBitmap: []byte{1},
+		}
+	}
+	other := makeProof(common.BytesToHash([]byte("other")))
+	found := makeProof(common.BytesToHash([]byte("other")), txHash)
+	empty := makeProof(txHash)
+	empty.CommitSig, empty.CommitBitmap = nil, nil
+
+	if got := findCXReceiptsProof([]*types.CXReceiptsProof{other, empty, found}, txHash); got != found {
+		t.Errorf("unexpected proof %v", got)
+	}
+	if got := findCXReceiptsProof([]*types.CXReceiptsProof{other, empty}, txHash); got != nil {
+		t.Errorf("expected no proof, got %v", got)
+	}
+}
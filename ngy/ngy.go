@@ -11,7 +11,9 @@ import (
 	"github.com/ethereum/go-ethereum/event"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/nordicenergy/nordicenergy-core/api/proto"
+	"github.com/nordicenergy/nordicenergy-core/api/service/explorer"
 	"github.com/nordicenergy/nordicenergy-core/block"
+	"github.com/nordicenergy/nordicenergy-core/consensus"
 	"github.com/nordicenergy/nordicenergy-core/core"
 	"github.com/nordicenergy/nordicenergy-core/core/state"
 	"github.com/nordicenergy/nordicenergy-core/core/types"
@@ -19,6 +21,7 @@ import (
 	nodeconfig "github.com/nordicenergy/nordicenergy-core/internal/configs/node"
 	commonRPC "github.com/nordicenergy/nordicenergy-core/rpc/common"
 	"github.com/nordicenergy/nordicenergy-core/shard"
+	"github.com/nordicenergy/nordicenergy-core/staking/slash"
 	staking "github.com/nordicenergy/nordicenergy-core/staking/types"
 	lru "github.com/hashicorp/golang-lru"
 	"github.com/libp2p/go-libp2p-core/peer"
@@ -39,6 +42,8 @@ const (
 var (
 	// ErrFinalizedTransaction is returned if the transaction to be submitted is already on-chain
 	ErrFinalizedTransaction = errors.New("transaction already finalized")
+	// ErrSlashAlreadyPending is returned if the submitted double sign evidence is already pending
+	ErrSlashAlreadyPending = core.ErrSlashAlreadyPending
 )
 
 // nordicenergy implements the nordicenergy full node service.
@@ -82,16 +87,22 @@ type NodeAPI interface {
 	AddPendingTransaction(newTx *types.Transaction) error
 	Blockchain() *core.BlockChain
 	Beaconchain() *core.BlockChain
-	GetTransactionsHistory(address, txType, order string) ([]common.Hash, error)
-	GetStakingTransactionsHistory(address, txType, order string) ([]common.Hash, error)
+	GetTransactionsHistory(query explorer.TxHistoryQuery) ([]common.Hash, error)
+	GetStakingTransactionsHistory(query explorer.TxHistoryQuery) ([]common.Hash, error)
+	GetInternalTransactionsHistory(query explorer.TxHistoryQuery) ([]*explorer.InternalTx, error)
+	GetCxPayoutsHistory(query explorer.TxHistoryQuery) ([]common.Hash, error)
 	GetTransactionsCount(address, txType string) (uint64, error)
 	GetStakingTransactionsCount(address, txType string) (uint64, error)
+	GetTokenTransfers(query explorer.TokenTransferQuery) ([]*explorer.TokenTransfer, error)
+	GetTokenBalances(address string) ([]*explorer.TokenBalance, error)
+	GetTokenHolders(token string, pageIndex, pageSize uint32) ([]*explorer.TokenBalance, error)
 	IsCurrentlyLeader() bool
 	IsOutOfSync(*core.BlockChain) bool
 	GetMaxPeerHeight() uint64
 	ReportStakingErrorSink() types.TransactinetrrorReports
 	ReportPlainErrorSink() types.TransactinetrrorReports
 	PendingCXReceipts() []*types.CXReceiptsProof
+	CXReceiptsBroadcastTime(blockHash common.Hash, toShardID uint32) int64
 	GetNodeBootTime() int64
 	PeerConnectivity() (int, int, int)
 	ListPeer(topic string) []peer.ID
@@ -99,12 +110,15 @@ type NodeAPI interface {
 	ListBlockedPeer() []peer.ID
 
 	GetConsensusInternal() commonRPC.ConsensusInternal
+	GetCrossLinkStatus() []commonRPC.CrossLinkStatus
+	BroadcastSlash(witness *slash.Record)
 
 	// debug API
 	GetConsensusMode() string
 	GetConsensusPhase() string
 	GetConsensusViewChangingID() uint64
 	GetConsensusCurViewID() uint64
+	GetConsensusTimeline(count int) []consensus.RoundTimeline
 	ShutDown()
 }
 
@@ -165,6 +179,12 @@ func (ngy *nordicenergy) IsLeader() bool {
 	return ngy.NodeAPI.IsCurrentlyLeader()
 }
 
+// GetCrossLinkStatus returns the crosslinks of each shard received by the
+// beacon chain versus the head of the shard, with the gaps in them
+func (ngy *nordicenergy) GetCrossLinkStatus() []commonRPC.CrossLinkStatus {
+	return ngy.NodeAPI.GetCrossLinkStatus()
+}
+
 // GetNodeMetadata ..
 func (ngy *nordicenergy) GetNodeMetadata() commonRPC.NodeMetadata {
 	header := ngy.CurrentBlock().Header()
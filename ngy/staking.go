@@ -8,6 +8,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/nordicenergy/nordicenergy-core/api/service/explorer"
 	"github.com/nordicenergy/nordicenergy-core/block"
 	"github.com/nordicenergy/nordicenergy-core/consensus/quorum"
 	"github.com/nordicenergy/nordicenergy-core/core/rawdb"
@@ -20,6 +21,7 @@ import (
 	"github.com/nordicenergy/nordicenergy-core/shard/committee"
 	"github.com/nordicenergy/nordicenergy-core/staking/availability"
 	"github.com/nordicenergy/nordicenergy-core/staking/effective"
+	"github.com/nordicenergy/nordicenergy-core/staking/slash"
 	staking "github.com/nordicenergy/nordicenergy-core/staking/types"
 	"github.com/pkg/errors"
 )
@@ -158,9 +160,110 @@ func (ngy *nordicenergy) SendStakingTx(ctx context.Context, signedStakingTx *sta
 	return ErrFinalizedTransaction
 }
 
+// SubmitSlashingEvidence verifies the double sign record against the committee
+// of the evidence epoch, adds it to the pending slashes of the beacon chain
+// and broadcasts it to the beacon chain nodes, for the next leader to
+// include it whichever node received it
+func (ngy *nordicenergy) SubmitSlashingEvidence(record slash.Record) error {
+	if err := ngy.BeaconChain.AddPendingSlashingCandidate(record); err != nil {
+		return err
+	}
+	ngy.NodeAPI.BroadcastSlash(&record)
+	return nil
+}
+
+// GetSlashHistory returns the slashes applied in the epochs from fromEpoch to toEpoch
+func (ngy *nordicenergy) GetSlashHistory(fromEpoch, toEpoch uint64) ([]slash.HistoryRecord, error) {
+	history := []slash.HistoryRecord{}
+	for epoch := fromEpoch; epoch <= toEpoch; epoch++ {
+		applied, err := ngy.BeaconChain.ReadSlashHistory(new(big.Int).SetUint64(epoch))
+		if err != nil {
+			return nil, err
+		}
+		history = append(history, applied...)
+	}
+	return history, nil
+}
+
+// GetSlashHistoryByValidator returns the slashes applied to the validator
+func (ngy *nordicenergy) GetSlashHistoryByValidator(addr common.Address) ([]slash.HistoryRecord, error) {
+	return ngy.getSlashHistoryByAddress(addr, func(h *slash.HistoryRecord) bool {
+		return h.Record.Evidence.Offender == addr
+	})
+}
+
+// GetSlashHistoryByDelegator returns the slashes that charged the delegator
+func (ngy *nordicenergy) GetSlashHistoryByDelegator(addr common.Address) ([]slash.HistoryRecord, error) {
+	return ngy.getSlashHistoryByAddress(addr, func(h *slash.HistoryRecord) bool {
+		for i := range h.Losses {
+			if h.Losses[i].Delegator == addr {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+func (ngy *nordicenergy) getSlashHistoryByAddress(
+	addr common.Address, match func(*slash.HistoryRecord) bool,
+) ([]slash.HistoryRecord, error) {
+	epochs, err := ngy.BeaconChain.ReadSlashEpochsByAddress(addr)
+	if err != nil {
+		return nil, err
+	}
+	history := []slash.HistoryRecord{}
+	for _, epoch := range epochs {
+		applied, err := ngy.BeaconChain.ReadSlashHistory(new(big.Int).SetUint64(epoch))
+		if err != nil {
+			return nil, err
+		}
+		for i := range applied {
+			if match(&applied[i]) {
+				history = append(history, applied[i])
+			}
+		}
+	}
+	return history, nil
+}
+
+// DelegatorEpochEarnings is what a delegator earned from its delegations in an epoch
+type DelegatorEpochEarnings struct {
+	Epoch    uint64
+	Earnings staking.DelegationEarnings
+}
+
+// GetDelegatorEarningHistory returns the rewards the delegator earned in every
+// epoch it earned any, in increasing epoch order
+func (ngy *nordicenergy) GetDelegatorEarningHistory(
+	addr common.Address,
+) ([]DelegatorEpochEarnings, error) {
+	epochs, err := ngy.BeaconChain.ReadDelegatorEarningEpochs(addr)
+	if err != nil {
+		return nil, err
+	}
+	history := make([]DelegatorEpochEarnings, 0, len(epochs))
+	for _, epoch := range epochs {
+		earnings, err := ngy.BeaconChain.ReadDelegatorEarnings(
+			addr, new(big.Int).SetUint64(epoch),
+		)
+		if err != nil {
+			return nil, err
+		}
+		history = append(history, DelegatorEpochEarnings{epoch, earnings})
+	}
+	return history, nil
+}
+
+// GetDelegatorLifetimeEarnings returns the rewards the delegator earned since staking
+func (ngy *nordicenergy) GetDelegatorLifetimeEarnings(
+	addr common.Address,
+) (staking.DelegationEarnings, error) {
+	return ngy.BeaconChain.ReadDelegatorLifetimeEarnings(addr)
+}
+
 // GetStakingTransactionsHistory returns list of staking transactions hashes of address.
-func (ngy *nordicenergy) GetStakingTransactionsHistory(address, txType, order string) ([]common.Hash, error) {
-	return ngy.NodeAPI.GetStakingTransactionsHistory(address, txType, order)
+func (ngy *nordicenergy) GetStakingTransactionsHistory(query explorer.TxHistoryQuery) ([]common.Hash, error) {
+	return ngy.NodeAPI.GetStakingTransactionsHistory(query)
 }
 
 // GetStakingTransactionsCount returns the number of staking transactions of address.
@@ -304,6 +407,9 @@ func (ngy *nordicenergy) GetValidatorInformation(
 			Signing:     wrapper.Counters,
 			APR:         zero,
 		},
+		PendingCommission: wrapper.PendingCommissionChange(now),
+		CommissionHistory: wrapper.CommissionHistory(now),
+		JailedUntil:       wrapper.JailedUntil,
 	}
 
 	snapshot, err := bc.ReadValidatorSnapshotAtEpoch(
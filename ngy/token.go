@@ -0,0 +1,20 @@
+package ngy
+
+import (
+	"github.com/nordicenergy/nordicenergy-core/api/service/explorer"
+)
+
+// GetTokenTransfers returns the token transfers of the query.
+func (ngy *nordicenergy) GetTokenTransfers(query explorer.TokenTransferQuery) ([]*explorer.TokenTransfer, error) {
+	return ngy.NodeAPI.GetTokenTransfers(query)
+}
+
+// GetTokenBalances returns the balances of the tokens held by address.
+func (ngy *nordicenergy) GetTokenBalances(address string) ([]*explorer.TokenBalance, error) {
+	return ngy.NodeAPI.GetTokenBalances(address)
+}
+
+// GetTokenHolders returns the balances of the holders of token.
+func (ngy *nordicenergy) GetTokenHolders(token string, pageIndex, pageSize uint32) ([]*explorer.TokenBalance, error) {
+	return ngy.NodeAPI.GetTokenHolders(token, pageIndex, pageSize)
+}
@@ -0,0 +1,153 @@
+package tracers
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/nordicenergy/nordicenergy-core/core/vm"
+)
+
+// Types of the internal transactions
+const (
+	InternalTxCall         = "call"
+	InternalTxCreate       = "create"
+	InternalTxSelfDestruct = "selfdestruct"
+)
+
+// InternalTx is a value transfer made by a contract during the execution of a
+// transaction
+type InternalTx struct {
+	Type  string
+	From  common.Address
+	To    common.Address
+	Value *big.Int
+	// Depth is the call depth of the transfer, 1 for the calls of the
+	// contract called by the transaction
+	Depth int
+}
+
+// internalCall is a call made by a contract whose result is not known yet,
+// with the transfers made by the callee
+type internalCall struct {
+	tx    *InternalTx
+	depth int
+	txs   []*InternalTx
+}
+
+// InternalTxTracer is a native vm.Tracer recording the value transfers made
+// by contracts. The transfers of the calls reverted, and all of them if the
+// transaction fails, are dropped.
+type InternalTxTracer struct {
+	calls []*internalCall
+	txs   []*InternalTx
+	err   error
+}
+
+// NewInternalTxTracer returns a tracer of the internal transactions of a
+// transaction
+func NewInternalTxTracer() *InternalTxTracer {
+	return &InternalTxTracer{}
+}
+
+// CaptureStart implements the vm.Tracer interface
+func (t *InternalTxTracer) CaptureStart(from common.Address, to common.Address, create bool, input []byte, gas uint64, value *big.Int) error {
+	return nil
+}
+
+// CaptureState implements the vm.Tracer interface to record the transfers of
+// the calls, creations and self-destructs executed
+func (t *InternalTxTracer) CaptureState(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) error {
+	if err != nil {
+		// the frame fails, its caller finds out
+		return nil
+	}
+	// the calls made at depth have returned, with their result on the stack
+	for len(t.calls) > 0 && t.calls[len(t.calls)-1].depth >= depth {
+		call := t.calls[len(t.calls)-1]
+		t.calls = t.calls[:len(t.calls)-1]
+		if call.depth > depth {
+			// the caller failed before running any other operation
+			continue
+		}
+		result := stack.Back(0)
+		if result.Sign() == 0 {
+			// the call failed
+			continue
+		}
+		if call.tx.Type == InternalTxCreate {
+			call.tx.To = common.BigToAddress(result)
+		}
+		t.record(append([]*InternalTx{call.tx}, call.txs...)...)
+	}
+
+	switch op {
+	case vm.CALL:
+		if value := stack.Back(2); value.Sign() > 0 {
+			t.calls = append(t.calls, &internalCall{
+				tx: &InternalTx{
+					Type:  InternalTxCall,
+					From:  contract.Address(),
+					To:    common.BigToAddress(stack.Back(1)),
+					Value: new(big.Int).Set(value),
+					Depth: depth,
+				},
+				depth: depth,
+			})
+		}
+	case vm.CREATE, vm.CREATE2:
+		if value := stack.Back(0); value.Sign() > 0 {
+			t.calls = append(t.calls, &internalCall{
+				tx: &InternalTx{
+					Type:  InternalTxCreate,
+					From:  contract.Address(),
+					Value: new(big.Int).Set(value),
+					Depth: depth,
+				},
+				depth: depth,
+			})
+		}
+	case vm.SELFDESTRUCT:
+		if value := env.StateDB.GetBalance(contract.Address()); value.Sign() > 0 {
+			t.record(&InternalTx{
+				Type:  InternalTxSelfDestruct,
+				From:  contract.Address(),
+				To:    common.BigToAddress(stack.Back(0)),
+				Value: new(big.Int).Set(value),
+				Depth: depth,
+			})
+		}
+	}
+	return nil
+}
+
+// record adds txs to the transfers of the pending call made last, or to the
+// ones of the transaction if there is none
+func (t *InternalTxTracer) record(txs ...*InternalTx) {
+	if len(t.calls) > 0 {
+		call := t.calls[len(t.calls)-1]
+		call.txs = append(call.txs, txs...)
+		return
+	}
+	t.txs = append(t.txs, txs...)
+}
+
+// CaptureFault implements the vm.Tracer interface
+func (t *InternalTxTracer) CaptureFault(env *vm.EVM, pc uint64, op vm.OpCode, gas, cost uint64, memory *vm.Memory, stack *vm.Stack, contract *vm.Contract, depth int, err error) error {
+	return nil
+}
+
+// CaptureEnd implements the vm.Tracer interface
+func (t *InternalTxTracer) CaptureEnd(output []byte, gasUsed uint64, _ time.Duration, err error) error {
+	t.err = err
+	return nil
+}
+
+// InternalTxs returns the value transfers made by contracts, none if the
+// transaction failed
+func (t *InternalTxTracer) InternalTxs() []*InternalTx {
+	if t.err != nil {
+		return nil
+	}
+	return t.txs
+}
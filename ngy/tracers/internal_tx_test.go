@@ -0,0 +1,108 @@
+package tracers
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/nordicenergy/nordicenergy-core/core/state"
+	"github.com/nordicenergy/nordicenergy-core/core/vm"
+	"github.com/nordicenergy/nordicenergy-core/core/vm/runtime"
+)
+
+var (
+	// testContract is the address runtime.Execute runs its code at
+	testContract = common.BytesToAddress([]byte("contract"))
+	testCallee   = common.BytesToAddress([]byte{0x0b})
+	testReceiver = common.BytesToAddress([]byte{0x0c})
+)
+
+// callCode is the code calling to with value, the result popped
+func callCode(to common.Address, value byte) []byte {
+	// retLength, retOffset, argsLength, argsOffset, value
+	code := []byte{0x60, 0, 0x60, 0, 0x60, 0, 0x60, 0, 0x60, value}
+	code = append(append(code, byte(vm.PUSH20)), to.Bytes()...)
+	return append(code, byte(vm.GAS), byte(vm.CALL), byte(vm.POP))
+}
+
+// revertCode is the code reverting the frame
+var revertCode = []byte{0x60, 0, 0x60, 0, byte(vm.REVERT)}
+
+// traceInternalTxs runs code at testContract, holding a balance, with the
+// callee code and returns the internal transactions traced
+func traceInternalTxs(t *testing.T, code, calleeCode []byte) ([]*InternalTx, error) {
+	db, err := state.New(common.Hash{}, state.NewDatabase(rawdb.NewMemoryDatabase()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.AddBalance(testContract, big.NewInt(100))
+	db.SetCode(testCallee, calleeCode)
+	tracer := NewInternalTxTracer()
+	_, _, err = runtime.Execute(code, nil, &runtime.Config{
+		State:     db,
+		EVMConfig: vm.Config{Debug: true, Tracer: tracer},
+	})
+	return tracer.InternalTxs(), err
+}
+
+func TestInternalTxTracerNestedCalls(t *testing.T) {
+	code := append(callCode(testCallee, 10), byte(vm.STOP))
+	calleeCode := append(callCode(testReceiver, 3), byte(vm.STOP))
+	txs, err := traceInternalTxs(t, code, calleeCode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []*InternalTx{
+		{Type: InternalTxCall, From: testContract, To: testCallee, Value: big.NewInt(10), Depth: 1},
+		{Type: InternalTxCall, From: testCallee, To: testReceiver, Value: big.NewInt(3), Depth: 2},
+	}
+	if !reflect.DeepEqual(txs, expected) {
+		t.Errorf("traced %v, expected %v", txs, expected)
+	}
+}
+
+func TestInternalTxTracerRevertedCall(t *testing.T) {
+	// the callee reverts after its own transfer, both are dropped
+	code := append(callCode(testCallee, 10), callCode(testReceiver, 4)...)
+	code = append(code, byte(vm.STOP))
+	calleeCode := append(callCode(testReceiver, 3), revertCode...)
+	txs, err := traceInternalTxs(t, code, calleeCode)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []*InternalTx{
+		{Type: InternalTxCall, From: testContract, To: testReceiver, Value: big.NewInt(4), Depth: 1},
+	}
+	if !reflect.DeepEqual(txs, expected) {
+		t.Errorf("traced %v, expected the transfer of the call not reverted only", txs)
+	}
+
+	// the transaction reverts, all the transfers are dropped
+	code = append(callCode(testCallee, 10), revertCode...)
+	calleeCode = append(callCode(testReceiver, 3), byte(vm.STOP))
+	if txs, err := traceInternalTxs(t, code, calleeCode); err == nil || len(txs) != 0 {
+		t.Errorf("traced %v (%v), expected none for the transaction reverted", txs, err)
+	}
+}
+
+func TestInternalTxTracerCreate(t *testing.T) {
+	// create an empty contract with value 5: size, offset, value
+	code := []byte{0x60, 0, 0x60, 0, 0x60, 5, byte(vm.CREATE), byte(vm.POP), byte(vm.STOP)}
+	txs, err := traceInternalTxs(t, code, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []*InternalTx{{
+		Type:  InternalTxCreate,
+		From:  testContract,
+		To:    crypto.CreateAddress(testContract, 0),
+		Value: big.NewInt(5),
+		Depth: 1,
+	}}
+	if !reflect.DeepEqual(txs, expected) {
+		t.Errorf("traced %v, expected %v", txs, expected)
+	}
+}
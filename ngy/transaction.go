@@ -5,6 +5,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/nordicenergy/nordicenergy-core/api/service/explorer"
 	"github.com/nordicenergy/nordicenergy-core/core"
 	"github.com/nordicenergy/nordicenergy-core/core/rawdb"
 	"github.com/nordicenergy/nordicenergy-core/core/types"
@@ -55,8 +56,18 @@ func (ngy *nordicenergy) GetReceipts(ctx context.Context, hash common.Hash) (typ
 }
 
 // GetTransactionsHistory returns list of transactions hashes of address.
-func (ngy *nordicenergy) GetTransactionsHistory(address, txType, order string) ([]common.Hash, error) {
-	return ngy.NodeAPI.GetTransactionsHistory(address, txType, order)
+func (ngy *nordicenergy) GetTransactionsHistory(query explorer.TxHistoryQuery) ([]common.Hash, error) {
+	return ngy.NodeAPI.GetTransactionsHistory(query)
+}
+
+// GetInternalTransactionsHistory returns list of internal transactions of address.
+func (ngy *nordicenergy) GetInternalTransactionsHistory(query explorer.TxHistoryQuery) ([]*explorer.InternalTx, error) {
+	return ngy.NodeAPI.GetInternalTransactionsHistory(query)
+}
+
+// GetCxPayoutsHistory returns list of hashes of the cross-shard transactions paid out to address.
+func (ngy *nordicenergy) GetCxPayoutsHistory(query explorer.TxHistoryQuery) ([]common.Hash, error) {
+	return ngy.NodeAPI.GetCxPayoutsHistory(query)
 }
 
 // GetAccountNonce returns the nonce value of the given address for the given block number
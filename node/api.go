@@ -2,6 +2,7 @@ package node
 
 import (
 	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/nordicenergy/nordicenergy-core/consensus"
 	"github.com/nordicenergy/nordicenergy-core/core/types"
 	"github.com/nordicenergy/nordicenergy-core/ngy"
 	"github.com/nordicenergy/nordicenergy-core/rosetta"
@@ -126,6 +127,11 @@ func (node *Node) GetConsensusCurViewID() uint64 {
 	return node.Consensus.GetCurBlockViewID()
 }
 
+// GetConsensusTimeline returns the traces of the last count consensus rounds
+func (node *Node) GetConsensusTimeline(count int) []consensus.RoundTimeline {
+	return node.Consensus.RoundTimelines(count)
+}
+
 // GetConsensusBlockNum returns the current block number of the consensus
 func (node *Node) GetConsensusBlockNum() uint64 {
 	return node.Consensus.GetBlockNum()
@@ -56,6 +56,73 @@ var (
 			"type",
 		},
 	)
+	// nodeRejectedMessageCounterVec is used to keep track of the p2p messages
+	// rejected, by the reason they were rejected for
+	nodeRejectedMessageCounterVec = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ngy",
+			Subsystem: "p2p",
+			Name:      "rejected_msg",
+			Help:      "number of rejected p2p messages",
+		},
+		[]string{
+			"reason",
+		},
+	)
+	// nodeCxPoolGaugeVec is the number of outgoing cross-shard receipts
+	// waiting for delivery, per destination shard
+	nodeCxPoolGaugeVec = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ngy",
+			Subsystem: "node",
+			Name:      "cx_pool_outstanding",
+			Help:      "number of outgoing cross-shard receipts waiting for delivery",
+		},
+		[]string{
+			"to_shard",
+		},
+	)
+	// nodeCxPoolCounterVec counts the cross-shard receipts re-broadcast and
+	// removed from the CxPool, by result
+	nodeCxPoolCounterVec = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ngy",
+			Subsystem: "node",
+			Name:      "cx_pool_result",
+			Help:      "number of cross-shard receipts rebroadcast, delivered, expired or dropped",
+		},
+		[]string{
+			"result",
+		},
+	)
+	// nodeCrossLinkGaugeVec is the last continuous crosslink, the head and
+	// the number of blocks missing their crosslink of each shard, on the
+	// beacon chain
+	nodeCrossLinkGaugeVec = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "ngy",
+			Subsystem: "node",
+			Name:      "crosslink",
+			Help:      "last continuous crosslink, head and missing crosslinks of the shards",
+		},
+		[]string{
+			"shard",
+			"type",
+		},
+	)
+	// nodeCrossLinkRepairCounterVec counts the crosslinks fetched from the
+	// shard nodes to fill the gaps, by result
+	nodeCrossLinkRepairCounterVec = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ngy",
+			Subsystem: "node",
+			Name:      "crosslink_repair",
+			Help:      "number of crosslinks fetched from shard nodes, added or invalid, and of failed requests",
+		},
+		[]string{
+			"result",
+		},
+	)
 	onceMetrics sync.Once
 )
 
@@ -66,6 +133,11 @@ func initMetrics() {
 			nodeP2PMessageCounterVec,
 			nodeConsensusMessageCounterVec,
 			nodeNodeMessageCounterVec,
+			nodeRejectedMessageCounterVec,
+			nodeCxPoolGaugeVec,
+			nodeCxPoolCounterVec,
+			nodeCrossLinkGaugeVec,
+			nodeCrossLinkRepairCounterVec,
 		)
 	})
 }
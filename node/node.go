@@ -20,6 +20,7 @@ import (
 	"github.com/nordicenergy/nordicenergy-core/api/service/syncing"
 	"github.com/nordicenergy/nordicenergy-core/api/service/syncing/downloader"
 	"github.com/nordicenergy/nordicenergy-core/consensus"
+	"github.com/nordicenergy/nordicenergy-core/consensus/protection"
 	"github.com/nordicenergy/nordicenergy-core/core"
 	"github.com/nordicenergy/nordicenergy-core/core/rawdb"
 	"github.com/nordicenergy/nordicenergy-core/core/types"
@@ -60,6 +61,11 @@ const (
 	broadcastTimeout  int64 = 60 * 1000000000 // 1 mins
 	//SyncIDLength is the length of bytes for syncID
 	SyncIDLength = 20
+	// cxBroadcastsCacheSize is the number of receipts proof broadcasts remembered
+	cxBroadcastsCacheSize = 1024
+	// maxCxRebroadcastsPerBlock is the number of receipts proofs of the CxPool
+	// re-broadcast at most after each block
+	maxCxRebroadcastsPerBlock = 32
 )
 
 // use to push new block to outofsync node
@@ -120,8 +126,25 @@ type Node struct {
 	IsInSync      *abool.AtomicBool
 	proposedBlock map[uint64]*types.Block
 
+	// eventHooks delivers the node events to the subscribed webhooks, nil if
+	// there are none
+	eventHooks *webhooks.Dispatcher
+	// poolFull is whether the last check found the transaction pool full
+	poolFull *abool.AtomicBool
+	// poolCapacity is the number of transactions the pool holds
+	poolCapacity uint64
+	// slashingProtection records what the local keys signed, nil if the
+	// node does not run consensus or has no database directory
+	slashingProtection *protection.DB
+
 	deciderCache   *lru.Cache
 	committeeCache *lru.Cache
+	// cxBroadcasts holds the last time the node broadcast the receipts proof
+	// of a block to a shard, keyed by core.CxEntry
+	cxBroadcasts *lru.Cache
+	// crossLinkRepair fetches the crosslinks missing on the beacon chain from
+	// the shard nodes, nil if not set up
+	crossLinkRepair *crossLinkRepair
 
 	Metrics metrics.Registry
 
@@ -212,6 +235,7 @@ func (node *Node) addPendingTransactions(newTxs types.Transactions) []error {
 		Int("totalPending", pendingCount).
 		Int("totalQueued", queueCount).
 		Msg("[addPendingTransactions] Adding more transactions")
+	node.checkPoolOverflow(pendingCount, queueCount)
 	return errs
 }
 
@@ -365,6 +389,7 @@ var (
 	errNoSenderPubKey    = errors.New("no sender public BLS key in message")
 	errWrongSizeOfBitmap = errors.New("wrong size of sender bitmap")
 	errWrongShardID      = errors.New("wrong shard id")
+	errWrongViewID       = errors.New("view id of the current block belongs to the past")
 	errInvalidNodeMsg    = errors.New("invalid node message")
 	errIgnoreBeaconMsg   = errors.New("ignore beacon sync block")
 	errInvalidEpoch      = errors.New("invalid epoch for transaction")
@@ -494,6 +519,13 @@ func (node *Node) validateShardBoundMessage(
 			nodeConsensusMessageCounterVec.With(prometheus.Labels{"type": "invalid_shard"}).Inc()
 			return nil, nil, true, errors.WithStack(errWrongShardID)
 		}
+		// a view change already moved the current block past the view ID
+		if !node.Consensus.IsViewChangingMode() &&
+			maybeCon.BlockNum == node.Consensus.GetBlockNum() &&
+			maybeCon.ViewId < node.Consensus.GetCurBlockViewID() {
+			nodeConsensusMessageCounterVec.With(prometheus.Labels{"type": "invalid_view_id"}).Inc()
+			return nil, nil, true, errors.WithStack(errWrongViewID)
+		}
 		senderKey = maybeCon.SenderPubkey
 
 		if len(maybeCon.SenderPubkeyBitmap) > 0 {
@@ -504,6 +536,11 @@ func (node *Node) validateShardBoundMessage(
 			nodeConsensusMessageCounterVec.With(prometheus.Labels{"type": "invalid_shard"}).Inc()
 			return nil, nil, true, errors.WithStack(errWrongShardID)
 		}
+		if maybeVC.BlockNum == node.Consensus.GetBlockNum() &&
+			maybeVC.ViewId < node.Consensus.GetViewChangingID() {
+			nodeConsensusMessageCounterVec.With(prometheus.Labels{"type": "invalid_view_id"}).Inc()
+			return nil, nil, true, errors.WithStack(errWrongViewID)
+		}
 		senderKey = maybeVC.SenderPubkey
 	} else {
 		nodeConsensusMessageCounterVec.With(prometheus.Labels{"type": "invalid"}).Inc()
@@ -625,6 +662,8 @@ func (node *Node) StartPubSub() error {
 		handleEArg     []byte
 		senderPubKey   *bls.SerializedPublicKey
 		actionType     proto_node.MessageType
+		// from is the peer that published the message
+		from libp2p_peer.ID
 	}
 
 	isThisNodeAnExplorerNode := node.NodeConfig.Role() == nodeconfig.ExplorerNode
@@ -653,8 +692,8 @@ func (node *Node) StartPubSub() error {
 
 				// first to validate the size of the p2p message
 				if len(ngyMsg) < p2pMsgPrefixSize {
-					// TODO (lc): block peers sending empty messages
 					nodeP2PMessageCounterVec.With(prometheus.Labels{"type": "invalid_size"}).Inc()
+					node.rejectMessage(msg.GetFrom(), rejectInvalidSize)
 					return libp2p_pubsub.ValidationReject
 				}
 
@@ -666,6 +705,7 @@ func (node *Node) StartPubSub() error {
 					// received consensus message in non-consensus bound topic
 					if !isConsensusBound {
 						nodeP2PMessageCounterVec.With(prometheus.Labels{"type": "invalid_bound"}).Inc()
+						node.rejectMessage(msg.GetFrom(), rejectInvalidBound)
 						errChan <- withError{
 							errors.WithStack(errConsensusMessageOnUnexpectedTopic), msg,
 						}
@@ -680,6 +720,12 @@ func (node *Node) StartPubSub() error {
 
 					if err != nil {
 						errChan <- withError{err, msg.GetFrom()}
+						node.rejectMessage(msg.GetFrom(), consensusRejectReason(err))
+						// a message for a past view is stale rather than invalid,
+						// it is dropped without the relaying peers being penalized
+						if errors.Cause(err) == errWrongViewID {
+							return libp2p_pubsub.ValidationIgnore
+						}
 						return libp2p_pubsub.ValidationReject
 					}
 
@@ -693,6 +739,7 @@ func (node *Node) StartPubSub() error {
 						handleC:        node.Consensus.HandleMessageUpdate,
 						handleCArg:     validMsg,
 						senderPubKey:   senderPubKey,
+						from:           msg.GetFrom(),
 					}
 					return libp2p_pubsub.ValidationAccept
 
@@ -700,6 +747,7 @@ func (node *Node) StartPubSub() error {
 					// node message is almost empty
 					if len(openBox) <= p2pNodeMsgPrefixSize {
 						nodeP2PMessageCounterVec.With(prometheus.Labels{"type": "invalid_size"}).Inc()
+						node.rejectMessage(msg.GetFrom(), rejectInvalidSize)
 						return libp2p_pubsub.ValidationReject
 					}
 					nodeP2PMessageCounterVec.With(prometheus.Labels{"type": "node_total"}).Inc()
@@ -713,8 +761,8 @@ func (node *Node) StartPubSub() error {
 							// but propogate the messages to other nodes
 							return libp2p_pubsub.ValidationAccept
 						default:
-							// TODO (lc): block peers sending error messages
 							errChan <- withError{err, msg.GetFrom()}
+							node.rejectMessage(msg.GetFrom(), rejectInvalidNodeMessage)
 							return libp2p_pubsub.ValidationReject
 						}
 					}
@@ -728,6 +776,7 @@ func (node *Node) StartPubSub() error {
 				default:
 					// ignore garbled messages
 					nodeP2PMessageCounterVec.With(prometheus.Labels{"type": "ignored"}).Inc()
+					node.rejectMessage(msg.GetFrom(), rejectInvalidCategory)
 					return libp2p_pubsub.ValidationReject
 				}
 				select {
@@ -785,6 +834,9 @@ func (node *Node) StartPubSub() error {
 							} else {
 								if err := msg.handleC(ctx, msg.handleCArg, msg.senderPubKey); err != nil {
 									errChan <- withError{err, msg.senderPubKey}
+									if errors.Cause(err) == consensus.ErrVerifyMessageSignature {
+										node.rejectMessage(msg.from, rejectInvalidSignature)
+									}
 								}
 							}
 						}
@@ -942,6 +994,7 @@ func New(
 	}
 	node.shardChains = collection
 	node.IsInSync = abool.NewBool(false)
+	node.poolFull = abool.NewBool(false)
 
 	if host != nil && consensusObj != nil {
 		// Consensus and associated channel to communicate blocks
@@ -970,11 +1023,18 @@ func New(
 		txPoolConfig.Blacklist = blacklist
 		txPoolConfig.Journal = fmt.Sprintf("%v/%v", node.NodeConfig.DBDir, txPoolConfig.Journal)
 		node.TxPool = core.NewTxPool(txPoolConfig, node.Blockchain().Config(), blockchain, node.TransactinetrrorSink)
-		node.CxPool = core.NewCxPool(core.CxPoolSize)
+		node.poolCapacity = txPoolConfig.GlobalSlots + txPoolConfig.GlobalQueue
+		cxPool, err := core.NewDurableCxPool(core.CxPoolSize, blockchain.ChainDb())
+		if err != nil {
+			utils.Logger().Error().Err(err).Msg("cannot load the CxPool, starting with an empty one")
+			cxPool = core.NewCxPool(core.CxPoolSize)
+		}
+		node.CxPool = cxPool
 		node.Worker = worker.New(node.Blockchain().Config(), blockchain, chain.Engine)
 
 		node.deciderCache, _ = lru.New(16)
 		node.committeeCache, _ = lru.New(16)
+		node.cxBroadcasts, _ = lru.New(cxBroadcastsCacheSize)
 
 		if node.Blockchain().ShardID() != shard.BeaconChainShardID {
 			node.BeaconWorker = worker.New(
@@ -989,6 +1049,21 @@ func New(
 		// the sequence number is the next block number to be added in consensus protocol, which is
 		// always net more than current chain header block
 		node.Consensus.SetBlockNum(blockchain.CurrentBlock().NumberU64() + 1)
+		node.Consensus.SetFBFTLogStore(blockchain.ChainDb())
+		if dbDir := node.NodeConfig.DBDir; dbDir != "" {
+			slashingProtection, err := protection.Open(protection.Dir(dbDir))
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Cannot open the slashing protection database: %v\n", err)
+				os.Exit(-1)
+			}
+			node.slashingProtection = slashingProtection
+			node.Consensus.SetSlashingProtection(slashingProtection)
+		}
+		node.setupWebhooks(blockchain.ChainDb())
+		if node.NodeConfig.ShardID == shard.BeaconChainShardID ||
+			node.NodeConfig.Role() == nodeconfig.ExplorerNode {
+			node.SetupCrossLinkRepair()
+		}
 	}
 
 	utils.Logger().Info().
@@ -1186,6 +1261,13 @@ func (node *Node) ShutDown() {
 		utils.Logger().Error().Err(err).Msg("failed to stop services")
 	}
 
+	if node.eventHooks != nil {
+		node.eventHooks.Stop()
+	}
+	if node.crossLinkRepair != nil {
+		node.crossLinkRepair.cancel()
+	}
+
 	// Currently pubSub need to be stopped after consensus.
 	utils.Logger().Info().Msg("stopping pub-sub")
 	node.StopPubSub()
@@ -1195,6 +1277,12 @@ func (node *Node) ShutDown() {
 		utils.Logger().Error().Err(err).Msg("failed to stop p2p host")
 	}
 
+	if node.slashingProtection != nil {
+		if err := node.slashingProtection.Close(); err != nil {
+			utils.Logger().Error().Err(err).Msg("failed to close the slashing protection database")
+		}
+	}
+
 	const msg = "Successfully shut down!\n"
 	utils.Logger().Print(msg)
 	fmt.Print(msg)
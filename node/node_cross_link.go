@@ -62,18 +62,6 @@ func (node *Node) VerifyBlockCrossLinks(block *types.Block) error {
 // ProcessCrossLinkMessage verify and process Node/CrossLink message into crosslink when it's valid
 func (node *Node) ProcessCrossLinkMessage(msgPayload []byte) {
 	if node.NodeConfig.ShardID == shard.BeaconChainShardID {
-		pendingCLs, err := node.Blockchain().ReadPendingCrossLinks()
-		if err == nil && len(pendingCLs) >= maxPendingCrossLinkSize {
-			utils.Logger().Debug().
-				Msgf("[ProcessingCrossLink] Pending Crosslink reach maximum size: %d", len(pendingCLs))
-			return
-		}
-
-		existingCLs := map[common2.Hash]struct{}{}
-		for _, pending := range pendingCLs {
-			existingCLs[pending.Hash()] = struct{}{}
-		}
-
 		crosslinks := []types.CrossLink{}
 		if err := rlp.DecodeBytes(msgPayload, &crosslinks); err != nil {
 			utils.Logger().Error().
@@ -82,46 +70,69 @@ func (node *Node) ProcessCrossLinkMessage(msgPayload []byte) {
 			return
 		}
 
-		candidates := []types.CrossLink{}
 		utils.Logger().Debug().
 			Msgf("[ProcessingCrossLink] Received crosslinks: %d", len(crosslinks))
+		// A sanity check to prevent spamming
+		if len(crosslinks) > crossLinkBatchSize*2+1 {
+			crosslinks = crosslinks[:crossLinkBatchSize*2+1]
+		}
+		node.addPendingCrossLinks(crosslinks)
+	}
+}
 
-		for i, cl := range crosslinks {
-			if i > crossLinkBatchSize*2 { // A sanity check to prevent spamming
-				break
-			}
-
-			if _, ok := existingCLs[cl.Hash()]; ok {
-				utils.Logger().Debug().Err(err).
-					Msgf("[ProcessingCrossLink] Cross Link already exists in pending queue, pass. Beacon Epoch: %d, Block num: %d, Epoch: %d, shardID %d",
-						node.Blockchain().CurrentHeader().Epoch(), cl.Number(), cl.Epoch(), cl.ShardID())
-				continue
-			}
-
-			exist, err := node.Blockchain().ReadCrossLink(cl.ShardID(), cl.Number().Uint64())
-			if err == nil && exist != nil {
-				utils.Logger().Debug().Err(err).
-					Msgf("[ProcessingCrossLink] Cross Link already exists, pass. Beacon Epoch: %d, Block num: %d, Epoch: %d, shardID %d", node.Blockchain().CurrentHeader().Epoch(), cl.Number(), cl.Epoch(), cl.ShardID())
-				continue
-			}
-
-			if err = node.VerifyCrossLink(cl); err != nil {
-				utils.Logger().Info().
-					Str("cross-link-issue", err.Error()).
-					Msgf("[ProcessingCrossLink] Failed to verify new cross link for blockNum %d epochNum %d shard %d skipped: %v", cl.BlockNum(), cl.Epoch().Uint64(), cl.ShardID(), cl)
-				continue
-			}
-
-			candidates = append(candidates, cl)
-			utils.Logger().Debug().
-				Msgf("[ProcessingCrossLink] Committing for shardID %d, blockNum %d",
-					cl.ShardID(), cl.Number().Uint64(),
-				)
+// addPendingCrossLinks verifies the crosslinks neither pending nor committed
+// yet and adds the valid ones to the pending crosslinks. It returns the number
+// of crosslinks added and the number failing verification.
+func (node *Node) addPendingCrossLinks(crosslinks []types.CrossLink) (int, int) {
+	pendingCLs, err := node.Blockchain().ReadPendingCrossLinks()
+	if err == nil && len(pendingCLs) >= maxPendingCrossLinkSize {
+		utils.Logger().Debug().
+			Msgf("[ProcessingCrossLink] Pending Crosslink reach maximum size: %d", len(pendingCLs))
+		return 0, 0
+	}
+
+	existingCLs := map[common2.Hash]struct{}{}
+	for _, pending := range pendingCLs {
+		existingCLs[pending.Hash()] = struct{}{}
+	}
+
+	candidates, invalid := []types.CrossLink{}, 0
+	for _, cl := range crosslinks {
+		if _, ok := existingCLs[cl.Hash()]; ok {
+			utils.Logger().Debug().Err(err).
+				Msgf("[ProcessingCrossLink] Cross Link already exists in pending queue, pass. Beacon Epoch: %d, Block num: %d, Epoch: %d, shardID %d",
+					node.Blockchain().CurrentHeader().Epoch(), cl.Number(), cl.Epoch(), cl.ShardID())
+			continue
+		}
+
+		exist, err := node.Blockchain().ReadCrossLink(cl.ShardID(), cl.Number().Uint64())
+		if err == nil && exist != nil {
+			utils.Logger().Debug().Err(err).
+				Msgf("[ProcessingCrossLink] Cross Link already exists, pass. Beacon Epoch: %d, Block num: %d, Epoch: %d, shardID %d", node.Blockchain().CurrentHeader().Epoch(), cl.Number(), cl.Epoch(), cl.ShardID())
+			continue
 		}
-		Len, _ := node.Blockchain().AddPendingCrossLinks(candidates)
+
+		if err = node.VerifyCrossLink(cl); err != nil {
+			invalid++
+			utils.Logger().Info().
+				Str("cross-link-issue", err.Error()).
+				Msgf("[ProcessingCrossLink] Failed to verify new cross link for blockNum %d epochNum %d shard %d skipped: %v", cl.BlockNum(), cl.Epoch().Uint64(), cl.ShardID(), cl)
+			continue
+		}
+
+		candidates = append(candidates, cl)
 		utils.Logger().Debug().
-			Msgf("[ProcessingCrossLink] Add pending crosslinks,  total pending: %d", Len)
+			Msgf("[ProcessingCrossLink] Committing for shardID %d, blockNum %d",
+				cl.ShardID(), cl.Number().Uint64(),
+			)
+	}
+	if len(candidates) == 0 {
+		return 0, invalid
 	}
+	Len, _ := node.Blockchain().AddPendingCrossLinks(candidates)
+	utils.Logger().Debug().
+		Msgf("[ProcessingCrossLink] Add pending crosslinks,  total pending: %d", Len)
+	return len(candidates), invalid
 }
 
 // VerifyCrossLink verifies the header is valid
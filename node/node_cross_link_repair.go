@@ -0,0 +1,288 @@
+package node
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/nordicenergy/nordicenergy-core/core/types"
+	nodeconfig "github.com/nordicenergy/nordicenergy-core/internal/configs/node"
+	"github.com/nordicenergy/nordicenergy-core/internal/utils"
+	"github.com/nordicenergy/nordicenergy-core/p2p/stream/protocols/light"
+	sttypes "github.com/nordicenergy/nordicenergy-core/p2p/stream/types"
+	rpc_common "github.com/nordicenergy/nordicenergy-core/rpc/common"
+	"github.com/nordicenergy/nordicenergy-core/shard"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// crossLinkRepairInterval is the interval the beacon chain checks the
+	// crosslinks of the shards for gaps at
+	crossLinkRepairInterval = 30 * time.Second
+	// crossLinkRepairTimeout is the time given to the requests of a shard
+	// each interval
+	crossLinkRepairTimeout = 10 * time.Second
+	// crossLinkRepairDelay is the number of blocks below the head of a shard
+	// whose crosslinks are left to the regular broadcast of the shard leader
+	crossLinkRepairDelay = 8
+	// maxCrossLinkRepairRequests is the maximum number of crosslink requests
+	// sent to a shard each interval
+	maxCrossLinkRepairRequests = 4
+	// maxCrossLinkGapScan is the number of blocks after the last continuous
+	// crosslink of a shard scanned for gaps
+	maxCrossLinkGapScan = 1024
+)
+
+// crossLinkSource is the light protocol of a shard, as used to fetch its
+// crosslinks
+type crossLinkSource interface {
+	GetHead(ctx context.Context) (uint64, sttypes.StreamID, error)
+	GetCrossLinks(ctx context.Context, from, count uint64) ([]types.CrossLink, sttypes.StreamID, error)
+	RemoveStream(stid sttypes.StreamID)
+}
+
+// crossLinkRepair fetches the crosslinks missing on the beacon chain from the
+// nodes of the shards
+type crossLinkRepair struct {
+	protocols map[uint32]crossLinkSource
+
+	// heads is the last head of each shard reported by a shard node
+	lock  sync.Mutex
+	heads map[uint32]uint64
+
+	ctx    context.Context
+	cancel func()
+}
+
+func (r *crossLinkRepair) head(shardID uint32) uint64 {
+	if r == nil {
+		return 0
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	return r.heads[shardID]
+}
+
+func (r *crossLinkRepair) setHead(shardID uint32, head uint64) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.heads[shardID] = head
+}
+
+// SetupCrossLinkRepair registers the light protocol streams of the crosslink
+// repair, to be called before the host starts. The node serves the
+// crosslinks of its shard, and beacon nodes fetch the crosslinks missing on
+// the beacon chain from the nodes of the other shards. It is set up by New
+// on beacon and explorer nodes.
+func (node *Node) SetupCrossLinkRepair() {
+	newProtocol := func(shardID uint32, chain light.Chain) *light.Protocol {
+		return light.NewProtocol(light.Config{
+			Chain:     chain,
+			Host:      node.host.GetP2PHost(),
+			Discovery: node.host.GetDiscovery(),
+			ShardID:   nodeconfig.ShardID(shardID),
+			Network:   node.NodeConfig.GetNetworkType(),
+			SmConfig:  light.DefaultStreamManagerConfig,
+		})
+	}
+	node.host.AddStreamProtocol(newProtocol(node.NodeConfig.ShardID, node.Blockchain()))
+	if node.NodeConfig.ShardID != shard.BeaconChainShardID {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	repair := &crossLinkRepair{
+		protocols: map[uint32]crossLinkSource{},
+		heads:     map[uint32]uint64{},
+		ctx:       ctx,
+		cancel:    cancel,
+	}
+	for shardID := uint32(1); shardID < node.numShards(); shardID++ {
+		proto := newProtocol(shardID, nil)
+		node.host.AddStreamProtocol(proto)
+		repair.protocols[shardID] = proto
+	}
+	node.crossLinkRepair = repair
+	go node.crossLinkRepairLoop()
+}
+
+func (node *Node) numShards() uint32 {
+	return shard.Schedule.InstanceForEpoch(node.Blockchain().CurrentHeader().Epoch()).NumShards()
+}
+
+// crossLinkRepairLoop checks the crosslinks of the shards for gaps and
+// fetches the missing ones at each interval
+func (node *Node) crossLinkRepairLoop() {
+	ticker := time.NewTicker(crossLinkRepairInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			for shardID, proto := range node.crossLinkRepair.protocols {
+				node.repairCrossLinks(shardID, proto)
+			}
+		case <-node.crossLinkRepair.ctx.Done():
+			return
+		}
+	}
+}
+
+// repairCrossLinks fetches the crosslinks of the gaps of the shard from a
+// node of the shard, and adds the valid ones to the pending crosslinks
+func (node *Node) repairCrossLinks(shardID uint32, proto crossLinkSource) {
+	ctx, cancel := context.WithTimeout(node.crossLinkRepair.ctx, crossLinkRepairTimeout)
+	defer cancel()
+	logger := utils.Logger().With().Uint32("shardID", shardID).Logger()
+
+	head, _, err := proto.GetHead(ctx)
+	if err != nil {
+		logger.Debug().Err(err).Msg("[CrossLinkRepair] cannot get shard head")
+		nodeCrossLinkRepairCounterVec.With(prometheus.Labels{"result": "failed"}).Inc()
+		return
+	}
+	node.crossLinkRepair.setHead(shardID, head)
+	status := node.crossLinkStatus(shardID, node.pendingCrossLinkNums()[shardID])
+	updateCrossLinkMetrics(status)
+	if head <= crossLinkRepairDelay {
+		return
+	}
+
+	requests := 0
+	for _, gap := range status.Gaps {
+		to := gap.To
+		if to > head-crossLinkRepairDelay {
+			to = head - crossLinkRepairDelay
+		}
+		for from := gap.From; from <= to; requests++ {
+			if requests >= maxCrossLinkRepairRequests {
+				return
+			}
+			count := to - from + 1
+			if count > light.MaxCrossLinksPerRequest {
+				count = light.MaxCrossLinksPerRequest
+			}
+			cls, stid, err := proto.GetCrossLinks(ctx, from, count)
+			if err != nil {
+				logger.Debug().Err(err).Uint64("from", from).Uint64("count", count).
+					Msg("[CrossLinkRepair] cannot get crosslinks")
+				nodeCrossLinkRepairCounterVec.With(prometheus.Labels{"result": "failed"}).Inc()
+				if err == light.ErrInvalidCrossLinks {
+					proto.RemoveStream(stid)
+				}
+				return
+			}
+			added, invalid := node.addPendingCrossLinks(cls)
+			nodeCrossLinkRepairCounterVec.With(prometheus.Labels{"result": "added"}).Add(float64(added))
+			nodeCrossLinkRepairCounterVec.With(prometheus.Labels{"result": "invalid"}).Add(float64(invalid))
+			logger.Info().Uint64("from", from).Int("added", added).Int("invalid", invalid).
+				Msg("[CrossLinkRepair] fetched missing crosslinks")
+			from += uint64(len(cls))
+		}
+	}
+}
+
+// GetCrossLinkStatus returns the crosslinks of each shard received by the
+// beacon chain versus the head of the shard, nil on the other shards
+func (node *Node) GetCrossLinkStatus() []rpc_common.CrossLinkStatus {
+	if node.NodeConfig.ShardID != shard.BeaconChainShardID {
+		return nil
+	}
+	pending := node.pendingCrossLinkNums()
+	res := []rpc_common.CrossLinkStatus{}
+	for shardID := uint32(1); shardID < node.numShards(); shardID++ {
+		status := node.crossLinkStatus(shardID, pending[shardID])
+		updateCrossLinkMetrics(status)
+		res = append(res, status)
+	}
+	return res
+}
+
+// pendingCrossLinkNums returns the blocks of the pending crosslinks by shard
+func (node *Node) pendingCrossLinkNums() map[uint32]map[uint64]struct{} {
+	res := map[uint32]map[uint64]struct{}{}
+	pending, err := node.Blockchain().ReadPendingCrossLinks()
+	if err != nil {
+		return res
+	}
+	for _, cl := range pending {
+		if _, ok := res[cl.ShardID()]; !ok {
+			res[cl.ShardID()] = map[uint64]struct{}{}
+		}
+		res[cl.ShardID()][cl.BlockNum()] = struct{}{}
+	}
+	return res
+}
+
+// crossLinkStatus returns the crosslinks of the shard, given the blocks of
+// its pending crosslinks. The head of the shard is the one last reported by
+// a shard node, or else the highest block with a crosslink.
+func (node *Node) crossLinkStatus(shardID uint32, pending map[uint64]struct{}) rpc_common.CrossLinkStatus {
+	status := rpc_common.CrossLinkStatus{
+		ShardID:   shardID,
+		ShardHead: node.crossLinkRepair.head(shardID),
+		Gaps:      []rpc_common.CrossLinkGap{},
+		Pending:   len(pending),
+	}
+	last, err := node.Blockchain().ReadShardLastCrossLink(shardID)
+	if err != nil || last == nil {
+		// no crosslink of the shard yet
+		return status
+	}
+	status.LastContinuous = last.BlockNum()
+	known := func(num uint64) bool {
+		if _, ok := pending[num]; ok {
+			return true
+		}
+		cl, err := node.Blockchain().ReadCrossLink(shardID, num)
+		return err == nil && cl != nil
+	}
+	var highest uint64
+	status.Gaps, highest = crossLinkGaps(status.LastContinuous, status.ShardHead, known)
+	if status.ShardHead < highest {
+		status.ShardHead = highest
+	}
+	for _, gap := range status.Gaps {
+		status.Missing += gap.To - gap.From + 1
+	}
+	return status
+}
+
+// crossLinkGaps returns the ranges of blocks without a known crosslink after
+// the last continuous one up to head, scanning at most maxCrossLinkGapScan
+// blocks, and the highest block with a known crosslink. With head unknown,
+// zero, the blocks after the highest one are not reported.
+func crossLinkGaps(last, head uint64, known func(uint64) bool) ([]rpc_common.CrossLinkGap, uint64) {
+	end := last + maxCrossLinkGapScan
+	if head != 0 && head < end {
+		end = head
+	}
+	gaps, highest := []rpc_common.CrossLinkGap{}, last
+	for num := last + 1; num <= end; num++ {
+		if known(num) {
+			highest = num
+			continue
+		}
+		if n := len(gaps); n > 0 && gaps[n-1].To == num-1 {
+			gaps[n-1].To = num
+		} else {
+			gaps = append(gaps, rpc_common.CrossLinkGap{From: num, To: num})
+		}
+	}
+	if n := len(gaps); head == 0 && n > 0 && gaps[n-1].From > highest {
+		gaps = gaps[:n-1]
+	}
+	return gaps, highest
+}
+
+// updateCrossLinkMetrics sets the crosslink gauges of the shard of status
+func updateCrossLinkMetrics(status rpc_common.CrossLinkStatus) {
+	shardID := strconv.FormatUint(uint64(status.ShardID), 10)
+	for kind, value := range map[string]uint64{
+		"last_continuous": status.LastContinuous,
+		"shard_head":      status.ShardHead,
+		"missing":         status.Missing,
+	} {
+		nodeCrossLinkGaugeVec.With(prometheus.Labels{"shard": shardID, "type": kind}).Set(float64(value))
+	}
+}
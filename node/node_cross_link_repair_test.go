@@ -0,0 +1,149 @@
+package node
+
+import (
+	"context"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/nordicenergy/nordicenergy-core/consensus"
+	"github.com/nordicenergy/nordicenergy-core/consensus/quorum"
+	"github.com/nordicenergy/nordicenergy-core/core/rawdb"
+	"github.com/nordicenergy/nordicenergy-core/core/types"
+	"github.com/nordicenergy/nordicenergy-core/crypto/bls"
+	"github.com/nordicenergy/nordicenergy-core/internal/utils"
+	"github.com/nordicenergy/nordicenergy-core/multibls"
+	"github.com/nordicenergy/nordicenergy-core/p2p"
+	"github.com/nordicenergy/nordicenergy-core/p2p/stream/protocols/light"
+	sttypes "github.com/nordicenergy/nordicenergy-core/p2p/stream/types"
+	rpc_common "github.com/nordicenergy/nordicenergy-core/rpc/common"
+	"github.com/nordicenergy/nordicenergy-core/shard"
+)
+
+func TestCrossLinkGaps(t *testing.T) {
+	known := map[uint64]bool{13: true, 14: true, 18: true}
+	tests := []struct {
+		head    uint64
+		gaps    []rpc_common.CrossLinkGap
+		highest uint64
+	}{
+		{0, []rpc_common.CrossLinkGap{{From: 11, To: 12}, {From: 15, To: 17}}, 18},
+		{20, []rpc_common.CrossLinkGap{{From: 11, To: 12}, {From: 15, To: 17}, {From: 19, To: 20}}, 18},
+		{14, []rpc_common.CrossLinkGap{{From: 11, To: 12}}, 14},
+		{10, []rpc_common.CrossLinkGap{}, 10},
+	}
+	for i, test := range tests {
+		gaps, highest := crossLinkGaps(10, test.head, func(num uint64) bool { return known[num] })
+		if !reflect.DeepEqual(gaps, test.gaps) {
+			t.Errorf("Test %v: gaps %v, expected %v", i, gaps, test.gaps)
+		}
+		if highest != test.highest {
+			t.Errorf("Test %v: highest %d, expected %d", i, highest, test.highest)
+		}
+	}
+
+	gaps, highest := crossLinkGaps(10, 0, func(uint64) bool { return false })
+	if len(gaps) != 0 || highest != 10 {
+		t.Errorf("gaps %v up to %d, expected none without head nor crosslink", gaps, highest)
+	}
+	gaps, _ = crossLinkGaps(10, 10+2*maxCrossLinkGapScan, func(uint64) bool { return false })
+	if len(gaps) != 1 || gaps[0].To != 10+maxCrossLinkGapScan {
+		t.Errorf("gaps %v, expected the scan limited to %d blocks", gaps, maxCrossLinkGapScan)
+	}
+}
+
+// testCrossLinkSource serves head and unsigned crosslinks for the requested
+// blocks, or err, recording the requests
+type testCrossLinkSource struct {
+	head     uint64
+	err      error
+	requests [][2]uint64
+	removed  []sttypes.StreamID
+}
+
+func (s *testCrossLinkSource) GetHead(ctx context.Context) (uint64, sttypes.StreamID, error) {
+	return s.head, "stream", nil
+}
+
+func (s *testCrossLinkSource) GetCrossLinks(
+	ctx context.Context, from, count uint64,
+) ([]types.CrossLink, sttypes.StreamID, error) {
+	s.requests = append(s.requests, [2]uint64{from, count})
+	if s.err != nil {
+		return nil, "stream", s.err
+	}
+	cls := []types.CrossLink{}
+	for num := from; num < from+count; num++ {
+		cls = append(cls, types.CrossLink{
+			BlockNumberF: new(big.Int).SetUint64(num),
+			ViewIDF:      new(big.Int).SetUint64(num),
+			ShardIDF:     1,
+			EpochF:       big.NewInt(0),
+		})
+	}
+	return cls, "stream", nil
+}
+
+func (s *testCrossLinkSource) RemoveStream(stid sttypes.StreamID) {
+	s.removed = append(s.removed, stid)
+}
+
+func TestRepairCrossLinks(t *testing.T) {
+	blsKey := bls.RandPrivateKey()
+	leader := p2p.Peer{IP: "127.0.0.1", Port: "8882", ConsensusPubKey: blsKey.GetPublicKey()}
+	priKey, _, _ := utils.GenKeyP2P("127.0.0.1", "9902")
+	host, err := p2p.NewHost(p2p.HostConfig{
+		Self:   &leader,
+		BLSKey: priKey,
+	})
+	if err != nil {
+		t.Fatalf("newhost failure: %v", err)
+	}
+	decider := quorum.NewDecider(
+		quorum.SuperMajorityVote, shard.BeaconChainShardID,
+	)
+	consensus, err := consensus.New(
+		host, shard.BeaconChainShardID, leader, multibls.GetPrivateKeys(blsKey), decider,
+	)
+	if err != nil {
+		t.Fatalf("Cannot craeate consensus: %v", err)
+	}
+	node := New(host, consensus, testDBFactory, nil, nil)
+	if node.crossLinkRepair == nil {
+		t.Fatal("crosslink repair not set up on the beacon chain")
+	}
+	defer node.crossLinkRepair.cancel()
+
+	last := types.CrossLink{
+		BlockNumberF: big.NewInt(10), ViewIDF: big.NewInt(10), ShardIDF: 1, EpochF: big.NewInt(0),
+	}
+	if err := rawdb.WriteShardLastCrossLink(node.Blockchain().ChainDb(), 1, last.Serialize()); err != nil {
+		t.Fatal(err)
+	}
+
+	// the gap up to the repair delay below the head is requested in
+	// batches, up to the maximum number of requests
+	source := &testCrossLinkSource{head: 10 + crossLinkRepairDelay + 300}
+	node.repairCrossLinks(1, source)
+	if head := node.crossLinkRepair.head(1); head != source.head {
+		t.Errorf("shard head %d, expected %d", head, source.head)
+	}
+	expected := [][2]uint64{}
+	for i := uint64(0); i < maxCrossLinkRepairRequests; i++ {
+		expected = append(expected, [2]uint64{11 + i*light.MaxCrossLinksPerRequest, light.MaxCrossLinksPerRequest})
+	}
+	if !reflect.DeepEqual(source.requests, expected) {
+		t.Errorf("requests %v, expected %v", source.requests, expected)
+	}
+	if pending, _ := node.Blockchain().ReadPendingCrossLinks(); len(pending) != 0 {
+		t.Errorf("%d unsigned crosslinks added to the pending ones", len(pending))
+	}
+
+	// a stream serving crosslinks not matching the request is dropped
+	invalid := &testCrossLinkSource{head: source.head, err: light.ErrInvalidCrossLinks}
+	node.repairCrossLinks(1, invalid)
+	if len(invalid.requests) != 1 || len(invalid.removed) != 1 {
+		t.Errorf("%d requests and %d streams removed, expected 1 of each",
+			len(invalid.requests), len(invalid.removed))
+	}
+}
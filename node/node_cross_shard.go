@@ -1,6 +1,9 @@
 package node
 
 import (
+	"strconv"
+	"time"
+
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/rlp"
 	proto_node "github.com/nordicenergy/nordicenergy-core/api/proto/node"
@@ -11,6 +14,7 @@ import (
 	"github.com/nordicenergy/nordicenergy-core/p2p"
 	"github.com/nordicenergy/nordicenergy-core/shard"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 // BroadcastCXReceipts broadcasts cross shard receipts to correspoding
@@ -36,16 +40,28 @@ func (node *Node) BroadcastCXReceipts(newBlock *types.Block) {
 	myShardID := node.Consensus.ShardID
 	utils.Logger().Info().Int("shardNum", shardNum).Uint32("myShardID", myShardID).Uint64("blockNum", newBlock.NumberU64()).Msg("[BroadcastCXReceipts]")
 
+	now := time.Now()
 	for i := 0; i < shardNum; i++ {
 		if i == int(myShardID) {
 			continue
 		}
-		node.BroadcastCXReceiptsWithShardID(newBlock, commitSig, commitBitmap, uint32(i))
+		if !node.BroadcastCXReceiptsWithShardID(newBlock, commitSig, commitBitmap, uint32(i)) {
+			continue
+		}
+		// re-broadcast the receipts until their delivery is confirmed
+		// or the maximum number of attempts is reached
+		entry := core.CxEntry{BlockHash: newBlock.Hash(), ToShardID: uint32(i)}
+		if !node.CxPool.Track(entry, now) {
+			utils.Logger().Warn().Uint32("toShardID", uint32(i)).
+				Uint64("blockNum", newBlock.NumberU64()).
+				Msg("[BroadcastCXReceipts] CxPool full, receipts will not be re-broadcast")
+		}
 	}
 }
 
-// BroadcastCXReceiptsWithShardID broadcasts cross shard receipts to given ToShardID
-func (node *Node) BroadcastCXReceiptsWithShardID(block *types.Block, commitSig []byte, commitBitmap []byte, toShardID uint32) {
+// BroadcastCXReceiptsWithShardID broadcasts cross shard receipts to given
+// ToShardID. It returns whether the block had receipts to broadcast.
+func (node *Node) BroadcastCXReceiptsWithShardID(block *types.Block, commitSig []byte, commitBitmap []byte, toShardID uint32) bool {
 	myShardID := node.Consensus.ShardID
 	utils.Logger().Debug().
 		Uint32("toShardID", toShardID).
@@ -58,7 +74,7 @@ func (node *Node) BroadcastCXReceiptsWithShardID(block *types.Block, commitSig [
 		utils.Logger().Debug().Uint32("ToShardID", toShardID).
 			Int("numCXReceipts", len(cxReceipts)).
 			Msg("[CXMerkleProof] No receipts found for the destination shard")
-		return
+		return false
 	}
 
 	merkleProof, err := node.Blockchain().CXMerkleProof(toShardID, block)
@@ -66,7 +82,7 @@ func (node *Node) BroadcastCXReceiptsWithShardID(block *types.Block, commitSig [
 		utils.Logger().Warn().
 			Uint32("ToShardID", toShardID).
 			Msg("[BroadcastCXReceiptsWithShardID] Unable to get merkleProof")
-		return
+		return false
 	}
 
 	cxReceiptsProof := &types.CXReceiptsProof{
@@ -86,33 +102,89 @@ func (node *Node) BroadcastCXReceiptsWithShardID(block *types.Block, commitSig [
 	go node.host.SendMessageToGroups([]nodeconfig.GroupID{groupID},
 		p2p.ConstructMessage(proto_node.ConstructCXReceiptsProof(cxReceiptsProof)),
 	)
+	if node.cxBroadcasts != nil {
+		node.cxBroadcasts.Add(core.CxEntry{BlockHash: block.Hash(), ToShardID: toShardID}, time.Now().Unix())
+	}
+	return true
+}
+
+// CXReceiptsBroadcastTime returns the unix time the node last broadcast the
+// receipts proof of the block blockHash to toShardID, zero if it did not
+func (node *Node) CXReceiptsBroadcastTime(blockHash common.Hash, toShardID uint32) int64 {
+	if node.cxBroadcasts == nil {
+		return 0
+	}
+	if t, ok := node.cxBroadcasts.Get(core.CxEntry{BlockHash: blockHash, ToShardID: toShardID}); ok {
+		return t.(int64)
+	}
+	return 0
 }
 
-// BroadcastMissingCXReceipts broadcasts missing cross shard receipts per request
+// BroadcastMissingCXReceipts re-broadcasts the receipts of the CxPool due,
+// either requested by users or not confirmed delivered yet
 func (node *Node) BroadcastMissingCXReceipts() {
-	sendNextTime := []core.CxEntry{}
-	it := node.CxPool.Pool().Iterator()
-	for entry := range it.C {
-		cxEntry := entry.(core.CxEntry)
-		toShardID := cxEntry.ToShardID
-		blk := node.Blockchain().GetBlockByHash(cxEntry.BlockHash)
+	now := time.Now()
+	for _, entry := range node.CxPool.Due(now, maxCxRebroadcastsPerBlock) {
+		blk := node.Blockchain().GetBlockByHash(entry.BlockHash)
 		if blk == nil {
+			node.CxPool.Remove(entry)
+			nodeCxPoolCounterVec.With(prometheus.Labels{"result": "dropped"}).Inc()
 			continue
 		}
-		blockNum := blk.NumberU64()
-		nextHeader := node.Blockchain().GetHeaderByNumber(blockNum + 1)
+		if node.cxDelivered(blk, entry.ToShardID) {
+			node.CxPool.Remove(entry)
+			nodeCxPoolCounterVec.With(prometheus.Labels{"result": "delivered"}).Inc()
+			continue
+		}
+		nextHeader := node.Blockchain().GetHeaderByNumber(blk.NumberU64() + 1)
 		if nextHeader == nil {
-			sendNextTime = append(sendNextTime, cxEntry)
+			// this should not happen or maybe happen for impatient user
 			continue
 		}
 		sig := nextHeader.LastCommitSignature()
 		bitmap := nextHeader.LastCommitBitmap()
-		node.BroadcastCXReceiptsWithShardID(blk, sig[:], bitmap, toShardID)
+		node.BroadcastCXReceiptsWithShardID(blk, sig[:], bitmap, entry.ToShardID)
+		if node.CxPool.Retry(entry, now) {
+			nodeCxPoolCounterVec.With(prometheus.Labels{"result": "rebroadcast"}).Inc()
+		} else {
+			utils.Logger().Warn().Uint32("toShardID", entry.ToShardID).
+				Uint64("blockNum", blk.NumberU64()).
+				Msg("[BroadcastMissingCXReceipts] delivery not confirmed, giving up")
+			nodeCxPoolCounterVec.With(prometheus.Labels{"result": "expired"}).Inc()
+		}
+	}
+	node.updateCxPoolMetrics()
+}
+
+// canConfirmCxDelivery returns whether a node of fromShardID can tell the
+// receipts to toShardID were spent, which it can only for the beacon chain
+// it follows
+func canConfirmCxDelivery(fromShardID, toShardID uint32) bool {
+	return toShardID == shard.BeaconChainShardID && fromShardID != shard.BeaconChainShardID
+}
+
+// cxDelivered returns whether the destination shard is known to have spent
+// the receipts of blk. Receipts to shards the node cannot confirm are
+// re-broadcast until CxPoolMaxAttempts, the destination shard rejecting the
+// receipts already spent.
+func (node *Node) cxDelivered(blk *types.Block, toShardID uint32) bool {
+	if !canConfirmCxDelivery(blk.ShardID(), toShardID) {
+		return false
 	}
-	node.CxPool.Clear()
-	// this should not happen or maybe happen for impatient user
-	for _, entry := range sendNextTime {
-		node.CxPool.Add(entry)
+	return node.Beaconchain().IsSpent(&types.CXReceiptsProof{
+		MerkleProof: &types.CXMerkleProof{ShardID: blk.ShardID(), BlockNum: blk.Number()},
+	})
+}
+
+// updateCxPoolMetrics sets the number of receipts waiting for delivery per
+// destination shard
+func (node *Node) updateCxPoolMetrics() {
+	outstanding := node.CxPool.Outstanding()
+	shardNum := shard.Schedule.InstanceForEpoch(node.Blockchain().CurrentHeader().Epoch()).NumShards()
+	for i := uint32(0); i < shardNum; i++ {
+		nodeCxPoolGaugeVec.With(prometheus.Labels{
+			"to_shard": strconv.FormatUint(uint64(i), 10),
+		}).Set(float64(outstanding[i]))
 	}
 }
 
@@ -2,8 +2,6 @@ package node
 
 import (
 	"context"
-	"sort"
-	"sync"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/rlp"
@@ -11,13 +9,14 @@ import (
 	"github.com/nordicenergy/nordicenergy-core/api/service/explorer"
 	"github.com/nordicenergy/nordicenergy-core/consensus"
 	"github.com/nordicenergy/nordicenergy-core/consensus/signature"
+	"github.com/nordicenergy/nordicenergy-core/core"
 	"github.com/nordicenergy/nordicenergy-core/core/types"
+	"github.com/nordicenergy/nordicenergy-core/core/vm"
 	"github.com/nordicenergy/nordicenergy-core/internal/utils"
+	"github.com/nordicenergy/nordicenergy-core/ngy/tracers"
 	"github.com/pkg/errors"
 )
 
-var once sync.Once
-
 var (
 	errBlockBeforeCommit = errors.New(
 		"explorer hasnt received the block before the committed msg",
@@ -128,19 +127,9 @@ func (node *Node) AddNewBlockForExplorer(block *types.Block) {
 		}
 		// Clean up the blocks to avoid OOM.
 		node.Consensus.FBFTLog.DeleteBlockByNumber(block.NumberU64())
-		// Do dump all blocks from state syncing for explorer net time
-		// TODO: some blocks can be dumped before state syncing finished.
-		// And they would be dumped again here. Please fix it.
-		once.Do(func() {
-			utils.Logger().Info().Int64("starting height", int64(block.NumberU64())-1).
-				Msg("[Explorer] Populating explorer data from state synced blocks")
-			go func() {
-				for blockHeight := int64(block.NumberU64()) - 1; blockHeight >= 0; blockHeight-- {
-					explorer.GetStorageInstance(node.SelfPeer.IP, node.SelfPeer.Port).Dump(
-						node.Blockchain().GetBlockByNumber(uint64(blockHeight)), uint64(blockHeight))
-				}
-			}()
-		})
+		// Index in the background the blocks from state syncing, and the ones
+		// of an index of the previous format
+		explorer.GetStorageInstance(node.SelfPeer.IP, node.SelfPeer.Port).StartReindexer(node.Blockchain())
 	} else {
 		utils.Logger().Error().Err(err).Msg("[Explorer] Error when adding new block for explorer node")
 	}
@@ -153,7 +142,19 @@ func (node *Node) commitBlockForExplorer(block *types.Block) {
 	}
 	// Dump new block into level db.
 	utils.Logger().Info().Uint64("blockNum", block.NumberU64()).Msg("[Explorer] Committing block into explorer DB")
-	explorer.GetStorageInstance(node.SelfPeer.IP, node.SelfPeer.Port).Dump(block, block.NumberU64())
+	storage := explorer.GetStorageInstance(node.SelfPeer.IP, node.SelfPeer.Port)
+	storage.Dump(block, block.NumberU64())
+	storage.DumpTokenTransfers(block, node.Blockchain().GetReceiptsByHash(block.Hash()))
+	storage.DumpCxPayouts(block)
+	if node.NodeConfig.Explorer.TraceInternalTxs {
+		txs, err := node.traceInternalTxs(block)
+		if err != nil {
+			utils.Logger().Error().Err(err).Uint64("blockNum", block.NumberU64()).
+				Msg("[Explorer] cannot trace the internal transactions of block")
+		} else {
+			storage.DumpInternalTxs(block, txs)
+		}
+	}
 
 	curNum := block.NumberU64()
 	if curNum-100 > 0 {
@@ -162,114 +163,92 @@ func (node *Node) commitBlockForExplorer(block *types.Block) {
 	}
 }
 
-// GetTransactionsHistory returns list of transactions hashes of address.
-func (node *Node) GetTransactionsHistory(address, txType, order string) ([]common.Hash, error) {
-	addressData := &explorer.Address{}
-	key := explorer.GetAddressKey(address)
-	bytes, err := explorer.GetStorageInstance(node.SelfPeer.IP, node.SelfPeer.Port).GetDB().Get([]byte(key), nil)
+// traceInternalTxs executes again the transactions of block, on the state of
+// its parent, to trace the value transfers made by contracts
+func (node *Node) traceInternalTxs(block *types.Block) ([]*explorer.InternalTx, error) {
+	bc := node.Blockchain()
+	parent := bc.GetBlock(block.ParentHash(), block.NumberU64()-1)
+	if parent == nil {
+		return nil, errors.Errorf("parent %#x not found", block.ParentHash())
+	}
+	statedb, err := bc.StateAt(parent.Root())
 	if err != nil {
-		utils.Logger().Debug().Err(err).
-			Msgf("[Explorer] Error retrieving transaction history for address %s", address)
-		return make([]common.Hash, 0), nil
+		return nil, errors.Wrap(err, "cannot get the state of the parent block")
 	}
-	if err = rlp.DecodeBytes(bytes, &addressData); err != nil {
-		utils.Logger().Error().Err(err).Msg("[Explorer] Cannot convert address data from DB")
+	beneficiary, err := bc.GetECDSAFromCoinbase(block.Header())
+	if err != nil {
 		return nil, err
 	}
-	if order == "DESC" {
-		sort.Slice(addressData.TXs[:], func(i, j int) bool {
-			return addressData.TXs[i].Timestamp > addressData.TXs[j].Timestamp
-		})
-	} else {
-		sort.Slice(addressData.TXs[:], func(i, j int) bool {
-			return addressData.TXs[i].Timestamp < addressData.TXs[j].Timestamp
-		})
-	}
-	hashes := make([]common.Hash, 0)
-	for _, tx := range addressData.TXs {
-		if txType == "" || txType == "ALL" || txType == tx.Type {
-			hash := common.HexToHash(tx.Hash)
-			hashes = append(hashes, hash)
+
+	txs := []*explorer.InternalTx{}
+	gp, usedGas := new(core.GasPool).AddGas(block.GasLimit()), new(uint64)
+	for i, tx := range block.Transactions() {
+		tracer := tracers.NewInternalTxTracer()
+		statedb.Prepare(tx.Hash(), block.Hash(), i)
+		if _, _, _, err := core.ApplyTransaction(
+			bc.Config(), bc, &beneficiary, gp, statedb, block.Header(), tx, usedGas,
+			vm.Config{Debug: true, Tracer: tracer},
+		); err != nil {
+			return nil, errors.Wrapf(err, "cannot apply transaction %d", i)
+		}
+		for seq, itx := range tracer.InternalTxs() {
+			txs = append(txs, &explorer.InternalTx{
+				Type:     itx.Type,
+				From:     itx.From,
+				To:       itx.To,
+				Value:    itx.Value,
+				TxHash:   tx.HashByType(),
+				BlockNum: block.NumberU64(),
+				Time:     block.Time().Uint64(),
+				TxIndex:  uint32(i),
+				Seq:      uint32(seq),
+			})
 		}
 	}
-	return hashes, nil
+	return txs, nil
+}
+
+// GetTransactionsHistory returns list of transactions hashes of address.
+func (node *Node) GetTransactionsHistory(query explorer.TxHistoryQuery) ([]common.Hash, error) {
+	return explorer.GetStorageInstance(node.SelfPeer.IP, node.SelfPeer.Port).GetTxHistory(query, false)
 }
 
 // GetStakingTransactionsHistory returns list of staking transactions hashes of address.
-func (node *Node) GetStakingTransactionsHistory(address, txType, order string) ([]common.Hash, error) {
-	addressData := &explorer.Address{}
-	key := explorer.GetAddressKey(address)
-	bytes, err := explorer.GetStorageInstance(node.SelfPeer.IP, node.SelfPeer.Port).GetDB().Get([]byte(key), nil)
-	if err != nil {
-		utils.Logger().Debug().Err(err).
-			Msgf("[Explorer] Staking transaction history for address %s not found", address)
-		return make([]common.Hash, 0), nil
-	}
-	if err = rlp.DecodeBytes(bytes, &addressData); err != nil {
-		utils.Logger().Error().Err(err).Msg("[Explorer] Cannot convert address data from DB")
-		return nil, err
-	}
-	if order == "DESC" {
-		sort.Slice(addressData.StakingTXs[:], func(i, j int) bool {
-			return addressData.StakingTXs[i].Timestamp > addressData.StakingTXs[j].Timestamp
-		})
-	} else {
-		sort.Slice(addressData.StakingTXs[:], func(i, j int) bool {
-			return addressData.StakingTXs[i].Timestamp < addressData.StakingTXs[j].Timestamp
-		})
-	}
-	hashes := make([]common.Hash, 0)
-	for _, tx := range addressData.StakingTXs {
-		if txType == "" || txType == "ALL" || txType == tx.Type {
-			hash := common.HexToHash(tx.Hash)
-			hashes = append(hashes, hash)
-		}
-	}
-	return hashes, nil
+func (node *Node) GetStakingTransactionsHistory(query explorer.TxHistoryQuery) ([]common.Hash, error) {
+	return explorer.GetStorageInstance(node.SelfPeer.IP, node.SelfPeer.Port).GetTxHistory(query, true)
+}
+
+// GetCxPayoutsHistory returns list of hashes of the cross-shard transactions paid out to address.
+func (node *Node) GetCxPayoutsHistory(query explorer.TxHistoryQuery) ([]common.Hash, error) {
+	return explorer.GetStorageInstance(node.SelfPeer.IP, node.SelfPeer.Port).GetCxPayouts(query)
 }
 
 // GetTransactionsCount returns the number of regular transactions hashes of address for input type.
 func (node *Node) GetTransactionsCount(address, txType string) (uint64, error) {
-	addressData := &explorer.Address{}
-	key := explorer.GetAddressKey(address)
-	bytes, err := explorer.GetStorageInstance(node.SelfPeer.IP, node.SelfPeer.Port).GetDB().Get([]byte(key), nil)
-	if err != nil {
-		utils.Logger().Error().Err(err).Str("addr", address).Msg("[Explorer] Address not found")
-		return 0, nil
-	}
-	if err = rlp.DecodeBytes(bytes, &addressData); err != nil {
-		utils.Logger().Error().Err(err).Msg("[Explorer] Cannot convert address data from DB")
-		return 0, err
-	}
-
-	count := uint64(0)
-	for _, tx := range addressData.TXs {
-		if txType == "" || txType == "ALL" || txType == tx.Type {
-			count++
-		}
-	}
-	return count, nil
+	return explorer.GetStorageInstance(node.SelfPeer.IP, node.SelfPeer.Port).GetTxCount(address, txType, false)
 }
 
 // GetStakingTransactionsCount returns the number of staking transactions hashes of address for input type.
 func (node *Node) GetStakingTransactionsCount(address, txType string) (uint64, error) {
-	addressData := &explorer.Address{}
-	key := explorer.GetAddressKey(address)
-	bytes, err := explorer.GetStorageInstance(node.SelfPeer.IP, node.SelfPeer.Port).GetDB().Get([]byte(key), nil)
-	if err != nil {
-		utils.Logger().Error().Err(err).Str("addr", address).Msg("[Explorer] Address not found")
-		return 0, nil
-	}
-	if err = rlp.DecodeBytes(bytes, &addressData); err != nil {
-		utils.Logger().Error().Err(err).Msg("[Explorer] Cannot convert address data from DB")
-		return 0, err
-	}
+	return explorer.GetStorageInstance(node.SelfPeer.IP, node.SelfPeer.Port).GetTxCount(address, txType, true)
+}
 
-	count := uint64(0)
-	for _, tx := range addressData.StakingTXs {
-		if txType == "" || txType == "ALL" || txType == tx.Type {
-			count++
-		}
-	}
-	return count, nil
+// GetTokenTransfers returns the token transfers of the query.
+func (node *Node) GetTokenTransfers(query explorer.TokenTransferQuery) ([]*explorer.TokenTransfer, error) {
+	return explorer.GetStorageInstance(node.SelfPeer.IP, node.SelfPeer.Port).GetTokenTransfers(query)
+}
+
+// GetTokenBalances returns the balances of the tokens held by address.
+func (node *Node) GetTokenBalances(address string) ([]*explorer.TokenBalance, error) {
+	return explorer.GetStorageInstance(node.SelfPeer.IP, node.SelfPeer.Port).GetTokenBalances(address)
+}
+
+// GetTokenHolders returns the balances of the holders of token.
+func (node *Node) GetTokenHolders(token string, pageIndex, pageSize uint32) ([]*explorer.TokenBalance, error) {
+	return explorer.GetStorageInstance(node.SelfPeer.IP, node.SelfPeer.Port).GetTokenHolders(token, pageIndex, pageSize)
+}
+
+// GetInternalTransactionsHistory returns the internal transactions of the query.
+func (node *Node) GetInternalTransactionsHistory(query explorer.TxHistoryQuery) ([]*explorer.InternalTx, error) {
+	return explorer.GetStorageInstance(node.SelfPeer.IP, node.SelfPeer.Port).GetInternalTxs(query)
 }
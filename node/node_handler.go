@@ -6,6 +6,7 @@ import (
 	"math/rand"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/nordicenergy/nordicenergy-core/api/proto"
 	proto_node "github.com/nordicenergy/nordicenergy-core/api/proto/node"
@@ -371,8 +372,20 @@ func (node *Node) PostConsensusProcessing(newBlock *types.Block) error {
 	// Broadcast client requested missing cross shard receipts if there is any
 	node.BroadcastMissingCXReceipts()
 
+	node.publishBlockEvents(newBlock)
+
 	if h := node.NodeConfig.WebHooks.Hooks; h != nil {
 		if h.Availability != nil {
+			jailed := map[common.Address]availability.DowntimeRecord{}
+			if newBlock.IsLastBlockInEpoch() {
+				records, err := node.Beaconchain().ReadDowntimeRecords(newBlock.Epoch())
+				if err != nil {
+					utils.Logger().Err(err).Msg("failed reading downtime records")
+				}
+				for _, record := range records {
+					jailed[record.Validator] = record
+				}
+			}
 			for _, addr := range node.GetAddresses(newBlock.Epoch()) {
 				wrapper, err := node.Beaconchain().ReadValidatorInformation(addr)
 				if err != nil {
@@ -391,6 +404,13 @@ func (node *Node) PostConsensusProcessing(newBlock *types.Block) error {
 
 				computed.BlocksLeftInEpoch = lastBlockOfEpoch - node.Beaconchain().CurrentBlock().Header().Number().Uint64()
 
+				if record, ok := jailed[addr]; ok && h.Availability.OnJailed != "" {
+					url := h.Availability.OnJailed
+					go func() {
+						webhooks.DoPost(url, record)
+					}()
+				}
+
 				if err != nil && computed.IsBelowThreshold {
 					url := h.Availability.OnDroppedBelowThreshold
 					go func() {
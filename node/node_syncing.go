@@ -267,6 +267,7 @@ func (node *Node) doSync(bc *core.BlockChain, worker *worker.Worker, willJoinCon
 	// TODO: treat fake maximum height
 	if node.stateSync.IsOutOfSync(bc, true) {
 		node.IsInSync.UnSet()
+		node.publishSyncLag(bc)
 		if willJoinConsensus {
 			node.Consensus.BlocksNotSynchronized()
 		}
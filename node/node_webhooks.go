@@ -0,0 +1,180 @@
+package node
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/nordicenergy/nordicenergy-core/consensus"
+	"github.com/nordicenergy/nordicenergy-core/core"
+	"github.com/nordicenergy/nordicenergy-core/core/types"
+	common2 "github.com/nordicenergy/nordicenergy-core/internal/common"
+	"github.com/nordicenergy/nordicenergy-core/internal/utils"
+	"github.com/nordicenergy/nordicenergy-core/shard"
+	"github.com/nordicenergy/nordicenergy-core/webhooks"
+)
+
+// webhooksConfigInterval is the interval the hooks file is checked for
+// changes at
+const webhooksConfigInterval = 30 * time.Second
+
+// setupWebhooks starts delivering the node events to the subscriptions of
+// the hooks of the node config, keeping the pending deliveries in db
+func (node *Node) setupWebhooks(db ethdb.KeyValueStore) {
+	hooks := node.NodeConfig.WebHooks.Hooks
+	if hooks == nil {
+		return
+	}
+	d, err := webhooks.NewDispatcher(hooks, db)
+	if err != nil {
+		utils.Logger().Error().Err(err).Msg("[webhooks] cannot create the dispatcher")
+		return
+	}
+	node.SetWebhookDispatcher(d)
+	d.Start()
+	if path := node.NodeConfig.WebHooks.Path; path != "" {
+		go d.WatchConfig(path, webhooksConfigInterval)
+	}
+}
+
+// SetWebhookDispatcher sets the dispatcher the node publishes its events to
+func (node *Node) SetWebhookDispatcher(d *webhooks.Dispatcher) {
+	node.eventHooks = d
+	node.Consensus.OnViewChange = func(blockNum, nextViewID uint64, cause consensus.ViewChangeCause) {
+		event := node.newEvent(webhooks.EventViewChange, blockNum, map[string]interface{}{
+			"next-view-id": nextViewID,
+			"cause":        cause,
+		})
+		node.publishEvent(event)
+	}
+}
+
+func (node *Node) newEvent(t webhooks.EventType, blockNum uint64, data interface{}) *webhooks.Event {
+	epoch := node.Blockchain().CurrentHeader().Epoch().Uint64()
+	return webhooks.NewEvent(t, node.NodeConfig.ShardID, blockNum, epoch, data)
+}
+
+func (node *Node) publishEvent(event *webhooks.Event) {
+	if node.eventHooks != nil {
+		node.eventHooks.Publish(event)
+	}
+}
+
+// publishBlockEvents publishes the events of the committed block
+func (node *Node) publishBlockEvents(newBlock *types.Block) {
+	d := node.eventHooks
+	if d == nil {
+		return
+	}
+	header := newBlock.Header()
+	blockEvent := func(t webhooks.EventType, data interface{}) *webhooks.Event {
+		return webhooks.NewEvent(
+			t, header.ShardID(), header.Number().Uint64(), header.Epoch().Uint64(), data,
+		)
+	}
+	d.Publish(blockEvent(webhooks.EventNewBlock, map[string]interface{}{
+		"hash":             header.Hash().Hex(),
+		"view-id":          header.ViewID().Uint64(),
+		"num-txs":          len(newBlock.Transactions()),
+		"num-staking-txs":  len(newBlock.StakingTransactions()),
+		"num-cx-receipts":  len(newBlock.IncomingReceipts()),
+		"timestamp":        header.Time().Uint64(),
+		"parent-hash":      header.ParentHash().Hex(),
+		"coinbase-address": common2.MustAddressToBech32(header.Coinbase()),
+	}))
+
+	if ss := header.ShardState(); len(ss) > 0 && d.Subscribed(webhooks.EventCommitteeElection) {
+		state, err := shard.DecodeWrapper(ss)
+		if err != nil {
+			utils.Logger().Warn().Err(err).Uint64("blockNum", newBlock.NumberU64()).
+				Msg("[webhooks] cannot decode shard state")
+		} else {
+			committees := map[uint32]int{}
+			for _, c := range state.Shards {
+				committees[c.ShardID] = len(c.Slots)
+			}
+			d.Publish(blockEvent(webhooks.EventCommitteeElection, map[string]interface{}{
+				"epoch":      state.Epoch,
+				"committees": committees,
+			}))
+		}
+	}
+
+	if !newBlock.IsLastBlockInEpoch() {
+		return
+	}
+	d.Publish(blockEvent(webhooks.EventEpochChange, map[string]interface{}{
+		"next-epoch": header.Epoch().Uint64() + 1,
+	}))
+	if node.NodeConfig.ShardID == shard.BeaconChainShardID &&
+		d.Subscribed(webhooks.EventValidatorStatus) {
+		node.publishValidatorStatusChanges(blockEvent)
+	}
+}
+
+// publishValidatorStatusChanges publishes the validators whose status at the
+// end of the epoch differs from the one of their snapshot at its start
+func (node *Node) publishValidatorStatusChanges(
+	blockEvent func(t webhooks.EventType, data interface{}) *webhooks.Event,
+) {
+	addrs, err := node.Beaconchain().ReadValidatorList()
+	if err != nil {
+		utils.Logger().Warn().Err(err).Msg("[webhooks] cannot read validator list")
+		return
+	}
+	for _, addr := range addrs {
+		wrapper, err := node.Beaconchain().ReadValidatorInformation(addr)
+		if err != nil {
+			continue
+		}
+		snapshot, err := node.Beaconchain().ReadValidatorSnapshot(addr)
+		if err != nil || snapshot.Validator.Status == wrapper.Status {
+			continue
+		}
+		event := blockEvent(webhooks.EventValidatorStatus, map[string]interface{}{
+			"validator": common2.MustAddressToBech32(addr),
+			"previous":  snapshot.Validator.Status.String(),
+			"status":    wrapper.Status.String(),
+		})
+		event.Validators = append(event.Validators, addr)
+		node.eventHooks.Publish(event)
+	}
+}
+
+// checkPoolOverflow publishes the pool getting full, once until it is no
+// longer full
+func (node *Node) checkPoolOverflow(pending, queued int) {
+	if node.eventHooks == nil {
+		return
+	}
+	if uint64(pending+queued) < node.poolCapacity {
+		node.poolFull.UnSet()
+		return
+	}
+	if node.poolFull.SetToIf(false, true) {
+		node.publishEvent(node.newEvent(
+			webhooks.EventPoolOverflow,
+			node.Blockchain().CurrentHeader().Number().Uint64(),
+			map[string]interface{}{
+				"pending":  pending,
+				"queued":   queued,
+				"capacity": node.poolCapacity,
+			},
+		))
+	}
+}
+
+// publishSyncLag publishes the node being behind the highest of its peers
+func (node *Node) publishSyncLag(bc *core.BlockChain) {
+	if node.eventHooks == nil || !node.eventHooks.Subscribed(webhooks.EventSyncLag) {
+		return
+	}
+	current, peerHeight := bc.CurrentBlock().NumberU64(), node.stateSync.GetMaxPeerHeight()
+	if peerHeight <= current {
+		return
+	}
+	event := node.newEvent(webhooks.EventSyncLag, current, map[string]interface{}{
+		"peer-height": peerHeight,
+	})
+	event.SyncLag = peerHeight - current
+	node.publishEvent(event)
+}
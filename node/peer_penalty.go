@@ -0,0 +1,68 @@
+package node
+
+import (
+	libp2p_peer "github.com/libp2p/go-libp2p-core/peer"
+	"github.com/nordicenergy/nordicenergy-core/shard"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Reasons p2p messages are rejected for
+const (
+	rejectInvalidSize        = "invalid_size"
+	rejectInvalidCategory    = "invalid_category"
+	rejectInvalidBound       = "invalid_bound"
+	rejectInvalidMessage     = "invalid_message"
+	rejectWrongShard         = "wrong_shard"
+	rejectWrongViewID        = "wrong_view_id"
+	rejectInvalidKeySize     = "invalid_key_size"
+	rejectNotInCommittee     = "not_in_committee"
+	rejectInvalidBitmap      = "invalid_bitmap"
+	rejectInvalidSignature   = "invalid_signature"
+	rejectInvalidNodeMessage = "invalid_node_message"
+)
+
+// messagePenalties is how much the score of a peer goes down for a message it
+// published rejected for the reason. Malformed messages and bad signatures
+// cost the most, since an honest node never sends them. Messages an honest
+// node could send from a slightly different view of the chain, like a view ID
+// it did not catch up on yet, cost little, so that only floods of them get the
+// peer blocked.
+var messagePenalties = map[string]float64{
+	rejectInvalidSize:        100,
+	rejectInvalidCategory:    100,
+	rejectInvalidBound:       100,
+	rejectInvalidMessage:     100,
+	rejectWrongShard:         10,
+	rejectWrongViewID:        1,
+	rejectInvalidKeySize:     100,
+	rejectNotInCommittee:     10,
+	rejectInvalidBitmap:      10,
+	rejectInvalidSignature:   100,
+	rejectInvalidNodeMessage: 10,
+}
+
+// consensusRejectReason returns the reason a consensus message failed
+// validation with err
+func consensusRejectReason(err error) string {
+	switch errors.Cause(err) {
+	case errWrongShardID:
+		return rejectWrongShard
+	case errWrongViewID:
+		return rejectWrongViewID
+	case errNotRightKeySize:
+		return rejectInvalidKeySize
+	case shard.ErrValidNotInCommittee:
+		return rejectNotInCommittee
+	case errWrongSizeOfBitmap:
+		return rejectInvalidBitmap
+	}
+	return rejectInvalidMessage
+}
+
+// rejectMessage counts a message rejected for the reason and penalizes the
+// peer that published it
+func (node *Node) rejectMessage(peer libp2p_peer.ID, reason string) {
+	nodeRejectedMessageCounterVec.With(prometheus.Labels{"reason": reason}).Inc()
+	node.host.PenalizePeer(peer, messagePenalties[reason])
+}
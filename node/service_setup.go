@@ -7,6 +7,7 @@ import (
 	"github.com/nordicenergy/nordicenergy-core/api/service/blockproposal"
 	"github.com/nordicenergy/nordicenergy-core/api/service/consensus"
 	"github.com/nordicenergy/nordicenergy-core/api/service/explorer"
+	"github.com/nordicenergy/nordicenergy-core/api/service/exporter"
 )
 
 // RegisterValidatorServices register the validator services.
@@ -31,6 +32,17 @@ func (node *Node) RegisterExplorerServices() {
 	)
 }
 
+// RegisterExporterService registers the service exporting the chain to the
+// sinks of config
+func (node *Node) RegisterExporterService(config exporter.Config) error {
+	s, err := exporter.New(config, node.Blockchain())
+	if err != nil {
+		return err
+	}
+	node.serviceManager.Register(service.Exporter, s)
+	return nil
+}
+
 // RegisterService register a service to the node service manager
 func (node *Node) RegisterService(st service.Type, s service.Service) {
 	node.serviceManager.Register(st, s)
@@ -21,6 +21,7 @@ import (
 	libp2p_network "github.com/libp2p/go-libp2p-core/network"
 	libp2p_peer "github.com/libp2p/go-libp2p-core/peer"
 	libp2p_peerstore "github.com/libp2p/go-libp2p-core/peerstore"
+	libp2p_protocol "github.com/libp2p/go-libp2p-core/protocol"
 	libp2p_pubsub "github.com/libp2p/go-libp2p-pubsub"
 	ma "github.com/multiformats/go-multiaddr"
 	"github.com/pkg/errors"
@@ -48,6 +49,11 @@ type Host interface {
 	ListPeer(topic string) []libp2p_peer.ID
 	ListTopic() []string
 	ListBlockedPeer() []libp2p_peer.ID
+	// PenalizePeer lowers the score of a peer for a bad message it published,
+	// and blocks it once the score reaches BlockPeerScore
+	PenalizePeer(peer libp2p_peer.ID, penalty float64)
+	// BlockPeer drops the messages of a peer and disconnects from it
+	BlockPeer(peer libp2p_peer.ID)
 }
 
 // Peer is the object for a p2p peer (node)
@@ -109,6 +115,8 @@ func NewHost(cfg HostConfig) (Host, error) {
 		return nil, errors.Wrap(err, "cannot create DHT discovery")
 	}
 
+	scorer := newPeerScorer()
+	blocklist := libp2p_pubsub.NewMapBlacklist()
 	options := []libp2p_pubsub.Option{
 		// WithValidateQueueSize sets the buffer of validate queue. Defaults to 32. When queue is full, validation is throttled and new messages are dropped.
 		libp2p_pubsub.WithValidateQueueSize(512),
@@ -120,6 +128,10 @@ func NewHost(cfg HostConfig) (Host, error) {
 		libp2p_pubsub.WithValidateThrottle(MaxMessageHandlers),
 		libp2p_pubsub.WithMaxMessageSize(MaxMessageSize),
 		libp2p_pubsub.WithDiscovery(disc.GetRawDiscovery()),
+		// WithPeerScore scores peers by the messages they publish, see PenalizePeer
+		libp2p_pubsub.WithPeerScore(peerScoreParams(scorer), peerScoreThresholds),
+		// WithBlacklist drops the messages of and from the blocked peers
+		libp2p_pubsub.WithBlacklist(blocklist),
 	}
 
 	traceFile := os.Getenv("P2P_TRACEFILE")
@@ -160,6 +172,9 @@ func NewHost(cfg HostConfig) (Host, error) {
 		priKey:    key,
 		discovery: disc,
 		logger:    &subLogger,
+		scorer:    scorer,
+		blocklist: blocklist,
+		blocked:   map[libp2p_peer.ID]struct{}{},
 		ctx:       ctx,
 		cancel:    cancel,
 	}
@@ -183,7 +198,9 @@ type HostV2 struct {
 	lock         sync.Mutex
 	discovery    discovery.Discovery
 	logger       *zerolog.Logger
+	scorer       *peerScorer
 	blocklist    libp2p_pubsub.Blacklist
+	blocked      map[libp2p_peer.ID]struct{}
 	ctx          context.Context
 	cancel       func()
 }
@@ -228,10 +245,13 @@ func (host *HostV2) C() (int, int, int) {
 }
 
 // AddStreamProtocol adds the stream protocols to the host to be started and closed
-// when the host starts or close
+// when the host starts or close, and handles their incoming streams
 func (host *HostV2) AddStreamProtocol(protocols ...sttypes.Protocol) {
 	for _, protocol := range protocols {
 		host.streamProtos = append(host.streamProtos, protocol)
+		host.h.SetStreamHandlerMatch(
+			libp2p_protocol.ID(protocol.ProtoID()), protocol.Match, protocol.HandleStream,
+		)
 	}
 }
 
@@ -347,11 +367,43 @@ func (host *HostV2) ListPeer(topic string) []libp2p_peer.ID {
 
 // ListBlockedPeer returns list of blocked peer
 func (host *HostV2) ListBlockedPeer() []libp2p_peer.ID {
-	// TODO: this is a place holder for now
-	peers := make([]libp2p_peer.ID, 0)
+	host.lock.Lock()
+	defer host.lock.Unlock()
+	peers := make([]libp2p_peer.ID, 0, len(host.blocked))
+	for peer := range host.blocked {
+		peers = append(peers, peer)
+	}
 	return peers
 }
 
+// PenalizePeer lowers the score of peer by penalty, and blocks peer once its
+// score reaches BlockPeerScore
+func (host *HostV2) PenalizePeer(peer libp2p_peer.ID, penalty float64) {
+	if peer == "" || peer == host.GetID() {
+		return
+	}
+	if score := host.scorer.penalize(peer, penalty); score <= BlockPeerScore {
+		host.BlockPeer(peer)
+	}
+}
+
+// BlockPeer drops the messages of and from peer and disconnects from it
+func (host *HostV2) BlockPeer(peer libp2p_peer.ID) {
+	host.lock.Lock()
+	if _, ok := host.blocked[peer]; ok {
+		host.lock.Unlock()
+		return
+	}
+	host.blocked[peer] = struct{}{}
+	host.lock.Unlock()
+
+	host.blocklist.Add(peer)
+	if err := host.h.Network().ClosePeer(peer); err != nil {
+		host.logger.Warn().Err(err).Str("peer", peer.Pretty()).Msg("cannot disconnect blocked peer")
+	}
+	host.logger.Info().Str("peer", peer.Pretty()).Msg("peer blocked")
+}
+
 // GetPeerCount ...
 func (host *HostV2) GetPeerCount() int {
 	return host.h.Peerstore().Peers().Len()
@@ -0,0 +1,109 @@
+package p2p
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	libp2p_peer "github.com/libp2p/go-libp2p-core/peer"
+	libp2p_pubsub "github.com/libp2p/go-libp2p-pubsub"
+)
+
+const (
+	// BlockPeerScore is the score at which a peer gets blocked
+	BlockPeerScore = -1000
+	// peerScoreHalfLife is the time it takes for the score of a peer to go
+	// half way back to zero
+	peerScoreHalfLife = 10 * time.Minute
+	// maxScoredPeers is the number of scored peers above which the peers with
+	// a score back to about zero are forgotten
+	maxScoredPeers = 10000
+)
+
+// peerScoreParams score peers only by the score the node gives them through
+// PenalizePeer, the gossipsub topic scores are left out
+func peerScoreParams(scorer *peerScorer) *libp2p_pubsub.PeerScoreParams {
+	return &libp2p_pubsub.PeerScoreParams{
+		Topics:            map[string]*libp2p_pubsub.TopicScoreParams{},
+		AppSpecificScore:  scorer.score,
+		AppSpecificWeight: 1,
+		DecayInterval:     time.Second,
+		DecayToZero:       0.01,
+		RetainScore:       time.Hour,
+	}
+}
+
+// peerScoreThresholds stop gossiping with peers a quarter of the way to being
+// blocked, stop publishing to them half of the way there and ignore their
+// messages three quarters of the way there
+var peerScoreThresholds = &libp2p_pubsub.PeerScoreThresholds{
+	GossipThreshold:   BlockPeerScore / 4,
+	PublishThreshold:  BlockPeerScore / 2,
+	GraylistThreshold: BlockPeerScore * 3 / 4,
+}
+
+type peerScore struct {
+	value   float64
+	updated time.Time
+}
+
+// peerScorer keeps the scores the node gives peers for the messages they
+// publish. A score only goes down with penalties, and decays back to zero
+// over time so that occasional bad messages of an honest peer are forgotten.
+type peerScorer struct {
+	lock   sync.Mutex
+	scores map[libp2p_peer.ID]*peerScore
+	now    func() time.Time
+}
+
+func newPeerScorer() *peerScorer {
+	return &peerScorer{
+		scores: map[libp2p_peer.ID]*peerScore{},
+		now:    time.Now,
+	}
+}
+
+// decayed returns s decayed to now
+func (s *peerScore) decayed(now time.Time) float64 {
+	elapsed := now.Sub(s.updated)
+	if elapsed <= 0 {
+		return s.value
+	}
+	return s.value * math.Pow(0.5, float64(elapsed)/float64(peerScoreHalfLife))
+}
+
+// score returns the current score of peer
+func (ps *peerScorer) score(peer libp2p_peer.ID) float64 {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+	if s, ok := ps.scores[peer]; ok {
+		return s.decayed(ps.now())
+	}
+	return 0
+}
+
+// penalize lowers the score of peer by penalty and returns the new score
+func (ps *peerScorer) penalize(peer libp2p_peer.ID, penalty float64) float64 {
+	ps.lock.Lock()
+	defer ps.lock.Unlock()
+	now := ps.now()
+	s, ok := ps.scores[peer]
+	if !ok {
+		if len(ps.scores) >= maxScoredPeers {
+			ps.prune(now)
+		}
+		s = &peerScore{}
+		ps.scores[peer] = s
+	}
+	s.value, s.updated = s.decayed(now)-penalty, now
+	return s.value
+}
+
+// prune forgets the peers whose score is back to about zero
+func (ps *peerScorer) prune(now time.Time) {
+	for peer, s := range ps.scores {
+		if s.decayed(now) > -1 {
+			delete(ps.scores, peer)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	libp2p_peer "github.com/libp2p/go-libp2p-core/peer"
+)
+
+func TestPeerScorer(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	scorer := newPeerScorer()
+	scorer.now = func() time.Time { return now }
+	spammer, honest := libp2p_peer.ID("spammer"), libp2p_peer.ID("honest")
+
+	if score := scorer.score(honest); score != 0 {
+		t.Errorf("score of an unknown peer is %v, expected 0", score)
+	}
+	scorer.penalize(honest, 10)
+	for i := 0; i < 9; i++ {
+		scorer.penalize(spammer, 100)
+	}
+	if score := scorer.penalize(spammer, 100); score > BlockPeerScore {
+		t.Errorf("score of the spammer is %v, expected to reach %v", score, BlockPeerScore)
+	}
+
+	// penalties decay by half every half life
+	now = now.Add(peerScoreHalfLife)
+	if score := scorer.score(honest); score != -5 {
+		t.Errorf("score of the honest peer after a half life is %v, expected -5", score)
+	}
+	if score := scorer.score(spammer); score != -500 {
+		t.Errorf("score of the spammer after a half life is %v, expected -500", score)
+	}
+
+	// only the peers with a score back to about zero are forgotten
+	now = now.Add(4 * peerScoreHalfLife)
+	scorer.prune(now)
+	if _, ok := scorer.scores[honest]; ok {
+		t.Error("honest peer not forgotten")
+	}
+	if _, ok := scorer.scores[spammer]; !ok {
+		t.Error("spammer forgotten")
+	}
+}
@@ -0,0 +1,125 @@
+package light
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/nordicenergy/nordicenergy-core/core/types"
+	lightclient "github.com/nordicenergy/nordicenergy-core/light"
+	sttypes "github.com/nordicenergy/nordicenergy-core/p2p/stream/types"
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrNoStream is returned when there is no stream to send a request on
+	ErrNoStream = errors.New("no light protocol stream")
+	// ErrInvalidCrossLinks is returned for crosslinks of another shard or
+	// blocks than requested
+	ErrInvalidCrossLinks = errors.New("crosslinks not matching the request")
+)
+
+// GetEpochHeader fetches the last beacon chain header of epoch with its
+// commit signature, to follow the committees with
+// lightclient.Client.AddEpochHeader. It returns the ID of the stream that
+// served it, for the caller to drop the stream if the header does not verify.
+func (p *Protocol) GetEpochHeader(ctx context.Context, epoch uint64) (*SignedHeader, sttypes.StreamID, error) {
+	var resp SignedHeader
+	stid, err := p.doRequest(ctx, codeGetEpochHeader, getEpochHeaderRequest{epoch}, &resp)
+	if err != nil {
+		return nil, stid, err
+	}
+	return &resp, stid, nil
+}
+
+// GetHeader fetches the header of number with its commit signature
+func (p *Protocol) GetHeader(ctx context.Context, number uint64) (*SignedHeader, sttypes.StreamID, error) {
+	var resp SignedHeader
+	stid, err := p.doRequest(ctx, codeGetHeader, getHeaderRequest{number}, &resp)
+	if err != nil {
+		return nil, stid, err
+	}
+	return &resp, stid, nil
+}
+
+// GetAccountProof fetches the proof of the account of address in the state of
+// the block of number, verified by lightclient.VerifyAccountProof
+func (p *Protocol) GetAccountProof(
+	ctx context.Context, number uint64, address common.Address,
+) (lightclient.Proof, sttypes.StreamID, error) {
+	var resp lightclient.Proof
+	stid, err := p.doRequest(ctx, codeGetAccountProof, getAccountProofRequest{number, address}, &resp)
+	return resp, stid, err
+}
+
+// GetReceiptProof fetches the proof of the index-th receipt of the block of
+// number, verified by lightclient.VerifyReceiptProof
+func (p *Protocol) GetReceiptProof(
+	ctx context.Context, number, index uint64,
+) (lightclient.Proof, sttypes.StreamID, error) {
+	var resp lightclient.Proof
+	stid, err := p.doRequest(ctx, codeGetReceiptProof, getReceiptProofRequest{number, index}, &resp)
+	return resp, stid, err
+}
+
+// GetHead fetches the number of the current block of the shard
+func (p *Protocol) GetHead(ctx context.Context) (uint64, sttypes.StreamID, error) {
+	var resp uint64
+	stid, err := p.doRequest(ctx, codeGetHead, getHeadRequest{}, &resp)
+	return resp, stid, err
+}
+
+// GetCrossLinks fetches the crosslinks of up to count blocks of the shard
+// from the block of number from, with ErrInvalidCrossLinks if the stream
+// served others. The signatures of the crosslinks are to be verified by the
+// caller.
+func (p *Protocol) GetCrossLinks(
+	ctx context.Context, from, count uint64,
+) ([]types.CrossLink, sttypes.StreamID, error) {
+	var resp []types.CrossLink
+	stid, err := p.doRequest(ctx, codeGetCrossLinks, getCrossLinksRequest{from, count}, &resp)
+	if err != nil {
+		return nil, stid, err
+	}
+	if uint64(len(resp)) > count {
+		return nil, stid, ErrInvalidCrossLinks
+	}
+	for i, cl := range resp {
+		if cl.ShardID() != uint32(p.protoSpec.ShardID) || cl.BlockNum() != from+uint64(i) {
+			return nil, stid, ErrInvalidCrossLinks
+		}
+	}
+	return resp, stid, nil
+}
+
+// RemoveStream closes and drops a stream, for one that served invalid data
+func (p *Protocol) RemoveStream(stid sttypes.StreamID) {
+	for _, st := range p.streams() {
+		if st.ID() == stid {
+			st.close()
+			return
+		}
+	}
+}
+
+// doRequest sends the request of code on a stream and decodes the response
+// into resp. It tries the streams one after the other until one answers.
+func (p *Protocol) doRequest(
+	ctx context.Context, code uint64, req interface{}, resp interface{},
+) (sttypes.StreamID, error) {
+	msg, err := newRequest(code, req)
+	if err != nil {
+		return "", err
+	}
+	err = ErrNoStream
+	for _, st := range p.streams() {
+		var respMsg *message
+		if respMsg, err = st.request(ctx, msg); err != nil {
+			if ctx.Err() != nil {
+				return "", err
+			}
+			continue
+		}
+		return st.ID(), respMsg.decodeResponse(resp)
+	}
+	return "", err
+}
@@ -0,0 +1,138 @@
+package light
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/nordicenergy/nordicenergy-core/block"
+	"github.com/nordicenergy/nordicenergy-core/core/types"
+	"github.com/nordicenergy/nordicenergy-core/crypto/bls"
+	"github.com/pkg/errors"
+)
+
+// Codes of the requests of the protocol. The response to a request has the
+// code of the request.
+const (
+	codeGetEpochHeader uint64 = iota + 1
+	codeGetHeader
+	codeGetAccountProof
+	codeGetReceiptProof
+	codeGetHead
+	codeGetCrossLinks
+)
+
+// MaxCrossLinksPerRequest is the maximum number of crosslinks served per request
+const MaxCrossLinksPerRequest = 64
+
+// message is a request or a response of the protocol. Messages are RLP
+// encoded so that light clients only need the chain encodings.
+type message struct {
+	ReqID      uint64
+	Code       uint64
+	IsResponse bool
+	// Payload is the RLP encoded request or response of the code
+	Payload []byte
+	// Error is the reason the request failed, the payload is then empty
+	Error string
+}
+
+func (msg *message) String() string {
+	kind := "request"
+	if msg.IsResponse {
+		kind = "response"
+	}
+	return fmt.Sprintf("%s %d code %d", kind, msg.ReqID, msg.Code)
+}
+
+func newRequest(code uint64, req interface{}) (*message, error) {
+	payload, err := rlp.EncodeToBytes(req)
+	if err != nil {
+		return nil, err
+	}
+	return &message{Code: code, Payload: payload}, nil
+}
+
+// decodeResponse decodes the payload of a response into resp
+func (msg *message) decodeResponse(resp interface{}) error {
+	if msg.Error != "" {
+		return &ResponseError{msg.Error}
+	}
+	return rlp.DecodeBytes(msg.Payload, resp)
+}
+
+// ResponseError is the error from an error response
+type ResponseError struct {
+	msg string
+}
+
+// Error is the error string of ResponseError
+func (err *ResponseError) Error() string {
+	return fmt.Sprintf("[RESPONSE] %v", err.msg)
+}
+
+type getEpochHeaderRequest struct {
+	Epoch uint64
+}
+
+type getHeaderRequest struct {
+	Number uint64
+}
+
+type getAccountProofRequest struct {
+	Number  uint64
+	Address common.Address
+}
+
+type getReceiptProofRequest struct {
+	Number uint64
+	Index  uint64
+}
+
+type getHeadRequest struct{}
+
+type getCrossLinksRequest struct {
+	From  uint64
+	Count uint64
+}
+
+// SignedHeader is a header with the commit signature on it
+type SignedHeader struct {
+	Header       *block.Header
+	CommitSig    []byte
+	CommitBitmap []byte
+}
+
+var errInvalidCommitSig = errors.New("commit signature too short")
+
+// newSignedHeader returns header with its commit signature commitSigAndBitmap,
+// as stored by the chain
+func newSignedHeader(header *block.Header, commitSigAndBitmap []byte) (*SignedHeader, error) {
+	if len(commitSigAndBitmap) < bls.BLSSignatureSizeInBytes {
+		return nil, errInvalidCommitSig
+	}
+	return &SignedHeader{
+		Header:       header,
+		CommitSig:    commitSigAndBitmap[:bls.BLSSignatureSizeInBytes],
+		CommitBitmap: commitSigAndBitmap[bls.BLSSignatureSizeInBytes:],
+	}, nil
+}
+
+// newCrossLink returns the crosslink of header with its commit signature
+// commitSigAndBitmap, as stored by the chain
+func newCrossLink(header *block.Header, commitSigAndBitmap []byte) (*types.CrossLink, error) {
+	signed, err := newSignedHeader(header, commitSigAndBitmap)
+	if err != nil {
+		return nil, err
+	}
+	cl := &types.CrossLink{
+		HashF:        header.Hash(),
+		BlockNumberF: header.Number(),
+		ViewIDF:      header.ViewID(),
+		BitmapF:      signed.CommitBitmap,
+		ShardIDF:     header.ShardID(),
+		EpochF:       header.Epoch(),
+	}
+	copy(cl.SignatureF[:], signed.CommitSig)
+	return cl, nil
+}
@@ -0,0 +1,199 @@
+// Package light is the stream protocol light clients fetch the data they
+// verify with the light package from: the epoch headers of the beacon chain
+// carrying the committees, headers of a shard with their commit signature,
+// and the proofs of accounts and receipts. The beacon chain also fetches the
+// crosslinks missing from its chain from the nodes of the shards.
+package light
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-version"
+	libp2p_host "github.com/libp2p/go-libp2p-core/host"
+	libp2p_network "github.com/libp2p/go-libp2p-core/network"
+	nodeconfig "github.com/nordicenergy/nordicenergy-core/internal/configs/node"
+	"github.com/nordicenergy/nordicenergy-core/internal/utils"
+	"github.com/nordicenergy/nordicenergy-core/p2p/discovery"
+	"github.com/nordicenergy/nordicenergy-core/p2p/stream/common/ratelimiter"
+	"github.com/nordicenergy/nordicenergy-core/p2p/stream/common/streammanager"
+	sttypes "github.com/nordicenergy/nordicenergy-core/p2p/stream/types"
+	"github.com/rs/zerolog"
+)
+
+const (
+	// serviceSpecifier is the service of the protocol ID
+	serviceSpecifier = "light"
+	// protoVersion is the version of the protocol
+	protoVersion = "1.0.0"
+
+	// advertiseInterval is the interval the node advertises it serves light
+	// clients at when the advertisement fails
+	advertiseInterval = time.Minute
+
+	// DefaultGlobalRateLimit is the default number of requests served per second
+	DefaultGlobalRateLimit = 100
+	// DefaultStreamRateLimit is the default number of requests served per
+	// second on a stream
+	DefaultStreamRateLimit = 10
+)
+
+var (
+	// MyVersion is the version of the protocol
+	MyVersion = version.Must(version.NewVersion(protoVersion))
+
+	// DefaultStreamManagerConfig is the default config of the stream manager
+	DefaultStreamManagerConfig = streammanager.Config{
+		HardLoCap: 2,
+		SoftLoCap: 4,
+		HiCap:     64,
+		DiscBatch: 8,
+	}
+)
+
+// Config is the config of the protocol
+type Config struct {
+	// Chain is the chain the node serves light clients from, nil for a light
+	// client not serving any
+	Chain     Chain
+	Host      libp2p_host.Host
+	Discovery discovery.Discovery
+	ShardID   nodeconfig.ShardID
+	Network   nodeconfig.NetworkType
+	SmConfig  streammanager.Config
+	// GlobalRateLimit and StreamRateLimit are the number of requests per
+	// second served in total and on each stream, the defaults if zero
+	GlobalRateLimit int
+	StreamRateLimit int
+}
+
+// Protocol is the light client protocol
+type Protocol struct {
+	chain     Chain
+	disc      discovery.Discovery
+	protoSpec sttypes.ProtoSpec
+
+	sm streammanager.StreamManager
+	rl ratelimiter.RateLimiter
+
+	logger zerolog.Logger
+	ctx    context.Context
+	cancel func()
+}
+
+// NewProtocol creates the light client protocol
+func NewProtocol(config Config) *Protocol {
+	ctx, cancel := context.WithCancel(context.Background())
+	p := &Protocol{
+		chain: config.Chain,
+		disc:  config.Discovery,
+		protoSpec: sttypes.ProtoSpec{
+			Service:     serviceSpecifier,
+			NetworkType: config.Network,
+			ShardID:     config.ShardID,
+			Version:     MyVersion,
+		},
+		ctx:    ctx,
+		cancel: cancel,
+	}
+	p.logger = utils.Logger().With().Str("protocol", string(p.ProtoID())).Logger()
+	p.sm = streammanager.NewStreamManager(
+		p.ProtoID(), config.Host, config.Discovery, p.HandleStream, config.SmConfig,
+	)
+	globalRate, streamRate := config.GlobalRateLimit, config.StreamRateLimit
+	if globalRate == 0 {
+		globalRate = DefaultGlobalRateLimit
+	}
+	if streamRate == 0 {
+		streamRate = DefaultStreamRateLimit
+	}
+	p.rl = ratelimiter.NewRateLimiter(p.sm, globalRate, streamRate)
+	return p
+}
+
+// Start starts the protocol
+func (p *Protocol) Start() {
+	p.sm.Start()
+	p.rl.Start()
+	if p.chain != nil {
+		go p.advertiseLoop()
+	}
+}
+
+// Close closes the protocol
+func (p *Protocol) Close() {
+	p.cancel()
+	p.rl.Close()
+	p.sm.Close()
+}
+
+// Specifier returns the specifier of the protocol
+func (p *Protocol) Specifier() string {
+	return fmt.Sprintf("%s/%s/%d", serviceSpecifier, p.protoSpec.NetworkType, p.protoSpec.ShardID)
+}
+
+// Version returns the version of the protocol
+func (p *Protocol) Version() *version.Version {
+	return MyVersion
+}
+
+// ProtoID returns the protocol ID
+func (p *Protocol) ProtoID() sttypes.ProtoID {
+	return p.protoSpec.ToProtoID()
+}
+
+// Match returns whether the protocol ID of a remote peer can be served, that
+// is for the same service, network and shard with the same major version
+func (p *Protocol) Match(targetID string) bool {
+	spec, err := sttypes.ProtoIDToProtoSpec(sttypes.ProtoID(targetID))
+	if err != nil {
+		return false
+	}
+	return spec.Service == p.protoSpec.Service &&
+		spec.NetworkType == p.protoSpec.NetworkType &&
+		spec.ShardID == p.protoSpec.ShardID &&
+		spec.Version.Segments()[0] == MyVersion.Segments()[0]
+}
+
+// HandleStream handles a new stream of the protocol, opened by either side
+func (p *Protocol) HandleStream(raw libp2p_network.Stream) {
+	st := newStream(sttypes.NewBaseStream(raw), p)
+	if err := p.sm.NewStream(st); err != nil {
+		p.logger.Info().Err(err).Str("stream ID", string(st.ID())).
+			Msg("stream rejected")
+		if err := raw.Reset(); err != nil {
+			p.logger.Debug().Err(err).Msg("cannot reset stream")
+		}
+		return
+	}
+	st.run()
+}
+
+// advertiseLoop advertises that the node serves light clients
+func (p *Protocol) advertiseLoop() {
+	for {
+		ttl, err := p.disc.Advertise(p.ctx, string(p.ProtoID()))
+		if err != nil {
+			p.logger.Debug().Err(err).Msg("cannot advertise light protocol")
+			ttl = advertiseInterval
+		}
+		select {
+		case <-time.After(ttl):
+		case <-p.ctx.Done():
+			return
+		}
+	}
+}
+
+// streams returns the streams of the protocol
+func (p *Protocol) streams() []*stream {
+	sts := p.sm.GetStreams()
+	res := make([]*stream, 0, len(sts))
+	for _, st := range sts {
+		if lst, ok := st.(*stream); ok {
+			res = append(res, lst)
+		}
+	}
+	return res
+}
@@ -0,0 +1,241 @@
+package light
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/nordicenergy/nordicenergy-core/block"
+	blockfactory "github.com/nordicenergy/nordicenergy-core/block/factory"
+	"github.com/nordicenergy/nordicenergy-core/core/state"
+	"github.com/nordicenergy/nordicenergy-core/core/types"
+	"github.com/nordicenergy/nordicenergy-core/crypto/bls"
+	lightclient "github.com/nordicenergy/nordicenergy-core/light"
+	sttypes "github.com/nordicenergy/nordicenergy-core/p2p/stream/types"
+)
+
+func TestProtocolRequests(t *testing.T) {
+	receipts := types.Receipts{}
+	for i := 0; i < 5; i++ {
+		receipts = append(receipts, types.NewReceipt(nil, false, uint64(21000*(i+1))))
+	}
+	header := blockfactory.NewTestHeader().With().
+		Number(big.NewInt(10)).
+		ReceiptHash(types.DeriveSha(receipts)).
+		Header()
+	chain := &testChain{
+		header:   header,
+		sig:      make([]byte, bls.BLSSignatureSizeInBytes+2),
+		receipts: receipts,
+	}
+	client, server := newTestProtocol(nil), newTestProtocol(chain)
+	clientSt, serverSt := newTestStreamPair(client, server)
+	go clientSt.run()
+	go serverSt.run()
+	defer clientSt.close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	msg, _ := newRequest(codeGetHeader, getHeaderRequest{10})
+	resp, err := clientSt.request(ctx, msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var signed SignedHeader
+	if err := resp.decodeResponse(&signed); err != nil {
+		t.Fatal(err)
+	}
+	if signed.Header.Hash() != header.Hash() {
+		t.Errorf("header %v, expected %v", signed.Header.Hash(), header.Hash())
+	}
+	if len(signed.CommitBitmap) != 2 {
+		t.Errorf("commit bitmap of %d bytes, expected 2", len(signed.CommitBitmap))
+	}
+
+	msg, _ = newRequest(codeGetReceiptProof, getReceiptProofRequest{10, 3})
+	if resp, err = clientSt.request(ctx, msg); err != nil {
+		t.Fatal(err)
+	}
+	var proof lightclient.Proof
+	if err := resp.decodeResponse(&proof); err != nil {
+		t.Fatal(err)
+	}
+	receipt, err := lightclient.VerifyReceiptProof(header, 3, proof)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if receipt.CumulativeGasUsed != receipts[3].CumulativeGasUsed {
+		t.Errorf("receipt gas %d, expected %d", receipt.CumulativeGasUsed, receipts[3].CumulativeGasUsed)
+	}
+
+	msg, _ = newRequest(codeGetHead, getHeadRequest{})
+	if resp, err = clientSt.request(ctx, msg); err != nil {
+		t.Fatal(err)
+	}
+	var head uint64
+	if err := resp.decodeResponse(&head); err != nil || head != 10 {
+		t.Errorf("head %d (%v), expected 10", head, err)
+	}
+
+	msg, _ = newRequest(codeGetCrossLinks, getCrossLinksRequest{10, 5})
+	if resp, err = clientSt.request(ctx, msg); err != nil {
+		t.Fatal(err)
+	}
+	var cls []types.CrossLink
+	if err := resp.decodeResponse(&cls); err != nil {
+		t.Fatal(err)
+	}
+	if len(cls) != 1 || cls[0].BlockNum() != 10 || cls[0].Hash() != header.Hash() {
+		t.Errorf("crosslinks %v, expected the one of the block 10", cls)
+	}
+
+	tests := []struct {
+		code uint64
+		req  interface{}
+		err  error
+	}{
+		{codeGetHeader, getHeaderRequest{11}, errUnknownHeader},
+		{codeGetEpochHeader, getEpochHeaderRequest{1}, errNotBeaconChain},
+		{codeGetCrossLinks, getCrossLinksRequest{11, 5}, errUnknownHeader},
+		{100, getHeaderRequest{10}, errUnknownCode},
+	}
+	for i, test := range tests {
+		msg, _ := newRequest(test.code, test.req)
+		resp, err := clientSt.request(ctx, msg)
+		if err != nil {
+			t.Fatalf("Test %v: %v", i, err)
+		}
+		err = resp.decodeResponse(&signed)
+		if err == nil || err.Error() != (&ResponseError{test.err.Error()}).Error() {
+			t.Errorf("Test %v: error %v, expected %v", i, err, test.err)
+		}
+	}
+
+	// the client side does not serve light clients
+	msg, _ = newRequest(codeGetHeader, getHeaderRequest{10})
+	if resp, err = serverSt.request(ctx, msg); err != nil {
+		t.Fatal(err)
+	}
+	if err := resp.decodeResponse(&signed); err == nil {
+		t.Errorf("header served by a protocol without a chain")
+	}
+}
+
+func TestStreamClose(t *testing.T) {
+	client, server := newTestProtocol(nil), newTestProtocol(nil)
+	clientSt, _ := newTestStreamPair(client, server)
+	go clientSt.run()
+
+	errC := make(chan error)
+	go func() {
+		msg, _ := newRequest(codeGetHeader, getHeaderRequest{1})
+		_, err := clientSt.request(context.Background(), msg)
+		errC <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	clientSt.close()
+	select {
+	case err := <-errC:
+		if err != errStreamClosed {
+			t.Errorf("error %v, expected %v", err, errStreamClosed)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("request not ended by the stream closing")
+	}
+}
+
+type testChain struct {
+	header   *block.Header
+	sig      []byte
+	receipts types.Receipts
+}
+
+func (c *testChain) ShardID() uint32 { return 1 }
+
+func (c *testChain) CurrentHeader() *block.Header { return c.header }
+
+func (c *testChain) GetHeaderByNumber(number uint64) *block.Header {
+	if number != c.header.Number().Uint64() {
+		return nil
+	}
+	return c.header
+}
+
+func (c *testChain) GetEpochBlockNumber(epoch *big.Int) (*big.Int, error) {
+	return nil, errors.New("no epoch")
+}
+
+func (c *testChain) ReadCommitSig(blockNum uint64) ([]byte, error) {
+	return c.sig, nil
+}
+
+func (c *testChain) StateAt(root common.Hash) (*state.DB, error) {
+	return nil, errors.New("no state")
+}
+
+func (c *testChain) GetReceiptsByHash(hash common.Hash) types.Receipts {
+	return c.receipts
+}
+
+type testRateLimiter struct{}
+
+func (rl testRateLimiter) LimitRequest(stid sttypes.StreamID) {}
+func (rl testRateLimiter) Start()                             {}
+func (rl testRateLimiter) Close()                             {}
+
+func newTestProtocol(chain Chain) *Protocol {
+	return &Protocol{chain: chain, rl: testRateLimiter{}}
+}
+
+// testStream is one end of an in-memory stream
+type testStream struct {
+	id     sttypes.StreamID
+	readC  chan []byte
+	writeC chan []byte
+	closeC chan struct{}
+	once   *sync.Once
+}
+
+func newTestStreamPair(a, b *Protocol) (*stream, *stream) {
+	ab, ba, closeC := make(chan []byte, 16), make(chan []byte, 16), make(chan struct{})
+	once := &sync.Once{}
+	stA := &testStream{id: "a", readC: ba, writeC: ab, closeC: closeC, once: once}
+	stB := &testStream{id: "b", readC: ab, writeC: ba, closeC: closeC, once: once}
+	return newStream(stA, a), newStream(stB, b)
+}
+
+func (st *testStream) ID() sttypes.StreamID { return st.id }
+
+func (st *testStream) ProtoID() sttypes.ProtoID { return "" }
+
+func (st *testStream) ProtoSpec() (sttypes.ProtoSpec, error) { return sttypes.ProtoSpec{}, nil }
+
+func (st *testStream) WriteBytes(b []byte) error {
+	select {
+	case st.writeC <- b:
+		return nil
+	case <-st.closeC:
+		return errStreamClosed
+	}
+}
+
+func (st *testStream) ReadBytes() ([]byte, error) {
+	select {
+	case b := <-st.readC:
+		return b, nil
+	case <-st.closeC:
+		return nil, errStreamClosed
+	}
+}
+
+func (st *testStream) Close() error {
+	st.once.Do(func() { close(st.closeC) })
+	return nil
+}
+
+func (st *testStream) ResetOnClose() error { return st.Close() }
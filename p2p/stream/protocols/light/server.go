@@ -0,0 +1,171 @@
+package light
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/nordicenergy/nordicenergy-core/block"
+	"github.com/nordicenergy/nordicenergy-core/core/state"
+	"github.com/nordicenergy/nordicenergy-core/core/types"
+	lightclient "github.com/nordicenergy/nordicenergy-core/light"
+	"github.com/nordicenergy/nordicenergy-core/shard"
+	"github.com/pkg/errors"
+)
+
+// Chain is the chain the protocol serves light clients from.
+// *core.BlockChain implements it.
+type Chain interface {
+	ShardID() uint32
+	CurrentHeader() *block.Header
+	GetHeaderByNumber(number uint64) *block.Header
+	GetEpochBlockNumber(epoch *big.Int) (*big.Int, error)
+	ReadCommitSig(blockNum uint64) ([]byte, error)
+	StateAt(root common.Hash) (*state.DB, error)
+	GetReceiptsByHash(hash common.Hash) types.Receipts
+}
+
+var (
+	errNotServing     = errors.New("not serving light clients")
+	errNotBeaconChain = errors.New("epoch headers are only served on the beacon chain")
+	errUnknownCode    = errors.New("unknown request code")
+	errUnknownHeader  = errors.New("unknown header")
+)
+
+// handleRequest returns the response to req
+func (p *Protocol) handleRequest(req *message) *message {
+	resp := &message{ReqID: req.ReqID, Code: req.Code, IsResponse: true}
+	result, err := p.serve(req)
+	if err == nil {
+		resp.Payload, err = rlp.EncodeToBytes(result)
+	}
+	if err != nil {
+		resp.Error = err.Error()
+	}
+	return resp
+}
+
+// serve returns the result of req
+func (p *Protocol) serve(req *message) (interface{}, error) {
+	if p.chain == nil {
+		return nil, errNotServing
+	}
+	switch req.Code {
+	case codeGetEpochHeader:
+		var r getEpochHeaderRequest
+		if err := rlp.DecodeBytes(req.Payload, &r); err != nil {
+			return nil, err
+		}
+		return p.getEpochHeader(r.Epoch)
+	case codeGetHeader:
+		var r getHeaderRequest
+		if err := rlp.DecodeBytes(req.Payload, &r); err != nil {
+			return nil, err
+		}
+		return p.getHeader(r.Number)
+	case codeGetAccountProof:
+		var r getAccountProofRequest
+		if err := rlp.DecodeBytes(req.Payload, &r); err != nil {
+			return nil, err
+		}
+		return p.getAccountProof(r.Number, r.Address)
+	case codeGetReceiptProof:
+		var r getReceiptProofRequest
+		if err := rlp.DecodeBytes(req.Payload, &r); err != nil {
+			return nil, err
+		}
+		return p.getReceiptProof(r.Number, r.Index)
+	case codeGetHead:
+		return p.chain.CurrentHeader().Number().Uint64(), nil
+	case codeGetCrossLinks:
+		var r getCrossLinksRequest
+		if err := rlp.DecodeBytes(req.Payload, &r); err != nil {
+			return nil, err
+		}
+		return p.getCrossLinks(r.From, r.Count)
+	}
+	return nil, errUnknownCode
+}
+
+// getEpochHeader returns the last beacon chain header of epoch, which carries
+// the shard state of the next epoch
+func (p *Protocol) getEpochHeader(epoch uint64) (*SignedHeader, error) {
+	if p.chain.ShardID() != shard.BeaconChainShardID {
+		return nil, errNotBeaconChain
+	}
+	next, err := p.chain.GetEpochBlockNumber(new(big.Int).SetUint64(epoch + 1))
+	if err != nil {
+		return nil, err
+	}
+	if next.Sign() == 0 {
+		return nil, errUnknownHeader
+	}
+	return p.getHeader(next.Uint64() - 1)
+}
+
+// getHeader returns the header of number with its commit signature
+func (p *Protocol) getHeader(number uint64) (*SignedHeader, error) {
+	header := p.chain.GetHeaderByNumber(number)
+	if header == nil {
+		return nil, errUnknownHeader
+	}
+	sig, err := p.chain.ReadCommitSig(number)
+	if err != nil {
+		return nil, errors.Wrap(err, "cannot read commit signature")
+	}
+	return newSignedHeader(header, sig)
+}
+
+// getAccountProof returns the proof of the account of address in the state of
+// the block of number
+func (p *Protocol) getAccountProof(number uint64, address common.Address) (lightclient.Proof, error) {
+	header := p.chain.GetHeaderByNumber(number)
+	if header == nil {
+		return nil, errUnknownHeader
+	}
+	db, err := p.chain.StateAt(header.Root())
+	if err != nil {
+		return nil, errors.Wrap(err, "state of the block is not available")
+	}
+	proof, err := db.GetProof(address)
+	return lightclient.Proof(proof), err
+}
+
+// getReceiptProof returns the proof of the index-th receipt of the block of
+// number
+func (p *Protocol) getReceiptProof(number, index uint64) (lightclient.Proof, error) {
+	header := p.chain.GetHeaderByNumber(number)
+	if header == nil {
+		return nil, errUnknownHeader
+	}
+	return lightclient.ProveReceipt(p.chain.GetReceiptsByHash(header.Hash()), index)
+}
+
+// getCrossLinks returns the crosslinks of up to count blocks from the block of
+// number from, for the beacon chain to fill the gaps in the crosslinks of the
+// shard
+func (p *Protocol) getCrossLinks(from, count uint64) ([]types.CrossLink, error) {
+	if count > MaxCrossLinksPerRequest {
+		count = MaxCrossLinksPerRequest
+	}
+	cls := []types.CrossLink{}
+	for number := from; number < from+count; number++ {
+		header := p.chain.GetHeaderByNumber(number)
+		if header == nil {
+			break
+		}
+		sig, err := p.chain.ReadCommitSig(number)
+		if err != nil {
+			break
+		}
+		cl, err := newCrossLink(header, sig)
+		if err != nil {
+			break
+		}
+		cls = append(cls, *cl)
+	}
+	if len(cls) == 0 {
+		return nil, errUnknownHeader
+	}
+	return cls, nil
+}
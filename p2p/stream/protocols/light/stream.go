@@ -0,0 +1,147 @@
+package light
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	sttypes "github.com/nordicenergy/nordicenergy-core/p2p/stream/types"
+	"github.com/pkg/errors"
+	"github.com/rs/zerolog"
+)
+
+var errStreamClosed = errors.New("stream closed")
+
+// stream is a stream of the protocol. Both ends of a stream can send
+// requests, the responses are matched to the requests by request ID.
+type stream struct {
+	sttypes.Stream
+	protocol *Protocol
+
+	lock     sync.Mutex
+	pending  map[uint64]chan *message
+	closed   bool
+	closeC   chan struct{}
+	closeOne sync.Once
+	// writeLock serializes the writes, WriteBytes not being safe for
+	// concurrent use
+	writeLock sync.Mutex
+
+	logger zerolog.Logger
+}
+
+func newStream(st sttypes.Stream, protocol *Protocol) *stream {
+	return &stream{
+		Stream:   st,
+		protocol: protocol,
+		pending:  map[uint64]chan *message{},
+		closeC:   make(chan struct{}),
+		logger: protocol.logger.With().
+			Str("stream ID", string(st.ID())).Logger(),
+	}
+}
+
+// run reads the messages of the stream until it fails
+func (st *stream) run() {
+	defer st.close()
+	for {
+		b, err := st.ReadBytes()
+		if err != nil {
+			st.logger.Debug().Err(err).Msg("stream read failed")
+			return
+		}
+		var msg message
+		if err := rlp.DecodeBytes(b, &msg); err != nil {
+			st.logger.Warn().Err(err).Msg("invalid message")
+			return
+		}
+		if msg.IsResponse {
+			st.deliver(&msg)
+			continue
+		}
+		st.protocol.rl.LimitRequest(st.ID())
+		go func() {
+			resp := st.protocol.handleRequest(&msg)
+			if err := st.write(resp); err != nil {
+				st.logger.Debug().Err(err).Str("response", resp.String()).
+					Msg("cannot write response")
+			}
+		}()
+	}
+}
+
+// request sends req and waits for its response
+func (st *stream) request(ctx context.Context, req *message) (*message, error) {
+	req.ReqID = sttypes.GenReqID()
+	respC := make(chan *message, 1)
+	st.lock.Lock()
+	if st.closed {
+		st.lock.Unlock()
+		return nil, errStreamClosed
+	}
+	st.pending[req.ReqID] = respC
+	st.lock.Unlock()
+	defer func() {
+		st.lock.Lock()
+		delete(st.pending, req.ReqID)
+		st.lock.Unlock()
+	}()
+
+	if err := st.write(req); err != nil {
+		return nil, err
+	}
+	select {
+	case resp := <-respC:
+		if resp.Code != req.Code {
+			return nil, errors.Errorf("response code %d to request code %d", resp.Code, req.Code)
+		}
+		return resp, nil
+	case <-st.closeC:
+		return nil, errStreamClosed
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// deliver hands resp to the request waiting for it
+func (st *stream) deliver(resp *message) {
+	st.lock.Lock()
+	respC, ok := st.pending[resp.ReqID]
+	st.lock.Unlock()
+	if !ok {
+		st.logger.Debug().Str("response", resp.String()).Msg("unexpected response")
+		return
+	}
+	select {
+	case respC <- resp:
+	default:
+	}
+}
+
+func (st *stream) write(msg *message) error {
+	b, err := rlp.EncodeToBytes(msg)
+	if err != nil {
+		return err
+	}
+	st.writeLock.Lock()
+	defer st.writeLock.Unlock()
+	return st.WriteBytes(b)
+}
+
+// close closes the stream and removes it from the stream manager
+func (st *stream) close() {
+	st.closeOne.Do(func() {
+		st.lock.Lock()
+		st.closed = true
+		st.lock.Unlock()
+		close(st.closeC)
+		if err := st.Close(); err != nil {
+			st.logger.Debug().Err(err).Msg("cannot close stream")
+		}
+		if st.protocol.sm != nil {
+			if err := st.protocol.sm.RemoveStream(st.ID()); err != nil {
+				st.logger.Debug().Err(err).Msg("cannot remove stream")
+			}
+		}
+	})
+}
@@ -55,6 +55,7 @@ var (
 		staking.DirectiveDelegate.String(),
 		staking.DirectiveUndelegate.String(),
 		staking.DirectiveCollectRewards.String(),
+		staking.DirectiveUnjail.String(),
 	}
 
 	// MutuallyExclusiveOperations for invariant: A transaction can only contain 1 type of 'native' operation.
@@ -88,18 +89,104 @@ var (
 // CreateValidatorOperationMetadata ..
 type CreateValidatorOperationMetadata rpcV2.CreateValidatorMsg
 
+// UnmarshalFromInterface ..
+func (s *CreateValidatorOperationMetadata) UnmarshalFromInterface(data interface{}) error {
+	var T CreateValidatorOperationMetadata
+	if err := unmarshalOperationMetadata(data, &T); err != nil {
+		return err
+	}
+	if T.ValidatorAddress == "" {
+		return fmt.Errorf("expected validator address to be present for CreateValidatorOperationMetadata")
+	}
+	if T.CommissionRate == nil || T.MaxCommissionRate == nil || T.MaxChangeRate == nil {
+		return fmt.Errorf("expected commission rates to be present for CreateValidatorOperationMetadata")
+	}
+	if T.MinSelfDelegation == nil || T.MaxTotalDelegation == nil || T.Amount == nil {
+		return fmt.Errorf("expected delegation amounts to be present for CreateValidatorOperationMetadata")
+	}
+	if len(T.SlotPubKeys) != len(T.SlotKeySigs) {
+		return fmt.Errorf("expected a signature for each slot public key for CreateValidatorOperationMetadata")
+	}
+	*s = T
+	return nil
+}
+
 // EditValidatorOperationMetadata ..
 type EditValidatorOperationMetadata rpcV2.EditValidatorMsg
 
+// UnmarshalFromInterface ..
+func (s *EditValidatorOperationMetadata) UnmarshalFromInterface(data interface{}) error {
+	var T EditValidatorOperationMetadata
+	if err := unmarshalOperationMetadata(data, &T); err != nil {
+		return err
+	}
+	if T.ValidatorAddress == "" {
+		return fmt.Errorf("expected validator address to be present for EditValidatorOperationMetadata")
+	}
+	if (T.SlotPubKeyToAdd == nil) != (T.SlotKeyToAddSig == nil) {
+		return fmt.Errorf("expected a signature for the slot public key to add for EditValidatorOperationMetadata")
+	}
+	*s = T
+	return nil
+}
+
 // DelegateOperationMetadata ..
 type DelegateOperationMetadata rpcV2.DelegateMsg
 
+// UnmarshalFromInterface ..
+func (s *DelegateOperationMetadata) UnmarshalFromInterface(data interface{}) error {
+	var T DelegateOperationMetadata
+	if err := unmarshalOperationMetadata(data, &T); err != nil {
+		return err
+	}
+	if T.DelegatorAddress == "" || T.ValidatorAddress == "" || T.Amount == nil {
+		return fmt.Errorf("expected delegator, validator & amount to be present for DelegateOperationMetadata")
+	}
+	*s = T
+	return nil
+}
+
 // UndelegateOperationMetadata ..
 type UndelegateOperationMetadata rpcV2.UndelegateMsg
 
+// UnmarshalFromInterface ..
+func (s *UndelegateOperationMetadata) UnmarshalFromInterface(data interface{}) error {
+	var T UndelegateOperationMetadata
+	if err := unmarshalOperationMetadata(data, &T); err != nil {
+		return err
+	}
+	if T.DelegatorAddress == "" || T.ValidatorAddress == "" || T.Amount == nil {
+		return fmt.Errorf("expected delegator, validator & amount to be present for UndelegateOperationMetadata")
+	}
+	*s = T
+	return nil
+}
+
 // CollectRewardsMetadata ..
 type CollectRewardsMetadata rpcV2.CollectRewardsMsg
 
+// UnmarshalFromInterface ..
+func (s *CollectRewardsMetadata) UnmarshalFromInterface(data interface{}) error {
+	var T CollectRewardsMetadata
+	if err := unmarshalOperationMetadata(data, &T); err != nil {
+		return err
+	}
+	if T.DelegatorAddress == "" {
+		return fmt.Errorf("expected delegator address to be present for CollectRewardsMetadata")
+	}
+	*s = T
+	return nil
+}
+
+// unmarshalOperationMetadata decodes the metadata of an operation, as decoded from JSON, to v
+func unmarshalOperationMetadata(data interface{}, v interface{}) error {
+	dat, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(dat, v)
+}
+
 // CrossShardTransactionOperationMetadata ..
 type CrossShardTransactionOperationMetadata struct {
 	From *types.AccountIdentifier `json:"from"`
@@ -73,6 +73,7 @@ func TestStakingOperationTypes(t *testing.T) {
 		staking.DirectiveDelegate.String(),
 		staking.DirectiveUndelegate.String(),
 		staking.DirectiveCollectRewards.String(),
+		staking.DirectiveUnjail.String(),
 	}
 	sort.Strings(referenceOperationTypes)
 	sort.Strings(stakingOperationTypes)
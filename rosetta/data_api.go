@@ -0,0 +1,75 @@
+package rosetta
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+
+	"github.com/nordicenergy/nordicenergy-core/ngy"
+	"github.com/nordicenergy/nordicenergy-core/rosetta/common"
+	"github.com/nordicenergy/nordicenergy-core/rosetta/services"
+)
+
+// dataAPIHandler serves the /account/coins, /search/transactions and
+// /events/blocks endpoints, which the rosetta-sdk-go server in use does not
+// route, and the other endpoints with sdkRouter.
+func dataAPIHandler(sdkRouter http.Handler, ngy *ngy.nordicenergy) http.Handler {
+	accountAPI := services.NewAccountAPI(ngy)
+	searchAPI := services.NewSearchAPI(ngy)
+	eventsAPI := services.NewEventsAPI(ngy)
+
+	mux := http.NewServeMux()
+	mux.Handle("/", sdkRouter)
+	mux.HandleFunc("/account/coins", func(w http.ResponseWriter, r *http.Request) {
+		request := &services.AccountCoinsRequest{}
+		if decodeRequest(w, r, request) {
+			response, rosettaError := accountAPI.AccountCoins(r.Context(), request)
+			writeResponse(w, response, rosettaError)
+		}
+	})
+	mux.HandleFunc("/search/transactions", func(w http.ResponseWriter, r *http.Request) {
+		request := &services.SearchTransactionsRequest{}
+		if decodeRequest(w, r, request) {
+			response, rosettaError := searchAPI.SearchTransactions(r.Context(), request)
+			writeResponse(w, response, rosettaError)
+		}
+	})
+	mux.HandleFunc("/events/blocks", func(w http.ResponseWriter, r *http.Request) {
+		request := &services.EventsBlocksRequest{}
+		if decodeRequest(w, r, request) {
+			response, rosettaError := eventsAPI.EventsBlocks(r.Context(), request)
+			writeResponse(w, response, rosettaError)
+		}
+	})
+	return mux
+}
+
+// decodeRequest decodes the JSON body of r to request, and writes the error
+// response if it cannot
+func decodeRequest(w http.ResponseWriter, r *http.Request, request interface{}) bool {
+	if r.Method != http.MethodPost {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return false
+	}
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(request); err != nil {
+		writeResponse(w, nil, common.NewError(common.SanityCheckError, map[string]interface{}{
+			"message": err.Error(),
+		}))
+		return false
+	}
+	return true
+}
+
+// writeResponse writes the JSON response, or the rosetta error with the
+// internal server error status
+func writeResponse(w http.ResponseWriter, response interface{}, rosettaError *types.Error) {
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	if rosettaError != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		response = rosettaError
+	}
+	_ = json.NewEncoder(w).Encode(response)
+}
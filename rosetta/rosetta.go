@@ -78,13 +78,13 @@ func newHTTPServer(handler http.Handler) *http.Server {
 }
 
 func getRouter(asserter *asserter.Asserter, ngy *ngy.nordicenergy) http.Handler {
-	return server.NewRouter(
+	return dataAPIHandler(server.NewRouter(
 		server.NewAccountAPIController(services.NewAccountAPI(ngy), asserter),
 		server.NewBlockAPIController(services.NewBlockAPI(ngy), asserter),
 		server.NewMempoolAPIController(services.NewMempoolAPI(ngy), asserter),
 		server.NewNetworkAPIController(services.NewNetworkAPI(ngy), asserter),
 		server.NewConstructionAPIController(services.NewConstructionAPI(ngy), asserter),
-	)
+	), ngy)
 }
 
 func recoverMiddleware(h http.Handler) http.Handler {
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 
-	"github.com/coinbase/rosetta-sdk-go/server"
 	"github.com/coinbase/rosetta-sdk-go/types"
 	ethCommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -20,8 +19,8 @@ type AccountAPI struct {
 	ngy *ngy.nordicenergy
 }
 
-// NewAccountAPI creates a new instance of a BlockAPI.
-func NewAccountAPI(ngy *ngy.nordicenergy) server.AccountAPIServicer {
+// NewAccountAPI creates a new instance of a AccountAPI.
+func NewAccountAPI(ngy *ngy.nordicenergy) *AccountAPI {
 	return &AccountAPI{
 		ngy: ngy,
 	}
@@ -76,6 +75,54 @@ func (s *AccountAPI) AccountBalance(
 	}, nil
 }
 
+// AccountCoinsRequest is the request of the /account/coins endpoint, not
+// served by the rosetta-sdk-go version in use.
+type AccountCoinsRequest struct {
+	NetworkIdentifier *types.NetworkIdentifier `json:"network_identifier"`
+	AccountIdentifier *types.AccountIdentifier `json:"account_identifier"`
+	IncludeMempool    bool                     `json:"include_mempool"`
+	Currencies        []*types.Currency        `json:"currencies,omitempty"`
+}
+
+// AccountCoinsResponse is the response of the /account/coins endpoint
+type AccountCoinsResponse struct {
+	BlockIdentifier *types.BlockIdentifier `json:"block_identifier"`
+	Coins           []*types.Coin          `json:"coins"`
+	Metadata        map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// AccountCoins implements the /account/coins endpoint.
+// The nordicenergy chain is account based, so no account owns coins.
+func (s *AccountAPI) AccountCoins(
+	ctx context.Context, request *AccountCoinsRequest,
+) (*AccountCoinsResponse, *types.Error) {
+	if err := assertValidNetworkIdentifier(request.NetworkIdentifier, s.ngy.ShardID); err != nil {
+		return nil, err
+	}
+
+	if _, err := getAddress(request.AccountIdentifier); err != nil {
+		return nil, common.NewError(common.SanityCheckError, map[string]interface{}{
+			"message": err.Error(),
+		})
+	}
+	for _, currency := range request.Currencies {
+		if currency == nil || types.Hash(currency) != common.NativeCurrencyHash {
+			return nil, common.NewError(common.SanityCheckError, map[string]interface{}{
+				"message": "unsupported currency",
+			})
+		}
+	}
+
+	block := s.ngy.CurrentBlock()
+	return &AccountCoinsResponse{
+		BlockIdentifier: &types.BlockIdentifier{
+			Index: block.Number().Int64(),
+			Hash:  block.Hash().String(),
+		},
+		Coins: []*types.Coin{},
+	}, nil
+}
+
 // AccountMetadata used for account identifiers
 type AccountMetadata struct {
 	Address string `json:"hex_address"`
@@ -12,9 +12,12 @@ import (
 	ethRpc "github.com/ethereum/go-ethereum/rpc"
 	"github.com/pkg/errors"
 
+	"github.com/nordicenergy/nordicenergy-core/core"
 	"github.com/nordicenergy/nordicenergy-core/internal/params"
 	"github.com/nordicenergy/nordicenergy-core/rosetta/common"
 	"github.com/nordicenergy/nordicenergy-core/rpc"
+	"github.com/nordicenergy/nordicenergy-core/shard"
+	stakingTypes "github.com/nordicenergy/nordicenergy-core/staking/types"
 )
 
 // ConstructMetadataOptions is constructed by ConstructionPreprocess for ConstructionMetadata options
@@ -22,6 +25,10 @@ type ConstructMetadataOptions struct {
 	TransactionMetadata *TransactionMetadata `json:"transaction_metadata"`
 	OperationType       string               `json:"operation_type,omitempty"`
 	GasPriceMultiplier  *float64             `json:"gas_price_multiplier,omitempty"`
+	// From is the sender, whose public key is used among the ones given to ConstructionMetadata
+	From *types.AccountIdentifier `json:"from,omitempty"`
+	// StakingMessage is the metadata of the staking operation, to estimate the gas of staking transactions
+	StakingMessage interface{} `json:"staking_message,omitempty"`
 }
 
 // UnmarshalFromInterface ..
@@ -81,6 +88,21 @@ func (s *ConstructAPI) ConstructionPreprocess(
 			"message": "given from & to shard are different for a native same shard transfer",
 		})
 	}
+	if compnetnts.IsStaking() && s.ngy.ShardID != shard.BeaconChainShardID {
+		return nil, common.NewError(common.InvalidTransactionConstructinetrror, map[string]interface{}{
+			"message": fmt.Sprintf("staking transactions are only accepted on shard %v", shard.BeaconChainShardID),
+		})
+	}
+	if compnetnts.Type == common.NativeTransferOperation && txMetadata.Data != nil {
+		// A transfer with data is a contract call, the contract being the receiver
+		if txMetadata.ContractAccountIdentifier == nil {
+			txMetadata.ContractAccountIdentifier = compnetnts.To
+		} else if txMetadata.ContractAccountIdentifier.Address != compnetnts.To.Address {
+			return nil, common.NewError(common.InvalidTransactionConstructinetrror, map[string]interface{}{
+				"message": "given contract account identifier is not the receiver of the contract call",
+			})
+		}
+	}
 	if request.SuggestedFeeMultiplier != nil && *request.SuggestedFeeMultiplier < 1 {
 		return nil, common.NewError(common.InvalidTransactionConstructinetrror, map[string]interface{}{
 			"message": "given gas price multiplier must be at least 1",
@@ -91,6 +113,8 @@ func (s *ConstructAPI) ConstructionPreprocess(
 		TransactionMetadata: txMetadata,
 		OperationType:       compnetnts.Type,
 		GasPriceMultiplier:  request.SuggestedFeeMultiplier,
+		From:                compnetnts.From,
+		StakingMessage:      compnetnts.StakingMessage,
 	})
 	if err != nil {
 		return nil, common.NewError(common.CatchAllError, map[string]interface{}{
@@ -155,12 +179,7 @@ func (s *ConstructAPI) ConstructionMetadata(
 		})
 	}
 
-	if len(request.PublicKeys) != 1 {
-		return nil, common.NewError(common.InvalidTransactionConstructinetrror, map[string]interface{}{
-			"message": "require sender public key only",
-		})
-	}
-	senderAddr, rosettaError := getAddressFromPublicKey(request.PublicKeys[0])
+	senderAddr, rosettaError := getSenderAddressFromPublicKeys(request.PublicKeys, options.From)
 	if rosettaError != nil {
 		return nil, rosettaError
 	}
@@ -184,6 +203,12 @@ func (s *ConstructAPI) ConstructionMetadata(
 			"message": "cross-shard transaction is not accepted yet",
 		})
 	}
+	if isStakingOperation(options.OperationType) &&
+		!s.ngy.BlockChain.Config().IsPreStaking(currBlock.Epoch()) {
+		return nil, common.NewError(common.InvalidTransactionConstructinetrror, map[string]interface{}{
+			"message": "staking transaction is not accepted yet",
+		})
+	}
 
 	data := hexutil.Bytes{}
 	if options.TransactionMetadata.Data != nil {
@@ -222,9 +247,9 @@ func (s *ConstructAPI) ConstructionMetadata(
 			)
 		}
 	} else {
-		return nil, common.NewError(common.InvalidTransactionConstructinetrror, map[string]interface{}{
-			"message": "staking operations are not supported",
-		})
+		estGasUsed, err = getStakingIntrinsicGas(
+			options, s.ngy.BlockChain.Config().IsIstanbul(currBlock.Epoch()),
+		)
 	}
 	if err != nil {
 		return nil, common.NewError(common.InvalidTransactionConstructinetrror, map[string]interface{}{
@@ -306,6 +331,59 @@ func getSuggestedNativeFeeAndPrice(
 	}, gasPrice
 }
 
+// getStakingIntrinsicGas returns the gas of the staking transaction of the options, which is only
+// its intrinsic gas as staking transactions do not execute code
+func getStakingIntrinsicGas(options *ConstructMetadataOptions, istanbul bool) (uint64, error) {
+	message, _, _, err := unmarshalStakingMessage(options.OperationType, options.StakingMessage)
+	if err != nil {
+		return 0, err
+	}
+	fulfiller, err := getStakingMessageFulfiller(message)
+	if err != nil {
+		return 0, err
+	}
+	tx, err := stakingTypes.NewStakingTransaction(0, 0, big.NewInt(0), fulfiller)
+	if err != nil {
+		return 0, err
+	}
+	return core.IntrinsicGas(
+		tx.Data(), false, true, istanbul, tx.StakingType() == stakingTypes.DirectiveCreateValidator,
+	)
+}
+
+// getSenderAddressFromPublicKeys returns the address of the public key of sender among the given
+// public keys, or of the only public key given if the sender is not known
+func getSenderAddressFromPublicKeys(
+	keys []*types.PublicKey, sender *types.AccountIdentifier,
+) (*ethCommon.Address, *types.Error) {
+	if sender == nil {
+		if len(keys) != 1 {
+			return nil, common.NewError(common.InvalidTransactionConstructinetrror, map[string]interface{}{
+				"message": "require sender public key only",
+			})
+		}
+		return getAddressFromPublicKey(keys[0])
+	}
+	senderAddr, err := getAddress(sender)
+	if err != nil {
+		return nil, common.NewError(common.InvalidTransactionConstructinetrror, map[string]interface{}{
+			"message": err.Error(),
+		})
+	}
+	for _, key := range keys {
+		address, rosettaError := getAddressFromPublicKey(key)
+		if rosettaError != nil {
+			return nil, rosettaError
+		}
+		if *address == senderAddr {
+			return address, nil
+		}
+	}
+	return nil, common.NewError(common.InvalidTransactionConstructinetrror, map[string]interface{}{
+		"message": "sender public key is not found in given public keys",
+	})
+}
+
 // isStakingOperation ..
 func isStakingOperation(op string) bool {
 	for _, stakingOp := range common.StakingOperationTypes {
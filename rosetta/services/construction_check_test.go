@@ -6,10 +6,14 @@ import (
 	"testing"
 
 	"github.com/coinbase/rosetta-sdk-go/types"
+	ethCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/pkg/errors"
 
+	internalCommon "github.com/nordicenergy/nordicenergy-core/internal/common"
 	"github.com/nordicenergy/nordicenergy-core/internal/params"
 	"github.com/nordicenergy/nordicenergy-core/rosetta/common"
+	stakingTypes "github.com/nordicenergy/nordicenergy-core/staking/types"
 )
 
 func TestConstructMetadataOptions(t *testing.T) {
@@ -241,3 +245,112 @@ func TestGetSuggestedNativeFeeAndPrice(t *testing.T) {
 	}
 
 }
+
+func TestGetStakingIntrinsicGas(t *testing.T) {
+	refKey := internalCommon.MustGeneratePrivateKey()
+	refAddr := internalCommon.MustAddressToBech32(crypto.PubkeyToAddress(refKey.PublicKey))
+	cases := []struct {
+		OperationType  string
+		StakingMessage interface{}
+		MinGas         uint64
+	}{
+		{
+			OperationType: stakingTypes.DirectiveCreateValidator.String(),
+			StakingMessage: &common.CreateValidatorOperationMetadata{
+				ValidatorAddress:   refAddr,
+				CommissionRate:     big.NewInt(1e17),
+				MaxCommissionRate:  big.NewInt(5e17),
+				MaxChangeRate:      big.NewInt(5e16),
+				MinSelfDelegation:  big.NewInt(1e18),
+				MaxTotalDelegation: big.NewInt(2e18),
+				Amount:             big.NewInt(1e18),
+			},
+			MinGas: params.TxGasValidatorCreation,
+		},
+		{
+			OperationType: stakingTypes.DirectiveDelegate.String(),
+			StakingMessage: &common.DelegateOperationMetadata{
+				DelegatorAddress: refAddr,
+				ValidatorAddress: refAddr,
+				Amount:           big.NewInt(1e18),
+			},
+			MinGas: params.TxGas,
+		},
+	}
+
+	for i, test := range cases {
+		mapString, err := types.MarshalMap(ConstructMetadataOptions{
+			TransactionMetadata: &TransactionMetadata{},
+			OperationType:       test.OperationType,
+			StakingMessage:      test.StakingMessage,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		options := &ConstructMetadataOptions{}
+		if err := options.UnmarshalFromInterface(mapString); err != nil {
+			t.Fatal(err)
+		}
+		gas, err := getStakingIntrinsicGas(options, true)
+		if err != nil {
+			t.Error(errors.WithMessage(err, fmt.Sprintf("error for test %v", i)))
+			continue
+		}
+		if gas <= test.MinGas {
+			t.Errorf("expected gas of test %v to be more than %v for the message data, got %v", i, test.MinGas, gas)
+		}
+	}
+
+	options := &ConstructMetadataOptions{
+		TransactionMetadata: &TransactionMetadata{},
+		OperationType:       stakingTypes.DirectiveUndelegate.String(),
+	}
+	if _, err := getStakingIntrinsicGas(options, true); err == nil {
+		t.Error("expected error for missing staking message")
+	}
+}
+
+func TestGetSenderAddressFromPublicKeys(t *testing.T) {
+	refKeys := []*types.PublicKey{}
+	refAddrs := []ethCommon.Address{}
+	for i := 0; i < 3; i++ {
+		key := internalCommon.MustGeneratePrivateKey()
+		refKeys = append(refKeys, &types.PublicKey{
+			Bytes:     crypto.CompressPubkey(&key.PublicKey),
+			CurveType: common.CurveType,
+		})
+		refAddrs = append(refAddrs, crypto.PubkeyToAddress(key.PublicKey))
+	}
+	sender, rosettaError := newAccountIdentifier(refAddrs[1])
+	if rosettaError != nil {
+		t.Fatal(rosettaError)
+	}
+
+	// test sender among several public keys
+	addr, rosettaError := getSenderAddressFromPublicKeys(refKeys, sender)
+	if rosettaError != nil {
+		t.Fatal(rosettaError)
+	}
+	if *addr != refAddrs[1] {
+		t.Error("expected address of the sender public key")
+	}
+
+	// test single public key of unknown sender
+	addr, rosettaError = getSenderAddressFromPublicKeys(refKeys[2:], nil)
+	if rosettaError != nil {
+		t.Fatal(rosettaError)
+	}
+	if *addr != refAddrs[2] {
+		t.Error("expected address of the only public key")
+	}
+
+	// test several public keys of unknown sender
+	if _, rosettaError = getSenderAddressFromPublicKeys(refKeys, nil); rosettaError == nil {
+		t.Error("expected error")
+	}
+
+	// test sender public key not given
+	if _, rosettaError = getSenderAddressFromPublicKeys(refKeys[2:], sender); rosettaError == nil {
+		t.Error("expected error")
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	"github.com/coinbase/rosetta-sdk-go/types"
+	ethCommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/pkg/errors"
@@ -74,6 +75,8 @@ func unpackWrappedTransactionFromString(
 }
 
 // ConstructionPayloads implements the /construction/payloads endpoint.
+// Transactions carry the single signature of their sender, so there is exactly one signing payload,
+// for the sender, whatever the public keys given for the other accounts of the operations.
 func (s *ConstructAPI) ConstructionPayloads(
 	ctx context.Context, request *types.ConstructionPayloadsRequest,
 ) (*types.ConstructionPayloadsResponse, *types.Error) {
@@ -91,19 +94,6 @@ func (s *ConstructAPI) ConstructionPayloads(
 			"message": errors.WithMessage(err, "invalid metadata").Error(),
 		})
 	}
-	if len(request.PublicKeys) != 1 {
-		return nil, common.NewError(common.InvalidTransactionConstructinetrror, map[string]interface{}{
-			"message": "require sender public key only",
-		})
-	}
-	senderAddr, rosettaError := getAddressFromPublicKey(request.PublicKeys[0])
-	if rosettaError != nil {
-		return nil, rosettaError
-	}
-	senderID, rosettaError := newAccountIdentifier(*senderAddr)
-	if rosettaError != nil {
-		return nil, rosettaError
-	}
 	compnetnts, rosettaError := GetOperationCompnetnts(request.Operations)
 	if rosettaError != nil {
 		return nil, rosettaError
@@ -113,10 +103,14 @@ func (s *ConstructAPI) ConstructionPayloads(
 			"message": "sender address is not found for given operations",
 		})
 	}
-	if senderID.Address != compnetnts.From.Address {
-		return nil, common.NewError(common.InvalidTransactionConstructinetrror, map[string]interface{}{
-			"message": "sender account identifier from operations does not match account identifier from public key",
-		})
+	// Public keys of other accounts than the sender may be given, they do not sign the transaction
+	senderAddr, rosettaError := getSenderAddressFromPublicKeys(request.PublicKeys, compnetnts.From)
+	if rosettaError != nil {
+		return nil, rosettaError
+	}
+	senderID, rosettaError := newAccountIdentifier(*senderAddr)
+	if rosettaError != nil {
+		return nil, rosettaError
 	}
 	if metadata.Transaction.FromShardID != nil && *metadata.Transaction.FromShardID != s.ngy.ShardID {
 		return nil, common.NewError(common.InvalidTransactionConstructinetrror, map[string]interface{}{
@@ -178,56 +172,71 @@ func (s *ConstructAPI) getSigningPayload(
 	return payload, nil
 }
 
-// ConstructionCombine implements the /construction/combine endpoint.
-func (s *ConstructAPI) ConstructionCombine(
-	ctx context.Context, request *types.ConstructionCombineRequest,
-) (*types.ConstructionCombineResponse, *types.Error) {
-	if err := assertValidNetworkIdentifier(request.NetworkIdentifier, s.ngy.ShardID); err != nil {
-		return nil, err
-	}
-	wrappedTransaction, tx, rosettaError := unpackWrappedTransactionFromString(request.UnsignedTransaction)
-	if rosettaError != nil {
-		return nil, rosettaError
-	}
-	if len(request.Signatures) != 1 {
-		return nil, common.NewError(common.InvalidTransactionConstructinetrror, map[string]interface{}{
-			"message": "require exactly 1 signature",
-		})
-	}
-	if tx.ShardID() != s.ngy.ShardID {
-		return nil, common.NewError(common.InvalidTransactionConstructinetrror, map[string]interface{}{
-			"message": fmt.Sprintf("transaction is for shard %v != shard %v", tx.ShardID(), s.ngy.ShardID),
+// getSenderSignature returns the signature of the signing payload of the sender, the only signer of
+// a transaction, and the address of its public key. Signatures of several signers are rejected
+// as there is no payload for any other signer.
+func (s *ConstructAPI) getSenderSignature(
+	tx ngyTypes.PoolTransaction, sender *types.AccountIdentifier, signatures []*types.Signature,
+) (*types.Signature, *ethCommon.Address, *types.Error) {
+	if len(signatures) != 1 || signatures[0] == nil {
+		return nil, nil, common.NewError(common.InvalidTransactionConstructinetrror, map[string]interface{}{
+			"message": fmt.Sprintf(
+				"require exactly 1 signature of the sender, the only signer, got %v", len(signatures),
+			),
 		})
 	}
-
-	sig := request.Signatures[0]
+	sig := signatures[0]
 	if sig.SignatureType != common.SignatureType {
-		return nil, common.NewError(common.InvalidTransactionConstructinetrror, map[string]interface{}{
+		return nil, nil, common.NewError(common.InvalidTransactionConstructinetrror, map[string]interface{}{
 			"message": fmt.Sprintf("invalid signature type, currently only support %v", common.SignatureType),
 		})
 	}
 	sigAddress, rosettaError := getAddressFromPublicKey(sig.PublicKey)
 	if rosettaError != nil {
-		return nil, rosettaError
+		return nil, nil, rosettaError
 	}
 	sigAccountID, rosettaError := newAccountIdentifier(*sigAddress)
 	if rosettaError != nil {
-		return nil, rosettaError
+		return nil, nil, rosettaError
 	}
-	if wrappedTransaction.From == nil || wrappedTransaction.From.Address != sigAccountID.Address {
-		return nil, common.NewError(common.InvalidTransactionConstructinetrror, map[string]interface{}{
+	if sender == nil || sender.Address != sigAccountID.Address {
+		return nil, nil, common.NewError(common.InvalidTransactionConstructinetrror, map[string]interface{}{
 			"message": "signer public key does not match unsigned transaction's sender",
 		})
 	}
 	txPayload, rosettaError := s.getSigningPayload(tx, sigAccountID)
 	if rosettaError != nil {
-		return nil, rosettaError
+		return nil, nil, rosettaError
 	}
 	if sig.SigningPayload == nil || types.Hash(sig.SigningPayload) != types.Hash(txPayload) {
-		return nil, common.NewError(common.InvalidTransactionConstructinetrror, map[string]interface{}{
+		return nil, nil, common.NewError(common.InvalidTransactionConstructinetrror, map[string]interface{}{
 			"message": "transaction signing payload does not match given signing payload",
 		})
 	}
+	return sig, sigAddress, nil
+}
+
+// ConstructionCombine implements the /construction/combine endpoint.
+// It takes the one signature of the payload of ConstructionPayloads, the sender's.
+func (s *ConstructAPI) ConstructionCombine(
+	ctx context.Context, request *types.ConstructionCombineRequest,
+) (*types.ConstructionCombineResponse, *types.Error) {
+	if err := assertValidNetworkIdentifier(request.NetworkIdentifier, s.ngy.ShardID); err != nil {
+		return nil, err
+	}
+	wrappedTransaction, tx, rosettaError := unpackWrappedTransactionFromString(request.UnsignedTransaction)
+	if rosettaError != nil {
+		return nil, rosettaError
+	}
+	if tx.ShardID() != s.ngy.ShardID {
+		return nil, common.NewError(common.InvalidTransactionConstructinetrror, map[string]interface{}{
+			"message": fmt.Sprintf("transaction is for shard %v != shard %v", tx.ShardID(), s.ngy.ShardID),
+		})
+	}
+	sig, sigAddress, rosettaError := s.getSenderSignature(tx, wrappedTransaction.From, request.Signatures)
+	if rosettaError != nil {
+		return nil, rosettaError
+	}
 
 	var err error
 	var signedTx ngyTypes.PoolTransaction
@@ -2,6 +2,7 @@ package services
 
 import (
 	"bytes"
+	"crypto/ecdsa"
 	"encoding/json"
 	"math/big"
 	"testing"
@@ -10,6 +11,7 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 
 	ngytypes "github.com/nordicenergy/nordicenergy-core/core/types"
+	"github.com/nordicenergy/nordicenergy-core/rosetta/common"
 	stakingTypes "github.com/nordicenergy/nordicenergy-core/staking/types"
 	"github.com/nordicenergy/nordicenergy-core/test/helpers"
 )
@@ -147,3 +149,63 @@ func TestUnpackWrappedTransactionFromString(t *testing.T) {
 		t.Fatal("expected error")
 	}
 }
+
+func TestGetSenderSignature(t *testing.T) {
+	senderKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	senderID, rosettaError := newAccountIdentifier(crypto.PubkeyToAddress(senderKey.PublicKey))
+	if rosettaError != nil {
+		t.Fatal(rosettaError)
+	}
+	tx, err := helpers.CreateTestTransaction(
+		tempTestSigner, 0, 1, 2, 100000, gasPrice, big.NewInt(1e10), []byte{},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := &ConstructAPI{signer: tempTestSigner, stakingSigner: tempTestStakingSigner}
+	payload, rosettaError := s.getSigningPayload(tx, senderID)
+	if rosettaError != nil {
+		t.Fatal(rosettaError)
+	}
+	newSignature := func(key *ecdsa.PrivateKey) *types.Signature {
+		return &types.Signature{
+			SigningPayload: payload,
+			PublicKey: &types.PublicKey{
+				Bytes:     crypto.CompressPubkey(&key.PublicKey),
+				CurveType: common.CurveType,
+			},
+			SignatureType: common.SignatureType,
+			Bytes:         make([]byte, SignedPayloadLength),
+		}
+	}
+
+	sig, addr, rosettaError := s.getSenderSignature(tx, senderID, []*types.Signature{newSignature(senderKey)})
+	if rosettaError != nil {
+		t.Fatal(rosettaError)
+	}
+	if addr == nil || *addr != crypto.PubkeyToAddress(senderKey.PublicKey) || sig == nil {
+		t.Errorf("expected the signature of the sender, got %v of %v", sig, addr)
+	}
+
+	// Only the sender signs the transaction
+	if _, _, rosettaError = s.getSenderSignature(tx, senderID, []*types.Signature{
+		newSignature(senderKey), newSignature(otherKey),
+	}); rosettaError == nil {
+		t.Error("expected error for signatures of several signers")
+	}
+	if _, _, rosettaError = s.getSenderSignature(
+		tx, senderID, []*types.Signature{newSignature(otherKey)},
+	); rosettaError == nil {
+		t.Error("expected error for a signature of another account than the sender")
+	}
+	if _, _, rosettaError = s.getSenderSignature(tx, senderID, nil); rosettaError == nil {
+		t.Error("expected error for no signature")
+	}
+}
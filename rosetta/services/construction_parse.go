@@ -3,12 +3,16 @@ package services
 import (
 	"context"
 	"fmt"
+	"math/big"
 
 	"github.com/coinbase/rosetta-sdk-go/types"
+	ethCommon "github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
 
 	ngyTypes "github.com/nordicenergy/nordicenergy-core/core/types"
 	"github.com/nordicenergy/nordicenergy-core/rosetta/common"
+	"github.com/nordicenergy/nordicenergy-core/staking"
+	stakingTypes "github.com/nordicenergy/nordicenergy-core/staking/types"
 )
 
 // ConstructionParse implements the /construction/parse endpoint.
@@ -49,12 +53,9 @@ func parseUnsignedTransaction(
 		})
 	}
 
-	// TODO (dm): implement intended receipt for staking transactions
-	intendedReceipt := &ngyTypes.Receipt{
-		GasUsed: tx.GasLimit(),
-	}
 	formattedTx, rosettaError := FormatTransaction(
-		tx, intendedReceipt, &ContractInfo{ContractCode: wrappedTransaction.ContractCode},
+		tx, newIntendedReceipt(tx, FormatDefaultSenderAddress),
+		&ContractInfo{ContractCode: wrappedTransaction.ContractCode},
 	)
 	if rosettaError != nil {
 		return nil, rosettaError
@@ -92,22 +93,18 @@ func parseSignedTransaction(
 		})
 	}
 
-	// TODO (dm): implement intended receipt for staking transactions
-	intendedReceipt := &ngyTypes.Receipt{
-		GasUsed: tx.GasLimit(),
-	}
-	formattedTx, rosettaError := FormatTransaction(
-		tx, intendedReceipt, &ContractInfo{ContractCode: wrappedTransaction.ContractCode},
-	)
-	if rosettaError != nil {
-		return nil, rosettaError
-	}
 	sender, err := tx.SenderAddress()
 	if err != nil {
 		return nil, common.NewError(common.InvalidTransactionConstructinetrror, map[string]interface{}{
 			"message": errors.WithMessage(err, "unable to get sender address for signed transaction").Error(),
 		})
 	}
+	formattedTx, rosettaError := FormatTransaction(
+		tx, newIntendedReceipt(tx, sender), &ContractInfo{ContractCode: wrappedTransaction.ContractCode},
+	)
+	if rosettaError != nil {
+		return nil, rosettaError
+	}
 	senderID, rosettaError := newAccountIdentifier(sender)
 	if rosettaError != nil {
 		return nil, rosettaError
@@ -125,3 +122,20 @@ func parseSignedTransaction(
 		AccountIdentifierSigners: []*types.AccountIdentifier{senderID},
 	}, nil
 }
+
+// newIntendedReceipt returns the receipt the transaction of sender is intended to have once executed,
+// using all of its gas. The rewards collected are only known once executed, so they are intended to be 0.
+func newIntendedReceipt(tx ngyTypes.PoolTransaction, sender ethCommon.Address) *ngyTypes.Receipt {
+	receipt := &ngyTypes.Receipt{
+		GasUsed: tx.GasLimit(),
+	}
+	if stakingTx, ok := tx.(*stakingTypes.StakingTransaction); ok &&
+		stakingTx.StakingType() == stakingTypes.DirectiveCollectRewards {
+		receipt.Logs = []*ngyTypes.Log{{
+			Address: sender,
+			Topics:  []ethCommon.Hash{staking.CollectRewardsTopic},
+			Data:    big.NewInt(0).Bytes(),
+		}}
+	}
+	return receipt
+}
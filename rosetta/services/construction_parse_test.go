@@ -1,15 +1,21 @@
 package services
 
 import (
+	"bytes"
 	"context"
+	"crypto/ecdsa"
+	"encoding/json"
 	"math/big"
 	"testing"
 
 	"github.com/coinbase/rosetta-sdk-go/parser"
+	"github.com/coinbase/rosetta-sdk-go/types"
 	"github.com/ethereum/go-ethereum/crypto"
 
 	ngytypes "github.com/nordicenergy/nordicenergy-core/core/types"
 	internalCommon "github.com/nordicenergy/nordicenergy-core/internal/common"
+	"github.com/nordicenergy/nordicenergy-core/rosetta/common"
+	rpcV2 "github.com/nordicenergy/nordicenergy-core/rpc/v2"
 	stakingTypes "github.com/nordicenergy/nordicenergy-core/staking/types"
 	"github.com/nordicenergy/nordicenergy-core/test/helpers"
 )
@@ -78,8 +84,273 @@ func TestParseUnsignedTransaction(t *testing.T) {
 	}
 }
 
-func TestParseUnsignedTransactionStaking(t *testing.T) {
-	// TODO (dm): implement staking test
+func TestParseConstructedStakingTransaction(t *testing.T) {
+	refKey := internalCommon.MustGeneratePrivateKey()
+	refAddr := crypto.PubkeyToAddress(refKey.PublicKey)
+	refAccID, rosettaError := newAccountIdentifier(refAddr)
+	if rosettaError != nil {
+		t.Fatal(rosettaError)
+	}
+	validatorAddr := internalCommon.MustAddressToBech32(
+		crypto.PubkeyToAddress(internalCommon.MustGeneratePrivateKey().PublicKey),
+	)
+	refSlotKey := rpcV2.BLSPublicKey{0x01}
+	refSlotKeySig := rpcV2.BLSSignature{0x02}
+
+	cases := []struct {
+		directive stakingTypes.Directive
+		message   interface{}
+		amount    *big.Int
+	}{
+		{
+			directive: stakingTypes.DirectiveCreateValidator,
+			message: &common.CreateValidatorOperationMetadata{
+				ValidatorAddress:   refAccID.Address,
+				CommissionRate:     big.NewInt(1e17),
+				MaxCommissionRate:  big.NewInt(5e17),
+				MaxChangeRate:      big.NewInt(5e16),
+				MinSelfDelegation:  tennets,
+				MaxTotalDelegation: twelvenets,
+				Amount:             tennets,
+				Name:               "SuperHero",
+				Website:            "Secret Website",
+				Identity:           "YouWouldNotKnow",
+				SecurityContact:    "LicenseToKill",
+				Details:            "blah blah blah",
+				SlotPubKeys:        []rpcV2.BLSPublicKey{refSlotKey},
+				SlotKeySigs:        []rpcV2.BLSSignature{refSlotKeySig},
+			},
+			amount: tennets,
+		},
+		{
+			directive: stakingTypes.DirectiveEditValidator,
+			message: &common.EditValidatorOperationMetadata{
+				ValidatorAddress:   refAccID.Address,
+				CommissionRate:     big.NewInt(2e17),
+				MinSelfDelegation:  tennets,
+				MaxTotalDelegation: twelvenets,
+				Name:               "SuperHero",
+				SlotPubKeyToAdd:    &rpcV2.BLSPublicKey{0x03},
+				SlotPubKeyToRemove: &refSlotKey,
+				SlotKeyToAddSig:    &refSlotKeySig,
+			},
+			amount: big.NewInt(0),
+		},
+		{
+			directive: stakingTypes.DirectiveEditValidator,
+			message: &common.EditValidatorOperationMetadata{
+				ValidatorAddress: refAccID.Address,
+				CommissionRate:   big.NewInt(0),
+				Name:             "SuperHero",
+			},
+			amount: big.NewInt(0),
+		},
+		{
+			directive: stakingTypes.DirectiveEditValidator,
+			message: &common.EditValidatorOperationMetadata{
+				ValidatorAddress: refAccID.Address,
+				Details:          "unchanged commission rate",
+			},
+			amount: big.NewInt(0),
+		},
+		{
+			directive: stakingTypes.DirectiveDelegate,
+			message: &common.DelegateOperationMetadata{
+				DelegatorAddress: refAccID.Address,
+				ValidatorAddress: validatorAddr,
+				Amount:           tennets,
+			},
+			amount: tennets,
+		},
+		{
+			directive: stakingTypes.DirectiveUndelegate,
+			message: &common.UndelegateOperationMetadata{
+				DelegatorAddress: refAccID.Address,
+				ValidatorAddress: validatorAddr,
+				Amount:           tennets,
+			},
+			amount: big.NewInt(0),
+		},
+		{
+			directive: stakingTypes.DirectiveCollectRewards,
+			message: &common.CollectRewardsMetadata{
+				DelegatorAddress: refAccID.Address,
+			},
+			amount: big.NewInt(0),
+		},
+	}
+	for _, test := range cases {
+		refMetadata, err := types.MarshalMap(test.message)
+		if err != nil {
+			t.Fatal(err)
+		}
+		refOperations := []*types.Operation{
+			{
+				OperationIdentifier: &types.OperationIdentifier{Index: 0},
+				Type:                test.directive.String(),
+				Account:             refAccID,
+				Amount: &types.Amount{
+					Value:    negativeBigValue(test.amount),
+					Currency: &common.NativeCurrency,
+				},
+				Metadata: refMetadata,
+			},
+		}
+		for _, key := range []*ecdsa.PrivateKey{nil, refKey} {
+			parsedResponse := parseConstructedTransaction(t, refOperations, &TransactionMetadata{}, key)
+			assertParsedOperations(t, refOperations, parsedResponse.Operations)
+			if key != nil && (len(parsedResponse.AccountIdentifierSigners) != 1 ||
+				types.Hash(parsedResponse.AccountIdentifierSigners[0]) != types.Hash(refAccID)) {
+				t.Errorf("%v: unexpected signers %v", test.directive, parsedResponse.AccountIdentifierSigners)
+			}
+		}
+	}
+}
+
+func TestParseConstructedContractTransaction(t *testing.T) {
+	refKey := internalCommon.MustGeneratePrivateKey()
+	refAccID, rosettaError := newAccountIdentifier(crypto.PubkeyToAddress(refKey.PublicKey))
+	if rosettaError != nil {
+		t.Fatal(rosettaError)
+	}
+	refContractID, rosettaError := newAccountIdentifier(
+		crypto.PubkeyToAddress(internalCommon.MustGeneratePrivateKey().PublicKey),
+	)
+	if rosettaError != nil {
+		t.Fatal(rosettaError)
+	}
+	refData := "0x6080604052348015600f57600080fd5b50"
+	refCallData := "0xa9059cbb00000000000000000000000000000000000000000000000000000000000000ff"
+
+	// Test contract creation
+	refOperations := []*types.Operation{
+		{
+			OperationIdentifier: &types.OperationIdentifier{Index: 0},
+			Type:                common.ContractCreationOperation,
+			Account:             refAccID,
+			Amount: &types.Amount{
+				Value:    negativeBigValue(tennets),
+				Currency: &common.NativeCurrency,
+			},
+		},
+	}
+	for _, key := range []*ecdsa.PrivateKey{nil, refKey} {
+		parsedResponse := parseConstructedTransaction(t, refOperations, &TransactionMetadata{Data: &refData}, key)
+		assertParsedOperations(t, refOperations, parsedResponse.Operations)
+	}
+
+	// Test contract call
+	refOperations = []*types.Operation{
+		{
+			OperationIdentifier: &types.OperationIdentifier{Index: 0},
+			Type:                common.NativeTransferOperation,
+			Account:             refAccID,
+			Amount: &types.Amount{
+				Value:    negativeBigValue(tennets),
+				Currency: &common.NativeCurrency,
+			},
+		},
+		{
+			OperationIdentifier: &types.OperationIdentifier{Index: 1},
+			RelatedOperations:   []*types.OperationIdentifier{{Index: 0}},
+			Type:                common.NativeTransferOperation,
+			Account:             refContractID,
+			Amount: &types.Amount{
+				Value:    tennets.String(),
+				Currency: &common.NativeCurrency,
+			},
+		},
+	}
+	for _, key := range []*ecdsa.PrivateKey{nil, refKey} {
+		parsedResponse := parseConstructedTransaction(t, refOperations, &TransactionMetadata{
+			ContractAccountIdentifier: refContractID,
+			Data:                      &refCallData,
+		}, key)
+		assertParsedOperations(t, refOperations, parsedResponse.Operations)
+	}
+}
+
+// parseConstructedTransaction constructs the transaction of the operations as ConstructionPayloads does,
+// signs it with key if it is not nil, and parses it as ConstructionParse does
+func parseConstructedTransaction(
+	t *testing.T, operations []*types.Operation, txMetadata *TransactionMetadata, key *ecdsa.PrivateKey,
+) *types.ConstructionParseResponse {
+	compnetnts, rosettaError := GetOperationCompnetnts(operations)
+	if rosettaError != nil {
+		t.Fatal(rosettaError)
+	}
+	metadata := &ConstructMetadata{
+		Nonce:        1,
+		GasLimit:     1e5,
+		GasPrice:     gasPrice,
+		ContractCode: []byte{0x60, 0x80},
+		Transaction:  txMetadata,
+	}
+	tx, rosettaError := ConstructTransaction(compnetnts, metadata, 0)
+	if rosettaError != nil {
+		t.Fatal(rosettaError)
+	}
+	var err error
+	if key != nil {
+		switch unsignedTx := tx.(type) {
+		case *stakingTypes.StakingTransaction:
+			tx, err = stakingTypes.Sign(unsignedTx, tempTestStakingSigner, key)
+		case *ngytypes.Transaction:
+			tx, err = ngytypes.SignTx(unsignedTx, tempTestSigner, key)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	buf := &bytes.Buffer{}
+	if err := tx.EncodeRLP(buf); err != nil {
+		t.Fatal(err)
+	}
+	marshalledBytes, err := json.Marshal(WrappedTransaction{
+		RLPBytes:     buf.Bytes(),
+		IsStaking:    compnetnts.IsStaking(),
+		ContractCode: metadata.ContractCode,
+		From:         compnetnts.From,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wrappedTransaction, parsedTx, rosettaError := unpackWrappedTransactionFromString(string(marshalledBytes))
+	if rosettaError != nil {
+		t.Fatal(rosettaError)
+	}
+
+	var response *types.ConstructionParseResponse
+	if key != nil {
+		response, rosettaError = parseSignedTransaction(context.Background(), wrappedTransaction, parsedTx)
+	} else {
+		response, rosettaError = parseUnsignedTransaction(context.Background(), wrappedTransaction, parsedTx)
+	}
+	if rosettaError != nil {
+		t.Fatal(rosettaError)
+	}
+	return response
+}
+
+// assertParsedOperations checks the parsed operations are the intended ones, with their metadata,
+// along with the expected gas operation
+func assertParsedOperations(t *testing.T, intended, parsed []*types.Operation) {
+	p := parser.Parser{}
+	if err := p.ExpectedOperations(intended, parsed, false, false); err != nil {
+		t.Error(err)
+	}
+	for _, op := range intended {
+		found := false
+		for _, parsedOp := range parsed {
+			if parsedOp.Type == op.Type && types.Hash(parsedOp.Account) == types.Hash(op.Account) &&
+				types.Hash(parsedOp.Metadata) == types.Hash(op.Metadata) {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("operation %v not found in parsed operations", op.Type)
+		}
+	}
 }
 
 func TestParseSignedTransaction(t *testing.T) {
@@ -0,0 +1,439 @@
+package services
+
+import (
+	"context"
+	"encoding/binary"
+	"sync"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/pkg/errors"
+
+	"github.com/nordicenergy/nordicenergy-core/core"
+	"github.com/nordicenergy/nordicenergy-core/internal/utils"
+	"github.com/nordicenergy/nordicenergy-core/ngy"
+	"github.com/nordicenergy/nordicenergy-core/rosetta/common"
+)
+
+const (
+	// BlockAddedEvent is the type of the event of a block added to the canonical chain
+	BlockAddedEvent = "block_added"
+	// BlockRemovedEvent is the type of the event of a block removed from the canonical chain
+	BlockRemovedEvent = "block_removed"
+
+	// defaultEventsLimit is the number of events returned if the request does not limit it
+	defaultEventsLimit = 100
+	// maxEventsLimit is the max number of events returned
+	maxEventsLimit = 1000
+	// eventsReorgDepth is the number of canonical blocks tracked to find the ones removed by a reorg
+	eventsReorgDepth = 128
+)
+
+// BlockEvent is an event of the /events/blocks endpoint
+type BlockEvent struct {
+	Sequence        int64                  `json:"sequence"`
+	BlockIdentifier *types.BlockIdentifier `json:"block_identifier"`
+	Type            string                 `json:"type"`
+}
+
+// EventsBlocksRequest is the request of the /events/blocks endpoint, not
+// served by the rosetta-sdk-go version in use.
+type EventsBlocksRequest struct {
+	NetworkIdentifier *types.NetworkIdentifier `json:"network_identifier"`
+	Offset            *int64                   `json:"offset,omitempty"`
+	Limit             *int64                   `json:"limit,omitempty"`
+}
+
+// EventsBlocksResponse is the response of the /events/blocks endpoint
+type EventsBlocksResponse struct {
+	MaxSequence int64         `json:"max_sequence"`
+	Events      []*BlockEvent `json:"events"`
+}
+
+// EventsAPI serves the block events of the canonical chain, followed through
+// the chain head events
+type EventsAPI struct {
+	ngy *ngy.nordicenergy
+	log *blockEventLog
+}
+
+// NewEventsAPI creates a new instance of EventsAPI. The block events are kept
+// in the chain database, the log is created from the current block if the
+// database has none.
+func NewEventsAPI(ngy *ngy.nordicenergy) *EventsAPI {
+	current := ngy.BlockChain.CurrentBlock()
+	s := &EventsAPI{
+		ngy: ngy,
+		log: newBlockEventLog(ngy.ChainDb(), current.NumberU64(), func(number uint64) (ethcommon.Hash, bool) {
+			header := ngy.BlockChain.GetHeaderByNumber(number)
+			if header == nil {
+				return ethcommon.Hash{}, false
+			}
+			return header.Hash(), true
+		}),
+	}
+	// the blocks added since the log was kept last
+	s.update(current.NumberU64(), current.Hash(), current.ParentHash())
+	go s.follow()
+	return s
+}
+
+// follow adds the events of the chain head events to the log
+func (s *EventsAPI) follow() {
+	headC := make(chan core.ChainHeadEvent, 16)
+	sub := s.ngy.BlockChain.SubscribeChainHeadEvent(headC)
+	defer sub.Unsubscribe()
+	for {
+		select {
+		case ev := <-headC:
+			head := ev.Block
+			s.update(head.NumberU64(), head.Hash(), head.ParentHash())
+		case err := <-sub.Err():
+			utils.Logger().Error().Err(err).Msg("[Rosetta] chain head subscription closed, block events stopped")
+			return
+		}
+	}
+}
+
+// update adds the events of the canonical head to the log
+func (s *EventsAPI) update(number uint64, hash, parent ethcommon.Hash) {
+	s.log.update(number, hash, parent, func(number uint64, hash ethcommon.Hash) (ethcommon.Hash, bool) {
+		header := s.ngy.BlockChain.GetHeader(hash, number)
+		if header == nil {
+			return ethcommon.Hash{}, false
+		}
+		return header.ParentHash(), true
+	})
+}
+
+// EventsBlocks implements the /events/blocks endpoint
+func (s *EventsAPI) EventsBlocks(
+	ctx context.Context, request *EventsBlocksRequest,
+) (*EventsBlocksResponse, *types.Error) {
+	if err := assertValidNetworkIdentifier(request.NetworkIdentifier, s.ngy.ShardID); err != nil {
+		return nil, err
+	}
+
+	limit := int64(defaultEventsLimit)
+	if request.Limit != nil {
+		limit = *request.Limit
+	}
+	if limit < 0 || (request.Offset != nil && *request.Offset < 0) {
+		return nil, common.NewError(common.SanityCheckError, map[string]interface{}{
+			"message": "negative offset or limit",
+		})
+	}
+	if limit > maxEventsLimit {
+		limit = maxEventsLimit
+	}
+
+	maxSequence, events := s.log.events(request.Offset, limit)
+	return &EventsBlocksResponse{
+		MaxSequence: maxSequence,
+		Events:      events,
+	}, nil
+}
+
+var (
+	// blockEventLogKey is the key of the state of the block event log
+	blockEventLogKey = []byte("rosetta-block-event-log")
+	// blockEventPrefix + sequence is the key of a block event after the first ones
+	blockEventPrefix = []byte("rosetta-block-event-")
+	// removedBlockPrefix + number is the key of the hash of a block of the first
+	// events removed since
+	removedBlockPrefix = []byte("rosetta-removed-block-")
+	// tipBlockPrefix + number is the key of the hash of a tracked canonical block
+	tipBlockPrefix = []byte("rosetta-tip-block-")
+
+	errNoBlockEventLog = errors.New("no block event log")
+)
+
+// blockEventLogState is the state of the block event log kept in the database
+type blockEventLogState struct {
+	Base     uint64
+	Head     uint64
+	Followed uint64
+}
+
+// storedBlockEvent is a block event after the first ones kept in the database
+type storedBlockEvent struct {
+	Type   string
+	Number uint64
+	Hash   ethcommon.Hash
+}
+
+// blockEventLog is the log of the block events. The blocks canonical when the
+// log is created are added by the first events, the event of block n having
+// the sequence n. The events of the blocks added and removed after follow.
+// The log is kept in db so that the sequences are the same across restarts,
+// only the blocks tracked to find the ones removed by a reorg are in memory.
+type blockEventLog struct {
+	lock sync.RWMutex
+	db   ethdb.KeyValueStore
+	// base is the number of the block added last by the first events
+	base uint64
+	// canonical returns the hash of the canonical block of number
+	canonical func(number uint64) (ethcommon.Hash, bool)
+	// removed are the hashes of the blocks of the first events removed since
+	removed map[uint64]ethcommon.Hash
+	// followed is the number of events after the first ones
+	followed uint64
+	// tip are the hashes of the canonical blocks added last by number, to
+	// find the ones removed by a reorg
+	tip map[uint64]ethcommon.Hash
+	// head is the number of the canonical block added last
+	head uint64
+}
+
+// newBlockEventLog returns the block event log kept in db, or a new log of
+// the canonical blocks up to base if db has none
+func newBlockEventLog(
+	db ethdb.KeyValueStore, base uint64, canonical func(number uint64) (ethcommon.Hash, bool),
+) *blockEventLog {
+	l := &blockEventLog{
+		db:        db,
+		base:      base,
+		canonical: canonical,
+		removed:   map[uint64]ethcommon.Hash{},
+		tip:       map[uint64]ethcommon.Hash{},
+		head:      base,
+	}
+	if err := l.load(); err == nil {
+		return l
+	} else if err != errNoBlockEventLog {
+		utils.Logger().Error().Err(err).Msg("[Rosetta] cannot read the block events, starting a new log")
+	}
+
+	batch := db.NewBatch()
+	for number := base; number+eventsReorgDepth > base; number-- {
+		hash, ok := canonical(number)
+		if !ok {
+			break
+		}
+		l.tip[number] = hash
+		putBlockHash(batch, tipBlockPrefix, number, hash)
+		if number == 0 {
+			break
+		}
+	}
+	l.write(batch)
+	return l
+}
+
+// load reads the log kept in the database
+func (l *blockEventLog) load() error {
+	data, err := l.db.Get(blockEventLogKey)
+	if err != nil || len(data) == 0 {
+		return errNoBlockEventLog
+	}
+	state := blockEventLogState{}
+	if err := rlp.DecodeBytes(data, &state); err != nil {
+		return err
+	}
+	l.base, l.head, l.followed = state.Base, state.Head, state.Followed
+	for number := l.head; number+eventsReorgDepth > l.head; number-- {
+		if hash, ok := getBlockHash(l.db, tipBlockPrefix, number); ok {
+			l.tip[number] = hash
+		}
+		if number == 0 {
+			break
+		}
+	}
+	for number := l.base; number+eventsReorgDepth > l.base; number-- {
+		if hash, ok := getBlockHash(l.db, removedBlockPrefix, number); ok {
+			l.removed[number] = hash
+		}
+		if number == 0 {
+			break
+		}
+	}
+	return nil
+}
+
+// write writes batch along with the state of the log
+func (l *blockEventLog) write(batch ethdb.Batch) {
+	state, err := rlp.EncodeToBytes(blockEventLogState{Base: l.base, Head: l.head, Followed: l.followed})
+	if err == nil {
+		err = batch.Put(blockEventLogKey, state)
+	}
+	if err == nil {
+		err = batch.Write()
+	}
+	if err != nil {
+		utils.Logger().Error().Err(err).Msg("[Rosetta] cannot write the block events")
+	}
+}
+
+// update adds the events of the new canonical head, the events of the blocks
+// removed by a reorg first. parentOf returns the parent hash of a block.
+func (l *blockEventLog) update(
+	number uint64, hash, parent ethcommon.Hash,
+	parentOf func(number uint64, hash ethcommon.Hash) (ethcommon.Hash, bool),
+) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	// walk back from the new head to the block tracked it descends from
+	type block struct {
+		number uint64
+		hash   ethcommon.Hash
+	}
+	added := []block{}
+	for first := true; ; first = false {
+		if tracked, ok := l.tip[number]; ok && tracked == hash {
+			break
+		}
+		if _, ok := l.tip[number]; !ok && number <= l.head {
+			utils.Logger().Warn().Uint64("number", number).
+				Msg("[Rosetta] reorg deeper than the blocks tracked for the block events")
+			break
+		}
+		if !first {
+			var ok bool
+			if parent, ok = parentOf(number, hash); !ok {
+				utils.Logger().Warn().Uint64("number", number).
+					Msg("[Rosetta] block of the block events not found")
+				break
+			}
+		}
+		added = append(added, block{number, hash})
+		if number == 0 {
+			break
+		}
+		number, hash = number-1, parent
+	}
+
+	batch := l.db.NewBatch()
+	// the blocks tracked after the common ancestor are removed, the latest first
+	for n := l.head; n > number && n+eventsReorgDepth > l.head; n-- {
+		removed, ok := l.tip[n]
+		if !ok {
+			continue
+		}
+		l.append(batch, BlockRemovedEvent, n, removed)
+		delete(l.tip, n)
+		deleteBlockHash(batch, tipBlockPrefix, n)
+		if n <= l.base {
+			if _, ok := l.removed[n]; !ok {
+				l.removed[n] = removed
+				putBlockHash(batch, removedBlockPrefix, n, removed)
+			}
+		}
+	}
+	l.head = number
+	for i := len(added) - 1; i >= 0; i-- {
+		l.append(batch, BlockAddedEvent, added[i].number, added[i].hash)
+		l.tip[added[i].number] = added[i].hash
+		putBlockHash(batch, tipBlockPrefix, added[i].number, added[i].hash)
+		l.head = added[i].number
+	}
+	for n := range l.tip {
+		if n+eventsReorgDepth <= l.head {
+			delete(l.tip, n)
+			deleteBlockHash(batch, tipBlockPrefix, n)
+		}
+	}
+	l.write(batch)
+}
+
+// append adds the event to batch
+func (l *blockEventLog) append(batch ethdb.Batch, eventType string, number uint64, hash ethcommon.Hash) {
+	data, err := rlp.EncodeToBytes(storedBlockEvent{Type: eventType, Number: number, Hash: hash})
+	if err == nil {
+		err = batch.Put(blockEventKey(l.base+1+l.followed), data)
+	}
+	if err != nil {
+		utils.Logger().Error().Err(err).Msg("[Rosetta] cannot write the block event")
+	}
+	l.followed++
+}
+
+// events returns the max sequence and the limit events from offset, the
+// latest ones if offset is nil
+func (l *blockEventLog) events(offset *int64, limit int64) (int64, []*BlockEvent) {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+
+	maxSequence := int64(l.base) + int64(l.followed)
+	from := maxSequence - limit + 1
+	if offset != nil {
+		from = *offset
+	}
+	if from < 0 {
+		from = 0
+	}
+	events := []*BlockEvent{}
+	for sequence := from; sequence <= maxSequence && int64(len(events)) < limit; sequence++ {
+		if sequence > int64(l.base) {
+			event, ok := l.event(uint64(sequence))
+			if !ok {
+				break
+			}
+			events = append(events, event)
+			continue
+		}
+		hash, ok := l.removed[uint64(sequence)]
+		if !ok {
+			if hash, ok = l.canonical(uint64(sequence)); !ok {
+				break
+			}
+		}
+		events = append(events, &BlockEvent{
+			Sequence:        sequence,
+			BlockIdentifier: &types.BlockIdentifier{Index: sequence, Hash: hash.String()},
+			Type:            BlockAddedEvent,
+		})
+	}
+	return maxSequence, events
+}
+
+// event returns the event after the first ones of sequence
+func (l *blockEventLog) event(sequence uint64) (*BlockEvent, bool) {
+	data, err := l.db.Get(blockEventKey(sequence))
+	if err != nil {
+		return nil, false
+	}
+	stored := storedBlockEvent{}
+	if err := rlp.DecodeBytes(data, &stored); err != nil {
+		utils.Logger().Error().Err(err).Uint64("sequence", sequence).Msg("[Rosetta] cannot read the block event")
+		return nil, false
+	}
+	return &BlockEvent{
+		Sequence:        int64(sequence),
+		BlockIdentifier: &types.BlockIdentifier{Index: int64(stored.Number), Hash: stored.Hash.String()},
+		Type:            stored.Type,
+	}, true
+}
+
+func blockEventKey(sequence uint64) []byte {
+	return numberKey(blockEventPrefix, sequence)
+}
+
+func numberKey(prefix []byte, number uint64) []byte {
+	key := make([]byte, len(prefix)+8)
+	copy(key, prefix)
+	binary.BigEndian.PutUint64(key[len(prefix):], number)
+	return key
+}
+
+func getBlockHash(db ethdb.KeyValueReader, prefix []byte, number uint64) (ethcommon.Hash, bool) {
+	data, err := db.Get(numberKey(prefix, number))
+	if err != nil || len(data) != ethcommon.HashLength {
+		return ethcommon.Hash{}, false
+	}
+	return ethcommon.BytesToHash(data), true
+}
+
+func putBlockHash(batch ethdb.Batch, prefix []byte, number uint64, hash ethcommon.Hash) {
+	if err := batch.Put(numberKey(prefix, number), hash.Bytes()); err != nil {
+		utils.Logger().Error().Err(err).Msg("[Rosetta] cannot write the block of the block events")
+	}
+}
+
+func deleteBlockHash(batch ethdb.Batch, prefix []byte, number uint64) {
+	if err := batch.Delete(numberKey(prefix, number)); err != nil {
+		utils.Logger().Error().Err(err).Msg("[Rosetta] cannot delete the block of the block events")
+	}
+}
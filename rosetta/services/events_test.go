@@ -0,0 +1,102 @@
+package services
+
+import (
+	"testing"
+
+	ethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+)
+
+// testBlockHash is the hash of block number of fork
+func testBlockHash(number uint64, fork byte) ethcommon.Hash {
+	return ethcommon.Hash{fork, byte(number)}
+}
+
+func TestBlockEventLog(t *testing.T) {
+	// the canonical chain, block 0 to 3 of fork 0
+	canonical := map[uint64]ethcommon.Hash{}
+	for n := uint64(0); n <= 3; n++ {
+		canonical[n] = testBlockHash(n, 0)
+	}
+	canonicalOf := func(number uint64) (ethcommon.Hash, bool) {
+		hash, ok := canonical[number]
+		return hash, ok
+	}
+	db := rawdb.NewMemoryDatabase()
+	log := newBlockEventLog(db, 3, canonicalOf)
+	// the parent of a block of a fork forking from block 1
+	parentOf := func(number uint64, hash ethcommon.Hash) (ethcommon.Hash, bool) {
+		if hash[0] == 1 && number > 2 {
+			return testBlockHash(number-1, 1), true
+		}
+		return testBlockHash(number-1, 0), true
+	}
+
+	log.update(4, testBlockHash(4, 0), testBlockHash(3, 0), parentOf)
+	canonical[4] = testBlockHash(4, 0)
+	// reorg replacing the blocks 2 to 4 by the ones of fork 1 up to 5
+	log.update(5, testBlockHash(5, 1), testBlockHash(4, 1), parentOf)
+	for n := uint64(2); n <= 5; n++ {
+		canonical[n] = testBlockHash(n, 1)
+	}
+
+	expected := []struct {
+		eventType string
+		hash      ethcommon.Hash
+	}{
+		{BlockAddedEvent, testBlockHash(0, 0)},
+		{BlockAddedEvent, testBlockHash(1, 0)},
+		{BlockAddedEvent, testBlockHash(2, 0)},
+		{BlockAddedEvent, testBlockHash(3, 0)},
+		{BlockAddedEvent, testBlockHash(4, 0)},
+		{BlockRemovedEvent, testBlockHash(4, 0)},
+		{BlockRemovedEvent, testBlockHash(3, 0)},
+		{BlockRemovedEvent, testBlockHash(2, 0)},
+		{BlockAddedEvent, testBlockHash(2, 1)},
+		{BlockAddedEvent, testBlockHash(3, 1)},
+		{BlockAddedEvent, testBlockHash(4, 1)},
+		{BlockAddedEvent, testBlockHash(5, 1)},
+	}
+	offset := int64(0)
+	maxSequence, events := log.events(&offset, 100)
+	if maxSequence != int64(len(expected)-1) || len(events) != len(expected) {
+		t.Fatalf("max sequence %d and %d events, expected %d and %d",
+			maxSequence, len(events), len(expected)-1, len(expected))
+	}
+	for i, event := range events {
+		if event.Sequence != int64(i) || event.Type != expected[i].eventType ||
+			event.BlockIdentifier.Hash != expected[i].hash.String() {
+			t.Errorf("event %d: %s of block %s, expected %s of block %s", event.Sequence,
+				event.Type, event.BlockIdentifier.Hash, expected[i].eventType, expected[i].hash.String())
+		}
+	}
+
+	// the log kept in the database has the same events after a restart
+	restarted := newBlockEventLog(db, 5, canonicalOf)
+	restartedMax, restartedEvents := restarted.events(&offset, 100)
+	if restartedMax != maxSequence || len(restartedEvents) != len(events) {
+		t.Fatalf("max sequence %d and %d events after restart, expected %d and %d",
+			restartedMax, len(restartedEvents), maxSequence, len(events))
+	}
+	for i, event := range restartedEvents {
+		if event.Type != events[i].Type || event.BlockIdentifier.Hash != events[i].BlockIdentifier.Hash {
+			t.Errorf("event %d after restart: %s of block %s, expected %s of block %s", event.Sequence,
+				event.Type, event.BlockIdentifier.Hash, events[i].Type, events[i].BlockIdentifier.Hash)
+		}
+	}
+	// and follows the chain from the blocks it tracked
+	restarted.update(6, testBlockHash(6, 1), testBlockHash(5, 1), parentOf)
+	if max, events := restarted.events(nil, 1); max != maxSequence+1 || len(events) != 1 ||
+		events[0].Type != BlockAddedEvent || events[0].BlockIdentifier.Hash != testBlockHash(6, 1).String() {
+		t.Errorf("unexpected event after restart %v", events)
+	}
+
+	// the latest events without offset
+	if _, events := log.events(nil, 2); len(events) != 2 || events[0].Sequence != 10 || events[1].Sequence != 11 {
+		t.Errorf("unexpected latest events %v", events)
+	}
+	offset = 3
+	if _, events := log.events(&offset, 3); len(events) != 3 || events[0].Sequence != 3 || events[2].Sequence != 5 {
+		t.Errorf("unexpected events from offset 3 %v", events)
+	}
+}
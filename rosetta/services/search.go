@@ -0,0 +1,353 @@
+package services
+
+import (
+	"context"
+	"sort"
+
+	"github.com/coinbase/rosetta-sdk-go/server"
+	"github.com/coinbase/rosetta-sdk-go/types"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+
+	"github.com/nordicenergy/nordicenergy-core/api/service/explorer"
+	"github.com/nordicenergy/nordicenergy-core/core/rawdb"
+	nodeconfig "github.com/nordicenergy/nordicenergy-core/internal/configs/node"
+	"github.com/nordicenergy/nordicenergy-core/ngy"
+	"github.com/nordicenergy/nordicenergy-core/rosetta/common"
+)
+
+const (
+	// SearchOperatorAnd matches the transactions meeting all the conditions of a search
+	SearchOperatorAnd = "and"
+	// SearchOperatorOr matches the transactions meeting any condition of a search
+	SearchOperatorOr = "or"
+
+	// defaultSearchLimit is the number of transactions returned if the request does not limit it
+	defaultSearchLimit = 100
+	// maxSearchLimit is the max number of transactions returned
+	maxSearchLimit = 1000
+)
+
+// SearchTransactionsRequest is the request of the /search/transactions
+// endpoint, not served by the rosetta-sdk-go version in use.
+type SearchTransactionsRequest struct {
+	NetworkIdentifier     *types.NetworkIdentifier     `json:"network_identifier"`
+	Operator              *string                      `json:"operator,omitempty"`
+	MaxBlock              *int64                       `json:"max_block,omitempty"`
+	Offset                *int64                       `json:"offset,omitempty"`
+	Limit                 *int64                       `json:"limit,omitempty"`
+	TransactionIdentifier *types.TransactionIdentifier `json:"transaction_identifier,omitempty"`
+	AccountIdentifier     *types.AccountIdentifier     `json:"account_identifier,omitempty"`
+	CoinIdentifier        *types.CoinIdentifier        `json:"coin_identifier,omitempty"`
+	Currency              *types.Currency              `json:"currency,omitempty"`
+	Status                *string                      `json:"status,omitempty"`
+	Type                  *string                      `json:"type,omitempty"`
+	Address               *string                      `json:"address,omitempty"`
+	Success               *bool                        `json:"success,omitempty"`
+}
+
+// BlockTransaction is a transaction with the block it is included in
+type BlockTransaction struct {
+	BlockIdentifier *types.BlockIdentifier `json:"block_identifier"`
+	Transaction     *types.Transaction     `json:"transaction"`
+}
+
+// SearchTransactionsResponse is the response of the /search/transactions endpoint
+type SearchTransactionsResponse struct {
+	Transactions []*BlockTransaction `json:"transactions"`
+	TotalCount   int64               `json:"total_count"`
+	NextOffset   *int64              `json:"next_offset,omitempty"`
+}
+
+// SearchAPI searches the transactions of the chain in the indexes of the node,
+// the transaction lookup entries and, on explorer nodes, the transactions
+// history of the addresses.
+type SearchAPI struct {
+	ngy   *ngy.nordicenergy
+	block server.BlockAPIServicer
+}
+
+// NewSearchAPI creates a new instance of SearchAPI.
+func NewSearchAPI(ngy *ngy.nordicenergy) *SearchAPI {
+	return &SearchAPI{
+		ngy:   ngy,
+		block: NewBlockAPI(ngy),
+	}
+}
+
+// searchedTx is a transaction found by a search
+type searchedTx struct {
+	hash      ethcommon.Hash
+	blockHash ethcommon.Hash
+	blockNum  uint64
+}
+
+// SearchTransactions implements the /search/transactions endpoint.
+// The transactions are found by their identifier or by account, the other
+// conditions filter them, so that they are only supported by the and operator.
+// The transactions are returned the latest first.
+func (s *SearchAPI) SearchTransactions(
+	ctx context.Context, request *SearchTransactionsRequest,
+) (*SearchTransactionsResponse, *types.Error) {
+	if err := assertValidNetworkIdentifier(request.NetworkIdentifier, s.ngy.ShardID); err != nil {
+		return nil, err
+	}
+	offset, limit, rosettaError := searchPage(request)
+	if rosettaError != nil {
+		return nil, rosettaError
+	}
+	operator := SearchOperatorAnd
+	if request.Operator != nil {
+		operator = *request.Operator
+	}
+	if operator != SearchOperatorAnd && operator != SearchOperatorOr {
+		return nil, common.NewError(common.SanityCheckError, map[string]interface{}{
+			"message": "unknown search operator " + operator,
+		})
+	}
+	filtered := request.Currency != nil || request.Status != nil || request.Type != nil || request.Success != nil
+	if operator == SearchOperatorOr && filtered {
+		return nil, common.NewError(common.SanityCheckError, map[string]interface{}{
+			"message": "or operator only supported on transaction, account and address conditions",
+		})
+	}
+
+	// the transactions of each condition finding transactions
+	sources := [][]*searchedTx{}
+	if request.TransactionIdentifier != nil {
+		sources = append(sources, s.lookupTxs([]ethcommon.Hash{
+			ethcommon.HexToHash(request.TransactionIdentifier.Hash),
+		}))
+	}
+	addresses := []string{}
+	if request.AccountIdentifier != nil {
+		addresses = append(addresses, request.AccountIdentifier.Address)
+	}
+	if request.Address != nil {
+		addresses = append(addresses, *request.Address)
+	}
+	for _, address := range addresses {
+		txs, rosettaError := s.accountTxs(address)
+		if rosettaError != nil {
+			return nil, rosettaError
+		}
+		sources = append(sources, txs)
+	}
+	if request.CoinIdentifier != nil {
+		// the chain is account based, no transaction spends coins
+		sources = append(sources, []*searchedTx{})
+	}
+	if len(sources) == 0 {
+		return nil, common.NewError(common.SanityCheckError, map[string]interface{}{
+			"message": "search without transaction, account, address or coin condition",
+		})
+	}
+
+	txs := combineSearchedTxs(sources, operator == SearchOperatorAnd)
+	if request.MaxBlock != nil {
+		kept := []*searchedTx{}
+		for _, tx := range txs {
+			if int64(tx.blockNum) <= *request.MaxBlock {
+				kept = append(kept, tx)
+			}
+		}
+		txs = kept
+	}
+
+	// the transactions are formatted to be filtered, then only the ones of the page
+	transactions := []*BlockTransaction{}
+	if filtered {
+		for _, tx := range txs {
+			transaction, rosettaError := s.formatTx(ctx, request.NetworkIdentifier, tx)
+			if rosettaError != nil {
+				return nil, rosettaError
+			}
+			if matchTransaction(transaction.Transaction, request) {
+				transactions = append(transactions, transaction)
+			}
+		}
+	}
+	total := int64(len(txs))
+	if filtered {
+		total = int64(len(transactions))
+	}
+
+	response := &SearchTransactionsResponse{
+		Transactions: []*BlockTransaction{},
+		TotalCount:   total,
+	}
+	for i := offset; i < total && i < offset+limit; i++ {
+		if filtered {
+			response.Transactions = append(response.Transactions, transactions[i])
+			continue
+		}
+		transaction, rosettaError := s.formatTx(ctx, request.NetworkIdentifier, txs[i])
+		if rosettaError != nil {
+			return nil, rosettaError
+		}
+		response.Transactions = append(response.Transactions, transaction)
+	}
+	if offset+limit < total {
+		next := offset + limit
+		response.NextOffset = &next
+	}
+	return response, nil
+}
+
+// searchPage returns the offset and the limit of the page of a search
+func searchPage(request *SearchTransactionsRequest) (offset, limit int64, rosettaError *types.Error) {
+	limit = defaultSearchLimit
+	if request.Limit != nil {
+		limit = *request.Limit
+	}
+	if request.Offset != nil {
+		offset = *request.Offset
+	}
+	if limit < 0 || offset < 0 {
+		return 0, 0, common.NewError(common.SanityCheckError, map[string]interface{}{
+			"message": "negative offset or limit",
+		})
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+	return offset, limit, nil
+}
+
+// accountTxs returns the transactions, staking transactions and cross-shard
+// payouts of the bech32 address in the explorer history
+func (s *SearchAPI) accountTxs(address string) ([]*searchedTx, *types.Error) {
+	if nodeconfig.GetShardConfig(s.ngy.ShardID).Role() != nodeconfig.ExplorerNode {
+		return nil, common.NewError(common.SanityCheckError, map[string]interface{}{
+			"message": "search by account only supported on explorer nodes",
+		})
+	}
+	query := explorer.TxHistoryQuery{Address: address, Order: "DESC"}
+	hashes, err := s.ngy.GetTransactionsHistory(query)
+	if err != nil {
+		return nil, common.NewError(common.SanityCheckError, map[string]interface{}{
+			"message": err.Error(),
+		})
+	}
+	stakingHashes, err := s.ngy.GetStakingTransactionsHistory(query)
+	if err != nil {
+		return nil, common.NewError(common.CatchAllError, map[string]interface{}{
+			"message": err.Error(),
+		})
+	}
+	payoutHashes, err := s.ngy.GetCxPayoutsHistory(query)
+	if err != nil {
+		return nil, common.NewError(common.CatchAllError, map[string]interface{}{
+			"message": err.Error(),
+		})
+	}
+	hashes = append(hashes, stakingHashes...)
+	return s.lookupTxs(append(hashes, payoutHashes...)), nil
+}
+
+// lookupTxs returns the transactions, staking transactions and cross-shard
+// receipts of hashes found in the chain, the latest first
+func (s *SearchAPI) lookupTxs(hashes []ethcommon.Hash) []*searchedTx {
+	db := s.ngy.ChainDb()
+	txs := []*searchedTx{}
+	for _, hash := range hashes {
+		blockHash, blockNum, _ := rawdb.ReadTxLookupEntry(db, hash)
+		if blockHash == (ethcommon.Hash{}) {
+			blockHash, blockNum, _ = rawdb.ReadCxLookupEntry(db, hash)
+		}
+		if blockHash == (ethcommon.Hash{}) {
+			continue
+		}
+		txs = append(txs, &searchedTx{hash: hash, blockHash: blockHash, blockNum: blockNum})
+	}
+	sort.SliceStable(txs, func(i, j int) bool {
+		return txs[i].blockNum > txs[j].blockNum
+	})
+	return txs
+}
+
+// combineSearchedTxs returns the transactions of all the sources if all is
+// set, of any source otherwise, the latest first
+func combineSearchedTxs(sources [][]*searchedTx, all bool) []*searchedTx {
+	counts := map[ethcommon.Hash]int{}
+	combined := []*searchedTx{}
+	for _, source := range sources {
+		seen := map[ethcommon.Hash]bool{}
+		for _, tx := range source {
+			if seen[tx.hash] {
+				continue
+			}
+			seen[tx.hash] = true
+			if counts[tx.hash] == 0 {
+				combined = append(combined, tx)
+			}
+			counts[tx.hash]++
+		}
+	}
+	txs := []*searchedTx{}
+	for _, tx := range combined {
+		if !all || counts[tx.hash] == len(sources) {
+			txs = append(txs, tx)
+		}
+	}
+	sort.SliceStable(txs, func(i, j int) bool {
+		return txs[i].blockNum > txs[j].blockNum
+	})
+	return txs
+}
+
+// formatTx returns the rosetta transaction of tx, with its operations
+func (s *SearchAPI) formatTx(
+	ctx context.Context, network *types.NetworkIdentifier, tx *searchedTx,
+) (*BlockTransaction, *types.Error) {
+	blockID := &types.BlockIdentifier{
+		Index: int64(tx.blockNum),
+		Hash:  tx.blockHash.String(),
+	}
+	response, rosettaError := s.block.BlockTransaction(ctx, &types.BlockTransactionRequest{
+		NetworkIdentifier:     network,
+		BlockIdentifier:       blockID,
+		TransactionIdentifier: &types.TransactionIdentifier{Hash: tx.hash.String()},
+	})
+	if rosettaError != nil {
+		return nil, rosettaError
+	}
+	return &BlockTransaction{
+		BlockIdentifier: blockID,
+		Transaction:     response.Transaction,
+	}, nil
+}
+
+// matchTransaction returns if an operation of transaction meets each of the
+// filtering conditions of request
+func matchTransaction(transaction *types.Transaction, request *SearchTransactionsRequest) bool {
+	matchOperation := func(match func(op *types.Operation) bool) bool {
+		for _, op := range transaction.Operations {
+			if match(op) {
+				return true
+			}
+		}
+		return false
+	}
+	if request.Type != nil && !matchOperation(func(op *types.Operation) bool {
+		return op.Type == *request.Type
+	}) {
+		return false
+	}
+	if request.Status != nil && !matchOperation(func(op *types.Operation) bool {
+		return op.Status == *request.Status
+	}) {
+		return false
+	}
+	if request.Success != nil && !matchOperation(func(op *types.Operation) bool {
+		return (op.Status == common.SuccessOperationStatus.Status) == *request.Success
+	}) {
+		return false
+	}
+	if request.Currency != nil && !matchOperation(func(op *types.Operation) bool {
+		return op.Amount != nil && op.Amount.Currency != nil &&
+			types.Hash(op.Amount.Currency) == types.Hash(request.Currency)
+	}) {
+		return false
+	}
+	return true
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/coinbase/rosetta-sdk-go/types"
+	ethcommon "github.com/ethereum/go-ethereum/common"
+
+	"github.com/nordicenergy/nordicenergy-core/rosetta/common"
+)
+
+func TestCombineSearchedTxs(t *testing.T) {
+	tx1 := &searchedTx{hash: ethcommon.Hash{1}, blockNum: 1}
+	tx2 := &searchedTx{hash: ethcommon.Hash{2}, blockNum: 2}
+	tx3 := &searchedTx{hash: ethcommon.Hash{3}, blockNum: 3}
+	sources := [][]*searchedTx{{tx1, tx2}, {tx3, tx2, tx2}}
+
+	if txs := combineSearchedTxs(sources, true); len(txs) != 1 || txs[0] != tx2 {
+		t.Errorf("and: unexpected transactions %v", txs)
+	}
+	txs := combineSearchedTxs(sources, false)
+	if len(txs) != 3 || txs[0] != tx3 || txs[1] != tx2 || txs[2] != tx1 {
+		t.Errorf("or: unexpected transactions %v", txs)
+	}
+	if txs := combineSearchedTxs(append(sources, []*searchedTx{}), true); len(txs) != 0 {
+		t.Errorf("and with an empty source: unexpected transactions %v", txs)
+	}
+}
+
+func TestMatchTransaction(t *testing.T) {
+	transaction := &types.Transaction{
+		Operations: []*types.Operation{
+			{
+				Type:   common.ExpendGasOperation,
+				Status: common.SuccessOperationStatus.Status,
+				Amount: &types.Amount{Value: "-1", Currency: &common.NativeCurrency},
+			},
+			{
+				Type:   common.NativeTransferOperation,
+				Status: common.FailureOperationStatus.Status,
+			},
+		},
+	}
+	str := func(s string) *string { return &s }
+	boolean := func(b bool) *bool { return &b }
+	tests := []struct {
+		request *SearchTransactionsRequest
+		match   bool
+	}{
+		{&SearchTransactionsRequest{}, true},
+		{&SearchTransactionsRequest{Type: str(common.NativeTransferOperation)}, true},
+		{&SearchTransactionsRequest{Type: str(common.ContractCreationOperation)}, false},
+		{&SearchTransactionsRequest{Status: str(common.FailureOperationStatus.Status)}, true},
+		{&SearchTransactionsRequest{Success: boolean(false)}, true},
+		{&SearchTransactionsRequest{Currency: &common.NativeCurrency}, true},
+		{&SearchTransactionsRequest{Currency: &types.Currency{Symbol: "X", Decimals: 18}}, false},
+		{&SearchTransactionsRequest{
+			Type:   str(common.ExpendGasOperation),
+			Status: str(common.ContractFailureOperationStatus.Status),
+		}, false},
+	}
+	for i, test := range tests {
+		if match := matchTransaction(transaction, test.request); match != test.match {
+			t.Errorf("test %d: match %v, expected %v", i, match, test.match)
+		}
+	}
+}
@@ -9,7 +9,13 @@ import (
 	"github.com/pkg/errors"
 
 	ngyTypes "github.com/nordicenergy/nordicenergy-core/core/types"
+	"github.com/nordicenergy/nordicenergy-core/crypto/bls"
+	internalCommon "github.com/nordicenergy/nordicenergy-core/internal/common"
+	"github.com/nordicenergy/nordicenergy-core/numeric"
 	"github.com/nordicenergy/nordicenergy-core/rosetta/common"
+	rpcV2 "github.com/nordicenergy/nordicenergy-core/rpc/v2"
+	"github.com/nordicenergy/nordicenergy-core/shard"
+	stakingTypes "github.com/nordicenergy/nordicenergy-core/staking/types"
 )
 
 // TransactionMetadata contains all (optional) information for a transaction.
@@ -39,7 +45,6 @@ func (t *TransactionMetadata) UnmarshalFromInterface(metaData interface{}) error
 }
 
 // ConstructTransaction object (unsigned).
-// TODO (dm): implement staking transaction construction
 func ConstructTransaction(
 	compnetnts *OperationCompnetnts, metadata *ConstructMetadata, sourceShardID uint32,
 ) (response ngyTypes.PoolTransaction, rosettaError *types.Error) {
@@ -56,6 +61,12 @@ func ConstructTransaction(
 	}
 
 	var tx ngyTypes.PoolTransaction
+	if compnetnts.IsStaking() {
+		if tx, rosettaError = constructStakingTransaction(compnetnts, metadata, sourceShardID); rosettaError != nil {
+			return nil, rosettaError
+		}
+		return tx, nil
+	}
 	switch compnetnts.Type {
 	case common.NativeCrossShardTransferOperation:
 		if tx, rosettaError = constructCrossShardTransaction(compnetnts, metadata, sourceShardID); rosettaError != nil {
@@ -168,3 +179,150 @@ func constructPlainTransaction(
 		metadata.Nonce, to, sourceShardID, compnetnts.Amount, metadata.GasLimit, metadata.GasPrice, data,
 	), nil
 }
+
+// constructStakingTransaction ..
+func constructStakingTransaction(
+	compnetnts *OperationCompnetnts, metadata *ConstructMetadata, sourceShardID uint32,
+) (ngyTypes.PoolTransaction, *types.Error) {
+	if sourceShardID != shard.BeaconChainShardID {
+		return nil, common.NewError(common.InvalidTransactionConstructinetrror, map[string]interface{}{
+			"message": fmt.Sprintf("staking transactions are only accepted on shard %v", shard.BeaconChainShardID),
+		})
+	}
+	fulfiller, err := getStakingMessageFulfiller(compnetnts.StakingMessage)
+	if err != nil {
+		return nil, common.NewError(common.InvalidTransactionConstructinetrror, map[string]interface{}{
+			"message": errors.WithMessage(err, "invalid staking message").Error(),
+		})
+	}
+	tx, err := stakingTypes.NewStakingTransaction(metadata.Nonce, metadata.GasLimit, metadata.GasPrice, fulfiller)
+	if err != nil {
+		return nil, common.NewError(common.CatchAllError, map[string]interface{}{
+			"message": err.Error(),
+		})
+	}
+	return tx, nil
+}
+
+// getStakingMessageFulfiller returns the fulfiller of the staking message of the given staking operation metadata
+func getStakingMessageFulfiller(message interface{}) (stakingTypes.StakeMsgFulfiller, error) {
+	switch msg := message.(type) {
+	case *common.CreateValidatorOperationMetadata:
+		validatorAddress, err := internalCommon.Bech32ToAddress(msg.ValidatorAddress)
+		if err != nil {
+			return nil, err
+		}
+		return func() (stakingTypes.Directive, interface{}) {
+			return stakingTypes.DirectiveCreateValidator, stakingTypes.CreateValidator{
+				ValidatorAddress: validatorAddress,
+				Description: stakingTypes.Description{
+					Name:            msg.Name,
+					Identity:        msg.Identity,
+					Website:         msg.Website,
+					SecurityContact: msg.SecurityContact,
+					Details:         msg.Details,
+				},
+				CommissionRates: stakingTypes.CommissionRates{
+					Rate:          numeric.NewDecFromBigIntWithPrec(msg.CommissionRate, numeric.Precision),
+					MaxRate:       numeric.NewDecFromBigIntWithPrec(msg.MaxCommissionRate, numeric.Precision),
+					MaxChangeRate: numeric.NewDecFromBigIntWithPrec(msg.MaxChangeRate, numeric.Precision),
+				},
+				MinSelfDelegation:  msg.MinSelfDelegation,
+				MaxTotalDelegation: msg.MaxTotalDelegation,
+				SlotPubKeys:        getSerializedPublicKeys(msg.SlotPubKeys),
+				SlotKeySigs:        getSerializedSignatures(msg.SlotKeySigs),
+				Amount:             msg.Amount,
+			}
+		}, nil
+	case *common.EditValidatorOperationMetadata:
+		validatorAddress, err := internalCommon.Bech32ToAddress(msg.ValidatorAddress)
+		if err != nil {
+			return nil, err
+		}
+		// A nil commission rate is formatted for edits that do not change the commission rate
+		var commissionRate *numeric.Dec
+		if msg.CommissionRate != nil {
+			rate := numeric.NewDecFromBigIntWithPrec(msg.CommissionRate, numeric.Precision)
+			commissionRate = &rate
+		}
+		return func() (stakingTypes.Directive, interface{}) {
+			return stakingTypes.DirectiveEditValidator, stakingTypes.EditValidator{
+				ValidatorAddress: validatorAddress,
+				Description: stakingTypes.Description{
+					Name:            msg.Name,
+					Identity:        msg.Identity,
+					Website:         msg.Website,
+					SecurityContact: msg.SecurityContact,
+					Details:         msg.Details,
+				},
+				CommissionRate:     commissionRate,
+				MinSelfDelegation:  msg.MinSelfDelegation,
+				MaxTotalDelegation: msg.MaxTotalDelegation,
+				SlotKeyToRemove:    (*bls.SerializedPublicKey)(msg.SlotPubKeyToRemove),
+				SlotKeyToAdd:       (*bls.SerializedPublicKey)(msg.SlotPubKeyToAdd),
+				SlotKeyToAddSig:    (*bls.SerializedSignature)(msg.SlotKeyToAddSig),
+			}
+		}, nil
+	case *common.DelegateOperationMetadata:
+		delegatorAddress, err := internalCommon.Bech32ToAddress(msg.DelegatorAddress)
+		if err != nil {
+			return nil, err
+		}
+		validatorAddress, err := internalCommon.Bech32ToAddress(msg.ValidatorAddress)
+		if err != nil {
+			return nil, err
+		}
+		return func() (stakingTypes.Directive, interface{}) {
+			return stakingTypes.DirectiveDelegate, stakingTypes.Delegate{
+				DelegatorAddress: delegatorAddress,
+				ValidatorAddress: validatorAddress,
+				Amount:           msg.Amount,
+			}
+		}, nil
+	case *common.UndelegateOperationMetadata:
+		delegatorAddress, err := internalCommon.Bech32ToAddress(msg.DelegatorAddress)
+		if err != nil {
+			return nil, err
+		}
+		validatorAddress, err := internalCommon.Bech32ToAddress(msg.ValidatorAddress)
+		if err != nil {
+			return nil, err
+		}
+		return func() (stakingTypes.Directive, interface{}) {
+			return stakingTypes.DirectiveUndelegate, stakingTypes.Undelegate{
+				DelegatorAddress: delegatorAddress,
+				ValidatorAddress: validatorAddress,
+				Amount:           msg.Amount,
+			}
+		}, nil
+	case *common.CollectRewardsMetadata:
+		delegatorAddress, err := internalCommon.Bech32ToAddress(msg.DelegatorAddress)
+		if err != nil {
+			return nil, err
+		}
+		return func() (stakingTypes.Directive, interface{}) {
+			return stakingTypes.DirectiveCollectRewards, stakingTypes.CollectRewards{
+				DelegatorAddress: delegatorAddress,
+			}
+		}, nil
+	}
+	return nil, fmt.Errorf("unknown staking message %T", message)
+}
+
+// getSerializedPublicKeys returns the bls public keys of the staking message of the given RPC keys
+func getSerializedPublicKeys(keys []rpcV2.BLSPublicKey) []bls.SerializedPublicKey {
+	result := make([]bls.SerializedPublicKey, len(keys))
+	for i := range keys {
+		result[i] = bls.SerializedPublicKey(keys[i])
+	}
+	return result
+}
+
+// getSerializedSignatures returns the bls signatures of the staking message of the given RPC signatures
+func getSerializedSignatures(sigs []rpcV2.BLSSignature) []bls.SerializedSignature {
+	result := make([]bls.SerializedSignature, len(sigs))
+	for i := range sigs {
+		result[i] = bls.SerializedSignature(sigs[i])
+	}
+	return result
+}
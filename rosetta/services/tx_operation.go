@@ -83,13 +83,13 @@ func GetNativeOperationsFromStakingTransaction(
 	gasOperations := newNativeOperationsWithGas(gasExpended, accountID)
 
 	// Format staking message for metadata using decimal numbers (hence usage of rpcV2)
-	rpcStakingTx, err := rpcV2.NewStakingTransaction(tx, ethcommon.Hash{}, 0, 0, 0)
+	rpcStakingMsg, err := rpcV2.NewStakingMessage(tx)
 	if err != nil {
 		return nil, common.NewError(common.CatchAllError, map[string]interface{}{
 			"message": err.Error(),
 		})
 	}
-	metadata, err := types.MarshalMap(rpcStakingTx.Msg)
+	metadata, err := types.MarshalMap(rpcStakingMsg)
 	if err != nil {
 		return nil, common.NewError(common.CatchAllError, map[string]interface{}{
 			"message": err.Error(),
@@ -6,7 +6,9 @@ import (
 
 	"github.com/coinbase/rosetta-sdk-go/types"
 
+	internalCommon "github.com/nordicenergy/nordicenergy-core/internal/common"
 	"github.com/nordicenergy/nordicenergy-core/rosetta/common"
+	stakingTypes "github.com/nordicenergy/nordicenergy-core/staking/types"
 	"github.com/pkg/errors"
 )
 
@@ -38,7 +40,6 @@ func (s *OperationCompnetnts) IsStaking() bool {
 // Providing a gas expenditure operation is INVALID.
 // All staking & cross-shard operations require metadata matching the operation type to be a valid.
 // All other operations do not require metadata.
-// TODO (dm): implement staking transaction construction
 func GetOperationCompnetnts(
 	operations []*types.Operation,
 ) (*OperationCompnetnts, *types.Error) {
@@ -56,6 +57,9 @@ func GetOperationCompnetnts(
 	if len(operations) == transferOperationCount {
 		return getTransferOperationCompnetnts(operations)
 	}
+	if isStakingOperation(operations[0].Type) {
+		return getStakingOperationCompnetnts(operations[0])
+	}
 	switch operations[0].Type {
 	case common.NativeCrossShardTransferOperation:
 		return getCrossShardOperationCompnetnts(operations[0])
@@ -233,3 +237,107 @@ func getContractCreationOperationCompnetnts(
 	}
 	return compnetnts, nil
 }
+
+// getStakingOperationCompnetnts ..
+func getStakingOperationCompnetnts(
+	operation *types.Operation,
+) (*OperationCompnetnts, *types.Error) {
+	if operation == nil {
+		return nil, common.NewError(common.CatchAllError, map[string]interface{}{
+			"message": "nil operation",
+		})
+	}
+	if operation.Account == nil {
+		return nil, common.NewError(common.InvalidTransactionConstructinetrror, map[string]interface{}{
+			"message": "operation must have account sender/from identifier for staking",
+		})
+	}
+	message, signer, stakedAmount, err := unmarshalStakingMessage(operation.Type, operation.Metadata)
+	if err != nil {
+		return nil, common.NewError(common.InvalidTransactionConstructinetrror, map[string]interface{}{
+			"message": errors.WithMessage(err, "invalid metadata").Error(),
+		})
+	}
+	amount, err := types.AmountValue(operation.Amount)
+	if err != nil {
+		return nil, common.NewError(common.InvalidTransactionConstructinetrror, map[string]interface{}{
+			"message": err.Error(),
+		})
+	}
+	if types.Hash(operation.Amount.Currency) != common.NativeCurrencyHash {
+		return nil, common.NewError(common.InvalidTransactionConstructinetrror, map[string]interface{}{
+			"message": "invalid currency for provided amounts",
+		})
+	}
+
+	// Only the amount staked by creating a validator or delegating is deducted from the sender's balance
+	// when the transaction is executed, the rewards collected are only known once executed.
+	if stakedAmount == nil {
+		stakedAmount = big.NewInt(0)
+	}
+	if new(big.Int).Add(amount, stakedAmount).Sign() != 0 {
+		return nil, common.NewError(common.InvalidTransactionConstructinetrror, map[string]interface{}{
+			"message": fmt.Sprintf("sender amount must be the negative of the amount staked (%v) for %v",
+				stakedAmount, operation.Type),
+		})
+	}
+	from, err := getAddress(operation.Account)
+	if err != nil {
+		return nil, common.NewError(common.InvalidTransactionConstructinetrror, map[string]interface{}{
+			"message": err.Error(),
+		})
+	}
+	signerAddr, err := internalCommon.Bech32ToAddress(signer)
+	if err != nil || signerAddr != from {
+		return nil, common.NewError(common.InvalidTransactionConstructinetrror, map[string]interface{}{
+			"message": fmt.Sprintf("operation account identifier does not match the signer of %v", operation.Type),
+		})
+	}
+
+	return &OperationCompnetnts{
+		Type:           operation.Type,
+		From:           operation.Account,
+		Amount:         stakedAmount,
+		StakingMessage: message,
+	}, nil
+}
+
+// unmarshalStakingMessage returns the metadata of a staking operation of operationType,
+// with the bech32 address of the account that signs it and the amount it stakes, nil if none.
+func unmarshalStakingMessage(
+	operationType string, data interface{},
+) (message interface{}, signer string, amount *big.Int, err error) {
+	switch operationType {
+	case stakingTypes.DirectiveCreateValidator.String():
+		metadata := &common.CreateValidatorOperationMetadata{}
+		if err := metadata.UnmarshalFromInterface(data); err != nil {
+			return nil, "", nil, err
+		}
+		return metadata, metadata.ValidatorAddress, metadata.Amount, nil
+	case stakingTypes.DirectiveEditValidator.String():
+		metadata := &common.EditValidatorOperationMetadata{}
+		if err := metadata.UnmarshalFromInterface(data); err != nil {
+			return nil, "", nil, err
+		}
+		return metadata, metadata.ValidatorAddress, nil, nil
+	case stakingTypes.DirectiveDelegate.String():
+		metadata := &common.DelegateOperationMetadata{}
+		if err := metadata.UnmarshalFromInterface(data); err != nil {
+			return nil, "", nil, err
+		}
+		return metadata, metadata.DelegatorAddress, metadata.Amount, nil
+	case stakingTypes.DirectiveUndelegate.String():
+		metadata := &common.UndelegateOperationMetadata{}
+		if err := metadata.UnmarshalFromInterface(data); err != nil {
+			return nil, "", nil, err
+		}
+		return metadata, metadata.DelegatorAddress, nil, nil
+	case stakingTypes.DirectiveCollectRewards.String():
+		metadata := &common.CollectRewardsMetadata{}
+		if err := metadata.UnmarshalFromInterface(data); err != nil {
+			return nil, "", nil, err
+		}
+		return metadata, metadata.DelegatorAddress, nil, nil
+	}
+	return nil, "", nil, fmt.Errorf("%v is unsupported for construction", operationType)
+}
@@ -9,6 +9,7 @@ import (
 
 	internalCommon "github.com/nordicenergy/nordicenergy-core/internal/common"
 	"github.com/nordicenergy/nordicenergy-core/rosetta/common"
+	stakingTypes "github.com/nordicenergy/nordicenergy-core/staking/types"
 )
 
 func TestGetContractCreationOperationCompnetnts(t *testing.T) {
@@ -606,3 +607,117 @@ func TestGetOperationCompnetnts(t *testing.T) {
 		t.Error("expected error")
 	}
 }
+
+func TestGetStakingOperationCompnetnts(t *testing.T) {
+	refFromKey := internalCommon.MustGeneratePrivateKey()
+	refFrom, rosettaError := newAccountIdentifier(crypto.PubkeyToAddress(refFromKey.PublicKey))
+	if rosettaError != nil {
+		t.Fatal(rosettaError)
+	}
+	refValidatorKey := internalCommon.MustGeneratePrivateKey()
+	refValidator, rosettaError := newAccountIdentifier(crypto.PubkeyToAddress(refValidatorKey.PublicKey))
+	if rosettaError != nil {
+		t.Fatal(rosettaError)
+	}
+	newOperation := func(directive stakingTypes.Directive, amount string, message interface{}) *types.Operation {
+		metadata, err := types.MarshalMap(message)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return &types.Operation{
+			Type:     directive.String(),
+			Account:  refFrom,
+			Amount:   &types.Amount{Value: amount, Currency: &common.NativeCurrency},
+			Metadata: metadata,
+		}
+	}
+	refDelegate := &common.DelegateOperationMetadata{
+		DelegatorAddress: refFrom.Address,
+		ValidatorAddress: refValidator.Address,
+		Amount:           big.NewInt(12000),
+	}
+
+	// test valid delegate operation
+	compnetnts, rosettaError := getStakingOperationCompnetnts(
+		newOperation(stakingTypes.DirectiveDelegate, "-12000", refDelegate),
+	)
+	if rosettaError != nil {
+		t.Fatal(rosettaError)
+	}
+	if !compnetnts.IsStaking() || compnetnts.Type != stakingTypes.DirectiveDelegate.String() {
+		t.Error("expected delegate staking compnetnts")
+	}
+	if compnetnts.Amount.Cmp(refDelegate.Amount) != 0 {
+		t.Error("expected amount to be the delegated amount")
+	}
+	if types.Hash(compnetnts.From) != types.Hash(refFrom) {
+		t.Error("expected sender to be the delegator")
+	}
+
+	// test valid collect rewards operation through GetOperationCompnetnts
+	compnetnts, rosettaError = GetOperationCompnetnts([]*types.Operation{
+		newOperation(stakingTypes.DirectiveCollectRewards, "0", &common.CollectRewardsMetadata{
+			DelegatorAddress: refFrom.Address,
+		}),
+	})
+	if rosettaError != nil {
+		t.Fatal(rosettaError)
+	}
+	if _, ok := compnetnts.StakingMessage.(*common.CollectRewardsMetadata); !ok {
+		t.Error("expected collect rewards staking message")
+	}
+
+	// test amount not matching the delegated amount
+	_, rosettaError = getStakingOperationCompnetnts(
+		newOperation(stakingTypes.DirectiveDelegate, "-10000", refDelegate),
+	)
+	if rosettaError == nil {
+		t.Error("expected error")
+	}
+
+	// test non zero amount for undelegate
+	_, rosettaError = getStakingOperationCompnetnts(
+		newOperation(stakingTypes.DirectiveUndelegate, "-12000", &common.UndelegateOperationMetadata{
+			DelegatorAddress: refFrom.Address,
+			ValidatorAddress: refValidator.Address,
+			Amount:           big.NewInt(12000),
+		}),
+	)
+	if rosettaError == nil {
+		t.Error("expected error")
+	}
+
+	// test operation account not signing the staking message
+	_, rosettaError = getStakingOperationCompnetnts(
+		newOperation(stakingTypes.DirectiveEditValidator, "0", &common.EditValidatorOperationMetadata{
+			ValidatorAddress: refValidator.Address,
+		}),
+	)
+	if rosettaError == nil {
+		t.Error("expected error")
+	}
+
+	// test invalid metadata
+	_, rosettaError = getStakingOperationCompnetnts(
+		newOperation(stakingTypes.DirectiveCreateValidator, "-12000", refDelegate),
+	)
+	if rosettaError == nil {
+		t.Error("expected error")
+	}
+
+	// test unsupported directive
+	_, rosettaError = getStakingOperationCompnetnts(
+		newOperation(stakingTypes.DirectiveUnjail, "0", map[string]interface{}{
+			"validatorAddress": refFrom.Address,
+		}),
+	)
+	if rosettaError == nil {
+		t.Error("expected error")
+	}
+
+	// test nil operation
+	_, rosettaError = getStakingOperationCompnetnts(nil)
+	if rosettaError == nil {
+		t.Error("expected error")
+	}
+}
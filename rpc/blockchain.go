@@ -554,6 +554,27 @@ func (s *PublicBlockchainService) GetLastCrossLinks(
 	return responseSlice, nil
 }
 
+// GetCrossLinkStatus returns the last continuous crosslink of each shard
+// versus the head of the shard, with the gaps in the crosslinks
+func (s *PublicBlockchainService) GetCrossLinkStatus(
+	ctx context.Context,
+) ([]StructuredResponse, error) {
+	if !isBeaconShard(s.ngy) {
+		return nil, ErrNotBeaconShard
+	}
+
+	// Format response, all output is the same for all versions
+	responseSlice := []StructuredResponse{}
+	for _, el := range s.ngy.GetCrossLinkStatus() {
+		response, err := NewStructuredResponse(el)
+		if err != nil {
+			return nil, err
+		}
+		responseSlice = append(responseSlice, response)
+	}
+	return responseSlice, nil
+}
+
 // GetHeaderByNumber returns block header at given number
 func (s *PublicBlockchainService) GetHeaderByNumber(
 	ctx context.Context, blockNumber BlockNumber,
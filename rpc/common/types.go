@@ -47,6 +47,28 @@ type ConsensusInternal struct {
 	ConsensusTime int64  `json:"finality"`
 }
 
+// CrossLinkStatus captures the crosslinks of a shard on the beacon chain
+type CrossLinkStatus struct {
+	ShardID uint32 `json:"shardID"`
+	// LastContinuous is the block of the last crosslink with no gap before it
+	LastContinuous uint64 `json:"lastContinuousCrossLink"`
+	// ShardHead is the last block of the shard known to the node, from a
+	// shard node or else from the crosslinks received
+	ShardHead uint64 `json:"shardHead"`
+	// Missing is the number of blocks without a crosslink nor a pending one
+	// up to the shard head, Gaps the ranges of these blocks
+	Missing uint64         `json:"missing"`
+	Gaps    []CrossLinkGap `json:"gaps"`
+	Pending int            `json:"pending"`
+}
+
+// CrossLinkGap is a range of blocks of a shard, bounds included, missing
+// their crosslinks
+type CrossLinkGap struct {
+	From uint64 `json:"from"`
+	To   uint64 `json:"to"`
+}
+
 // NodeMetadata captures select metadata of the RPC answering node
 type NodeMetadata struct {
 	BLSPublicKey   []string           `json:"blskey"`
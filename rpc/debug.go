@@ -5,6 +5,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/nordicenergy/nordicenergy-core/consensus"
 	"github.com/nordicenergy/nordicenergy-core/ngy"
 	"github.com/nordicenergy/nordicenergy-core/internal/utils"
 )
@@ -58,6 +59,14 @@ func (s *PrivateDebugService) GetConsensusMode(
 	return s.ngy.NodeAPI.GetConsensusMode()
 }
 
+// GetConsensusTimeline returns the traces of the last count consensus rounds,
+// the most recent first, all the rounds the node keeps if count is 0
+func (s *PrivateDebugService) GetConsensusTimeline(
+	ctx context.Context, count int,
+) []consensus.RoundTimeline {
+	return s.ngy.NodeAPI.GetConsensusTimeline(count)
+}
+
 // GetConsensusPhase return the current consensus mode
 func (s *PrivateDebugService) GetConsensusPhase(
 	ctx context.Context,
@@ -19,4 +19,8 @@ var (
 	ErrUnknownRPCVersion = errors.New("API service has an unknown version")
 	// ErrTransactionNotFound when attempting to get a transaction that does not exist or has not been finalized
 	ErrTransactionNotFound = errors.New("transaction not found")
+	// ErrInvalidReporterAddress when the reporter of slashing evidence is not a valid address
+	ErrInvalidReporterAddress = errors.New("invalid slashing evidence reporter address")
+	// ErrInvalidEpochRange when the given epoch range is reversed or too large
+	ErrInvalidEpochRange = errors.New("invalid epoch range")
 )
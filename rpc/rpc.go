@@ -141,6 +141,8 @@ func getAPIs(ngy *ngy.nordicenergy, debugEnable bool) []rpc.API {
 		NewPublicStakingAPI(ngy, V1),
 		NewPublicStakingAPI(ngy, V2),
 		NewPublicTracerAPI(ngy, Debug),
+		NewPublicTokenAPI(ngy, V1),
+		NewPublicTokenAPI(ngy, V2),
 		// Legacy methods (subject to removal)
 		v1.NewPublicLegacyAPI(ngy, "ngy"),
 		eth.NewPublicEthService(ngy, "eth"),
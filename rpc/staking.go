@@ -7,15 +7,23 @@ import (
 
 	"github.com/pkg/errors"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/nordicenergy/nordicenergy-core/core"
+	"github.com/nordicenergy/nordicenergy-core/core/types"
 	"github.com/nordicenergy/nordicenergy-core/ngy"
 	internal_common "github.com/nordicenergy/nordicenergy-core/internal/common"
+	"github.com/nordicenergy/nordicenergy-core/internal/utils"
 	"github.com/nordicenergy/nordicenergy-core/shard"
+	"github.com/nordicenergy/nordicenergy-core/staking/slash"
+	staking "github.com/nordicenergy/nordicenergy-core/staking/types"
 )
 
 const (
-	validatorsPageSize = 100
+	validatorsPageSize        = 100
+	slashHistoryMaxEpochRange = 1000
 )
 
 // PublicStakingService provides an API to access nordicenergy's staking services.
@@ -639,6 +647,165 @@ func (s *PublicStakingService) GetAvailableRedelegationBalance(
 	return redelegationTotal, nil
 }
 
+// SubmitSlashingEvidence verifies the RLP encoded double sign evidence against the
+// committee of its epoch and adds it to the pending slashes of the beacon chain.
+// The reporter address receives the snitch reward once the slash is applied.
+func (s *PublicStakingService) SubmitSlashingEvidence(
+	ctx context.Context, encodedEvidence hexutil.Bytes, reporter string,
+) (common.Hash, error) {
+	if !isBeaconShard(s.ngy) {
+		return common.Hash{}, ErrNotBeaconShard
+	}
+
+	// DOS prevention
+	if len(encodedEvidence) >= types.MaxEncodedPoolTransactionSize {
+		err := errors.Wrapf(core.ErrOversizedData, "encoded evidence size: %d", len(encodedEvidence))
+		return common.Hash{}, err
+	}
+
+	evidence := slash.Evidence{}
+	if err := rlp.DecodeBytes(encodedEvidence, &evidence); err != nil {
+		return common.Hash{}, err
+	}
+	reporterAddr := internal_common.ParseAddr(reporter)
+	if reporterAddr == (common.Address{}) {
+		return common.Hash{}, errors.Wrapf(ErrInvalidReporterAddress, "given %s", reporter)
+	}
+
+	record := slash.Record{Evidence: evidence, Reporter: reporterAddr}
+	if err := s.ngy.SubmitSlashingEvidence(record); err != nil {
+		utils.Logger().Warn().Err(err).Msg("Could not submit slashing evidence")
+		return record.Hash(), err
+	}
+
+	utils.Logger().Info().
+		RawJSON("record", []byte(record.String())).
+		Msg("Submitted slashing evidence")
+
+	// Response output is the same for all versions
+	return record.Hash(), nil
+}
+
+// GetSlashesByValidator returns the slashes applied to the given validator
+func (s *PublicStakingService) GetSlashesByValidator(
+	ctx context.Context, address string,
+) ([]StructuredResponse, error) {
+	if !isBeaconShard(s.ngy) {
+		return nil, ErrNotBeaconShard
+	}
+	history, err := s.ngy.GetSlashHistoryByValidator(internal_common.ParseAddr(address))
+	if err != nil {
+		return nil, err
+	}
+	return slashHistoryResponse(history)
+}
+
+// GetSlashesByDelegator returns the slashes that charged the given delegator
+func (s *PublicStakingService) GetSlashesByDelegator(
+	ctx context.Context, address string,
+) ([]StructuredResponse, error) {
+	if !isBeaconShard(s.ngy) {
+		return nil, ErrNotBeaconShard
+	}
+	history, err := s.ngy.GetSlashHistoryByDelegator(internal_common.ParseAddr(address))
+	if err != nil {
+		return nil, err
+	}
+	return slashHistoryResponse(history)
+}
+
+// GetSlashesByEpochRange returns the slashes applied in the epochs from fromEpoch to toEpoch
+func (s *PublicStakingService) GetSlashesByEpochRange(
+	ctx context.Context, fromEpoch, toEpoch uint64,
+) ([]StructuredResponse, error) {
+	if !isBeaconShard(s.ngy) {
+		return nil, ErrNotBeaconShard
+	}
+	if fromEpoch > toEpoch || toEpoch-fromEpoch >= slashHistoryMaxEpochRange {
+		return nil, errors.Wrapf(
+			ErrInvalidEpochRange, "from %d to %d, max range %d",
+			fromEpoch, toEpoch, slashHistoryMaxEpochRange,
+		)
+	}
+	history, err := s.ngy.GetSlashHistory(fromEpoch, toEpoch)
+	if err != nil {
+		return nil, err
+	}
+	return slashHistoryResponse(history)
+}
+
+func slashHistoryResponse(history []slash.HistoryRecord) ([]StructuredResponse, error) {
+	// Response output is the same for all versions
+	result := []StructuredResponse{}
+	for i := range history {
+		record, err := NewStructuredResponse(history[i])
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, record)
+	}
+	return result, nil
+}
+
+// GetDelegatorEarningHistory returns the rewards the delegator earned in each epoch
+func (s *PublicStakingService) GetDelegatorEarningHistory(
+	ctx context.Context, address string,
+) ([]StructuredResponse, error) {
+	if !isBeaconShard(s.ngy) {
+		return nil, ErrNotBeaconShard
+	}
+	history, err := s.ngy.GetDelegatorEarningHistory(internal_common.ParseAddr(address))
+	if err != nil {
+		return nil, err
+	}
+
+	// Response output is the same for all versions
+	result := []StructuredResponse{}
+	for i := range history {
+		earnings, err := delegationEarningsResponse(history[i].Earnings)
+		if err != nil {
+			return nil, err
+		}
+		earnings["epoch"] = history[i].Epoch
+		result = append(result, earnings)
+	}
+	return result, nil
+}
+
+// GetDelegatorLifetimeEarnings returns the rewards the delegator earned since staking
+func (s *PublicStakingService) GetDelegatorLifetimeEarnings(
+	ctx context.Context, address string,
+) (StructuredResponse, error) {
+	if !isBeaconShard(s.ngy) {
+		return nil, ErrNotBeaconShard
+	}
+	earnings, err := s.ngy.GetDelegatorLifetimeEarnings(internal_common.ParseAddr(address))
+	if err != nil {
+		return nil, err
+	}
+
+	// Response output is the same for all versions
+	return delegationEarningsResponse(earnings)
+}
+
+func delegationEarningsResponse(earnings staking.DelegationEarnings) (StructuredResponse, error) {
+	perValidator := []StructuredResponse{}
+	for i := range earnings {
+		validatorAddress, err := internal_common.AddressToBech32(earnings[i].ValidatorAddress)
+		if err != nil {
+			return nil, err
+		}
+		perValidator = append(perValidator, StructuredResponse{
+			"validator": validatorAddress,
+			"amount":    earnings[i].Amount,
+		})
+	}
+	return StructuredResponse{
+		"total":    earnings.Total(),
+		"earnings": perValidator,
+	}, nil
+}
+
 func isBeaconShard(ngy *ngy.nordicenergy) bool {
 	return ngy.ShardID == shard.BeaconChainShardID
 }
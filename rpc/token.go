@@ -0,0 +1,156 @@
+package rpc
+
+import (
+	"context"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/nordicenergy/nordicenergy-core/api/service/explorer"
+	internal_common "github.com/nordicenergy/nordicenergy-core/internal/common"
+	"github.com/nordicenergy/nordicenergy-core/ngy"
+)
+
+// PublicTokenService provides an API to access the token transfers and
+// balances indexed by the explorer node.
+type PublicTokenService struct {
+	ngy     *ngy.nordicenergy
+	version Version
+}
+
+// NewPublicTokenAPI creates a new API for the RPC interface
+func NewPublicTokenAPI(ngy *ngy.nordicenergy, version Version) rpc.API {
+	return rpc.API{
+		Namespace: version.Namespace(),
+		Version:   APIVersion,
+		Service:   &PublicTokenService{ngy, version},
+		Public:    true,
+	}
+}
+
+// TokenTransfer is a token transfer of the RPC responses
+type TokenTransfer struct {
+	Token       string      `json:"token"`
+	Standard    string      `json:"standard"`
+	From        string      `json:"from"`
+	To          string      `json:"to"`
+	TokenID     *big.Int    `json:"tokenId"`
+	Value       *big.Int    `json:"value"`
+	TxHash      common.Hash `json:"transactionHash"`
+	BlockNumber uint64      `json:"blockNumber"`
+	Timestamp   uint64      `json:"timestamp"`
+	LogIndex    uint32      `json:"logIndex"`
+}
+
+// TokenBalance is a token balance of the RPC responses
+type TokenBalance struct {
+	Holder   string   `json:"holder"`
+	Token    string   `json:"token"`
+	Standard string   `json:"standard"`
+	TokenID  *big.Int `json:"tokenId"`
+	Balance  *big.Int `json:"balance"`
+}
+
+// GetTokenTransfers returns the token transfers of a holder, of a token
+// contract, or of a token by a holder.
+func (s *PublicTokenService) GetTokenTransfers(
+	ctx context.Context, args TokenTransferArgs,
+) (StructuredResponse, error) {
+	address, err := toBech32(args.Address)
+	if err != nil {
+		return nil, err
+	}
+	token, err := toBech32(args.Token)
+	if err != nil {
+		return nil, err
+	}
+	transfers, err := s.ngy.GetTokenTransfers(explorer.TokenTransferQuery{
+		Address:   address,
+		Token:     token,
+		Order:     args.Order,
+		PageIndex: args.PageIndex,
+		PageSize:  pageSize(args.PageSize),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]TokenTransfer, len(transfers))
+	for i, t := range transfers {
+		result[i] = TokenTransfer{
+			Token:       internal_common.MustAddressToBech32(t.Token),
+			Standard:    t.Standard,
+			From:        internal_common.MustAddressToBech32(t.From),
+			To:          internal_common.MustAddressToBech32(t.To),
+			TokenID:     t.TokenID,
+			Value:       t.Value,
+			TxHash:      t.TxHash,
+			BlockNumber: t.BlockNum,
+			Timestamp:   t.Time,
+			LogIndex:    t.LogIndex,
+		}
+	}
+	return StructuredResponse{"transfers": result}, nil
+}
+
+// GetTokenBalances returns the balances of the tokens held by address.
+func (s *PublicTokenService) GetTokenBalances(
+	ctx context.Context, address string,
+) (StructuredResponse, error) {
+	holder, err := toBech32(address)
+	if err != nil {
+		return nil, err
+	}
+	balances, err := s.ngy.GetTokenBalances(holder)
+	if err != nil {
+		return nil, err
+	}
+	return StructuredResponse{"balances": tokenBalances(balances)}, nil
+}
+
+// GetTokenHolders returns the holders of a token contract and their balances.
+func (s *PublicTokenService) GetTokenHolders(
+	ctx context.Context, args TokenHolderArgs,
+) (StructuredResponse, error) {
+	token, err := toBech32(args.Token)
+	if err != nil {
+		return nil, err
+	}
+	balances, err := s.ngy.GetTokenHolders(token, args.PageIndex, pageSize(args.PageSize))
+	if err != nil {
+		return nil, err
+	}
+	return StructuredResponse{"holders": tokenBalances(balances)}, nil
+}
+
+func tokenBalances(balances []*explorer.TokenBalance) []TokenBalance {
+	result := make([]TokenBalance, len(balances))
+	for i, b := range balances {
+		result[i] = TokenBalance{
+			Holder:   internal_common.MustAddressToBech32(b.Holder),
+			Token:    internal_common.MustAddressToBech32(b.Token),
+			Standard: b.Standard,
+			TokenID:  b.TokenID,
+			Balance:  b.Balance,
+		}
+	}
+	return result
+}
+
+// toBech32 returns the bech32 address of the hex or bech32 address, empty if
+// address is empty
+func toBech32(address string) (string, error) {
+	if address == "" || strings.HasPrefix(address, "net1") {
+		return address, nil
+	}
+	return internal_common.AddressToBech32(internal_common.ParseAddr(address))
+}
+
+// pageSize returns size, the default page size if zero
+func pageSize(size uint32) uint32 {
+	if size == 0 {
+		return defaultPageSize
+	}
+	return size
+}
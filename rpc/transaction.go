@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"math/big"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -28,6 +29,10 @@ import (
 
 const (
 	defaultPageSize = uint32(100)
+	// cxStatusPollInterval is the interval the status of a cross-shard
+	// transaction is checked at between blocks, for the stages that do not
+	// come with a block
+	cxStatusPollInterval = 2 * time.Second
 )
 
 // PublicTransactionService provides an API to access nordicenergy's transaction service.
@@ -256,13 +261,11 @@ func (s *PublicTransactionService) GetTransactionsHistory(
 			return nil, err
 		}
 	}
-	hashes, err := s.ngy.GetTransactionsHistory(address, args.TxType, args.Order)
+	result, err = s.ngy.GetTransactionsHistory(args.query(address))
 	if err != nil {
 		return nil, err
 	}
 
-	result = returnHashesWithPagination(hashes, args.PageIndex, args.PageSize)
-
 	// Just hashes have same response format for all versions
 	if !args.FullTx {
 		return StructuredResponse{"transactions": result}, nil
@@ -305,7 +308,7 @@ func (s *PublicTransactionService) GetStakingTransactionsHistory(
 			return nil, nil
 		}
 	}
-	hashes, err := s.ngy.GetStakingTransactionsHistory(address, args.TxType, args.Order)
+	result, err = s.ngy.GetStakingTransactionsHistory(args.query(address))
 	if err != nil {
 		utils.Logger().Debug().
 			Err(err).
@@ -314,8 +317,6 @@ func (s *PublicTransactionService) GetStakingTransactionsHistory(
 		return nil, nil
 	}
 
-	result = returnHashesWithPagination(hashes, args.PageIndex, args.PageSize)
-
 	// Just hashes have same response format for all versions
 	if !args.FullTx {
 		return StructuredResponse{"staking_transactions": result}, nil
@@ -337,6 +338,36 @@ func (s *PublicTransactionService) GetStakingTransactionsHistory(
 	return StructuredResponse{"staking_transactions": txs}, nil
 }
 
+// GetInternalTransactionsHistory returns the value transfers made by contracts
+// to or from a particular address, indexed by the explorer nodes tracing the
+// blocks.
+func (s *PublicTransactionService) GetInternalTransactionsHistory(
+	ctx context.Context, args TxHistoryArgs,
+) (StructuredResponse, error) {
+	address, err := toBech32(args.Address)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.ngy.GetInternalTransactionsHistory(args.query(address))
+	if err != nil {
+		return nil, err
+	}
+
+	txs := make([]InternalTransaction, len(result))
+	for i, tx := range result {
+		txs[i] = InternalTransaction{
+			Type:        tx.Type,
+			From:        internal_common.MustAddressToBech32(tx.From),
+			To:          internal_common.MustAddressToBech32(tx.To),
+			Value:       tx.Value,
+			TxHash:      tx.TxHash,
+			BlockNumber: tx.BlockNum,
+			Timestamp:   tx.Time,
+		}
+	}
+	return StructuredResponse{"internal_transactions": txs}, nil
+}
+
 // GetBlockTransactionCountByNumber returns the number of transactions in the block with the given block number.
 // Note that the return type is an interface to account for the different versions
 func (s *PublicTransactionService) GetBlockTransactionCountByNumber(
@@ -703,19 +734,63 @@ func (s *PublicTransactionService) ResendCx(ctx context.Context, txID common.Has
 	return success, nil
 }
 
-// returnHashesWithPagination returns result with pagination (offset, page in TxHistoryArgs).
-func returnHashesWithPagination(hashes []common.Hash, pageIndex uint32, pageSize uint32) []common.Hash {
-	size := defaultPageSize
-	if pageSize > 0 {
-		size = pageSize
-	}
-	if uint64(size)*uint64(pageIndex) >= uint64(len(hashes)) {
-		return make([]common.Hash, 0)
+// GetCxStatus returns the lifecycle stage of the cross-shard transaction of
+// the given hash, as seen from the source or destination shard of this node
+func (s *PublicTransactionService) GetCxStatus(
+	ctx context.Context, txID common.Hash,
+) (StructuredResponse, error) {
+	status, err := s.ngy.GetCxStatus(txID)
+	if err != nil {
+		return nil, err
 	}
-	if uint64(size)*uint64(pageIndex)+uint64(size) > uint64(len(hashes)) {
-		return hashes[size*pageIndex:]
+	// Response output is the same for all versions
+	return NewStructuredResponse(status)
+}
+
+// CxStatusChanges subscribes to the lifecycle stage of the cross-shard
+// transaction of the given hash, notifying the status when it changes. No
+// status follows a final one, sent once the receipt is spent or this node
+// cannot follow it any further.
+func (s *PublicTransactionService) CxStatusChanges(
+	ctx context.Context, txID common.Hash,
+) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	if _, err := s.ngy.GetCxStatus(txID); err != nil {
+		return nil, err
 	}
-	return hashes[size*pageIndex : size*pageIndex+size]
+	rpcSub := notifier.CreateSubscription()
+
+	go func() {
+		heads := make(chan core.ChainHeadEvent, 16)
+		headsSub := s.ngy.SubscribeChainHeadEvent(heads)
+		defer headsSub.Unsubscribe()
+		ticker := time.NewTicker(cxStatusPollInterval)
+		defer ticker.Stop()
+
+		var last *ngy.CxStatus
+		for {
+			status, err := s.ngy.GetCxStatus(txID)
+			if err == nil && (last == nil || *status != *last) {
+				_ = notifier.Notify(rpcSub.ID, status)
+				last = status
+			}
+			if last != nil && last.Final {
+				return
+			}
+			select {
+			case <-heads:
+			case <-ticker.C:
+			case <-rpcSub.Err():
+				return
+			case <-notifier.Closed():
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
 }
 
 // EstimateGas - estimate gas cost for a given operation
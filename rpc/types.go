@@ -14,6 +14,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/math"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/nordicenergy/nordicenergy-core/api/service/explorer"
 	"github.com/nordicenergy/nordicenergy-core/block"
 	"github.com/nordicenergy/nordicenergy-core/core/types"
 	"github.com/nordicenergy/nordicenergy-core/internal/utils"
@@ -180,6 +181,52 @@ type TxHistoryArgs struct {
 	FullTx    bool   `json:"fullTx"`
 	TxType    string `json:"txType"`
 	Order     string `json:"order"`
+	// FromTime and ToTime bound the time of the blocks of the transactions,
+	// in unix seconds and included, unbounded if zero
+	FromTime uint64 `json:"fromTime"`
+	ToTime   uint64 `json:"toTime"`
+}
+
+// query returns the query of the transactions of address of args
+func (ta *TxHistoryArgs) query(address string) explorer.TxHistoryQuery {
+	return explorer.TxHistoryQuery{
+		Address:   address,
+		TxType:    ta.TxType,
+		Order:     ta.Order,
+		PageIndex: ta.PageIndex,
+		PageSize:  pageSize(ta.PageSize),
+		FromTime:  ta.FromTime,
+		ToTime:    ta.ToTime,
+	}
+}
+
+// InternalTransaction is a value transfer made by a contract during the
+// execution of a transaction
+type InternalTransaction struct {
+	Type        string      `json:"type"`
+	From        string      `json:"from"`
+	To          string      `json:"to"`
+	Value       *big.Int    `json:"value"`
+	TxHash      common.Hash `json:"transactionHash"`
+	BlockNumber uint64      `json:"blockNumber"`
+	Timestamp   uint64      `json:"timestamp"`
+}
+
+// TokenTransferArgs are the arguments of a query of token transfers, of a
+// holder, of a token contract or of a token by a holder
+type TokenTransferArgs struct {
+	Address   string `json:"address"`
+	Token     string `json:"token"`
+	PageIndex uint32 `json:"pageIndex"`
+	PageSize  uint32 `json:"pageSize"`
+	Order     string `json:"order"`
+}
+
+// TokenHolderArgs are the arguments of a query of the holders of a token
+type TokenHolderArgs struct {
+	Token     string `json:"token"`
+	PageIndex uint32 `json:"pageIndex"`
+	PageSize  uint32 `json:"pageSize"`
 }
 
 // UnmarshalFromInterface ..
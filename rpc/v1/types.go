@@ -149,6 +149,12 @@ type CollectRewardsMsg struct {
 	DelegatorAddress string `json:"delegatorAddress"`
 }
 
+// UnjailMsg represents a staking transaction's unjail directive that
+// will serialize to the RPC representation
+type UnjailMsg struct {
+	ValidatorAddress string `json:"validatorAddress"`
+}
+
 // DelegateMsg represents a staking transaction's delegate directive that
 // will serialize to the RPC representation
 type DelegateMsg struct {
@@ -493,6 +499,20 @@ func NewStakingTransaction(tx *staking.StakingTransaction, blockHash common.Hash
 			return nil, err
 		}
 		rpcMsg = &CollectRewardsMsg{DelegatorAddress: delegatorAddress}
+	case staking.DirectiveUnjail:
+		rawMsg, err := staking.RLPDecodeStakeMsg(tx.Data(), staking.DirectiveUnjail)
+		if err != nil {
+			return nil, err
+		}
+		msg, ok := rawMsg.(*staking.Unjail)
+		if !ok {
+			return nil, fmt.Errorf("could not decode staking message")
+		}
+		validatorAddress, err := internal_common.AddressToBech32(msg.ValidatorAddress)
+		if err != nil {
+			return nil, err
+		}
+		rpcMsg = &UnjailMsg{ValidatorAddress: validatorAddress}
 	case staking.DirectiveDelegate:
 		rawMsg, err := staking.RLPDecodeStakeMsg(tx.Data(), staking.DirectiveDelegate)
 		if err != nil {
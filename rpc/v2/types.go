@@ -1,6 +1,7 @@
 package v2
 
 import (
+	"encoding/hex"
 	"fmt"
 	"math/big"
 	"strings"
@@ -108,38 +109,101 @@ type StakingTransaction struct {
 	Msg              interface{}  `json:"msg"`
 }
 
+// BLSPublicKey is a bls public key that serializes to, and parses from, the hex
+// string of the RPC representation
+type BLSPublicKey bls.SerializedPublicKey
+
+// MarshalText ..
+func (pk BLSPublicKey) MarshalText() ([]byte, error) {
+	return bls.SerializedPublicKey(pk).MarshalText()
+}
+
+// UnmarshalText ..
+func (pk *BLSPublicKey) UnmarshalText(text []byte) error {
+	return decodeBLSHexText(text, pk[:], "key")
+}
+
+// BLSSignature is a bls signature that serializes to, and parses from, the hex
+// string of the RPC representation
+type BLSSignature bls.SerializedSignature
+
+// MarshalText ..
+func (sig BLSSignature) MarshalText() ([]byte, error) {
+	text := make([]byte, hex.EncodedLen(len(sig)))
+	hex.Encode(text, sig[:])
+	return text, nil
+}
+
+// UnmarshalText ..
+func (sig *BLSSignature) UnmarshalText(text []byte) error {
+	return decodeBLSHexText(text, sig[:], "signature")
+}
+
+// decodeBLSHexText decodes the hex text, with an optional 0x prefix, of exactly
+// the size of out to out
+func decodeBLSHexText(text []byte, out []byte, kind string) error {
+	if len(text) >= 2 && text[0] == '0' && (text[1] == 'x' || text[1] == 'X') {
+		text = text[2:]
+	}
+	if hex.DecodedLen(len(text)) != len(out) {
+		return fmt.Errorf(
+			"%s size (BLS) size mismatch, expected %d have %d", kind, len(out), hex.DecodedLen(len(text)),
+		)
+	}
+	_, err := hex.Decode(out, text)
+	return err
+}
+
+func newBLSPublicKeys(keys []bls.SerializedPublicKey) []BLSPublicKey {
+	result := make([]BLSPublicKey, len(keys))
+	for i := range keys {
+		result[i] = BLSPublicKey(keys[i])
+	}
+	return result
+}
+
+func newBLSSignatures(sigs []bls.SerializedSignature) []BLSSignature {
+	result := make([]BLSSignature, len(sigs))
+	for i := range sigs {
+		result[i] = BLSSignature(sigs[i])
+	}
+	return result
+}
+
 // CreateValidatorMsg represents a staking transaction's create validator directive that
 // will serialize to the RPC representation
 type CreateValidatorMsg struct {
-	ValidatorAddress   string                    `json:"validatorAddress"`
-	CommissionRate     *big.Int                  `json:"commissionRate"`
-	MaxCommissionRate  *big.Int                  `json:"maxCommissionRate"`
-	MaxChangeRate      *big.Int                  `json:"maxChangeRate"`
-	MinSelfDelegation  *big.Int                  `json:"minSelfDelegation"`
-	MaxTotalDelegation *big.Int                  `json:"maxTotalDelegation"`
-	Amount             *big.Int                  `json:"amount"`
-	Name               string                    `json:"name"`
-	Website            string                    `json:"website"`
-	Identity           string                    `json:"identity"`
-	SecurityContact    string                    `json:"securityContact"`
-	Details            string                    `json:"details"`
-	SlotPubKeys        []bls.SerializedPublicKey `json:"slotPubKeys"`
+	ValidatorAddress   string         `json:"validatorAddress"`
+	CommissionRate     *big.Int       `json:"commissionRate"`
+	MaxCommissionRate  *big.Int       `json:"maxCommissionRate"`
+	MaxChangeRate      *big.Int       `json:"maxChangeRate"`
+	MinSelfDelegation  *big.Int       `json:"minSelfDelegation"`
+	MaxTotalDelegation *big.Int       `json:"maxTotalDelegation"`
+	Amount             *big.Int       `json:"amount"`
+	Name               string         `json:"name"`
+	Website            string         `json:"website"`
+	Identity           string         `json:"identity"`
+	SecurityContact    string         `json:"securityContact"`
+	Details            string         `json:"details"`
+	SlotPubKeys        []BLSPublicKey `json:"slotPubKeys"`
+	SlotKeySigs        []BLSSignature `json:"slotKeySigs"`
 }
 
 // EditValidatorMsg represents a staking transaction's edit validator directive that
 // will serialize to the RPC representation
 type EditValidatorMsg struct {
-	ValidatorAddress   string                   `json:"validatorAddress"`
-	CommissionRate     *big.Int                 `json:"commissionRate"`
-	MinSelfDelegation  *big.Int                 `json:"minSelfDelegation"`
-	MaxTotalDelegation *big.Int                 `json:"maxTotalDelegation"`
-	Name               string                   `json:"name"`
-	Website            string                   `json:"website"`
-	Identity           string                   `json:"identity"`
-	SecurityContact    string                   `json:"securityContact"`
-	Details            string                   `json:"details"`
-	SlotPubKeyToAdd    *bls.SerializedPublicKey `json:"slotPubKeyToAdd"`
-	SlotPubKeyToRemove *bls.SerializedPublicKey `json:"slotPubKeyToRemove"`
+	ValidatorAddress   string        `json:"validatorAddress"`
+	CommissionRate     *big.Int      `json:"commissionRate"`
+	MinSelfDelegation  *big.Int      `json:"minSelfDelegation"`
+	MaxTotalDelegation *big.Int      `json:"maxTotalDelegation"`
+	Name               string        `json:"name"`
+	Website            string        `json:"website"`
+	Identity           string        `json:"identity"`
+	SecurityContact    string        `json:"securityContact"`
+	Details            string        `json:"details"`
+	SlotPubKeyToAdd    *BLSPublicKey `json:"slotPubKeyToAdd"`
+	SlotPubKeyToRemove *BLSPublicKey `json:"slotPubKeyToRemove"`
+	SlotKeyToAddSig    *BLSSignature `json:"slotKeyToAddSig"`
 }
 
 // CollectRewardsMsg represents a staking transaction's collect rewards directive that
@@ -148,6 +212,12 @@ type CollectRewardsMsg struct {
 	DelegatorAddress string `json:"delegatorAddress"`
 }
 
+// UnjailMsg represents a staking transaction's unjail directive that
+// will serialize to the RPC representation
+type UnjailMsg struct {
+	ValidatorAddress string `json:"validatorAddress"`
+}
+
 // DelegateMsg represents a staking transaction's delegate directive that
 // will serialize to the RPC representation
 type DelegateMsg struct {
@@ -424,7 +494,41 @@ func NewStakingTransaction(
 	}
 	v, r, s := tx.RawSignatureValues()
 
-	var rpcMsg interface{}
+	rpcMsg, err := NewStakingMessage(tx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &StakingTransaction{
+		Gas:       tx.GasLimit(),
+		GasPrice:  tx.GasPrice(),
+		Hash:      tx.Hash(),
+		Nonce:     tx.Nonce(),
+		Timestamp: timestamp,
+		V:         (*hexutil.Big)(v),
+		R:         (*hexutil.Big)(r),
+		S:         (*hexutil.Big)(s),
+		Type:      tx.StakingType().String(),
+		Msg:       rpcMsg,
+	}
+	if blockHash != (common.Hash{}) {
+		result.BlockHash = blockHash
+		result.BlockNumber = new(big.Int).SetUint64(blockNumber)
+		result.TransactionIndex = index
+	}
+
+	fromAddr, err := internal_common.AddressToBech32(from)
+	if err != nil {
+		return nil, err
+	}
+	result.From = fromAddr
+
+	return result, nil
+}
+
+// NewStakingMessage returns the message of the staking transaction that will serialize to the RPC
+// representation, nil for an unknown directive. The transaction need not be signed.
+func NewStakingMessage(tx *staking.StakingTransaction) (interface{}, error) {
 	switch tx.StakingType() {
 	case staking.DirectiveCreateValidator:
 		rawMsg, err := staking.RLPDecodeStakeMsg(tx.Data(), staking.DirectiveCreateValidator)
@@ -439,7 +543,7 @@ func NewStakingTransaction(
 		if err != nil {
 			return nil, err
 		}
-		rpcMsg = &CreateValidatorMsg{
+		return &CreateValidatorMsg{
 			ValidatorAddress:   validatorAddress,
 			CommissionRate:     msg.CommissionRates.Rate.Int,
 			MaxCommissionRate:  msg.CommissionRates.MaxRate.Int,
@@ -452,8 +556,9 @@ func NewStakingTransaction(
 			Identity:           msg.Description.Identity,
 			SecurityContact:    msg.Description.SecurityContact,
 			Details:            msg.Description.Details,
-			SlotPubKeys:        msg.SlotPubKeys,
-		}
+			SlotPubKeys:        newBLSPublicKeys(msg.SlotPubKeys),
+			SlotKeySigs:        newBLSSignatures(msg.SlotKeySigs),
+		}, nil
 	case staking.DirectiveEditValidator:
 		rawMsg, err := staking.RLPDecodeStakeMsg(tx.Data(), staking.DirectiveEditValidator)
 		if err != nil {
@@ -467,12 +572,13 @@ func NewStakingTransaction(
 		if err != nil {
 			return nil, err
 		}
-		// Edit validators txs need not have commission rates to edit
-		commissionRate := &big.Int{}
+		// Edit validators txs need not have commission rates to edit,
+		// a nil commission rate leaves it unchanged
+		var commissionRate *big.Int
 		if msg.CommissionRate != nil {
 			commissionRate = msg.CommissionRate.Int
 		}
-		rpcMsg = &EditValidatorMsg{
+		return &EditValidatorMsg{
 			ValidatorAddress:   validatorAddress,
 			CommissionRate:     commissionRate,
 			MinSelfDelegation:  msg.MinSelfDelegation,
@@ -482,9 +588,10 @@ func NewStakingTransaction(
 			Identity:           msg.Description.Identity,
 			SecurityContact:    msg.Description.SecurityContact,
 			Details:            msg.Description.Details,
-			SlotPubKeyToAdd:    msg.SlotKeyToAdd,
-			SlotPubKeyToRemove: msg.SlotKeyToRemove,
-		}
+			SlotPubKeyToAdd:    (*BLSPublicKey)(msg.SlotKeyToAdd),
+			SlotPubKeyToRemove: (*BLSPublicKey)(msg.SlotKeyToRemove),
+			SlotKeyToAddSig:    (*BLSSignature)(msg.SlotKeyToAddSig),
+		}, nil
 	case staking.DirectiveCollectRewards:
 		rawMsg, err := staking.RLPDecodeStakeMsg(tx.Data(), staking.DirectiveCollectRewards)
 		if err != nil {
@@ -498,7 +605,21 @@ func NewStakingTransaction(
 		if err != nil {
 			return nil, err
 		}
-		rpcMsg = &CollectRewardsMsg{DelegatorAddress: delegatorAddress}
+		return &CollectRewardsMsg{DelegatorAddress: delegatorAddress}, nil
+	case staking.DirectiveUnjail:
+		rawMsg, err := staking.RLPDecodeStakeMsg(tx.Data(), staking.DirectiveUnjail)
+		if err != nil {
+			return nil, err
+		}
+		msg, ok := rawMsg.(*staking.Unjail)
+		if !ok {
+			return nil, fmt.Errorf("could not decode staking message")
+		}
+		validatorAddress, err := internal_common.AddressToBech32(msg.ValidatorAddress)
+		if err != nil {
+			return nil, err
+		}
+		return &UnjailMsg{ValidatorAddress: validatorAddress}, nil
 	case staking.DirectiveDelegate:
 		rawMsg, err := staking.RLPDecodeStakeMsg(tx.Data(), staking.DirectiveDelegate)
 		if err != nil {
@@ -516,11 +637,11 @@ func NewStakingTransaction(
 		if err != nil {
 			return nil, err
 		}
-		rpcMsg = &DelegateMsg{
+		return &DelegateMsg{
 			DelegatorAddress: delegatorAddress,
 			ValidatorAddress: validatorAddress,
 			Amount:           msg.Amount,
-		}
+		}, nil
 	case staking.DirectiveUndelegate:
 		rawMsg, err := staking.RLPDecodeStakeMsg(tx.Data(), staking.DirectiveUndelegate)
 		if err != nil {
@@ -538,38 +659,13 @@ func NewStakingTransaction(
 		if err != nil {
 			return nil, err
 		}
-		rpcMsg = &UndelegateMsg{
+		return &UndelegateMsg{
 			DelegatorAddress: delegatorAddress,
 			ValidatorAddress: validatorAddress,
 			Amount:           msg.Amount,
-		}
+		}, nil
 	}
-
-	result := &StakingTransaction{
-		Gas:       tx.GasLimit(),
-		GasPrice:  tx.GasPrice(),
-		Hash:      tx.Hash(),
-		Nonce:     tx.Nonce(),
-		Timestamp: timestamp,
-		V:         (*hexutil.Big)(v),
-		R:         (*hexutil.Big)(r),
-		S:         (*hexutil.Big)(s),
-		Type:      tx.StakingType().String(),
-		Msg:       rpcMsg,
-	}
-	if blockHash != (common.Hash{}) {
-		result.BlockHash = blockHash
-		result.BlockNumber = new(big.Int).SetUint64(blockNumber)
-		result.TransactionIndex = index
-	}
-
-	fromAddr, err := internal_common.AddressToBech32(from)
-	if err != nil {
-		return nil, err
-	}
-	result.From = fromAddr
-
-	return result, nil
+	return nil, nil
 }
 
 // NewBlock converts the given block to the RPC output which depends on fullTx. If inclTx is true transactions are
@@ -5,6 +5,8 @@ export GO111MODULE=on
 declare -A SRC
 SRC[nordicenergy]=./cmd/nordicenergy
 SRC[bootnode]=./cmd/bootnode
+SRC[blssigner]=./cmd/blssigner
+SRC[slashingprotection]=./cmd/slashingprotection
 
 BINDIR=bin
 BUCKET=unique-bucket-bin
@@ -72,7 +74,7 @@ ACTION:
    upload      upload binaries to s3
    release     upload binaries to release bucket
 
-   nordicenergy|bootnode|
+   nordicenergy|bootnode|blssigner|slashingprotection|
                only build the specified binary
 
 EXAMPLES:
@@ -282,6 +284,6 @@ case "$ACTION" in
    "build") build_only ;;
    "upload") upload ;;
    "release") release ;;
-   "nordicenergy"|"bootnode") build_only $ACTION ;;
+   "nordicenergy"|"bootnode"|"blssigner"|"slashingprotection") build_only $ACTION ;;
    *) usage ;;
 esac
@@ -0,0 +1,99 @@
+package availability
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/nordicenergy/nordicenergy-core/internal/utils"
+	"github.com/nordicenergy/nordicenergy-core/numeric"
+	staking2 "github.com/nordicenergy/nordicenergy-core/staking"
+	"github.com/nordicenergy/nordicenergy-core/staking/effective"
+	staking "github.com/nordicenergy/nordicenergy-core/staking/types"
+)
+
+// DowntimeRecord is the result of slashing and jailing a validator for downtime
+type DowntimeRecord struct {
+	Validator    common.Address    `json:"validator-address"`
+	Epoch        *big.Int          `json:"epoch"`
+	JailedUntil  *big.Int          `json:"jailed-until"`
+	Computed     *staking.Computed `json:"current-epoch-signing-percentage"`
+	TotalSlashed *big.Int          `json:"total-slashed,omitempty"`
+}
+
+// IsBelowDowntimeThreshold ..
+func IsBelowDowntimeThreshold(quotient numeric.Dec) bool {
+	return quotient.LTE(staking2.DowntimeJailThreshold)
+}
+
+// ComputeAndApplyDowntimeSlash slashes the delegations of a validator whose
+// signing over the epoch is at or below the downtime threshold, burning the
+// slashed tokens, and jails the validator for MinJailPeriodInEpoch epochs.
+// A nil record is returned if the validator was not jailed.
+func ComputeAndApplyDowntimeSlash(
+	bc Reader,
+	state ValidatorState,
+	addr common.Address,
+	epoch *big.Int,
+) (*DowntimeRecord, error) {
+	wrapper, err := state.ValidatorWrapper(addr)
+	if err != nil {
+		return nil, err
+	}
+	if wrapper.Status == effective.Banned || wrapper.Status == effective.Jailed {
+		return nil, nil
+	}
+
+	snapshot, err := bc.ReadValidatorSnapshot(wrapper.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	computed := ComputeCurrentSigning(snapshot.Validator, wrapper)
+	if computed.ToSign.Sign() == 0 || !IsBelowDowntimeThreshold(computed.Percentage) {
+		return nil, nil
+	}
+
+	slashed := slashDelegations(wrapper, staking2.DowntimeSlashRate)
+	wrapper.Status = effective.Jailed
+	wrapper.JailedUntil = new(big.Int).Add(
+		epoch, big.NewInt(staking2.MinJailPeriodInEpoch),
+	)
+
+	utils.Logger().Info().
+		Str("validator", addr.Hex()).
+		Str("threshold", staking2.DowntimeJailThreshold.String()).
+		Interface("computed", computed).
+		Uint64("jailed-until", wrapper.JailedUntil.Uint64()).
+		Str("slashed", slashed.String()).
+		Msg("validator failed downtime threshold, slashed and jailed")
+
+	return &DowntimeRecord{
+		Validator:    addr,
+		Epoch:        new(big.Int).Set(epoch),
+		JailedUntil:  new(big.Int).Set(wrapper.JailedUntil),
+		Computed:     computed,
+		TotalSlashed: slashed,
+	}, nil
+}
+
+// slashDelegations takes rate out of every delegation and pending undelegation
+// of the validator and returns the total amount taken
+func slashDelegations(wrapper *staking.ValidatorWrapper, rate numeric.Dec) *big.Int {
+	total := big.NewInt(0)
+	slash := func(amount *big.Int) {
+		if amount == nil || amount.Sign() <= 0 {
+			return
+		}
+		debt := rate.MulInt(amount).TruncateInt()
+		amount.Sub(amount, debt)
+		total.Add(total, debt)
+	}
+	for i := range wrapper.Delegations {
+		delegation := &wrapper.Delegations[i]
+		slash(delegation.Amount)
+		for j := range delegation.Undelegations {
+			slash(delegation.Undelegations[j].Amount)
+		}
+	}
+	return total
+}
@@ -0,0 +1,123 @@
+package availability
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/nordicenergy/nordicenergy-core/staking/effective"
+	staking "github.com/nordicenergy/nordicenergy-core/staking/types"
+)
+
+func TestComputeAndApplyDowntimeSlash(t *testing.T) {
+	tests := []struct {
+		ctx        *computeEPOSTestCtx
+		expJailed  bool
+		expStatus  effective.Eligibility
+		expSlashed int64
+	}{
+		// signed enough
+		{
+			ctx: &computeEPOSTestCtx{
+				addr:       common.Address{20, 20},
+				snapSigned: 100,
+				snapToSign: 100,
+				snapEli:    effective.Active,
+				curSigned:  160,
+				curToSign:  200,
+				curEli:     effective.Active,
+			},
+			expStatus: effective.Active,
+		},
+		// signed exactly half of the blocks
+		{
+			ctx: &computeEPOSTestCtx{
+				addr:       common.Address{20, 20},
+				snapSigned: 100,
+				snapToSign: 100,
+				snapEli:    effective.Active,
+				curSigned:  150,
+				curToSign:  200,
+				curEli:     effective.Active,
+			},
+			expJailed:  true,
+			expStatus:  effective.Jailed,
+			expSlashed: 1000000,
+		},
+		// no blocks to sign
+		{
+			ctx: &computeEPOSTestCtx{
+				addr:       common.Address{20, 20},
+				snapSigned: 100,
+				snapToSign: 100,
+				snapEli:    effective.Active,
+				curSigned:  100,
+				curToSign:  100,
+				curEli:     effective.Active,
+			},
+			expStatus: effective.Active,
+		},
+		// banned validators are left alone
+		{
+			ctx: &computeEPOSTestCtx{
+				addr:       common.Address{20, 20},
+				snapSigned: 100,
+				snapToSign: 100,
+				snapEli:    effective.Active,
+				curSigned:  100,
+				curToSign:  200,
+				curEli:     effective.Banned,
+			},
+			expStatus: effective.Banned,
+		},
+	}
+	epoch := big.NewInt(10)
+	for i, test := range tests {
+		test.ctx.makeStateAndReader()
+		wrapper, _ := test.ctx.state.ValidatorWrapper(test.ctx.addr)
+		wrapper.Delegations = staking.Delegations{
+			staking.NewDelegation(test.ctx.addr, big.NewInt(900000000)),
+			staking.NewDelegation(common.Address{1}, big.NewInt(100000000)),
+		}
+
+		record, err := ComputeAndApplyDowntimeSlash(
+			test.ctx.reader, test.ctx.state, test.ctx.addr, epoch,
+		)
+		if err != nil {
+			t.Fatalf("Test %v: %v", i, err)
+		}
+		if err := test.ctx.checkWrapperStatus(test.expStatus); err != nil {
+			t.Errorf("Test %v: %v", i, err)
+		}
+		if (record != nil) != test.expJailed {
+			t.Errorf("Test %v: unexpected jailed record %v", i, record)
+			continue
+		}
+		if !test.expJailed {
+			continue
+		}
+		if err := checkDowntimeRecord(record, wrapper, epoch, test.expSlashed); err != nil {
+			t.Errorf("Test %v: %v", i, err)
+		}
+	}
+}
+
+func checkDowntimeRecord(
+	record *DowntimeRecord, wrapper *staking.ValidatorWrapper, epoch *big.Int, expSlashed int64,
+) error {
+	expJailedUntil := big.NewInt(epoch.Int64() + 2)
+	if wrapper.JailedUntil.Cmp(expJailedUntil) != 0 {
+		return fmt.Errorf("wrapper jailed until %v / %v", wrapper.JailedUntil, expJailedUntil)
+	}
+	if record.JailedUntil.Cmp(expJailedUntil) != 0 {
+		return fmt.Errorf("record jailed until %v / %v", record.JailedUntil, expJailedUntil)
+	}
+	if record.TotalSlashed.Cmp(big.NewInt(expSlashed)) != 0 {
+		return fmt.Errorf("slashed %v / %v", record.TotalSlashed, expSlashed)
+	}
+	if total := wrapper.TotalDelegation(); total.Cmp(big.NewInt(1000000000-expSlashed)) != 0 {
+		return fmt.Errorf("total delegation after slash %v", total)
+	}
+	return nil
+}
@@ -19,6 +19,10 @@ const (
 	// from the network because they double-signed
 	// it can never be undnet
 	Banned
+	// Jailed means validator was slashed for signing too few
+	// blocks in an epoch and is out of the epos auction until
+	// it sends an unjail staking transaction
+	Jailed
 )
 
 func (e Eligibility) String() string {
@@ -29,6 +33,8 @@ func (e Eligibility) String() string {
 		return "inactive"
 	case Banned:
 		return doubleSigningBanned
+	case Jailed:
+		return "jailed"
 	default:
 		return "unknown"
 	}
@@ -101,6 +107,8 @@ const (
 	TurnedInactiveOrInsufficientUptime
 	// BannedForDoubleSigning ..
 	BannedForDoubleSigning
+	// JailedForDowntime ..
+	JailedForDowntime
 )
 
 func (r BootedStatus) String() string {
@@ -113,6 +121,8 @@ func (r BootedStatus) String() string {
 		return "manually turned inactive or insufficient uptime"
 	case BannedForDoubleSigning:
 		return doubleSigningBanned
+	case JailedForDowntime:
+		return "jailed for insufficient uptime"
 	default:
 		return "not booted"
 	}
@@ -10,7 +10,9 @@ import (
 	"github.com/nordicenergy/nordicenergy-core/internal/utils"
 	"github.com/nordicenergy/nordicenergy-core/numeric"
 	"github.com/nordicenergy/nordicenergy-core/shard"
+	"github.com/nordicenergy/nordicenergy-core/staking/availability"
 	stakingReward "github.com/nordicenergy/nordicenergy-core/staking/reward"
+	"github.com/nordicenergy/nordicenergy-core/staking/slash"
 )
 
 var (
@@ -38,6 +40,7 @@ func (noReward) ReadRoundResult() *reward.CompletedRound {
 		Total:            big.NewInt(0),
 		BeaconchainAward: []reward.Payout{},
 		ShardChainAward:  []reward.Payout{},
+		DelegatorAward:   []reward.DelegatorPayout{},
 	}
 }
 
@@ -56,6 +59,7 @@ func (p *preStakingEra) ReadRoundResult() *reward.CompletedRound {
 		Total:            p.payout,
 		BeaconchainAward: []reward.Payout{},
 		ShardChainAward:  []reward.Payout{},
+		DelegatorAward:   []reward.DelegatorPayout{},
 	}
 }
 
@@ -69,12 +73,14 @@ func NewStakingEraRewardForRound(
 	totalPayout *big.Int,
 	mia shard.SlotList,
 	beaconP, shardP []reward.Payout,
+	delegatorP []reward.DelegatorPayout,
 ) reward.Reader {
 	return &stakingEra{
 		CompletedRound: reward.CompletedRound{
 			Total:            totalPayout,
 			BeaconchainAward: beaconP,
 			ShardChainAward:  shardP,
+			DelegatorAward:   delegatorP,
 		},
 		missingSigners: mia,
 	}
@@ -90,6 +96,44 @@ func (r *stakingEra) ReadRoundResult() *reward.CompletedRound {
 	return &r.CompletedRound
 }
 
+type withSlashes struct {
+	reward.Reader
+	slashes []slash.HistoryRecord
+}
+
+// WithSlashes attaches the slashes applied in a block to its payout
+func WithSlashes(
+	payout reward.Reader, slashes []slash.HistoryRecord,
+) reward.Reader {
+	return &withSlashes{payout, slashes}
+}
+
+// ReadRoundResult ..
+func (r *withSlashes) ReadRoundResult() *reward.CompletedRound {
+	round := *r.Reader.ReadRoundResult()
+	round.Slashes = r.slashes
+	return &round
+}
+
+type withDowntime struct {
+	reward.Reader
+	records []availability.DowntimeRecord
+}
+
+// WithDowntime attaches the downtime slashes applied in a block to its payout
+func WithDowntime(
+	payout reward.Reader, records []availability.DowntimeRecord,
+) reward.Reader {
+	return &withDowntime{payout, records}
+}
+
+// ReadRoundResult ..
+func (r *withDowntime) ReadRoundResult() *reward.CompletedRound {
+	round := *r.Reader.ReadRoundResult()
+	round.Downtime = r.records
+	return &round
+}
+
 func adjust(amount numeric.Dec) numeric.Dec {
 	return amount.MulTruncate(
 		numeric.NewDecFromBigInt(big.NewInt(denominations.net)),
@@ -2,6 +2,7 @@ package staking
 
 import (
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/nordicenergy/nordicenergy-core/numeric"
 )
 
 const (
@@ -18,3 +19,19 @@ var (
 	CollectRewardsTopic = crypto.Keccak256Hash([]byte(collectRewardsStr))
 	DelegateTopic       = crypto.Keccak256Hash([]byte(delegateStr))
 )
+
+// downtime slashing parameters, applied once an epoch on the beaconchain
+var (
+	// DowntimeJailThreshold is the signing percentage over an epoch at or
+	// below which an elected validator is slashed and jailed
+	DowntimeJailThreshold = numeric.MustNewDecFromStr("0.5")
+	// DowntimeSlashRate is the fraction of every delegation,
+	// including pending undelegations, slashed for downtime
+	DowntimeSlashRate = numeric.MustNewDecFromStr("0.001")
+)
+
+const (
+	// MinJailPeriodInEpoch is the number of epochs a jailed
+	// validator has to wait before it can unjail
+	MinJailPeriodInEpoch = 2
+)
@@ -88,8 +88,10 @@ type Record struct {
 
 // Application tracks the slash application to state
 type Application struct {
-	TotalSlashed      *big.Int `json:"total-slashed"`
-	TotalSnitchReward *big.Int `json:"total-snitch-reward"`
+	TotalSlashed      *big.Int        `json:"total-slashed"`
+	TotalSnitchReward *big.Int        `json:"total-snitch-reward"`
+	Losses            []DelegatorLoss `json:"delegator-losses"`
+	Applied           []HistoryRecord `json:"applied"`
 }
 
 func (a *Application) String() string {
@@ -373,7 +375,10 @@ func delegatorSlashApply(
 
 	for _, delegationSnapshot := range snapshot.Delegations {
 		slashDebt := applySlashRate(delegationSnapshot.Amount, rate)
-		slashDiff := &Application{big.NewInt(0), big.NewInt(0)}
+		slashDiff := &Application{
+			TotalSlashed:      big.NewInt(0),
+			TotalSnitchReward: big.NewInt(0),
+		}
 		snapshotAddr := delegationSnapshot.DelegatorAddress
 		for i := range current.Delegations {
 			delegationNow := current.Delegations[i]
@@ -457,6 +462,14 @@ func delegatorSlashApply(
 				slashTrack.TotalSlashed.Add(
 					slashTrack.TotalSlashed, slashDiff.TotalSlashed,
 				)
+				// what was actually taken from the delegation, undelegations
+				// and rewards, which can be less than the debt
+				if slashDiff.TotalSlashed.Sign() == 1 {
+					slashTrack.Losses = append(slashTrack.Losses, DelegatorLoss{
+						Delegator: snapshotAddr,
+						Amount:    slashDiff.TotalSlashed,
+					})
+				}
 			}
 		}
 		// after the loops, paid off as much as could
@@ -473,12 +486,18 @@ func delegatorSlashApply(
 	return nil
 }
 
-// Apply ..
+// Apply applies the slashes to state and returns the totals along with
+// what was actually taken from each delegator for every record
 func Apply(
 	chain staking.ValidatorSnapshotReader, state *state.DB,
 	slashes Records, rate numeric.Dec,
 ) (*Application, error) {
-	slashDiff := &Application{big.NewInt(0), big.NewInt(0)}
+	slashDiff := &Application{
+		TotalSlashed:      big.NewInt(0),
+		TotalSnitchReward: big.NewInt(0),
+		Losses:            []DelegatorLoss{},
+		Applied:           []HistoryRecord{},
+	}
 	for _, slash := range slashes {
 		snapshot, err := chain.ReadValidatorSnapshotAtEpoch(
 			slash.Evidence.Epoch,
@@ -501,12 +520,29 @@ func Apply(
 		// NOTE invariant: first delegation is the validators own
 		// stake, rest are external delegations.
 		// Bottom line: everynet will be slashed under the same rule.
+		applied := &Application{
+			TotalSlashed:      big.NewInt(0),
+			TotalSnitchReward: big.NewInt(0),
+			Losses:            []DelegatorLoss{},
+		}
 		if err := delegatorSlashApply(
 			snapshot.Validator, current, rate, state,
-			slash.Reporter, slash.Evidence.Epoch, slashDiff,
+			slash.Reporter, slash.Evidence.Epoch, applied,
 		); err != nil {
 			return nil, err
 		}
+		slashDiff.TotalSlashed.Add(slashDiff.TotalSlashed, applied.TotalSlashed)
+		slashDiff.TotalSnitchReward.Add(
+			slashDiff.TotalSnitchReward, applied.TotalSnitchReward,
+		)
+		slashDiff.Losses = append(slashDiff.Losses, applied.Losses...)
+		slashDiff.Applied = append(slashDiff.Applied, HistoryRecord{
+			Record:       slash,
+			Rate:         rate,
+			TotalSlashed: applied.TotalSlashed,
+			SnitchReward: applied.TotalSnitchReward,
+			Losses:       applied.Losses,
+		})
 
 		// finally, kick them off forever
 		current.Status = effective.Banned
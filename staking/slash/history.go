@@ -0,0 +1,36 @@
+package slash
+
+import (
+	"encoding/json"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	common2 "github.com/nordicenergy/nordicenergy-core/internal/common"
+	"github.com/nordicenergy/nordicenergy-core/numeric"
+)
+
+// DelegatorLoss is the amount taken from a delegator of the offender,
+// counting its delegation, undelegations and forfeited rewards
+type DelegatorLoss struct {
+	Delegator common.Address `json:"delegator"`
+	Amount    *big.Int       `json:"amount"`
+}
+
+// MarshalJSON ..
+func (l DelegatorLoss) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Delegator string   `json:"delegator"`
+		Amount    *big.Int `json:"amount"`
+	}{common2.MustAddressToBech32(l.Delegator), l.Amount})
+}
+
+// HistoryRecord is a slash applied to state, as kept in the slashing history
+type HistoryRecord struct {
+	Record       Record          `json:"record"`
+	BlockNumber  uint64          `json:"block-number"`
+	Epoch        *big.Int        `json:"epoch"`
+	Rate         numeric.Dec     `json:"rate"`
+	TotalSlashed *big.Int        `json:"total-slashed"`
+	SnitchReward *big.Int        `json:"snitch-reward"`
+	Losses       []DelegatorLoss `json:"delegator-losses"`
+}
@@ -0,0 +1,78 @@
+package slash
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/nordicenergy/nordicenergy-core/numeric"
+)
+
+func TestApplyHistory(t *testing.T) {
+	tests := []struct {
+		rate    numeric.Dec
+		expLoss *big.Int
+	}{
+		{
+			// paid from delegation, undelegation and reward
+			rate:    numeric.NewDecWithPrec(875, 3),
+			expLoss: thirtyFiveKnets,
+		},
+		{
+			// debt of 60k, only 40k could be taken
+			rate:    numeric.NewDecWithPrec(150, 2),
+			expLoss: fourtyKnets,
+		},
+		{
+			rate:    numeric.ZeroDec(),
+			expLoss: common.Big0,
+		},
+	}
+	for i, test := range tests {
+		tc := applyTestCase{
+			snapshot: defaultSnapValidatorWrapper(),
+			current:  defaultCurrentValidatorWrapper(),
+			slashes:  Records{defaultSlashRecord()},
+			rate:     test.rate,
+		}
+		tc.makeData(t)
+		tc.apply()
+		if tc.gotErr != nil {
+			t.Fatalf("Test %v: %v", i, tc.gotErr)
+		}
+		if len(tc.gotDiff.Applied) != len(tc.slashes) {
+			t.Fatalf("Test %v: unexpected applied size %v / %v", i,
+				len(tc.gotDiff.Applied), len(tc.slashes))
+		}
+		if err := checkHistoryRecord(tc.gotDiff.Applied[0], test.expLoss); err != nil {
+			t.Errorf("Test %v: %v", i, err)
+		}
+	}
+}
+
+func checkHistoryRecord(h HistoryRecord, expLoss *big.Int) error {
+	expSnitch := new(big.Int).Div(expLoss, common.Big2)
+	if h.TotalSlashed.Cmp(expLoss) != 0 {
+		return fmt.Errorf("unexpected total slashed %v / %v", h.TotalSlashed, expLoss)
+	}
+	if h.SnitchReward.Cmp(expSnitch) != 0 {
+		return fmt.Errorf("unexpected snitch reward %v / %v", h.SnitchReward, expSnitch)
+	}
+	if expLoss.Sign() == 0 {
+		if len(h.Losses) != 0 {
+			return fmt.Errorf("unexpected losses %v", h.Losses)
+		}
+		return nil
+	}
+	if len(h.Losses) != 1 {
+		return fmt.Errorf("unexpected losses size %v / 1", len(h.Losses))
+	}
+	if h.Losses[0].Delegator != offAddr {
+		return fmt.Errorf("unexpected delegator %x", h.Losses[0].Delegator)
+	}
+	if h.Losses[0].Amount.Cmp(expLoss) != 0 {
+		return fmt.Errorf("unexpected loss %v / %v", h.Losses[0].Amount, expLoss)
+	}
+	return nil
+}
@@ -4,6 +4,13 @@ import (
 	"math/big"
 
 	"github.com/nordicenergy/nordicenergy-core/numeric"
+	"github.com/pkg/errors"
+)
+
+var (
+	errCommissionChangeNotInFuture = errors.New(
+		"commission change must take effect in a future epoch",
+	)
 )
 
 type (
@@ -33,3 +40,89 @@ func (cr CommissionRates) Copy() CommissionRates {
 		MaxChangeRate: cr.MaxChangeRate.Copy(),
 	}
 }
+
+// CommissionChange is a commission rate which takes effect from Epoch onwards
+type CommissionChange struct {
+	Epoch *big.Int    `json:"epoch"`
+	Rate  numeric.Dec `json:"rate"`
+}
+
+// Copy deep copies the staking.CommissionChange
+func (c CommissionChange) Copy() CommissionChange {
+	cp := CommissionChange{Rate: c.Rate.Copy()}
+	if c.Epoch != nil {
+		cp.Epoch = new(big.Int).Set(c.Epoch)
+	}
+	return cp
+}
+
+// ScheduleCommissionChange records that the commission rate becomes rate at the
+// effective epoch. Changes still pending as of the now epoch are replaced, so
+// at most one change is pending at any time.
+func (w *ValidatorWrapper) ScheduleCommissionChange(
+	now, effective *big.Int, rate numeric.Dec,
+) error {
+	if effective.Cmp(now) <= 0 {
+		return errors.Wrapf(
+			errCommissionChangeNotInFuture,
+			"now %s effective %s", now.String(), effective.String(),
+		)
+	}
+	applied := w.CommissionHistory(now)
+	w.CommissionSchedule = append(applied, CommissionChange{
+		Epoch: new(big.Int).Set(effective),
+		Rate:  rate.Copy(),
+	})
+	return nil
+}
+
+// ApplyCommissionSchedule sets the commission rate to the latest change
+// scheduled at or before the given epoch and reports whether the rate changed.
+func (w *ValidatorWrapper) ApplyCommissionSchedule(epoch *big.Int) bool {
+	applied := w.CommissionHistory(epoch)
+	if len(applied) == 0 {
+		return false
+	}
+	latest := applied[len(applied)-1]
+	if w.Rate.Equal(latest.Rate) {
+		return false
+	}
+	w.Rate = latest.Rate.Copy()
+	return true
+}
+
+// PruneCommissionSchedule drops the oldest commission changes which took
+// effect at the given epoch, keeping the last CommissionHistoryLength ones
+func (w *ValidatorWrapper) PruneCommissionSchedule(epoch *big.Int) {
+	applied := len(w.CommissionHistory(epoch))
+	if applied <= CommissionHistoryLength {
+		return
+	}
+	w.CommissionSchedule = append(
+		[]CommissionChange{}, w.CommissionSchedule[applied-CommissionHistoryLength:]...,
+	)
+}
+
+// CommissionHistory returns the commission changes that already took effect
+// at the given epoch, oldest first.
+func (w *ValidatorWrapper) CommissionHistory(epoch *big.Int) []CommissionChange {
+	history := []CommissionChange{}
+	for i := range w.CommissionSchedule {
+		if w.CommissionSchedule[i].Epoch.Cmp(epoch) > 0 {
+			break
+		}
+		history = append(history, w.CommissionSchedule[i])
+	}
+	return history
+}
+
+// PendingCommissionChange returns the commission change scheduled to take
+// effect after the given epoch, or nil if there is none.
+func (w *ValidatorWrapper) PendingCommissionChange(epoch *big.Int) *CommissionChange {
+	if l := len(w.CommissionSchedule); l > 0 {
+		if last := w.CommissionSchedule[l-1]; last.Epoch.Cmp(epoch) > 0 {
+			return &last
+		}
+	}
+	return nil
+}
@@ -3,6 +3,7 @@ package types
 import (
 	"errors"
 	"fmt"
+	"math/big"
 	"reflect"
 	"testing"
 
@@ -72,3 +73,90 @@ func assertDecCopy(d1, d2 numeric.Dec) error {
 	}
 	return nil
 }
+
+func TestValidatorWrapper_CommissionSchedule(t *testing.T) {
+	w := ValidatorWrapper{}
+	w.Rate = numeric.NewDecWithPrec(1, 1)
+
+	if err := w.ScheduleCommissionChange(
+		big.NewInt(10), big.NewInt(10), numeric.NewDecWithPrec(2, 1),
+	); err == nil {
+		t.Fatal("expected error scheduling change in the current epoch")
+	}
+	if err := w.ScheduleCommissionChange(
+		big.NewInt(10), big.NewInt(12), numeric.NewDecWithPrec(2, 1),
+	); err != nil {
+		t.Fatal(err)
+	}
+	// a second edit within the same epoch replaces the pending change
+	if err := w.ScheduleCommissionChange(
+		big.NewInt(10), big.NewInt(12), numeric.NewDecWithPrec(3, 1),
+	); err != nil {
+		t.Fatal(err)
+	}
+	if len(w.CommissionSchedule) != 1 {
+		t.Fatalf("expected 1 scheduled change, got %v", len(w.CommissionSchedule))
+	}
+
+	pending := w.PendingCommissionChange(big.NewInt(11))
+	if pending == nil || !pending.Rate.Equal(numeric.NewDecWithPrec(3, 1)) {
+		t.Errorf("unexpected pending change %v", pending)
+	}
+	if w.ApplyCommissionSchedule(big.NewInt(11)) {
+		t.Error("commission applied before scheduled epoch")
+	}
+	if !w.ApplyCommissionSchedule(big.NewInt(12)) {
+		t.Error("commission not applied at scheduled epoch")
+	}
+	if !w.Rate.Equal(numeric.NewDecWithPrec(3, 1)) {
+		t.Errorf("unexpected rate %v", w.Rate)
+	}
+	if w.ApplyCommissionSchedule(big.NewInt(13)) {
+		t.Error("commission applied twice")
+	}
+	if pending := w.PendingCommissionChange(big.NewInt(12)); pending != nil {
+		t.Errorf("unexpected pending change %v", pending)
+	}
+
+	// applied changes are kept as history
+	if err := w.ScheduleCommissionChange(
+		big.NewInt(20), big.NewInt(22), numeric.NewDecWithPrec(4, 1),
+	); err != nil {
+		t.Fatal(err)
+	}
+	if history := w.CommissionHistory(big.NewInt(21)); len(history) != 1 {
+		t.Errorf("expected 1 history entry, got %v", len(history))
+	}
+	if history := w.CommissionHistory(big.NewInt(22)); len(history) != 2 {
+		t.Errorf("expected 2 history entries, got %v", len(history))
+	}
+}
+
+func TestValidatorWrapper_PruneCommissionSchedule(t *testing.T) {
+	w := ValidatorWrapper{}
+	w.Rate = numeric.NewDecWithPrec(1, 1)
+	// one change per epoch from epoch 1, the last one pending at epoch last-1
+	last := int64(CommissionHistoryLength + 3)
+	for epoch := int64(1); epoch <= last; epoch++ {
+		w.CommissionSchedule = append(w.CommissionSchedule, CommissionChange{
+			Epoch: big.NewInt(epoch), Rate: numeric.NewDecWithPrec(epoch, 3),
+		})
+	}
+
+	w.PruneCommissionSchedule(big.NewInt(CommissionHistoryLength))
+	if l := len(w.CommissionSchedule); l != int(last) {
+		t.Errorf("pruned %v changes within the history length", int(last)-l)
+	}
+	w.PruneCommissionSchedule(big.NewInt(last - 1))
+	history := w.CommissionHistory(big.NewInt(last - 1))
+	if len(history) != CommissionHistoryLength {
+		t.Fatalf("expected %v history entries, got %v", CommissionHistoryLength, len(history))
+	}
+	if first := history[0].Epoch.Int64(); first != last-CommissionHistoryLength {
+		t.Errorf("oldest change kept from epoch %v, expected %v", first, last-CommissionHistoryLength)
+	}
+	if pending := w.PendingCommissionChange(big.NewInt(last - 1)); pending == nil ||
+		pending.Epoch.Int64() != last {
+		t.Errorf("pending change was pruned: %v", pending)
+	}
+}
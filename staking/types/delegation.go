@@ -109,6 +109,37 @@ type DelegationIndex struct {
 	BlockNum         *big.Int
 }
 
+// DelegationEarning is the reward a delegator earned from delegating to a validator
+type DelegationEarning struct {
+	ValidatorAddress common.Address
+	Amount           *big.Int
+}
+
+// DelegationEarnings is a slice of DelegationEarning, one per validator
+type DelegationEarnings []DelegationEarning
+
+// Add credits the amount earned from the validator
+func (e DelegationEarnings) Add(
+	validator common.Address, amount *big.Int,
+) DelegationEarnings {
+	for i := range e {
+		if e[i].ValidatorAddress == validator {
+			e[i].Amount = new(big.Int).Add(e[i].Amount, amount)
+			return e
+		}
+	}
+	return append(e, DelegationEarning{validator, new(big.Int).Set(amount)})
+}
+
+// Total returns the sum earned from all validators
+func (e DelegationEarnings) Total() *big.Int {
+	total := big.NewInt(0)
+	for i := range e {
+		total.Add(total, e[i].Amount)
+	}
+	return total
+}
+
 // NewDelegation creates a new delegation object
 func NewDelegation(delegatorAddr common.Address,
 	amount *big.Int) Delegation {
@@ -152,3 +152,24 @@ func TestUnlockedPremature(t *testing.T) {
 		t.Errorf("premature delegation shouldn't be unlocked")
 	}
 }
+
+func TestDelegationEarnings(t *testing.T) {
+	validator1, validator2 := common.Address{1}, common.Address{2}
+	earnings := DelegationEarnings{}
+	earnings = earnings.Add(validator1, big.NewInt(10))
+	earnings = earnings.Add(validator2, big.NewInt(20))
+	earnings = earnings.Add(validator1, big.NewInt(5))
+
+	if len(earnings) != 2 {
+		t.Fatalf("expect 2 earnings, got %v", len(earnings))
+	}
+	if earnings[0].ValidatorAddress != validator1 || earnings[0].Amount.Cmp(big.NewInt(15)) != 0 {
+		t.Errorf("unexpected earning from validator1: %v", earnings[0].Amount)
+	}
+	if earnings[1].ValidatorAddress != validator2 || earnings[1].Amount.Cmp(big.NewInt(20)) != 0 {
+		t.Errorf("unexpected earning from validator2: %v", earnings[1].Amount)
+	}
+	if total := earnings.Total(); total.Cmp(big.NewInt(35)) != 0 {
+		t.Errorf("unexpected total earnings: %v", total)
+	}
+}
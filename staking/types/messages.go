@@ -26,6 +26,8 @@ const (
 	DirectiveUndelegate
 	// DirectiveCollectRewards ...
 	DirectiveCollectRewards
+	// DirectiveUnjail ...
+	DirectiveUnjail
 )
 
 var (
@@ -35,6 +37,7 @@ var (
 		DirectiveDelegate:        "Delegate",
 		DirectiveUndelegate:      "Undelegate",
 		DirectiveCollectRewards:  "CollectRewards",
+		DirectiveUnjail:          "Unjail",
 	}
 	// ErrInvalidStakingKind given when caller gives bad staking message kind
 	ErrInvalidStakingKind = errors.New("bad staking kind")
@@ -212,3 +215,20 @@ func (v CollectRewards) Copy() StakeMsg {
 		DelegatorAddress: v.DelegatorAddress,
 	}
 }
+
+// Unjail - type for returning a validator jailed for downtime to the auction
+type Unjail struct {
+	ValidatorAddress common.Address `json:"validator_address"`
+}
+
+// Type of Unjail
+func (v Unjail) Type() Directive {
+	return DirectiveUnjail
+}
+
+// Copy returns a deep copy of the Unjail as a StakeMsg interface
+func (v Unjail) Copy() StakeMsg {
+	return Unjail{
+		ValidatorAddress: v.ValidatorAddress,
+	}
+}
@@ -23,6 +23,15 @@ func CopyValidatorWrapper(w staking.ValidatorWrapper) staking.ValidatorWrapper {
 	if w.BlockReward != nil {
 		cp.BlockReward = new(big.Int).Set(w.BlockReward)
 	}
+	if w.CommissionSchedule != nil {
+		cp.CommissionSchedule = make([]staking.CommissionChange, 0, len(w.CommissionSchedule))
+		for _, c := range w.CommissionSchedule {
+			cp.CommissionSchedule = append(cp.CommissionSchedule, c.Copy())
+		}
+	}
+	if w.JailedUntil != nil {
+		cp.JailedUntil = new(big.Int).Set(w.JailedUntil)
+	}
 	return cp
 }
 
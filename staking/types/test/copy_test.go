@@ -41,6 +41,11 @@ func makeNonZeroValidatorWrapper() staking.ValidatorWrapper {
 		Validator:   makeNonZeroValidator(),
 		Delegations: staking.Delegations{nonZeroDelegation, zeroDelegation},
 		BlockReward: common.Big1,
+		CommissionSchedule: []staking.CommissionChange{
+			{Epoch: common.Big1, Rate: numeric.NewDecWithPrec(1, 1)},
+			{Epoch: common.Big2, Rate: numeric.NewDecWithPrec(2, 1)},
+		},
+		JailedUntil: common.Big3,
 	}
 	w.Counters.NumBlocksToSign = common.Big1
 	w.Counters.NumBlocksSigned = common.Big2
@@ -202,6 +207,17 @@ func assertValidatorWrapperDeepCopy(w1, w2 staking.ValidatorWrapper) error {
 	if w1.BlockReward != nil && w1.BlockReward == w2.BlockReward {
 		return fmt.Errorf("BlockReward same address")
 	}
+	if !reflect.DeepEqual(w1.CommissionSchedule, w2.CommissionSchedule) {
+		return fmt.Errorf("CommissionSchedule not deep equal")
+	}
+	for i := range w1.CommissionSchedule {
+		if w1.CommissionSchedule[i].Epoch == w2.CommissionSchedule[i].Epoch {
+			return fmt.Errorf("CommissionSchedule[%v].Epoch same address", i)
+		}
+	}
+	if err := assertBigIntCopy(w1.JailedUntil, w2.JailedUntil); err != nil {
+		return fmt.Errorf("JailedUntil %v", err)
+	}
 	return nil
 }
 
@@ -44,6 +44,12 @@ func checkValidatorWrapperEqual(w1, w2 staking.ValidatorWrapper) error {
 	if err := checkBigIntEqual(w1.BlockReward, w2.BlockReward); err != nil {
 		return fmt.Errorf(".BlockReward %v", err)
 	}
+	if err := checkCommissionScheduleEqual(w1.CommissionSchedule, w2.CommissionSchedule); err != nil {
+		return fmt.Errorf(".CommissionSchedule%v", err)
+	}
+	if err := checkBigIntEqual(w1.JailedUntil, w2.JailedUntil); err != nil {
+		return fmt.Errorf(".JailedUntil %v", err)
+	}
 	return nil
 }
 
@@ -170,6 +176,21 @@ func checkCommissinetqual(c1, c2 staking.Commission) error {
 	return nil
 }
 
+func checkCommissionScheduleEqual(cs1, cs2 []staking.CommissionChange) error {
+	if len(cs1) != len(cs2) {
+		return fmt.Errorf(".len not equal: %v / %v", len(cs1), len(cs2))
+	}
+	for i := range cs1 {
+		if err := checkBigIntEqual(cs1[i].Epoch, cs2[i].Epoch); err != nil {
+			return fmt.Errorf("[%v].Epoch %v", i, err)
+		}
+		if err := checkDecEqual(cs1[i].Rate, cs2[i].Rate); err != nil {
+			return fmt.Errorf("[%v].Rate %v", i, err)
+		}
+	}
+	return nil
+}
+
 func checkCommissionRateEqual(cr1, cr2 staking.CommissionRates) error {
 	if err := checkDecEqual(cr1.Rate, cr2.Rate); err != nil {
 		return fmt.Errorf(".Rate %v", err)
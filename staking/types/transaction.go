@@ -291,6 +291,8 @@ func RLPDecodeStakeMsg(payload []byte, d Directive) (interface{}, error) {
 			ds = &Undelegate{}
 		case DirectiveCollectRewards:
 			ds = &CollectRewards{}
+		case DirectiveUnjail:
+			ds = &Unjail{}
 		default:
 			return nil, nil
 		}
@@ -2,6 +2,7 @@ package types
 
 import (
 	"encoding/json"
+	"io"
 	"math/big"
 
 	"github.com/nordicenergy/nordicenergy-core/crypto/bls"
@@ -31,6 +32,12 @@ const (
 	TenThousand              = 10000
 	APRHistoryLength         = 30
 	SigningHistoryLength     = 30
+	// CommissionChangeDelay is the number of epochs after the edit
+	// before a scheduled commission rate change takes effect
+	CommissionChangeDelay = 2
+	// CommissionHistoryLength is the number of commission changes which
+	// took effect kept in the validator wrapper
+	CommissionHistoryLength = 30
 )
 
 var (
@@ -66,6 +73,9 @@ var (
 	// ErrExcessiveBLSKeys ..
 	ErrExcessiveBLSKeys        = errors.New("more slot keys provided than allowed")
 	errCannotChangeBannedTrait = errors.New("cannot change validator banned status")
+	errCannotChangeJailedTrait = errors.New(
+		"cannot change jailed validator status, send an unjail transaction instead",
+	)
 )
 
 // ValidatorSnapshotReader ..
@@ -93,6 +103,74 @@ type ValidatorWrapper struct {
 	Counters counters `json:"-"`
 	// All the rewarded accumulated so far
 	BlockReward *big.Int `json:"-"`
+	// CommissionSchedule holds the commission rate changes ordered by the
+	// epoch they take effect, the last CommissionHistoryLength ones which
+	// took effect and the pending one if any
+	CommissionSchedule []CommissionChange `json:"-"`
+	// JailedUntil is the first epoch a validator jailed
+	// for downtime is allowed to unjail, nil if never jailed
+	JailedUntil *big.Int `json:"-"`
+}
+
+// validatorWrapperRLP is the storage layout of ValidatorWrapper.
+// Fields added to the wrapper after launch go into Optional, in order,
+// and are left out while unset so that existing state encodes unchanged.
+type validatorWrapperRLP struct {
+	Validator   Validator
+	Delegations Delegations
+	Counters    counters
+	BlockReward *big.Int
+	Optional    []rlp.RawValue `rlp:"tail"`
+}
+
+// EncodeRLP implements rlp.Encoder
+func (w ValidatorWrapper) EncodeRLP(writer io.Writer) error {
+	enc := validatorWrapperRLP{
+		Validator:   w.Validator,
+		Delegations: w.Delegations,
+		Counters:    w.Counters,
+		BlockReward: w.BlockReward,
+	}
+	optional := []interface{}{}
+	if len(w.CommissionSchedule) > 0 || w.JailedUntil != nil {
+		optional = append(optional, w.CommissionSchedule)
+	}
+	if w.JailedUntil != nil {
+		optional = append(optional, w.JailedUntil)
+	}
+	for _, field := range optional {
+		raw, err := rlp.EncodeToBytes(field)
+		if err != nil {
+			return err
+		}
+		enc.Optional = append(enc.Optional, raw)
+	}
+	return rlp.Encode(writer, enc)
+}
+
+// DecodeRLP implements rlp.Decoder
+func (w *ValidatorWrapper) DecodeRLP(s *rlp.Stream) error {
+	dec := validatorWrapperRLP{}
+	if err := s.Decode(&dec); err != nil {
+		return err
+	}
+	w.Validator = dec.Validator
+	w.Delegations = dec.Delegations
+	w.Counters = dec.Counters
+	w.BlockReward = dec.BlockReward
+	w.CommissionSchedule, w.JailedUntil = nil, nil
+	if len(dec.Optional) > 0 {
+		if err := rlp.DecodeBytes(dec.Optional[0], &w.CommissionSchedule); err != nil {
+			return err
+		}
+	}
+	if len(dec.Optional) > 1 {
+		w.JailedUntil = new(big.Int)
+		if err := rlp.DecodeBytes(dec.Optional[1], w.JailedUntil); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // ValidatorSnapshot contains validator snapshot and the corresponding epoch
@@ -153,6 +231,9 @@ type ValidatorRPCEnhanced struct {
 	BootedStatus         *string                  `json:"booted-status"`
 	ActiveStatus         string                   `json:"active-status"`
 	Lifetime             *AccumulatedOverLifetime `json:"lifetime"`
+	PendingCommission    *CommissionChange        `json:"pending-commission"`
+	CommissionHistory    []CommissionChange       `json:"commission-history"`
+	JailedUntil          *big.Int                 `json:"jailed-until"`
 }
 
 // AccumulatedOverLifetime ..
@@ -651,6 +732,10 @@ func UpdateValidatorFromEditMsg(validator *Validator, edit *EditValidator, epoch
 	switch validator.Status {
 	case effective.Banned:
 		return errCannotChangeBannedTrait
+	case effective.Jailed:
+		if edit.EPOSStatus != effective.Nil {
+			return errCannotChangeJailedTrait
+		}
 	default:
 		switch edit.EPOSStatus {
 		case effective.Active, effective.Inactive:
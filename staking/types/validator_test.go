@@ -1,6 +1,7 @@
 package types
 
 import (
+	"bytes"
 	"fmt"
 	"math/big"
 	"strings"
@@ -9,6 +10,7 @@ import (
 	"github.com/nordicenergy/nordicenergy-core/crypto/bls"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/nordicenergy/nordicenergy-core/crypto/hash"
 	common2 "github.com/nordicenergy/nordicenergy-core/internal/common"
 	"github.com/nordicenergy/nordicenergy-core/internal/genesis"
@@ -199,6 +201,60 @@ func TestTotalDelegation(t *testing.T) {
 	}
 }
 
+func TestValidatorWrapper_RLP(t *testing.T) {
+	legacy := makeValidValidatorWrapper()
+	legacy.BlockReward = big.NewInt(1)
+	legacy.Counters.NumBlocksSigned = big.NewInt(2)
+	legacy.Counters.NumBlocksToSign = big.NewInt(3)
+
+	scheduled := legacy
+	scheduled.CommissionSchedule = []CommissionChange{
+		{Epoch: big.NewInt(10), Rate: numeric.NewDecWithPrec(2, 1)},
+	}
+	jailed := legacy
+	jailed.Status = effective.Jailed
+	jailed.JailedUntil = big.NewInt(12)
+
+	// wrappers without the optional fields keep the launch encoding
+	oldEnc, err := rlp.EncodeToBytes([]interface{}{
+		legacy.Validator, legacy.Delegations, legacy.Counters, legacy.BlockReward,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	newEnc, err := rlp.EncodeToBytes(legacy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(oldEnc, newEnc) {
+		t.Errorf("encoding of wrapper without optional fields changed")
+	}
+
+	for i, w := range []ValidatorWrapper{legacy, scheduled, jailed} {
+		enc, err := rlp.EncodeToBytes(w)
+		if err != nil {
+			t.Fatalf("Test %v: %v", i, err)
+		}
+		var dec ValidatorWrapper
+		if err := rlp.DecodeBytes(enc, &dec); err != nil {
+			t.Fatalf("Test %v: %v", i, err)
+		}
+		if len(dec.CommissionSchedule) != len(w.CommissionSchedule) {
+			t.Errorf("Test %v: commission schedule %v / %v", i, dec.CommissionSchedule, w.CommissionSchedule)
+		}
+		if (dec.JailedUntil == nil) != (w.JailedUntil == nil) ||
+			(w.JailedUntil != nil && dec.JailedUntil.Cmp(w.JailedUntil) != 0) {
+			t.Errorf("Test %v: jailed until %v / %v", i, dec.JailedUntil, w.JailedUntil)
+		}
+		if dec.Status != w.Status {
+			t.Errorf("Test %v: status %v / %v", i, dec.Status, w.Status)
+		}
+		if dec.TotalDelegation().Cmp(w.TotalDelegation()) != 0 {
+			t.Errorf("Test %v: total delegation %v / %v", i, dec.TotalDelegation(), w.TotalDelegation())
+		}
+	}
+}
+
 func TestValidatorWrapper_SanityCheck(t *testing.T) {
 	tests := []struct {
 		editValidatorWrapper func(*ValidatorWrapper)
@@ -0,0 +1,329 @@
+package webhooks
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/nordicenergy/nordicenergy-core/internal/utils"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// The headers of the deliveries
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	// HeaderSignature is the hex HMAC-SHA256, keyed with the secret of the
+	// subscription, of the timestamp header, a dot and the body
+	HeaderSignature = "X-Webhook-Signature"
+)
+
+const (
+	// deliveryBatch is the number of deliveries attempted at once
+	deliveryBatch = 64
+	// pollInterval is the interval the queue is checked for retries at
+	pollInterval = time.Second
+)
+
+// Sign returns the signature of body sent at timestamp with secret
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Dispatcher delivers the node events to the subscriptions of the hooks,
+// retrying with an exponential backoff the failed deliveries
+type Dispatcher struct {
+	lock          sync.RWMutex
+	subscriptions map[string]*Subscription
+	config        DeliveryConfig
+
+	queue  *queue
+	client *http.Client
+	wakeC  chan struct{}
+	stopC  chan struct{}
+	stopOn sync.Once
+}
+
+// NewDispatcher returns the dispatcher of the subscriptions of hooks, keeping
+// the pending deliveries in db, in memory if nil
+func NewDispatcher(hooks *Hooks, db ethdb.KeyValueStore) (*Dispatcher, error) {
+	q, err := newQueue(db)
+	if err != nil {
+		return nil, err
+	}
+	d := &Dispatcher{
+		queue: q,
+		wakeC: make(chan struct{}, 1),
+		stopC: make(chan struct{}),
+	}
+	if err := d.Reload(hooks); err != nil {
+		return nil, err
+	}
+	initMetrics()
+	return d, nil
+}
+
+// Reload replaces the subscriptions and delivery config with the ones of
+// hooks. Pending deliveries to subscriptions no longer there are dropped.
+func (d *Dispatcher) Reload(hooks *Hooks) error {
+	if err := hooks.Validate(); err != nil {
+		return err
+	}
+	subs := map[string]*Subscription{}
+	for _, s := range hooks.Subscriptions {
+		subs[s.Name] = s
+	}
+	config := hooks.Delivery.withDefaults()
+	// the client in use by deliveries in flight is replaced, not modified
+	client := &http.Client{Timeout: config.Timeout}
+	d.lock.Lock()
+	d.subscriptions = subs
+	d.config = config
+	d.client = client
+	d.lock.Unlock()
+	return nil
+}
+
+// WatchConfig reloads the hooks from the YAML file at path when it changes,
+// checking it every interval
+func (d *Dispatcher) WatchConfig(path string, interval time.Duration) {
+	var lastMod time.Time
+	if info, err := os.Stat(path); err == nil {
+		lastMod = info.ModTime()
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			info, err := os.Stat(path)
+			if err != nil || !info.ModTime().After(lastMod) {
+				continue
+			}
+			lastMod = info.ModTime()
+			hooks, err := NewWebHooksFromPath(path)
+			if err == nil {
+				err = d.Reload(hooks)
+			}
+			if err != nil {
+				utils.Logger().Warn().Err(err).Str("path", path).
+					Msg("[webhooks] cannot reload the hooks, keeping the previous ones")
+				continue
+			}
+			utils.Logger().Info().Str("path", path).Msg("[webhooks] hooks reloaded")
+		case <-d.stopC:
+			return
+		}
+	}
+}
+
+// Subscribed returns whether any subscription is to events of type t, for
+// the callers to skip computing events nobody waits for
+func (d *Dispatcher) Subscribed(t EventType) bool {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	for _, s := range d.subscriptions {
+		for _, st := range s.Events {
+			if st == t {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Publish queues event for the subscriptions it matches
+func (d *Dispatcher) Publish(event *Event) {
+	d.lock.RLock()
+	matched := []string{}
+	for name, s := range d.subscriptions {
+		if s.Matches(event) {
+			matched = append(matched, name)
+		}
+	}
+	d.lock.RUnlock()
+	if len(matched) == 0 {
+		return
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		utils.Logger().Error().Err(err).Str("event", string(event.Type)).
+			Msg("[webhooks] cannot encode event")
+		return
+	}
+	for _, name := range matched {
+		dl := &delivery{
+			Subscription: name,
+			Event:        event.Type,
+			Payload:      payload,
+		}
+		if err := d.queue.push(dl); err != nil {
+			utils.Logger().Error().Err(err).Str("subscription", name).
+				Msg("[webhooks] cannot queue delivery")
+			continue
+		}
+		webhookCounterVec.With(prometheus.Labels{"result": "queued"}).Inc()
+	}
+	select {
+	case d.wakeC <- struct{}{}:
+	default:
+	}
+}
+
+// Start starts delivering the queued events
+func (d *Dispatcher) Start() {
+	go d.loop()
+}
+
+// Stop stops delivering the events, the pending ones are kept in the queue
+func (d *Dispatcher) Stop() {
+	d.stopOn.Do(func() { close(d.stopC) })
+}
+
+// Pending returns the number of deliveries waiting in the queue
+func (d *Dispatcher) Pending() int {
+	return d.queue.len()
+}
+
+func (d *Dispatcher) loop() {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-d.wakeC:
+		case <-ticker.C:
+		case <-d.stopC:
+			return
+		}
+		d.deliverDue()
+	}
+}
+
+// deliverDue attempts the deliveries due, the ones to a subscription one
+// after the other in order, stopping at the first to be retried
+func (d *Dispatcher) deliverDue() {
+	for {
+		due, err := d.queue.due(time.Now().UnixNano(), deliveryBatch)
+		if err != nil {
+			utils.Logger().Error().Err(err).Msg("[webhooks] cannot read the delivery queue")
+			return
+		}
+		bySubscription := map[string][]*delivery{}
+		for _, dl := range due {
+			bySubscription[dl.Subscription] = append(bySubscription[dl.Subscription], dl)
+		}
+		var wg sync.WaitGroup
+		for _, dls := range bySubscription {
+			wg.Add(1)
+			go func(dls []*delivery) {
+				defer wg.Done()
+				for _, dl := range dls {
+					if !d.attempt(dl) {
+						return
+					}
+				}
+			}(dls)
+		}
+		wg.Wait()
+		if len(due) < deliveryBatch {
+			return
+		}
+		select {
+		case <-d.stopC:
+			return
+		default:
+		}
+	}
+}
+
+// attempt makes one attempt of dl and records its outcome in the queue. It
+// returns false if dl is to be retried.
+func (d *Dispatcher) attempt(dl *delivery) bool {
+	d.lock.RLock()
+	sub, ok := d.subscriptions[dl.Subscription]
+	config, client := d.config, d.client
+	d.lock.RUnlock()
+	logger := utils.Logger().With().
+		Str("subscription", dl.Subscription).
+		Str("event", string(dl.Event)).
+		Uint64("delivery", dl.Seq).
+		Logger()
+	if !ok {
+		logger.Info().Msg("[webhooks] subscription removed, dropping delivery")
+		d.remove(dl, "dropped")
+		return true
+	}
+
+	err := d.post(client, sub, dl)
+	dl.Attempts++
+	if err == nil {
+		d.remove(dl, "delivered")
+		return true
+	}
+	if dl.Attempts >= config.MaxAttempts {
+		logger.Warn().Err(err).Int("attempts", dl.Attempts).
+			Msg("[webhooks] giving up delivery")
+		d.remove(dl, "failed")
+		return true
+	}
+	delay := config.backoff(dl.Attempts)
+	dl.NextAttempt = time.Now().Add(delay).UnixNano()
+	logger.Debug().Err(err).Int("attempts", dl.Attempts).Dur("retryIn", delay).
+		Msg("[webhooks] delivery failed")
+	webhookCounterVec.With(prometheus.Labels{"result": "retried"}).Inc()
+	if err := d.queue.update(dl); err != nil {
+		logger.Error().Err(err).Msg("[webhooks] cannot update delivery")
+	}
+	return false
+}
+
+func (d *Dispatcher) remove(dl *delivery, result string) {
+	webhookCounterVec.With(prometheus.Labels{"result": result}).Inc()
+	if err := d.queue.remove(dl); err != nil {
+		utils.Logger().Error().Err(err).Uint64("delivery", dl.Seq).
+			Msg("[webhooks] cannot remove delivery")
+	}
+}
+
+// post sends dl to sub with client, a non 2xx status being a failure
+func (d *Dispatcher) post(client *http.Client, sub *Subscription, dl *delivery) error {
+	req, err := http.NewRequest(http.MethodPost, sub.URL, bytes.NewReader(dl.Payload))
+	if err != nil {
+		return err
+	}
+	now := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderEvent, string(dl.Event))
+	req.Header.Set(HeaderDelivery, fmt.Sprintf("%d", dl.Seq))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(now, 10))
+	if sub.Secret != "" {
+		req.Header.Set(HeaderSignature, Sign(sub.Secret, now, dl.Payload))
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.Errorf("status %s", resp.Status)
+	}
+	return nil
+}
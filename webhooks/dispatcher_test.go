@@ -0,0 +1,257 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+)
+
+func TestExampleHooks(t *testing.T) {
+	hooks, err := NewWebHooksFromPath("webhook.example.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(hooks.Subscriptions) != 2 {
+		t.Fatalf("%d subscriptions, expected 2", len(hooks.Subscriptions))
+	}
+	if c := hooks.Delivery.withDefaults(); c.InitialBackoff != 5*time.Second || c.MaxBackoff != 10*time.Minute {
+		t.Errorf("backoff %v to %v, expected 5s to 10m", c.InitialBackoff, c.MaxBackoff)
+	}
+}
+
+func TestHooksValidate(t *testing.T) {
+	tests := []struct {
+		sub   Subscription
+		valid bool
+	}{
+		{Subscription{Name: "a", URL: "http://a", Events: []EventType{EventNewBlock}}, true},
+		{Subscription{Name: "a", URL: "http://a", Events: []EventType{"no-such-event"}}, false},
+		{Subscription{Name: "a", URL: "http://a"}, false},
+		{Subscription{Name: "a", Events: []EventType{EventNewBlock}}, false},
+		{Subscription{
+			Name: "a", URL: "http://a", Events: []EventType{EventValidatorStatus},
+			Filter: &Filter{Validators: []string{"not an address"}},
+		}, false},
+	}
+	for i, test := range tests {
+		sub := test.sub
+		err := (&Hooks{Subscriptions: []*Subscription{&sub}}).Validate()
+		if (err == nil) != test.valid {
+			t.Errorf("Test %v: error %v, expected valid %v", i, err, test.valid)
+		}
+	}
+	dup := &Hooks{Subscriptions: []*Subscription{
+		{Name: "a", URL: "http://a", Events: []EventType{EventNewBlock}},
+		{Name: "a", URL: "http://b", Events: []EventType{EventSyncLag}},
+	}}
+	if err := dup.Validate(); err == nil {
+		t.Errorf("duplicate subscriptions validated")
+	}
+}
+
+func TestSubscriptionMatches(t *testing.T) {
+	validator := common.HexToAddress("0x0B585F8DaEfBC68a311FbD4cB20d9174aD174016")
+	sub := &Subscription{
+		Name:   "a",
+		URL:    "http://a",
+		Events: []EventType{EventValidatorStatus, EventSyncLag},
+		Filter: &Filter{
+			ShardIDs:   []uint32{0},
+			Validators: []string{validator.Hex()},
+			MinSyncLag: 100,
+		},
+	}
+	if err := sub.validate(); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		event   Event
+		matches bool
+	}{
+		{Event{Type: EventValidatorStatus, Validators: []common.Address{validator}}, true},
+		{Event{Type: EventValidatorStatus, Validators: []common.Address{{1}}}, false},
+		{Event{Type: EventValidatorStatus, ShardID: 1, Validators: []common.Address{validator}}, false},
+		{Event{Type: EventSyncLag, SyncLag: 100}, true},
+		{Event{Type: EventSyncLag, SyncLag: 99}, false},
+		{Event{Type: EventNewBlock}, false},
+	}
+	for i, test := range tests {
+		if matches := sub.Matches(&test.event); matches != test.matches {
+			t.Errorf("Test %v: matches %v, expected %v", i, matches, test.matches)
+		}
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	c := (&DeliveryConfig{InitialBackoff: time.Second, MaxBackoff: 10 * time.Second}).withDefaults()
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second}
+	for i, exp := range expected {
+		if delay := c.backoff(i + 1); delay != exp {
+			t.Errorf("backoff after %d attempts %v, expected %v", i+1, delay, exp)
+		}
+	}
+}
+
+func TestDispatcherDelivery(t *testing.T) {
+	const secret = "secret"
+	var (
+		lock     sync.Mutex
+		calls    int
+		received []Event
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+		calls++
+		// fail the first attempt to be retried
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, _ := ioutil.ReadAll(r.Body)
+		ts, _ := strconv.ParseInt(r.Header.Get(HeaderTimestamp), 10, 64)
+		if r.Header.Get(HeaderSignature) != Sign(secret, ts, body) {
+			t.Errorf("invalid signature")
+		}
+		if r.Header.Get(HeaderEvent) != string(EventNewBlock) {
+			t.Errorf("event header %s, expected %s", r.Header.Get(HeaderEvent), EventNewBlock)
+		}
+		var event Event
+		if err := json.Unmarshal(body, &event); err != nil {
+			t.Error(err)
+		}
+		received = append(received, event)
+	}))
+	defer server.Close()
+
+	hooks := &Hooks{
+		Subscriptions: []*Subscription{{
+			Name: "a", URL: server.URL, Secret: secret, Events: []EventType{EventNewBlock},
+		}},
+		Delivery: &DeliveryConfig{InitialBackoff: 10 * time.Millisecond},
+	}
+	d, err := NewDispatcher(hooks, memorydb.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.Start()
+	defer d.Stop()
+	d.Publish(NewEvent(EventNewBlock, 0, 10, 1, nil))
+	d.Publish(NewEvent(EventSyncLag, 0, 10, 1, nil))
+
+	deadline := time.Now().Add(5 * time.Second)
+	for d.Pending() > 0 && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	lock.Lock()
+	defer lock.Unlock()
+	if len(received) != 1 || received[0].BlockNum != 10 {
+		t.Fatalf("received %v, expected the block 10", received)
+	}
+	if calls != 2 {
+		t.Errorf("%d attempts, expected 2", calls)
+	}
+}
+
+func TestDispatcherPersistence(t *testing.T) {
+	db := memorydb.New()
+	hooks := &Hooks{Subscriptions: []*Subscription{{
+		Name: "a", URL: "http://localhost:0", Events: []EventType{EventEpochChange},
+	}}}
+	d, err := NewDispatcher(hooks, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.Publish(NewEvent(EventEpochChange, 0, 10, 1, nil))
+	d.Publish(NewEvent(EventEpochChange, 0, 20, 2, nil))
+
+	restarted, err := NewDispatcher(hooks, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := restarted.Pending(); n != 2 {
+		t.Fatalf("%d deliveries after restart, expected 2", n)
+	}
+	restarted.Publish(NewEvent(EventEpochChange, 0, 30, 3, nil))
+	due, err := restarted.queue.due(time.Now().UnixNano(), deliveryBatch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, dl := range due {
+		if dl.Seq != uint64(i+1) {
+			t.Errorf("delivery %d with sequence %d, expected %d", i, dl.Seq, i+1)
+		}
+	}
+
+	// removing the subscription drops its deliveries
+	if err := restarted.Reload(&Hooks{}); err != nil {
+		t.Fatal(err)
+	}
+	restarted.deliverDue()
+	if n := restarted.Pending(); n != 0 {
+		t.Errorf("%d deliveries of a removed subscription", n)
+	}
+}
+
+func TestDispatcherReloadClient(t *testing.T) {
+	d, err := NewDispatcher(&Hooks{Delivery: &DeliveryConfig{Timeout: time.Second}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inFlight := d.client
+	if err := d.Reload(&Hooks{Delivery: &DeliveryConfig{Timeout: 2 * time.Second}}); err != nil {
+		t.Fatal(err)
+	}
+	// deliveries in flight keep the client they started with unchanged
+	if inFlight.Timeout != time.Second {
+		t.Errorf("client in use changed to timeout %v", inFlight.Timeout)
+	}
+	if d.client == inFlight || d.client.Timeout != 2*time.Second {
+		t.Errorf("client not replaced, timeout %v", d.client.Timeout)
+	}
+}
+
+func TestQueueOrder(t *testing.T) {
+	q, err := newQueue(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now().UnixNano()
+	retried := &delivery{Subscription: "a", NextAttempt: now + int64(time.Minute)}
+	for _, dl := range []*delivery{
+		retried,
+		{Subscription: "a"},
+		{Subscription: "b"},
+	} {
+		if err := q.push(dl); err != nil {
+			t.Fatal(err)
+		}
+	}
+	due, err := q.due(now, deliveryBatch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(due) != 1 || due[0].Subscription != "b" {
+		t.Fatalf("due %v, expected only the delivery to b", due)
+	}
+
+	retried.NextAttempt = now
+	if err := q.update(retried); err != nil {
+		t.Fatal(err)
+	}
+	due, err = q.due(now, deliveryBatch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(due) != 3 || due[0].Seq != 1 || due[1].Seq != 2 || due[2].Seq != 3 {
+		t.Errorf("due %v, expected the 3 deliveries in order", due)
+	}
+}
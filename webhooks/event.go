@@ -0,0 +1,75 @@
+package webhooks
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// EventType is the type of a node event webhooks subscribe to
+type EventType string
+
+// The catalogue of node events
+const (
+	// EventNewBlock is a block committed to the chain of the node
+	EventNewBlock EventType = "new-block"
+	// EventEpochChange is the last block of an epoch committed
+	EventEpochChange EventType = "epoch-change"
+	// EventCommitteeElection is a block carrying the committees of the next epoch
+	EventCommitteeElection EventType = "committee-election"
+	// EventViewChange is the node starting a view change
+	EventViewChange EventType = "view-change"
+	// EventValidatorStatus is a validator whose status changed during the epoch
+	EventValidatorStatus EventType = "validator-status-change"
+	// EventPoolOverflow is the pending transaction pool getting full
+	EventPoolOverflow EventType = "pending-pool-overflow"
+	// EventSyncLag is the node falling behind its peers
+	EventSyncLag EventType = "sync-lag"
+)
+
+// EventTypes is the catalogue of the events that can be subscribed to
+var EventTypes = []EventType{
+	EventNewBlock,
+	EventEpochChange,
+	EventCommitteeElection,
+	EventViewChange,
+	EventValidatorStatus,
+	EventPoolOverflow,
+	EventSyncLag,
+}
+
+// IsKnown returns whether t is in the catalogue
+func (t EventType) IsKnown() bool {
+	for _, known := range EventTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// Event is a node event, delivered as the JSON body of the webhooks
+type Event struct {
+	Type      EventType `json:"type"`
+	ShardID   uint32    `json:"shard-id"`
+	BlockNum  uint64    `json:"block-number"`
+	Epoch     uint64    `json:"epoch"`
+	Timestamp int64     `json:"timestamp"`
+	// Validators are the validators the event is about, if any
+	Validators []common.Address `json:"validators,omitempty"`
+	// SyncLag is the number of blocks the node is behind, for EventSyncLag
+	SyncLag uint64      `json:"sync-lag,omitempty"`
+	Data    interface{} `json:"data,omitempty"`
+}
+
+// NewEvent returns an event of type t at the block blockNum of epoch
+func NewEvent(t EventType, shardID uint32, blockNum, epoch uint64, data interface{}) *Event {
+	return &Event{
+		Type:      t,
+		ShardID:   shardID,
+		BlockNum:  blockNum,
+		Epoch:     epoch,
+		Timestamp: time.Now().Unix(),
+		Data:      data,
+	}
+}
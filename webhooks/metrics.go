@@ -0,0 +1,33 @@
+package webhooks
+
+import (
+	"sync"
+
+	prom "github.com/nordicenergy/nordicenergy-core/api/service/prometheus"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	// webhookCounterVec counts the deliveries by outcome
+	webhookCounterVec = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "ngy",
+			Subsystem: "webhooks",
+			Name:      "delivery",
+			Help:      "number of webhook deliveries queued, delivered, retried, failed or dropped",
+		},
+		[]string{
+			"result",
+		},
+	)
+
+	onceMetrics sync.Once
+)
+
+func initMetrics() {
+	onceMetrics.Do(func() {
+		prom.PromRegistry().MustRegister(
+			webhookCounterVec,
+		)
+	})
+}
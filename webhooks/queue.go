@@ -0,0 +1,147 @@
+package webhooks
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+)
+
+var deliveryPrefix = []byte("webhook-delivery-")
+
+// delivery is an event waiting to be delivered to a subscription
+type delivery struct {
+	Seq          uint64          `json:"seq"`
+	Subscription string          `json:"subscription"`
+	Event        EventType       `json:"event"`
+	Payload      json.RawMessage `json:"payload"`
+	Attempts     int             `json:"attempts"`
+	// NextAttempt is the unix time in nanoseconds of the next attempt
+	NextAttempt int64 `json:"next-attempt"`
+}
+
+// scheduled is the part of a delivery kept in memory, for the due ones to be
+// found without decoding the queue
+type scheduled struct {
+	subscription string
+	nextAttempt  int64
+}
+
+// queue is the delivery queue, kept in a database so that the deliveries
+// pending when the node stops are made after it restarts
+type queue struct {
+	db       ethdb.KeyValueStore
+	lock     sync.Mutex
+	seq      uint64
+	schedule map[uint64]scheduled
+}
+
+// newQueue returns the queue of the deliveries kept in db, in memory if nil
+func newQueue(db ethdb.KeyValueStore) (*queue, error) {
+	if db == nil {
+		db = memorydb.New()
+	}
+	q := &queue{db: db, schedule: map[uint64]scheduled{}}
+	it := db.NewIteratorWithPrefix(deliveryPrefix)
+	defer it.Release()
+	for it.Next() {
+		d := &delivery{}
+		if err := json.Unmarshal(it.Value(), d); err != nil {
+			return nil, err
+		}
+		q.schedule[d.Seq] = scheduled{d.Subscription, d.NextAttempt}
+		if d.Seq > q.seq {
+			q.seq = d.Seq
+		}
+	}
+	return q, it.Error()
+}
+
+func deliveryKey(seq uint64) []byte {
+	key := make([]byte, len(deliveryPrefix)+8)
+	copy(key, deliveryPrefix)
+	binary.BigEndian.PutUint64(key[len(deliveryPrefix):], seq)
+	return key
+}
+
+// push adds d to the queue with the next sequence number
+func (q *queue) push(d *delivery) error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	q.seq++
+	d.Seq = q.seq
+	return q.put(d)
+}
+
+// update writes back d after an attempt
+func (q *queue) update(d *delivery) error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return q.put(d)
+}
+
+func (q *queue) put(d *delivery) error {
+	q.schedule[d.Seq] = scheduled{d.Subscription, d.NextAttempt}
+	data, err := json.Marshal(d)
+	if err != nil {
+		return err
+	}
+	return q.db.Put(deliveryKey(d.Seq), data)
+}
+
+// remove removes d once delivered or given up
+func (q *queue) remove(d *delivery) error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	delete(q.schedule, d.Seq)
+	return q.db.Delete(deliveryKey(d.Seq))
+}
+
+// due returns up to limit deliveries whose next attempt is at or before now,
+// the oldest first. The deliveries to a subscription waiting for the retry
+// of an earlier one are not due, for each subscription to receive its events
+// in order.
+func (q *queue) due(now int64, limit int) ([]*delivery, error) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	seqs := make([]uint64, 0, len(q.schedule))
+	for seq := range q.schedule {
+		seqs = append(seqs, seq)
+	}
+	sort.Slice(seqs, func(i, j int) bool { return seqs[i] < seqs[j] })
+	waiting := map[string]struct{}{}
+	res := []*delivery{}
+	for _, seq := range seqs {
+		if len(res) >= limit {
+			break
+		}
+		s := q.schedule[seq]
+		if _, ok := waiting[s.subscription]; ok {
+			continue
+		}
+		if s.nextAttempt > now {
+			waiting[s.subscription] = struct{}{}
+			continue
+		}
+		data, err := q.db.Get(deliveryKey(seq))
+		if err != nil {
+			return nil, err
+		}
+		d := &delivery{}
+		if err := json.Unmarshal(data, d); err != nil {
+			return nil, err
+		}
+		res = append(res, d)
+	}
+	return res, nil
+}
+
+// len returns the number of deliveries in the queue
+func (q *queue) len() int {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	return len(q.schedule)
+}
@@ -0,0 +1,162 @@
+package webhooks
+
+import (
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	common2 "github.com/nordicenergy/nordicenergy-core/internal/common"
+	"github.com/pkg/errors"
+)
+
+// Defaults of the delivery of the events
+const (
+	DefaultMaxAttempts    = 8
+	DefaultInitialBackoff = 5 * time.Second
+	DefaultMaxBackoff     = 10 * time.Minute
+	DefaultTimeout        = 10 * time.Second
+)
+
+// Subscription is an endpoint subscribed to node events
+type Subscription struct {
+	Name string `yaml:"name"`
+	URL  string `yaml:"url"`
+	// Secret is the key of the HMAC signature of the payloads, none if empty
+	Secret string      `yaml:"secret"`
+	Events []EventType `yaml:"events"`
+	Filter *Filter     `yaml:"filter"`
+}
+
+// Filter narrows the events delivered to a subscription. Empty fields match
+// all the events.
+type Filter struct {
+	ShardIDs []uint32 `yaml:"shard-ids"`
+	// Validators are the addresses, bech32 or hex, of the validators events
+	// about validators must be about
+	Validators []string `yaml:"validators"`
+	// MinSyncLag is the number of blocks the node must be behind for sync lag
+	// events
+	MinSyncLag uint64 `yaml:"min-sync-lag"`
+
+	validators map[common.Address]struct{}
+}
+
+// DeliveryConfig is the config of the retries of the deliveries
+type DeliveryConfig struct {
+	MaxAttempts    int           `yaml:"max-attempts"`
+	InitialBackoff time.Duration `yaml:"initial-backoff"`
+	MaxBackoff     time.Duration `yaml:"max-backoff"`
+	Timeout        time.Duration `yaml:"timeout"`
+}
+
+// withDefaults returns the config with the defaults for the unset fields
+func (c *DeliveryConfig) withDefaults() DeliveryConfig {
+	res := DeliveryConfig{}
+	if c != nil {
+		res = *c
+	}
+	if res.MaxAttempts <= 0 {
+		res.MaxAttempts = DefaultMaxAttempts
+	}
+	if res.InitialBackoff <= 0 {
+		res.InitialBackoff = DefaultInitialBackoff
+	}
+	if res.MaxBackoff <= 0 {
+		res.MaxBackoff = DefaultMaxBackoff
+	}
+	if res.Timeout <= 0 {
+		res.Timeout = DefaultTimeout
+	}
+	return res
+}
+
+// backoff returns the delay before the attempt following the attempts-th one
+func (c *DeliveryConfig) backoff(attempts int) time.Duration {
+	delay := c.InitialBackoff
+	for i := 1; i < attempts && delay < c.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > c.MaxBackoff {
+		delay = c.MaxBackoff
+	}
+	return delay
+}
+
+func (s *Subscription) validate() error {
+	if s.Name == "" {
+		return errors.New("subscription without a name")
+	}
+	if s.URL == "" {
+		return errors.Errorf("subscription %s without a url", s.Name)
+	}
+	if len(s.Events) == 0 {
+		return errors.Errorf("subscription %s to no event", s.Name)
+	}
+	for _, t := range s.Events {
+		if !t.IsKnown() {
+			return errors.Errorf("subscription %s to unknown event %s", s.Name, t)
+		}
+	}
+	if s.Filter != nil && len(s.Filter.Validators) > 0 {
+		s.Filter.validators = map[common.Address]struct{}{}
+		for _, v := range s.Filter.Validators {
+			addr, err := common2.Bech32ToAddress(v)
+			if err != nil {
+				if !common.IsHexAddress(v) {
+					return errors.Errorf("subscription %s filters invalid validator %s", s.Name, v)
+				}
+				addr = common.HexToAddress(v)
+			}
+			s.Filter.validators[addr] = struct{}{}
+		}
+	}
+	return nil
+}
+
+// Matches returns whether event is to be delivered to the subscription
+func (s *Subscription) Matches(event *Event) bool {
+	subscribed := false
+	for _, t := range s.Events {
+		if t == event.Type {
+			subscribed = true
+			break
+		}
+	}
+	if !subscribed {
+		return false
+	}
+	if s.Filter == nil {
+		return true
+	}
+	return s.Filter.matches(event)
+}
+
+func (f *Filter) matches(event *Event) bool {
+	if len(f.ShardIDs) > 0 {
+		found := false
+		for _, id := range f.ShardIDs {
+			if id == event.ShardID {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if len(f.validators) > 0 && len(event.Validators) > 0 {
+		found := false
+		for _, addr := range event.Validators {
+			if _, ok := f.validators[addr]; ok {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	if event.Type == EventSyncLag && event.SyncLag < f.MinSyncLag {
+		return false
+	}
+	return true
+}
@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"net/http"
 
+	"github.com/pkg/errors"
 	"gopkg.in/yaml.v2"
 )
 
@@ -17,6 +18,7 @@ const (
 // AvailabilityHooks ..
 type AvailabilityHooks struct {
 	OnDroppedBelowThreshold string `yaml:"on-dropped-below-threshold"`
+	OnJailed                string `yaml:"on-jailed"`
 }
 
 // DoubleSignWebHooks ..
@@ -34,6 +36,25 @@ type Hooks struct {
 	Slashing       *DoubleSignWebHooks `yaml:"slashing-hooks"`
 	Availability   *AvailabilityHooks  `yaml:"availability-hooks"`
 	ProtocolIssues *BadBlockHooks      `yaml:"protocol-hooks"`
+	// Subscriptions are the endpoints subscribed to node events, delivered
+	// by a Dispatcher
+	Subscriptions []*Subscription `yaml:"subscriptions"`
+	Delivery      *DeliveryConfig `yaml:"delivery"`
+}
+
+// Validate checks the subscriptions of the hooks
+func (h *Hooks) Validate() error {
+	names := map[string]struct{}{}
+	for _, s := range h.Subscriptions {
+		if err := s.validate(); err != nil {
+			return err
+		}
+		if _, ok := names[s.Name]; ok {
+			return errors.Errorf("duplicate subscription %s", s.Name)
+		}
+		names[s.Name] = struct{}{}
+	}
+	return nil
 }
 
 // ReportResult ..
@@ -84,5 +105,8 @@ func NewWebHooksFromPath(yamlPath string) (*Hooks, error) {
 	if err := yaml.UnmarshalStrict(rawYAML, &t); err != nil {
 		return nil, err
 	}
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
 	return &t, nil
 }